@@ -4,27 +4,40 @@ package main
 import (
 	"bufio"
 	"context"
+	"crypto/ed25519"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"math/big"
 	gonet "net"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
+	"text/tabwriter"
 	"time"
 
+	"github.com/monolythium/mono-commander/internal/alerts"
 	"github.com/monolythium/mono-commander/internal/core"
+	"github.com/monolythium/mono-commander/internal/exporter"
+	"github.com/monolythium/mono-commander/internal/fleet"
 	"github.com/monolythium/mono-commander/internal/logs"
 	"github.com/monolythium/mono-commander/internal/mesh"
 	"github.com/monolythium/mono-commander/internal/monod"
 	"github.com/monolythium/mono-commander/internal/net"
+	"github.com/monolythium/mono-commander/internal/nodemon"
 	oshelpers "github.com/monolythium/mono-commander/internal/os"
+	"github.com/monolythium/mono-commander/internal/remote"
+	"github.com/monolythium/mono-commander/internal/rpc"
 	"github.com/monolythium/mono-commander/internal/tui"
 	"github.com/monolythium/mono-commander/internal/update"
 	"github.com/monolythium/mono-commander/internal/walletgen"
@@ -53,6 +66,12 @@ var (
 	jsonOutput bool
 	verbose    bool
 
+	// Remote execution flags
+	sshTarget     string
+	sshKey        string
+	sshKnownHosts string
+	sshBinary     string
+
 	// Root command
 	rootCmd = &cobra.Command{
 		Use:   "monoctl",
@@ -66,7 +85,10 @@ Start the interactive TUI:
 Or use CLI commands:
   monoctl networks list
   monoctl join --network Sprintnet --home ~/.monod
-  monoctl systemd install --network Sprintnet --home ~/.monod --user monod`,
+  monoctl systemd install --network Sprintnet --home ~/.monod --user monod
+
+Run any command on another host over SSH:
+  monoctl --ssh monod@node1 config doctor --network Sprintnet`,
 		Run: func(cmd *cobra.Command, args []string) {
 			// Refuse to run as root
 			if os.Geteuid() == 0 {
@@ -180,6 +202,51 @@ Examples:
 		Run:   runRPCCheck,
 	}
 
+	// Exporter command
+	exporterCmd = &cobra.Command{
+		Use:   "exporter",
+		Short: "Prometheus metrics exporter",
+	}
+
+	exporterServeCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve node and validator health on /metrics",
+		Long: `Serve node and validator health in the Prometheus text format on /metrics.
+
+Every scrape runs the same checks as 'status', 'rpc check', 'mesh status' and
+'config doctor', plus the validator's bonded, jailed and missed-blocks state
+when --validator is set (default: validator_address from
+~/.mono-commander/config.json). Failed checks show up as *_up metrics set to 0.
+
+Examples:
+  monoctl exporter serve --network Sprintnet
+  monoctl exporter serve --network Sprintnet --listen :9464 --validator monovaloper1...`,
+		Run: runExporterServe,
+	}
+
+	// Alerts command
+	alertsCmd = &cobra.Command{
+		Use:   "alerts",
+		Short: "Alert on node and validator health",
+	}
+
+	alertsRunCmd = &cobra.Command{
+		Use:   "run",
+		Short: "Evaluate alert rules and send notifications",
+		Long: `Evaluate alert rules over node status, validator health and configuration
+drift, and notify webhooks, email (SMTP) and local commands when a rule starts
+firing or resolves.
+
+Rules and notifiers are read from --config (default:
+~/.mono-commander/alerts.json). Rule types: node_down, height_stalled,
+min_peers, validator_jailed, missed_blocks and critical_drift.
+
+Examples:
+  monoctl alerts run
+  monoctl alerts run --config ./alerts.json --once`,
+		Run: runAlertsRun,
+	}
+
 	// Logs command
 	logsCmd = &cobra.Command{
 		Use:   "logs",
@@ -225,6 +292,29 @@ Examples:
 		Run: runValidatorCreate,
 	}
 
+	validatorUnjailCmd = &cobra.Command{
+		Use:   "unjail",
+		Short: "Unjail a jailed validator",
+		Long: `Unjail a validator that was jailed for downtime.
+
+Before anything is broadcast, the validator's slashing signing info is
+checked over Cosmos REST (--rest). The command refuses, with the reason, if
+the validator is not jailed, is tombstoned, is still within its jail period,
+or its self-delegation is below the minimum.
+
+--from must be the operator's key or account address. --validator is only
+needed when --from is a keyring key name.
+
+Examples:
+  # Dry-run (default): run the checks and preview the transaction
+  monoctl validator unjail --network sprintnet --from mono1...
+
+  # Execute the transaction
+  monoctl validator unjail --network sprintnet --from mykey \
+    --validator monovaloper1... --execute`,
+		Run: runValidatorUnjail,
+	}
+
 	// M4: Stake command group
 	stakeCmd = &cobra.Command{
 		Use:   "stake",
@@ -299,6 +389,148 @@ Examples:
 		Run:   runGovVote,
 	}
 
+	govListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List governance proposals",
+		Long: `List governance proposals over Cosmos REST, newest first.
+
+By default only proposals in their voting period are shown. Pass --voter
+(e.g. your validator's account address) to see whether it has voted.`,
+		Run: runGovList,
+	}
+
+	govShowCmd = &cobra.Command{
+		Use:   "show <proposal-id>",
+		Short: "Show a governance proposal",
+		Args:  cobra.ExactArgs(1),
+		Run:   runGovShow,
+	}
+
+	govTallyCmd = &cobra.Command{
+		Use:   "tally <proposal-id>",
+		Short: "Show the tally of a governance proposal",
+		Long: `Show a proposal's vote counts, turnout against quorum, and the outcome it
+would have if voting ended now.`,
+		Args: cobra.ExactArgs(1),
+		Run:  runGovTally,
+	}
+
+	govPendingCmd = &cobra.Command{
+		Use:   "pending",
+		Short: "List proposals your validator has not voted on",
+		Long: `List proposals in their voting period that the validator has not voted on
+yet, with the time left before voting ends.
+
+--validator takes the operator address (monovaloper1...) or its account
+address (mono1...). It defaults to "validator_address" in
+~/.mono-commander/config.json, which the TUI Health tab also uses.
+
+With --alert, pending votes are also sent as a signed alert to a self-hosted
+node-monitor ('monoctl monitor server', given with --api or NODEMON_API),
+using the keys from 'monoctl monitor register'. The hosted node-monitor does
+not accept alerts. Run it from cron or a systemd timer for reminders.`,
+		Run: runGovPending,
+	}
+
+	govSubmitCmd = &cobra.Command{
+		Use:   "submit <proposal-file>",
+		Short: "Submit a governance proposal from a JSON file",
+		Long: `Submit a governance proposal. The file uses the format of
+'monod tx gov submit-proposal':
+
+  {
+    "messages": [],
+    "metadata": "ipfs://...",
+    "deposit": "10000000000000000000alyth",
+    "title": "Proposal title",
+    "summary": "What the proposal does",
+    "expedited": false
+  }
+
+--deposit overrides the file's deposit; monod is then given a copy of the
+file with the new deposit.`,
+		Args: cobra.ExactArgs(1),
+		Run:  runGovSubmit,
+	}
+
+	govDepositCmd = &cobra.Command{
+		Use:   "deposit",
+		Short: "Deposit on a governance proposal",
+		Run:   runGovDeposit,
+	}
+
+	// Offline signing command group
+	txCmd = &cobra.Command{
+		Use:   "tx",
+		Short: "Offline transaction signing (export, sign, broadcast)",
+		Long: `Sign transactions on an air-gapped machine.
+
+Workflow:
+  1. Online:  monoctl tx export-unsigned <action> --from <address> ... --out unsigned_tx.json
+  2. Offline: monoctl tx sign unsigned_tx.json --offline --keystore <wallet.json> --out signed_tx.json
+  3. Online:  monoctl tx broadcast signed_tx.json
+
+The unsigned file carries the chain ID, account number and sequence, so
+signing needs no network access.
+
+Multisig accounts (e.g. a 2-of-3 treasury):
+  1. monoctl tx export-unsigned <action> --from <multisig-address> \
+       --multisig-pubkey treasury.pubkey.json ... --out unsigned_tx.json
+  2. Each member: monoctl tx sign unsigned_tx.json --multisig --offline \
+       --keystore <member.json> --out sig-<member>.json
+  3. monoctl tx multisign unsigned_tx.json sig-a.json sig-b.json --out signed_tx.json
+  4. monoctl tx broadcast signed_tx.json`,
+	}
+
+	txExportUnsignedCmd = &cobra.Command{
+		Use:   "export-unsigned",
+		Short: "Build an unsigned transaction for offline signing",
+		Long: `Build an unsigned transaction and stamp it with the signer's account number
+and sequence. Takes the same flags as the corresponding tx command; --from must
+be the offline signer's address.
+
+Examples:
+  monoctl tx export-unsigned delegate --network Sprintnet --from mono1... \
+    --to monovaloper1... --amount 1000000000000000000alyth --fees 10000alyth
+
+  # Without network access on this machine either
+  monoctl tx export-unsigned vote --network Sprintnet --from mono1... \
+    --proposal 3 --option yes --account-number 12 --sequence 4`,
+	}
+
+	txSignCmd = &cobra.Command{
+		Use:   "sign <unsigned-file>",
+		Short: "Sign an exported unsigned transaction with a keystore",
+		Long: `Sign a file produced by 'monoctl tx export-unsigned'.
+
+With --offline, the account number and sequence stored in the file are used
+and no network connection is made. Without it they are refreshed over
+Cosmos REST first.
+
+With --multisig, the keystore must belong to a member of the multisig the
+transaction was exported for, and the output is that member's partial
+signature for 'monoctl tx multisign'.`,
+		Args: cobra.ExactArgs(1),
+		Run:  runTxSign,
+	}
+
+	txMultisignCmd = &cobra.Command{
+		Use:   "multisign <unsigned-file> <signature-file>...",
+		Short: "Combine multisig members' partial signatures",
+		Long: `Verify the partial signatures produced by 'monoctl tx sign --multisig' and
+combine them into the multisig signature. At least the multisig's threshold
+of distinct members must have signed. No network access is needed.`,
+		Args: cobra.MinimumNArgs(2),
+		Run:  runTxMultisign,
+	}
+
+	txBroadcastCmd = &cobra.Command{
+		Use:   "broadcast <signed-file>",
+		Short: "Broadcast a signed transaction over Cosmos REST",
+		Args:  cobra.ExactArgs(1),
+		Run:   runTxBroadcast,
+	}
+
 	// M5: Mesh/Rosetta API command group
 	meshCmd = &cobra.Command{
 		Use:   "mesh",
@@ -396,6 +628,20 @@ Wallets are stored as encrypted keystore v3 JSON files in ~/.mono-commander/wall
 Generate a new wallet:
   monoctl wallet generate --name my-wallet
 
+Generate a wallet with a recovery phrase:
+  monoctl wallet generate --name my-wallet --mnemonic
+
+Restore a wallet from its recovery phrase:
+  monoctl wallet recover --name my-wallet
+
+Change a password or upgrade a keystore's encryption:
+  monoctl wallet passwd <file>
+  monoctl wallet rekey <file>...
+
+Use a wallet with monod's keyring (--from in tx commands):
+  monoctl wallet import-to-keyring --file <path> --name my-key
+  monoctl wallet export-from-keyring --name my-key
+
 List existing wallets:
   monoctl wallet list
 
@@ -411,19 +657,133 @@ Show wallet info:
 The keystore is encrypted with your password using scrypt KDF and AES-128-CTR.
 By default, the private key is NEVER displayed.
 
+With --mnemonic the key is derived from a new BIP39 recovery phrase on the
+BIP44 path m/44'/60'/0'/0/<index>, the path MetaMask and monod's keyring
+(eth_secp256k1, coin type 60) use. The phrase is shown once; write it down.
+
+With --count N, N wallets are generated with the same password and named
+<name>-1 ... <name>-N (--out is then a directory). With --mnemonic they are
+derived from one phrase at consecutive indexes. --manifest writes their
+names, addresses and keystore paths (never keys) to a .csv or .json file.
+
+--prefix and --suffix search for an address that starts or ends with the
+given characters, on all CPUs. They match the bech32 address after "mono1"
+(characters from qpzry9x8gf2tvdw0s3jn54khce6mua7l), or the EVM address
+after "0x" with --vanity-evm. Each character makes the search 32 (16 for
+EVM) times longer; Ctrl-C stops it.
+
 Examples:
   monoctl wallet generate --name my-wallet
+  monoctl wallet generate --name my-wallet --mnemonic --words 12
   monoctl wallet generate --out /custom/path/wallet.json
-  monoctl wallet generate --password-file /path/to/password.txt`,
+  monoctl wallet generate --password-file /path/to/password.txt
+  monoctl wallet generate --name faucet --count 40 --out ./faucets --manifest faucets.csv
+  monoctl wallet generate --name ops --prefix ops
+  monoctl wallet generate --prefix cafe --vanity-evm`,
 		Run: runWalletGenerate,
 	}
 
+	walletRecoverCmd = &cobra.Command{
+		Use:   "recover",
+		Short: "Restore a wallet from a BIP39 mnemonic",
+		Long: `Derive a key from a 12 or 24 word BIP39 mnemonic and save it as an
+encrypted keystore.
+
+The key is derived on m/44'/60'/0'/0/<index>, so index 0 restores the first
+MetaMask account and the key 'monod keys add --recover' creates.
+
+Examples:
+  monoctl wallet recover --name my-wallet
+  monoctl wallet recover --name second --index 1
+  monoctl wallet recover --mnemonic-file phrase.txt --password-file pw.txt`,
+		Run: runWalletRecover,
+	}
+
+	walletDeriveCmd = &cobra.Command{
+		Use:   "derive",
+		Short: "Show the addresses derived from a BIP39 mnemonic",
+		Long: `Show the EVM and bech32 addresses of BIP44 accounts derived from a
+mnemonic, without saving anything.
+
+Examples:
+  monoctl wallet derive --index 3
+  monoctl wallet derive --index 0 --count 5 --mnemonic-file phrase.txt`,
+		Run: runWalletDerive,
+	}
+
 	walletListCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List wallet keystore files",
 		Run:   runWalletList,
 	}
 
+	walletPasswdCmd = &cobra.Command{
+		Use:   "passwd <file>",
+		Short: "Change the password of a keystore",
+		Long: `Re-encrypt a keystore with a new password.
+
+The new file always uses the strong scrypt parameters, so this also upgrades
+light (N=4096) and PBKDF2 keystores. The file is replaced atomically; the old
+one is kept as <file>.<timestamp>.bak until you delete it.
+
+Examples:
+  monoctl wallet passwd ~/.mono-commander/wallets/<file>.json
+  monoctl wallet passwd wallet.json --old-password-file old.txt --new-password-file new.txt`,
+		Args: cobra.ExactArgs(1),
+		Run:  runWalletPasswd,
+	}
+
+	walletRekeyCmd = &cobra.Command{
+		Use:   "rekey <file>...",
+		Short: "Upgrade keystores to strong scrypt parameters",
+		Long: fmt.Sprintf(`Re-encrypt keystores created with weak parameters (scrypt below
+N=%d, such as CreateKeystoreLight's N=%d, or PBKDF2) with the strong
+scrypt parameters, keeping the password.
+
+Keystores that are already strong are skipped unless --force is set. Each
+file is replaced atomically; the old one is kept as <file>.<timestamp>.bak.
+
+Examples:
+  monoctl wallet rekey old-test-wallet.json
+  monoctl wallet rekey ~/.mono-commander/wallets/*.json --password-file pw.txt`, walletgen.ScryptN, walletgen.LightScryptN),
+		Args: cobra.MinimumNArgs(1),
+		Run:  runWalletRekey,
+	}
+
+	walletImportToKeyringCmd = &cobra.Command{
+		Use:   "import-to-keyring",
+		Short: "Import a keystore into monod's keyring",
+		Long: `Decrypt a keystore and add its key to monod's keyring as an
+eth_secp256k1 key, so tx commands can use it with --from <name>.
+
+The key is passed to 'monod keys import' as a passphrase-encrypted file in a
+private temp directory, never as an argument. The keyring address is checked
+against the keystore address.
+
+The file backend needs the keyring passphrase (prompted, or
+--keyring-passphrase-file).
+
+Examples:
+  monoctl wallet import-to-keyring --file ~/.mono-commander/wallets/<file>.json --name ops
+  monoctl wallet import-to-keyring --file wallet.json --name ops --keyring-backend test --home ~/.monod`,
+		Run: runWalletImportToKeyring,
+	}
+
+	walletExportFromKeyringCmd = &cobra.Command{
+		Use:   "export-from-keyring",
+		Short: "Export a monod keyring key to a keystore",
+		Long: `Export an eth_secp256k1 key from monod's keyring with
+'monod keys unsafe-export-eth-key' and save it as an encrypted keystore.
+
+The plaintext key only passes through memory. The keystore is encrypted
+with a new password.
+
+Examples:
+  monoctl wallet export-from-keyring --name ops
+  monoctl wallet export-from-keyring --name ops --keyring-backend file --wallet-name ops`,
+		Run: runWalletExportFromKeyring,
+	}
+
 	walletInfoCmd = &cobra.Command{
 		Use:   "info",
 		Short: "Show wallet info from keystore file",
@@ -606,7 +966,8 @@ archive nodes to serve genesis blocksync to new nodes.
 
 Commands:
   monoctl node configure --role <role> --home ~/.monod
-  monoctl node role --home ~/.monod`,
+  monoctl node role --home ~/.monod
+  monoctl node profile apply <name> --home ~/.monod`,
 	}
 
 	nodeConfigureCmd = &cobra.Command{
@@ -670,6 +1031,60 @@ Examples:
 		Run: runNodeReset,
 	}
 
+	// Node profile commands - user-defined config tuning
+	nodeProfileCmd = &cobra.Command{
+		Use:   "profile",
+		Short: "Apply user-defined config profiles",
+		Long: `Manage config profiles: named sets of config.toml, app.toml and client.toml
+values for tuning a node (RPC-heavy, low-memory, public RPC hardening, ...).
+
+Profiles are JSON or YAML files in ~/.mono-commander/profiles/ (<name>.json,
+<name>.yaml or <name>.yml), with the same keys:
+
+  {
+    "description": "Public RPC node",
+    "settings": [
+      {"file": "config.toml", "section": "rpc", "key": "max_open_connections", "value": 2000},
+      {"file": "app.toml", "section": "api", "key": "enable", "value": true,
+       "when": {"roles": ["archive_node"], "networks": ["Mainnet"]},
+       "severity": "CRITICAL"}
+    ]
+  }
+
+"when" limits a setting to node roles and/or networks. The applied profile is
+recorded in the node home: config doctor reports settings that drifted from it
+(WARNING unless "severity" says otherwise) and config repair re-applies them.
+Chain IDs, seeds and persistent_peers are managed by join and repair and cannot
+be set by a profile.`,
+	}
+
+	nodeProfileListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List profiles and the node's active profile",
+		Run:   runNodeProfileList,
+	}
+
+	nodeProfileApplyCmd = &cobra.Command{
+		Use:   "apply <name>",
+		Short: "Apply a profile to the node's config",
+		Long: `Apply a profile to the node's config files and make it the active profile.
+
+Only the profile's keys change; comments and formatting are kept and missing
+keys are added. Use --dry-run to see the changes as a diff first.
+
+Examples:
+  monoctl node profile apply rpc-heavy --home ~/.monod --dry-run
+  monoctl node profile apply rpc-heavy --home ~/.monod --network Mainnet`,
+		Args: cobra.ExactArgs(1),
+		Run:  runNodeProfileApply,
+	}
+
+	nodeProfileClearCmd = &cobra.Command{
+		Use:   "clear",
+		Short: "Stop tracking the active profile (config values are kept)",
+		Run:   runNodeProfileClear,
+	}
+
 	// Config command group - configuration drift detection and repair
 	configCmd = &cobra.Command{
 		Use:   "config",
@@ -696,11 +1111,23 @@ Detects drift in critical configuration fields:
   - seeds in config.toml (WARNING - affects connectivity)
   - persistent_peers in config.toml (WARNING - affects connectivity)
 
+It also checks:
+  - RPC, REST, gRPC and JSON-RPC servers listening on 0.0.0.0
+  - unsafe CORS origins and rpc.unsafe
+  - an empty minimum-gas-prices
+  - P2P and RPC ports against the network's port scheme
+  - external_address against the detected public IP (--public-ip off to skip)
+  - seed_mode, pruning and tx indexing against --role
+  - api/grpc/json-rpc enable flags against --apis
+
+Keys set by the active node profile are left to the profile.
+
 CRITICAL drift must be fixed immediately as it will cause consensus failures.
-WARNING drift affects network connectivity but won't cause consensus issues.
+WARNING drift affects connectivity or security but won't cause consensus issues.
 
 Examples:
   monoctl config doctor --network Sprintnet --home ~/.monod
+  monoctl config doctor --network Sprintnet --role seed_node --apis none
   monoctl config doctor --network Sprintnet --home ~/.monod --json`,
 		Run: runConfigDoctor,
 	}
@@ -715,6 +1142,16 @@ This command regenerates configuration values from the canonical network config:
   - Updates evm-chain-id in app.toml
   - Updates seeds in config.toml
   - Updates persistent_peers in config.toml
+  - Re-applies the active config profile
+  - Applies the role, port, external address, gas price and API fixes you
+    ask for with --role, --validator-name, --public-ip, --min-gas-prices
+    and --apis
+
+Listen addresses on 0.0.0.0, CORS and unsafe RPC are only reported, as
+public RPC and sentry nodes need them; --apply-security-fixes binds the
+servers to 127.0.0.1 and turns CORS and unsafe RPC off. Advisory (info)
+findings and drifts that need an operator, like an empty
+minimum-gas-prices, are listed as not repaired.
 
 Keys are preserved:
   - node_key.json (node identity)
@@ -724,6 +1161,8 @@ Use --dry-run to preview changes without applying them.
 
 Examples:
   monoctl config repair --network Sprintnet --home ~/.monod --dry-run
+  monoctl config repair --network Sprintnet --min-gas-prices 0.025alyth
+  monoctl config repair --network Sprintnet --apply-security-fixes
   monoctl config repair --network Sprintnet --home ~/.monod`,
 		Run: runConfigRepair,
 	}
@@ -745,7 +1184,9 @@ Commands:
   monoctl monitor heartbeat  # Send a single heartbeat
   monoctl monitor install    # Install systemd timer for automatic heartbeats
   monoctl monitor uninstall  # Remove systemd timer
-  monoctl monitor visibility # Set public/private visibility`,
+  monoctl monitor visibility # Set public/private visibility
+  monoctl monitor verify     # Verify a signed heartbeat payload
+  monoctl monitor server     # Run a self-hosted node-monitor API`,
 	}
 
 	monitorRegisterCmd = &cobra.Command{
@@ -785,8 +1226,12 @@ The heartbeat includes:
 
 NO IPs, hostnames, or RPC URLs are sent.
 
+Use --dry-run to print the signed payload instead of sending it, e.g. to
+check it with 'monitor verify'.
+
 Examples:
-  monoctl monitor heartbeat --network Sprintnet --home ~/.monod`,
+  monoctl monitor heartbeat --network Sprintnet --home ~/.monod
+  monoctl monitor heartbeat --network Sprintnet --dry-run > payload.json`,
 		Run: runMonitorHeartbeat,
 	}
 
@@ -837,12 +1282,225 @@ Examples:
   monoctl monitor visibility --private --network Sprintnet`,
 		Run: runMonitorVisibility,
 	}
+
+	monitorVerifyCmd = &cobra.Command{
+		Use:   "verify <payload.json>",
+		Short: "Verify a signed heartbeat payload",
+		Long: `Verify a heartbeat payload the way the node-monitor API does.
+
+Checks that the signed fields are canonically encoded, that the signature
+verifies against the public key, and that the timestamp is within --max-skew
+of the local clock. The public key defaults to this machine's monitor key
+(~/.mono-commander/monitor/monitor.pub); use --pubkey for another node's.
+
+Pass - to read the payload from stdin.
+
+Examples:
+  monoctl monitor heartbeat --network Sprintnet --dry-run | monoctl monitor verify -
+  monoctl monitor verify payload.json --pubkey <base64> --ignore-timestamp`,
+		Args: cobra.ExactArgs(1),
+		Run:  runMonitorVerify,
+	}
+
+	monitorServerCmd = &cobra.Command{
+		Use:   "server",
+		Short: "Run a self-hosted node-monitor API",
+		Long: `Run a node-monitor API for a private fleet instead of the hosted service.
+
+The server implements the endpoints used by 'monitor register',
+'monitor heartbeat', 'monitor visibility' and 'gov pending --alert':
+  POST /v1/register/start  register a node's public key (no Telegram linking)
+  POST /v1/heartbeat       verify and record a heartbeat, report lag
+  POST /v1/visibility      set public/private visibility
+  POST /v1/alert           verify and log an alert
+  GET  /v1/nodes           list public nodes (?network=...)
+
+Signed requests are verified against the registered ed25519 key, must be
+within --max-skew of the server clock, and may not reuse a nonce. Lag is measured against the highest
+height reported by any node of the same network in the last 5 minutes.
+Records are kept in a JSON file (--store).
+
+Point nodes at it with --api or the NODEMON_API environment variable.
+
+Examples:
+  monoctl monitor server --listen 0.0.0.0:8090
+  NODEMON_API=http://monitor.internal:8090 monoctl monitor heartbeat --network Sprintnet`,
+		Run: runMonitorServer,
+	}
+
+	// Fleet command group - many nodes from one inventory
+	fleetCmd = &cobra.Command{
+		Use:   "fleet",
+		Short: "Run checks and maintenance across an inventory of nodes",
+		Long: `Run status, health, drift and peers commands across every node listed in an
+inventory file, concurrently, and print one consolidated table (or JSON).
+
+The inventory (default: ~/.mono-commander/fleet.yaml, fleet.yml or fleet.json)
+lists each node's name, network, home, role, deployment mode (systemd or
+docker), RPC host or endpoints, SSH target, optional validator address and
+tags. Files ending in .yaml or .yml are YAML, anything else JSON, with the
+same keys:
+
+  {
+    "nodes": [
+      {"name": "sprint-val-1", "network": "Sprintnet", "role": "validator",
+       "host": "10.0.0.11", "validator": "monovaloper1...", "tags": ["eu"]},
+      {"name": "test-rpc-1", "network": "Testnet", "role": "fullnode",
+       "mode": "docker", "home": "/srv/monod", "ssh": "ops@10.0.1.21"}
+    ]
+  }
+
+Select a subset with --node, --network, --role and --tag.
+
+doctor and peers update work on node homes: on this machine, or over SSH for
+nodes with an "ssh" target (user@host[:port], authenticated with --ssh-key or
+ssh-agent and verified against --ssh-known-hosts). The SSH host is also the
+default RPC host, and a relative home is relative to the SSH user's home.
+
+Commands:
+  monoctl fleet status
+  monoctl fleet health --network Sprintnet
+  monoctl fleet doctor --role validator
+  monoctl fleet peers update --dry-run`,
+	}
+
+	fleetStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show height, sync state and peers of every node",
+		Run:   runFleetStatus,
+	}
+
+	fleetHealthCmd = &cobra.Command{
+		Use:   "health",
+		Short: "Run RPC and validator health checks on every node",
+		Run:   runFleetHealth,
+	}
+
+	fleetDoctorCmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Detect configuration drift in every node home",
+		Long: `Detect configuration drift in every node home against the canonical network
+config, as 'config doctor' does for one node.
+
+Node homes are read on this machine.`,
+		Run: runFleetDoctor,
+	}
+
+	fleetPeersCmd = &cobra.Command{
+		Use:   "peers",
+		Short: "Peer management across the fleet",
+	}
+
+	fleetPeersUpdateCmd = &cobra.Command{
+		Use:   "update",
+		Short: "Write the network peers patch to every node home",
+		Long: `Fetch each network's peers registry once and write config_patch.toml to every
+node home of that network, as 'peers update' does for one node.
+
+Node homes are written on this machine.`,
+		Run: runFleetPeersUpdate,
+	}
+
+	// Snapshot command group - data snapshot restore
+	snapshotCmd = &cobra.Command{
+		Use:   "snapshot",
+		Short: "Data snapshot management",
+		Long: `Restore node data from a published snapshot instead of syncing from genesis.
+
+Commands:
+  monoctl snapshot restore --network <network> --home ~/.monod`,
+	}
+
+	snapshotRestoreCmd = &cobra.Command{
+		Use:   "restore",
+		Short: "Download, verify and extract a data snapshot",
+		Long: `Restore the node data directory from a compressed snapshot.
+
+This command:
+  1. Resolves the snapshot URL and SHA256 from the network registry
+     (or uses --url/--sha256)
+  2. Downloads the archive into the node home
+  3. Verifies the archive SHA256
+  4. Extracts it into <home>/data
+
+priv_validator_state.json is NEVER taken from the snapshot. An existing
+state file is left untouched; otherwise CometBFT creates a fresh one on start.
+
+The data directory must not already contain blockchain databases.
+Run 'monoctl node reset --preserve-keys' first if it does.
+
+Supported formats: .tar.gz, .tar
+
+Examples:
+  monoctl snapshot restore --network Sprintnet --home ~/.monod
+  monoctl snapshot restore --home ~/.monod --url https://example.com/data.tar.gz --sha256 <sha>
+  monoctl snapshot restore --network Sprintnet --home ~/.monod --dry-run`,
+		Run: runSnapshotRestore,
+	}
+
+	// Address book command group - labeled addresses for tx commands
+	addrbookCmd = &cobra.Command{
+		Use:   "addrbook",
+		Short: "Labeled addresses for tx commands",
+		Long: `Keep labeled account and validator addresses in ~/.mono-commander/addrbook.json.
+
+Addresses can be added in bech32 or EVM (0x...) form. They are checked
+(including the bech32 or EIP-55 checksum) and stored as mono1... or
+monovaloper1.... 'bank send --to', 'stake delegate --to' and
+'stake redelegate --src/--dst' accept a label wherever they take an address.
+
+Commands:
+  monoctl addrbook add <label> <address> [--validator] [--note <text>]
+  monoctl addrbook list
+  monoctl addrbook remove <label>`,
+	}
+
+	addrbookAddCmd = &cobra.Command{
+		Use:   "add <label> <address>",
+		Short: "Label an account or validator address",
+		Long: `Label an account or validator operator address.
+
+The address may be mono1..., monovaloper1... or 0x.... With --validator an
+EVM address is stored as the monovaloper1... address of the same key.
+Labels are case-insensitive.
+
+Examples:
+  monoctl addrbook add faucet mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u
+  monoctl addrbook add treasury 0x9858EfFD232B4033E47d90003D41EC34EcaEda94 --note "ops multisig"
+  monoctl addrbook add val-eu monovaloper1... --note "EU validator"
+  monoctl bank send --from ops --to faucet --amount-lyth 1000`,
+		Args: cobra.ExactArgs(2),
+		Run:  runAddrbookAdd,
+	}
+
+	addrbookListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List labeled addresses",
+		Run:   runAddrbookList,
+	}
+
+	addrbookRemoveCmd = &cobra.Command{
+		Use:     "remove <label>",
+		Aliases: []string{"rm"},
+		Short:   "Remove a labeled address",
+		Args:    cobra.ExactArgs(1),
+		Run:     runAddrbookRemove,
+	}
 )
 
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "Output in JSON format")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().StringVar(&sshTarget, "ssh", "", "Run the command on user@host[:port] over SSH")
+	rootCmd.PersistentFlags().StringVar(&sshKey, "ssh-key", "", "SSH private key (default: ssh-agent and ~/.ssh/id_*)")
+	rootCmd.PersistentFlags().StringVar(&sshKnownHosts, "ssh-known-hosts", "", "known_hosts file (default: ~/.ssh/known_hosts)")
+	rootCmd.PersistentFlags().StringVar(&sshBinary, "ssh-binary", "", "monoctl path on the remote host (default: copy this binary)")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if sshTarget != "" {
+			runRemote(cmd)
+		}
+	}
 
 	// Version command
 	rootCmd.AddCommand(versionCmd)
@@ -861,7 +1519,9 @@ func init() {
 	joinCmd.Flags().String("monod-path", "", "Path to monod binary (auto-detected if not specified)")
 	joinCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
 	joinCmd.Flags().Bool("bootstrap", false, "Use bootstrap mode: trusted peers only, pex=false (recommended for deterministic sync)")
+	joinCmd.Flags().String("sync", "", "Sync strategy: default, bootstrap, statesync (statesync uses trusted_rpc_endpoints from the registry)")
 	joinCmd.Flags().Bool("clear-addrbook", false, "Clear addrbook.json to avoid poisoned peers")
+	joinCmd.Flags().Bool("snapshot", false, "Restore data/ from the registry snapshot (verified by SHA256) instead of syncing from genesis")
 	joinCmd.MarkFlagRequired("network")
 	rootCmd.AddCommand(joinCmd)
 
@@ -908,6 +1568,26 @@ func init() {
 	rpcCmd.AddCommand(rpcCheckCmd)
 	rootCmd.AddCommand(rpcCmd)
 
+	// Exporter command flags
+	exporterServeCmd.Flags().String("listen", "127.0.0.1:9464", "Address to serve /metrics on")
+	exporterServeCmd.Flags().String("network", "Localnet", "Network name")
+	exporterServeCmd.Flags().String("home", "", "Node home directory for drift detection (default: ~/.monod)")
+	exporterServeCmd.Flags().String("host", "localhost", "RPC host")
+	exporterServeCmd.Flags().String("comet-rpc", "", "Override Comet RPC endpoint")
+	exporterServeCmd.Flags().String("cosmos-rest", "", "Override Cosmos REST endpoint")
+	exporterServeCmd.Flags().String("evm-rpc", "", "Override EVM RPC endpoint")
+	exporterServeCmd.Flags().String("validator", "", "Validator operator or account address (default: validator_address from config)")
+	exporterServeCmd.Flags().Bool("no-drift", false, "Do not export configuration drift metrics")
+	exporterServeCmd.Flags().Bool("no-mesh", false, "Do not export Mesh/Rosetta sidecar metrics")
+	exporterCmd.AddCommand(exporterServeCmd)
+	rootCmd.AddCommand(exporterCmd)
+
+	// Alerts commands
+	alertsRunCmd.Flags().String("config", "", "Alerts config file (default: ~/.mono-commander/alerts.json)")
+	alertsRunCmd.Flags().Bool("once", false, "Evaluate the rules once and exit")
+	alertsCmd.AddCommand(alertsRunCmd)
+	rootCmd.AddCommand(alertsCmd)
+
 	// Logs command flags
 	logsCmd.Flags().String("network", "Localnet", "Network name")
 	logsCmd.Flags().String("home", "", "Node home directory")
@@ -933,11 +1613,15 @@ func init() {
 	validatorCreateCmd.MarkFlagRequired("moniker")
 	// Note: either amount or self-bond-lyth is required (validated in runValidatorCreate)
 	validatorCmd.AddCommand(validatorCreateCmd)
+
+	addTxFlags(validatorUnjailCmd)
+	validatorUnjailCmd.Flags().String("validator", "", "Validator operator address (monovaloper1...; default: derived from --from)")
+	validatorCmd.AddCommand(validatorUnjailCmd)
 	rootCmd.AddCommand(validatorCmd)
 
 	// M4: Stake delegate command
 	addTxFlags(stakeDelegateCmd)
-	stakeDelegateCmd.Flags().String("to", "", "Validator address (monovaloper1...)")
+	stakeDelegateCmd.Flags().String("to", "", "Validator address (monovaloper1... or 0x...) or address book label")
 	stakeDelegateCmd.Flags().String("amount", "", "Amount to delegate in alyth")
 	stakeDelegateCmd.MarkFlagRequired("to")
 	stakeDelegateCmd.MarkFlagRequired("amount")
@@ -953,8 +1637,8 @@ func init() {
 
 	// M4: Stake redelegate command
 	addTxFlags(stakeRedelegateCmd)
-	stakeRedelegateCmd.Flags().String("src", "", "Source validator address (monovaloper1...)")
-	stakeRedelegateCmd.Flags().String("dst", "", "Destination validator address (monovaloper1...)")
+	stakeRedelegateCmd.Flags().String("src", "", "Source validator address (monovaloper1... or 0x...) or address book label")
+	stakeRedelegateCmd.Flags().String("dst", "", "Destination validator address (monovaloper1... or 0x...) or address book label")
 	stakeRedelegateCmd.Flags().String("amount", "", "Amount to redelegate in alyth")
 	stakeRedelegateCmd.MarkFlagRequired("src")
 	stakeRedelegateCmd.MarkFlagRequired("dst")
@@ -964,7 +1648,7 @@ func init() {
 
 	// M4: Bank send command
 	addTxFlags(bankSendCmd)
-	bankSendCmd.Flags().String("to", "", "Recipient address (mono1...)")
+	bankSendCmd.Flags().String("to", "", "Recipient address (mono1... or 0x...) or address book label")
 	bankSendCmd.Flags().String("amount", "", "Amount in alyth (e.g., 210000000000000000000000alyth)")
 	bankSendCmd.Flags().Int64("amount-lyth", 0, "Amount in LYTH (user-friendly, e.g., 210000 for 210K LYTH)")
 	bankSendCmd.MarkFlagRequired("to")
@@ -985,8 +1669,78 @@ func init() {
 	govVoteCmd.MarkFlagRequired("proposal")
 	govVoteCmd.MarkFlagRequired("option")
 	govCmd.AddCommand(govVoteCmd)
+
+	addTxFlags(govDepositCmd)
+	govDepositCmd.Flags().String("proposal", "", "Proposal ID (required)")
+	govDepositCmd.Flags().String("amount", "", "Deposit amount in alyth (required)")
+	govDepositCmd.MarkFlagRequired("proposal")
+	govDepositCmd.MarkFlagRequired("amount")
+	govCmd.AddCommand(govDepositCmd)
+
+	addTxFlags(govSubmitCmd)
+	govSubmitCmd.Flags().String("deposit", "", "Initial deposit in alyth (overrides the file's deposit)")
+	govCmd.AddCommand(govSubmitCmd)
+
+	addGovQueryFlags(govListCmd)
+	govListCmd.Flags().String("status", "voting", "Proposal status: voting, deposit, passed, rejected, failed, all")
+	govListCmd.Flags().String("voter", "", "Show this address's vote on proposals in their voting period")
+	govListCmd.Flags().Int("limit", 20, "Maximum number of proposals to list")
+	govCmd.AddCommand(govListCmd)
+
+	addGovQueryFlags(govShowCmd)
+	govShowCmd.Flags().String("voter", "", "Show this address's vote")
+	govCmd.AddCommand(govShowCmd)
+
+	addGovQueryFlags(govTallyCmd)
+	govCmd.AddCommand(govTallyCmd)
+
+	addGovQueryFlags(govPendingCmd)
+	govPendingCmd.Flags().String("validator", "", "Validator operator or account address (default: validator_address from config)")
+	govPendingCmd.Flags().Bool("alert", false, "Send pending votes as an alert to a self-hosted node-monitor (--api or NODEMON_API)")
+	govPendingCmd.Flags().String("home", "", "Node home directory for monitor keys (default: ~/.monod)")
+	govPendingCmd.Flags().String("api", "", "Self-hosted node-monitor API endpoint for --alert (default: $NODEMON_API)")
+	govCmd.AddCommand(govPendingCmd)
 	rootCmd.AddCommand(govCmd)
 
+	// Offline signing commands
+	exportable := []struct {
+		name string
+		src  *cobra.Command
+	}{
+		{"create-validator", validatorCreateCmd},
+		{"delegate", stakeDelegateCmd},
+		{"unbond", stakeUnbondCmd},
+		{"redelegate", stakeRedelegateCmd},
+		{"send", bankSendCmd},
+		{"withdraw-rewards", rewardsWithdrawCmd},
+		{"vote", govVoteCmd},
+		{"deposit", govDepositCmd},
+		{"submit-proposal", govSubmitCmd},
+		{"unjail", validatorUnjailCmd},
+	}
+	for _, e := range exportable {
+		txExportUnsignedCmd.AddCommand(newExportUnsignedCmd(e.name, e.src))
+	}
+	txCmd.AddCommand(txExportUnsignedCmd)
+
+	txSignCmd.Flags().String("keystore", "", "Wallet keystore file to sign with (required)")
+	txSignCmd.Flags().String("password-file", "", "Path to file containing the keystore password")
+	txSignCmd.Flags().String("out", "signed_tx.json", "Output path for the signed transaction")
+	txSignCmd.Flags().Bool("offline", false, "Use the account number and sequence from the file; no network access")
+	txSignCmd.Flags().String("rest", "", "Cosmos REST endpoint used without --offline (default: http://localhost:1317)")
+	txSignCmd.Flags().Bool("multisig", false, "Produce a partial signature as a member of the transaction's multisig")
+	txSignCmd.MarkFlagRequired("keystore")
+	txCmd.AddCommand(txSignCmd)
+
+	txMultisignCmd.Flags().String("out", "signed_tx.json", "Output path for the signed transaction")
+	txCmd.AddCommand(txMultisignCmd)
+
+	txBroadcastCmd.Flags().String("rest", "", "Cosmos REST endpoint (default: http://localhost:1317)")
+	txBroadcastCmd.Flags().Bool("wait", true, "Wait for the tx to be included in a block and report its result")
+	txBroadcastCmd.Flags().Duration("wait-timeout", core.DefaultConfirmTimeout, "How long to wait for the tx to be included")
+	txCmd.AddCommand(txBroadcastCmd)
+	rootCmd.AddCommand(txCmd)
+
 	// M5: Mesh/Rosetta API commands
 	// mesh install
 	meshInstallCmd.Flags().String("network", "Localnet", "Network name (Localnet, Sprintnet, Testnet, Mainnet)")
@@ -1044,11 +1798,59 @@ func init() {
 	walletGenerateCmd.Flags().String("password-file", "", "Path to file containing password (alternative to interactive prompt)")
 	walletGenerateCmd.Flags().Bool("show-private-key", false, "Show private key after generation (DANGEROUS)")
 	walletGenerateCmd.Flags().Bool("insecure-show", false, "Required with --show-private-key to confirm understanding")
+	walletGenerateCmd.Flags().Bool("mnemonic", false, "Derive the key from a new BIP39 mnemonic")
+	walletGenerateCmd.Flags().Int("words", 24, "Mnemonic length with --mnemonic (12 or 24)")
+	walletGenerateCmd.Flags().Uint32("index", 0, "BIP44 account index with --mnemonic")
+	walletGenerateCmd.Flags().Bool("bip39-passphrase", false, "Prompt for an optional BIP39 passphrase with --mnemonic")
+	walletGenerateCmd.Flags().Int("count", 1, "Number of wallets to generate")
+	walletGenerateCmd.Flags().String("manifest", "", "Write a .csv or .json manifest of the generated addresses")
+	walletGenerateCmd.Flags().String("prefix", "", "Search for an address starting with these characters")
+	walletGenerateCmd.Flags().String("suffix", "", "Search for an address ending with these characters")
+	walletGenerateCmd.Flags().Bool("vanity-evm", false, "Match --prefix/--suffix against the EVM address instead of bech32")
+	walletGenerateCmd.Flags().Int("workers", 0, "Vanity search goroutines (default: number of CPUs)")
 	walletCmd.AddCommand(walletGenerateCmd)
 
+	walletRecoverCmd.Flags().String("name", "", "Wallet name (optional, used in filename)")
+	walletRecoverCmd.Flags().String("out", "", "Output path for keystore file (default: ~/.mono-commander/wallets/)")
+	walletRecoverCmd.Flags().String("password-file", "", "Path to file containing password (alternative to interactive prompt)")
+	walletRecoverCmd.Flags().String("mnemonic-file", "", "Path to file containing the mnemonic (alternative to interactive prompt)")
+	walletRecoverCmd.Flags().Uint32("index", 0, "BIP44 account index")
+	walletRecoverCmd.Flags().Bool("bip39-passphrase", false, "Prompt for the BIP39 passphrase the mnemonic was created with")
+	walletCmd.AddCommand(walletRecoverCmd)
+
+	walletDeriveCmd.Flags().String("mnemonic-file", "", "Path to file containing the mnemonic (alternative to interactive prompt)")
+	walletDeriveCmd.Flags().Uint32("index", 0, "First BIP44 account index")
+	walletDeriveCmd.Flags().Uint32("count", 1, "Number of accounts to show")
+	walletDeriveCmd.Flags().Bool("bip39-passphrase", false, "Prompt for the BIP39 passphrase the mnemonic was created with")
+	walletCmd.AddCommand(walletDeriveCmd)
+
 	walletListCmd.Flags().String("dir", "", "Directory to list (default: ~/.mono-commander/wallets/)")
 	walletCmd.AddCommand(walletListCmd)
 
+	walletPasswdCmd.Flags().String("old-password-file", "", "Path to file containing the current password")
+	walletPasswdCmd.Flags().String("new-password-file", "", "Path to file containing the new password")
+	walletCmd.AddCommand(walletPasswdCmd)
+
+	walletRekeyCmd.Flags().String("password-file", "", "Path to file containing the password of every keystore")
+	walletRekeyCmd.Flags().Bool("force", false, "Re-encrypt keystores that already use strong parameters")
+	walletCmd.AddCommand(walletRekeyCmd)
+
+	walletImportToKeyringCmd.Flags().String("file", "", "Path to keystore file (required)")
+	walletImportToKeyringCmd.Flags().String("name", "", "Key name in the keyring (required)")
+	walletImportToKeyringCmd.Flags().String("password-file", "", "Path to file containing the keystore password")
+	addKeyringFlags(walletImportToKeyringCmd)
+	walletImportToKeyringCmd.MarkFlagRequired("file")
+	walletImportToKeyringCmd.MarkFlagRequired("name")
+	walletCmd.AddCommand(walletImportToKeyringCmd)
+
+	walletExportFromKeyringCmd.Flags().String("name", "", "Key name in the keyring (required)")
+	walletExportFromKeyringCmd.Flags().String("wallet-name", "", "Wallet name (optional, used in filename)")
+	walletExportFromKeyringCmd.Flags().String("out", "", "Output path for keystore file (default: ~/.mono-commander/wallets/)")
+	walletExportFromKeyringCmd.Flags().String("password-file", "", "Path to file containing the new keystore password")
+	addKeyringFlags(walletExportFromKeyringCmd)
+	walletExportFromKeyringCmd.MarkFlagRequired("name")
+	walletCmd.AddCommand(walletExportFromKeyringCmd)
+
 	walletInfoCmd.Flags().String("file", "", "Path to keystore file (required)")
 	walletInfoCmd.MarkFlagRequired("file")
 	walletCmd.AddCommand(walletInfoCmd)
@@ -1121,18 +1923,31 @@ func init() {
 	nodeResetCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
 	nodeCmd.AddCommand(nodeResetCmd)
 
+	// Node profile flags
+	nodeProfileCmd.PersistentFlags().String("home", "", "Node home directory (default: ~/.monod)")
+	nodeProfileCmd.PersistentFlags().String("profiles-dir", "", "Profiles directory (default: ~/.mono-commander/profiles)")
+	nodeProfileApplyCmd.Flags().String("network", "", "Network name (for profile conditions)")
+	nodeProfileApplyCmd.Flags().Bool("dry-run", false, "Show the changes without applying them")
+	nodeProfileCmd.AddCommand(nodeProfileListCmd)
+	nodeProfileCmd.AddCommand(nodeProfileApplyCmd)
+	nodeProfileCmd.AddCommand(nodeProfileClearCmd)
+	nodeCmd.AddCommand(nodeProfileCmd)
+
 	rootCmd.AddCommand(nodeCmd)
 
 	// Config commands - drift detection and repair
 	configDoctorCmd.Flags().String("network", "", "Network name (Sprintnet, Testnet, Mainnet)")
 	configDoctorCmd.Flags().String("home", "", "Node home directory (default: ~/.monod)")
 	configDoctorCmd.MarkFlagRequired("network")
+	addDriftCheckFlags(configDoctorCmd)
 	configCmd.AddCommand(configDoctorCmd)
 
 	configRepairCmd.Flags().String("network", "", "Network name (Sprintnet, Testnet, Mainnet)")
 	configRepairCmd.Flags().String("home", "", "Node home directory (default: ~/.monod)")
 	configRepairCmd.Flags().Bool("dry-run", false, "Preview changes without applying them")
 	configRepairCmd.MarkFlagRequired("network")
+	addDriftCheckFlags(configRepairCmd)
+	configRepairCmd.Flags().Bool("apply-security-fixes", false, "Bind RPC/API listeners to 127.0.0.1 and disable CORS and unsafe RPC")
 	configCmd.AddCommand(configRepairCmd)
 
 	rootCmd.AddCommand(configCmd)
@@ -1150,6 +1965,7 @@ func init() {
 	monitorHeartbeatCmd.Flags().String("network", "", "Network name (Sprintnet, Testnet, Mainnet)")
 	monitorHeartbeatCmd.Flags().String("home", "", "Node home directory (default: ~/.monod)")
 	monitorHeartbeatCmd.Flags().String("api", "", "Node-monitor API endpoint (default: https://nodemon.mononodes.xyz)")
+	monitorHeartbeatCmd.Flags().Bool("dry-run", false, "Print the signed payload instead of sending it")
 	monitorHeartbeatCmd.MarkFlagRequired("network")
 	monitorCmd.AddCommand(monitorHeartbeatCmd)
 
@@ -1174,14 +1990,59 @@ func init() {
 	monitorVisibilityCmd.MarkFlagRequired("network")
 	monitorCmd.AddCommand(monitorVisibilityCmd)
 
+	monitorVerifyCmd.Flags().String("pubkey", "", "Base64 ed25519 public key (default: local monitor.pub)")
+	monitorVerifyCmd.Flags().Duration("max-skew", core.DefaultMonitorMaxSkew, "Maximum allowed clock skew")
+	monitorVerifyCmd.Flags().Bool("ignore-timestamp", false, "Skip the clock skew check, e.g. for old payloads")
+	monitorCmd.AddCommand(monitorVerifyCmd)
+
+	monitorServerCmd.Flags().String("listen", "127.0.0.1:8090", "Address to serve the API on")
+	monitorServerCmd.Flags().String("store", "", "Node records file (default: ~/.mono-commander/nodemon.json)")
+	monitorServerCmd.Flags().Duration("max-skew", core.DefaultMonitorMaxSkew, "Maximum allowed clock skew of signed requests")
+	monitorServerCmd.Flags().Int64("lag-threshold", nodemon.DefaultLagThreshold, "Blocks behind the canonical height before a node is lagging")
+	monitorCmd.AddCommand(monitorServerCmd)
+
 	rootCmd.AddCommand(monitorCmd)
+
+	// Fleet commands
+	fleetCmd.PersistentFlags().String("inventory", "", "Inventory file, YAML or JSON (default: ~/.mono-commander/fleet.yaml, fleet.yml or fleet.json)")
+	fleetCmd.PersistentFlags().StringSlice("node", nil, "Only these nodes (by name, repeatable)")
+	fleetCmd.PersistentFlags().String("network", "", "Only nodes of this network")
+	fleetCmd.PersistentFlags().String("role", "", "Only nodes with this role")
+	fleetCmd.PersistentFlags().String("tag", "", "Only nodes with this tag")
+	fleetCmd.PersistentFlags().Int("concurrency", fleet.DefaultConcurrency, "Nodes to work on at once")
+	fleetCmd.AddCommand(fleetStatusCmd)
+	fleetCmd.AddCommand(fleetHealthCmd)
+	fleetCmd.AddCommand(fleetDoctorCmd)
+	fleetPeersUpdateCmd.Flags().Bool("dry-run", false, "Show the patches without writing them")
+	fleetPeersCmd.AddCommand(fleetPeersUpdateCmd)
+	fleetCmd.AddCommand(fleetPeersCmd)
+	rootCmd.AddCommand(fleetCmd)
+
+	// Snapshot commands
+	snapshotRestoreCmd.Flags().String("network", "", "Network name (uses the registry snapshot_url)")
+	snapshotRestoreCmd.Flags().String("home", "", "Node home directory (default: ~/.monod)")
+	snapshotRestoreCmd.Flags().String("url", "", "Snapshot URL (overrides the registry)")
+	snapshotRestoreCmd.Flags().String("sha256", "", "Expected SHA256 of the snapshot archive (overrides the registry)")
+	snapshotRestoreCmd.Flags().Bool("insecure", false, "Skip checksum verification (not recommended)")
+	snapshotRestoreCmd.Flags().Bool("dry-run", false, "Show what would be done without making changes")
+	snapshotCmd.AddCommand(snapshotRestoreCmd)
+
+	rootCmd.AddCommand(snapshotCmd)
+
+	// Address book commands
+	addrbookAddCmd.Flags().Bool("validator", false, "The address is a validator operator (EVM addresses become monovaloper1...)")
+	addrbookAddCmd.Flags().String("note", "", "Free-form note shown by addrbook list")
+	addrbookCmd.AddCommand(addrbookAddCmd)
+	addrbookCmd.AddCommand(addrbookListCmd)
+	addrbookCmd.AddCommand(addrbookRemoveCmd)
+	rootCmd.AddCommand(addrbookCmd)
 }
 
 // addTxFlags adds common transaction flags to a command
 func addTxFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&txNetwork, "network", "Localnet", "Network name (Localnet, Sprintnet, Testnet, Mainnet)")
 	cmd.Flags().StringVar(&txHome, "home", "", "Node home directory (default: ~/.monod)")
-	cmd.Flags().StringVar(&txFrom, "from", "", "Key name or address to sign with (required)")
+	cmd.Flags().StringVar(&txFrom, "from", "", "Key name or address to sign with (required unless --keystore is set)")
 	cmd.Flags().StringVar(&txFees, "fees", "", "Transaction fees in alyth (e.g., 10000alyth)")
 	cmd.Flags().StringVar(&txGasPrices, "gas-prices", "", "Gas prices in alyth (e.g., 0.025alyth)")
 	cmd.Flags().StringVar(&txGas, "gas", "auto", "Gas limit or 'auto'")
@@ -1190,7 +2051,14 @@ func addTxFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&txKeyringBackend, "keyring-backend", "", "Keyring backend (os|file|test|memory)")
 	cmd.Flags().BoolVar(&txDryRun, "dry-run", true, "Only show command, do not execute")
 	cmd.Flags().BoolVar(&txExecute, "execute", false, "Execute the transaction (overrides dry-run)")
-	cmd.MarkFlagRequired("from")
+	cmd.Flags().String("keystore", "", "Sign natively with a wallet keystore file instead of monod")
+	cmd.Flags().String("password-file", "", "Path to file containing the keystore password")
+	cmd.Flags().String("rest", "", "Cosmos REST endpoint for native signing (default: http://localhost:1317)")
+	cmd.Flags().Bool("simulate", true, "Simulate the tx over Cosmos REST to estimate gas and fees (requires --from as an address)")
+	cmd.Flags().Float64("gas-adjustment", core.DefaultGasAdjustment, "Multiplier applied to simulated gas")
+	cmd.Flags().Bool("wait", true, "Wait for an executed tx to be included in a block and report its result")
+	cmd.Flags().Duration("wait-timeout", core.DefaultConfirmTimeout, "How long to wait for the tx to be included")
+	cmd.Flags().String("multisig-pubkey", "", "Multisig public key JSON file; sets --from to the multisig address (implies generate-only)")
 }
 
 func main() {
@@ -1252,7 +2120,9 @@ func runJoin(cmd *cobra.Command, args []string) {
 	monodPath, _ := cmd.Flags().GetString("monod-path")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	bootstrap, _ := cmd.Flags().GetBool("bootstrap")
+	syncFlag, _ := cmd.Flags().GetString("sync")
 	clearAddrbook, _ := cmd.Flags().GetBool("clear-addrbook")
+	useSnapshot, _ := cmd.Flags().GetBool("snapshot")
 
 	// Parse network name
 	network, err := core.ParseNetworkName(networkStr)
@@ -1280,6 +2150,18 @@ func runJoin(cmd *cobra.Command, args []string) {
 	if bootstrap {
 		syncStrategy = core.SyncStrategyBootstrap
 	}
+	if syncFlag != "" {
+		parsed, err := core.ParseSyncStrategy(syncFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if bootstrap && parsed != core.SyncStrategyBootstrap {
+			fmt.Fprintf(os.Stderr, "Error: --bootstrap conflicts with --sync %s\n", parsed)
+			os.Exit(1)
+		}
+		syncStrategy = parsed
+	}
 
 	opts := core.JoinOptions{
 		Network:       network,
@@ -1295,6 +2177,28 @@ func runJoin(cmd *cobra.Command, args []string) {
 		MonodPath:     monodPath,
 	}
 
+	if useSnapshot {
+		netConfig, err := core.GetNetworkConfigWithCache(strings.ToLower(string(network)), core.DefaultRef)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load network registry: %v\n", err)
+			os.Exit(1)
+		}
+		snapshotURL, snapshotSHA, err := core.ResolveSnapshotSource(netConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if snapshotSHA == "" {
+			fmt.Fprintf(os.Stderr, "Error: registry has no snapshot_sha256 for %s; refusing unverified snapshot\n", network)
+			os.Exit(1)
+		}
+		opts.SnapshotURL = snapshotURL
+		opts.SnapshotSHA256 = snapshotSHA
+		if !jsonOutput {
+			opts.SnapshotProgress = printSnapshotProgress
+		}
+	}
+
 	fetcher := net.NewHTTPFetcher()
 	result, err := core.Join(opts, fetcher)
 
@@ -1309,9 +2213,12 @@ func runJoin(cmd *cobra.Command, args []string) {
 
 	// Print steps
 	fmt.Printf("Join Network: %s\n", network)
-	if bootstrap {
+	switch syncStrategy {
+	case core.SyncStrategyBootstrap:
 		fmt.Println("Mode: BOOTSTRAP (trusted peers only, pex=false)")
 		fmt.Println("      Using bootstrap_peers for deterministic genesis sync")
+	case core.SyncStrategyStateSync:
+		fmt.Println("Mode: STATE SYNC (restore from snapshot, verified against trusted RPCs)")
 	}
 	if dryRun {
 		fmt.Println("(DRY RUN - no changes will be made)")
@@ -1564,13 +2471,17 @@ func runStatus(cmd *cobra.Command, args []string) {
 	}
 }
 
-func runRPCCheck(cmd *cobra.Command, args []string) {
+func runExporterServe(cmd *cobra.Command, args []string) {
+	listen, _ := cmd.Flags().GetString("listen")
 	networkStr, _ := cmd.Flags().GetString("network")
+	home, _ := cmd.Flags().GetString("home")
 	host, _ := cmd.Flags().GetString("host")
-	useRemote, _ := cmd.Flags().GetBool("remote")
 	cometRPC, _ := cmd.Flags().GetString("comet-rpc")
 	cosmosREST, _ := cmd.Flags().GetString("cosmos-rest")
 	evmRPC, _ := cmd.Flags().GetString("evm-rpc")
+	validator, _ := cmd.Flags().GetString("validator")
+	noDrift, _ := cmd.Flags().GetBool("no-drift")
+	noMesh, _ := cmd.Flags().GetBool("no-mesh")
 
 	network, err := core.ParseNetworkName(networkStr)
 	if err != nil {
@@ -1578,45 +2489,190 @@ func runRPCCheck(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	endpoints := resolveEndpoints(string(network), host, useRemote, cometRPC, cosmosREST, evmRPC)
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
+		os.Exit(1)
+	}
+	if home == "" {
+		home = filepath.Join(homeDir, ".monod")
+	}
 
-	results := core.CheckRPC(network, endpoints)
+	cfg := exporter.Config{
+		Network:   network,
+		Endpoints: resolveEndpoints(string(network), host, false, cometRPC, cosmosREST, evmRPC),
+		Home:      home,
+	}
 
-	if jsonOutput {
-		data, _ := json.MarshalIndent(results, "", "  ")
-		fmt.Println(string(data))
-		if !results.AllPass {
-			os.Exit(1)
+	if validator == "" {
+		if tuiCfg, err := tui.LoadConfig(); err == nil {
+			validator = tuiCfg.ValidatorAddress
 		}
-		return
 	}
-
-	// Human-readable output
-	fmt.Printf("RPC Health Check - %s\n", network)
-	fmt.Println(strings.Repeat("-", 50))
-
-	for _, r := range results.Results {
-		status := "[PASS]"
-		if r.Status == "FAIL" {
-			status = "[FAIL]"
+	if validator != "" {
+		if core.ValidateAddress(validator) == nil {
+			validator, _ = core.AccountToValoperAddress(validator)
 		}
-		fmt.Printf("%s %s (%s)\n", status, r.Type, r.Endpoint)
-		if r.Details != "" {
-			fmt.Printf("       %s\n", r.Details)
+		if err := core.ValidateValoperAddress(validator); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --validator: %v\n", err)
+			os.Exit(1)
 		}
-		if r.Message != "" {
-			fmt.Printf("       Error: %s\n", r.Message)
+		cfg.Validator = validator
+	}
+
+	if !noDrift {
+		// Prefer the canonical config; fall back to the built-in network
+		canonical, err := core.GetNetworkFromCanonical(network, "main")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not fetch canonical config, using built-in values: %v\n", err)
+			if canonical, err = core.GetNetwork(network); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
 		}
+		cfg.Drift = &core.DriftConfig{CosmosChainID: canonical.ChainID, EVMChainID: canonical.EVMChainID}
+	}
+	if !noMesh {
+		cfg.MeshHome = homeDir
 	}
 
-	fmt.Println()
-	if results.AllPass {
-		fmt.Println("All RPC endpoints healthy.")
-	} else {
-		fmt.Println("Some RPC endpoints failed.")
-		os.Exit(1)
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           exporter.New(cfg).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
 	}
-}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+
+	fmt.Printf("Serving %s metrics on http://%s/metrics\n", network, listen)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runAlertsRun(cmd *cobra.Command, args []string) {
+	configPath, _ := cmd.Flags().GetString("config")
+	once, _ := cmd.Flags().GetBool("once")
+
+	if configPath == "" {
+		var err error
+		if configPath, err = alerts.DefaultConfigPath(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	cfg, err := alerts.LoadConfig(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if cfg.Home == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
+			os.Exit(1)
+		}
+		cfg.Home = filepath.Join(homeDir, ".monod")
+	}
+	network := core.NetworkName(cfg.Network)
+
+	// Prefer the canonical config for drift detection; fall back to the
+	// built-in network
+	var drift *core.DriftConfig
+	canonical, err := core.GetNetworkFromCanonical(network, "main")
+	if err != nil {
+		canonical, err = core.GetNetwork(network)
+	}
+	if err == nil {
+		drift = &core.DriftConfig{CosmosChainID: canonical.ChainID, EVMChainID: canonical.EVMChainID}
+	}
+
+	engine := alerts.NewEngine(cfg, alerts.NewCollector(cfg, network, drift), alerts.NewNotifiers(cfg.Notifiers))
+
+	if once {
+		sent, err := engine.Evaluate(context.Background())
+		for _, a := range sent {
+			fmt.Println(a.Summary())
+		}
+		if len(sent) == 0 {
+			fmt.Println("No alerts firing")
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: notification failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Evaluating %d rules for %s every %s (%d notifiers)\n",
+		len(cfg.Rules), network, time.Duration(cfg.Interval), len(cfg.Notifiers))
+	engine.Run(ctx, os.Stdout)
+}
+
+func runRPCCheck(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	host, _ := cmd.Flags().GetString("host")
+	useRemote, _ := cmd.Flags().GetBool("remote")
+	cometRPC, _ := cmd.Flags().GetString("comet-rpc")
+	cosmosREST, _ := cmd.Flags().GetString("cosmos-rest")
+	evmRPC, _ := cmd.Flags().GetString("evm-rpc")
+
+	network, err := core.ParseNetworkName(networkStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	endpoints := resolveEndpoints(string(network), host, useRemote, cometRPC, cosmosREST, evmRPC)
+
+	results := core.CheckRPC(network, endpoints)
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+		if !results.AllPass {
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Human-readable output
+	fmt.Printf("RPC Health Check - %s\n", network)
+	fmt.Println(strings.Repeat("-", 50))
+
+	for _, r := range results.Results {
+		status := "[PASS]"
+		if r.Status == "FAIL" {
+			status = "[FAIL]"
+		}
+		fmt.Printf("%s %s (%s)\n", status, r.Type, r.Endpoint)
+		if r.Details != "" {
+			fmt.Printf("       %s\n", r.Details)
+		}
+		if r.Message != "" {
+			fmt.Printf("       Error: %s\n", r.Message)
+		}
+	}
+
+	fmt.Println()
+	if results.AllPass {
+		fmt.Println("All RPC endpoints healthy.")
+	} else {
+		fmt.Println("Some RPC endpoints failed.")
+		os.Exit(1)
+	}
+}
 
 // resolveEndpoints resolves RPC endpoints based on network and options.
 func resolveEndpoints(network, host string, useRemote bool, cometRPC, cosmosREST, evmRPC string) core.Endpoints {
@@ -1738,6 +2794,87 @@ func getTxOptions(cmd *cobra.Command) core.ValidatorActionOptions {
 	keyringBackend, _ := cmd.Flags().GetString("keyring-backend")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	execute, _ := cmd.Flags().GetBool("execute")
+	keystorePath, _ := cmd.Flags().GetString("keystore")
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	rest, _ := cmd.Flags().GetString("rest")
+	multisigPath, _ := cmd.Flags().GetString("multisig-pubkey")
+	simulate, _ := cmd.Flags().GetBool("simulate")
+	gasAdjustment, _ := cmd.Flags().GetFloat64("gas-adjustment")
+	wait, _ := cmd.Flags().GetBool("wait")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	if !wait {
+		waitTimeout = 0
+	}
+
+	// Exporting never signs or broadcasts
+	if isExportUnsigned(cmd) {
+		execute = false
+		// A given account number means no network access is wanted
+		if accountNumber, _ := cmd.Flags().GetInt64("account-number"); accountNumber >= 0 {
+			simulate = false
+		}
+	}
+
+	// Native signing: the keystore address is the sender
+	var signer *core.TxSigner
+	if keystorePath != "" {
+		ks, err := walletgen.LoadKeystore(keystorePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading keystore: %v\n", err)
+			os.Exit(1)
+		}
+		address, err := walletgen.GetKeystoreBech32Address(ks)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading keystore address: %v\n", err)
+			os.Exit(1)
+		}
+		if from == "" {
+			from = address
+		}
+
+		// Only decrypt when the key is actually needed
+		if execute {
+			password, err := readPassword(passwordFile, "Enter keystore password: ")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading password: %v\n", err)
+				os.Exit(1)
+			}
+			signer, err = core.LoadKeystoreSigner(keystorePath, password)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	// The multisig address is derived from its key; --from may only repeat it
+	var multisig *core.MultisigPubKey
+	if multisigPath != "" {
+		if keystorePath != "" {
+			fmt.Fprintf(os.Stderr, "Error: --keystore cannot be used with --multisig-pubkey; members sign with 'tx sign --multisig'\n")
+			os.Exit(1)
+		}
+		var err error
+		if multisig, err = core.LoadMultisigPubKey(multisigPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading multisig public key: %v\n", err)
+			os.Exit(1)
+		}
+		address, err := multisig.Address()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if from == "" {
+			from = address
+		} else if err := multisig.CheckAddress(from); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if from == "" {
+		fmt.Fprintf(os.Stderr, "Error: --from, --keystore or --multisig-pubkey is required\n")
+		os.Exit(1)
+	}
 
 	if home == "" {
 		homeDir, _ := os.UserHomeDir()
@@ -1767,7 +2904,108 @@ func getTxOptions(cmd *cobra.Command) core.ValidatorActionOptions {
 		DryRun:         dryRun && !execute, // execute overrides dry-run
 		Execute:        execute,
 		Logger:         logger,
+		Signer:         signer,
+		REST:           rest,
+		Multisig:       multisig,
+		Simulate:       simulate,
+		GasAdjustment:  gasAdjustment,
+		ConfirmTimeout: waitTimeout,
+	}
+}
+
+// exportUnsignedAnnotation marks the tx commands registered under 'tx export-unsigned'.
+const exportUnsignedAnnotation = "export-unsigned"
+
+// isExportUnsigned reports whether cmd was invoked through 'tx export-unsigned'.
+func isExportUnsigned(cmd *cobra.Command) bool {
+	return cmd.Annotations[exportUnsignedAnnotation] == "true"
+}
+
+// newExportUnsignedCmd mirrors a tx command under 'tx export-unsigned'. It
+// shares the source command's flags and run function; finishTxAction writes
+// the unsigned file instead of printing the dry-run.
+func newExportUnsignedCmd(name string, src *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:         name,
+		Short:       src.Short,
+		Annotations: map[string]string{exportUnsignedAnnotation: "true"},
+		Run:         src.Run,
+	}
+	cmd.Flags().AddFlagSet(src.Flags())
+	cmd.Flags().String("out", "unsigned_tx.json", "Output path for the unsigned transaction")
+	cmd.Flags().Int64("account-number", -1, "Account number (default: fetched over Cosmos REST)")
+	cmd.Flags().Int64("sequence", -1, "Account sequence (default: fetched over Cosmos REST)")
+	return cmd
+}
+
+// finishTxAction prints the result of a tx command, or writes the unsigned
+// transaction when invoked through 'tx export-unsigned'.
+func finishTxAction(cmd *cobra.Command, opts core.ValidatorActionOptions, result *core.ValidatorActionResult) {
+	if !isExportUnsigned(cmd) || result == nil || result.Command == nil {
+		printActionResult(result)
+		return
+	}
+
+	outPath, _ := cmd.Flags().GetString("out")
+	accountNumber, _ := cmd.Flags().GetInt64("account-number")
+	sequence, _ := cmd.Flags().GetInt64("sequence")
+
+	var account *rpc.Account
+	if accountNumber >= 0 || sequence >= 0 {
+		if accountNumber < 0 || sequence < 0 {
+			fmt.Fprintf(os.Stderr, "Error: --account-number and --sequence must be given together\n")
+			os.Exit(1)
+		}
+		account = &rpc.Account{Address: opts.From, AccountNumber: uint64(accountNumber), Sequence: uint64(sequence)}
+	}
+
+	// Export with the simulated gas limit
+	if result.GasEstimate != nil {
+		opts.Gas = strconv.FormatUint(result.GasEstimate.GasLimit, 10)
+	}
+
+	file, err := core.ExportUnsignedTx(context.Background(), opts, result.Command, account)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := core.WriteTxFile(outPath, file); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outPath, err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(file, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("%s\n", result.Description)
+	fmt.Println(strings.Repeat("-", 60))
+	fmt.Printf("Unsigned tx:    %s\n", outPath)
+	fmt.Printf("Signer:         %s\n", file.Signer)
+	fmt.Printf("Chain ID:       %s\n", file.ChainID)
+	fmt.Printf("Account number: %d\n", file.AccountNumber)
+	fmt.Printf("Sequence:       %d\n", file.Sequence)
+	fmt.Printf("Messages:       %d\n", len(file.Tx.Body.Messages))
+	if file.Multisig != nil {
+		fmt.Printf("Multisig:       %d-of-%d\n", file.Multisig.Threshold, len(file.Multisig.PublicKeys))
 	}
+	for _, warn := range result.Warnings {
+		fmt.Printf("\nWARNING: %s\n", warn)
+	}
+	fmt.Println()
+	fmt.Println("Next steps:")
+	if file.Multisig != nil {
+		fmt.Printf("  1. Give %s to at least %d members\n", outPath, file.Multisig.Threshold)
+		fmt.Printf("  2. Each member: monoctl tx sign %s --multisig --offline --keystore <member.json> --out sig-<member>.json\n", outPath)
+		fmt.Printf("  3. monoctl tx multisign %s sig-<a>.json sig-<b>.json --out signed_tx.json\n", outPath)
+		fmt.Println("  4. monoctl tx broadcast signed_tx.json")
+		return
+	}
+	fmt.Printf("  1. Copy %s to the offline machine\n", outPath)
+	fmt.Printf("  2. monoctl tx sign %s --offline --keystore <wallet.json> --out signed_tx.json\n", outPath)
+	fmt.Println("  3. Copy signed_tx.json back and run: monoctl tx broadcast signed_tx.json")
 }
 
 // printActionResult prints the result of a validator action
@@ -1805,6 +3043,21 @@ func printActionResult(result *core.ValidatorActionResult) {
 		fmt.Printf("\nWARNING: %s\n", warn)
 	}
 
+	// Print estimated cost
+	if est := result.GasEstimate; est != nil {
+		fmt.Println("\nEstimated cost:")
+		if est.Adjustment > 0 {
+			fmt.Printf("  Gas: %d (simulated %d x %g)\n", est.GasLimit, est.GasUsed, est.Adjustment)
+		} else {
+			fmt.Printf("  Gas: %d (simulated %d)\n", est.GasLimit, est.GasUsed)
+		}
+		if fee := est.FeeLYTH(); fee != "" {
+			fmt.Printf("  Fee: %s\n", fee)
+		} else {
+			fmt.Println("  Fee: pass --fees or --gas-prices to estimate")
+		}
+	}
+
 	// Print command preview
 	if result.Command != nil && !result.Executed {
 		fmt.Println("\nGenerated command:")
@@ -1821,17 +3074,67 @@ func printActionResult(result *core.ValidatorActionResult) {
 
 	// Print execution result
 	if result.Executed {
-		if result.Success {
+		switch {
+		case result.Success && result.Confirmed:
+			fmt.Println("\nTransaction confirmed!")
+		case result.Success:
 			fmt.Println("\nTransaction submitted successfully!")
-			if result.TxHash != "" {
-				fmt.Printf("  TxHash: %s\n", result.TxHash)
-			}
-			if result.Height > 0 {
-				fmt.Printf("  Height: %d\n", result.Height)
-			}
-		} else {
+		default:
 			fmt.Println("\nTransaction failed!")
 		}
+		if result.TxHash != "" {
+			fmt.Printf("  TxHash:   %s\n", result.TxHash)
+		}
+		if result.Height > 0 {
+			fmt.Printf("  Height:   %d\n", result.Height)
+		}
+		if result.Confirmed {
+			fmt.Printf("  Gas used: %d / %d\n", result.GasUsed, result.GasWanted)
+		}
+		if result.Code != 0 {
+			fmt.Printf("  Code:     %d (%s)\n", result.Code, result.Codespace)
+		}
+		printTxEvents(result.Events)
+	}
+}
+
+// printTxEvents prints the events of a confirmed transaction; attributes are
+// only shown with --verbose.
+func printTxEvents(events []rpc.TxEvent) {
+	if len(events) == 0 {
+		return
+	}
+	if !verbose {
+		fmt.Printf("  Events:   %d (use --verbose to show)\n", len(events))
+		return
+	}
+	fmt.Println("  Events:")
+	for _, ev := range events {
+		attrs := make([]string, 0, len(ev.Attributes))
+		for _, attr := range ev.Attributes {
+			attrs = append(attrs, attr.Key+"="+attr.Value)
+		}
+		fmt.Printf("    %s: %s\n", ev.Type, strings.Join(attrs, ", "))
+	}
+}
+
+func runValidatorUnjail(cmd *cobra.Command, args []string) {
+	opts := getTxOptions(cmd)
+
+	validator, _ := cmd.Flags().GetString("validator")
+	params := core.UnjailParams{ValidatorAddr: validator}
+
+	ctx := context.Background()
+	result, err := core.UnjailAction(ctx, opts, params)
+	if err != nil && result == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	finishTxAction(cmd, opts, result)
+
+	if result != nil && !result.Success {
+		os.Exit(1)
 	}
 }
 
@@ -1901,7 +3204,7 @@ func runValidatorCreate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	printActionResult(result)
+	finishTxAction(cmd, opts, result)
 
 	if result != nil && !result.Success {
 		os.Exit(1)
@@ -1913,6 +3216,7 @@ func runStakeDelegate(cmd *cobra.Command, args []string) {
 
 	validatorAddr, _ := cmd.Flags().GetString("to")
 	amount, _ := cmd.Flags().GetString("amount")
+	validatorAddr = resolveAddressArg(validatorAddr, true)
 
 	params := core.DelegateParams{
 		ValidatorAddr: validatorAddr,
@@ -1926,7 +3230,7 @@ func runStakeDelegate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	printActionResult(result)
+	finishTxAction(cmd, opts, result)
 
 	if result != nil && !result.Success {
 		os.Exit(1)
@@ -1951,7 +3255,7 @@ func runStakeUnbond(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	printActionResult(result)
+	finishTxAction(cmd, opts, result)
 
 	if result != nil && !result.Success {
 		os.Exit(1)
@@ -1964,6 +3268,8 @@ func runStakeRedelegate(cmd *cobra.Command, args []string) {
 	srcValidator, _ := cmd.Flags().GetString("src")
 	dstValidator, _ := cmd.Flags().GetString("dst")
 	amount, _ := cmd.Flags().GetString("amount")
+	srcValidator = resolveAddressArg(srcValidator, true)
+	dstValidator = resolveAddressArg(dstValidator, true)
 
 	params := core.RedelegateParams{
 		SrcValidatorAddr: srcValidator,
@@ -1978,7 +3284,7 @@ func runStakeRedelegate(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	printActionResult(result)
+	finishTxAction(cmd, opts, result)
 
 	if result != nil && !result.Success {
 		os.Exit(1)
@@ -1991,6 +3297,7 @@ func runBankSend(cmd *cobra.Command, args []string) {
 	toAddr, _ := cmd.Flags().GetString("to")
 	amountRaw, _ := cmd.Flags().GetString("amount")
 	amountLYTH, _ := cmd.Flags().GetInt64("amount-lyth")
+	toAddr = resolveAddressArg(toAddr, false)
 
 	// Validate: exactly one of --amount or --amount-lyth must be provided
 	if amountRaw == "" && amountLYTH == 0 {
@@ -2023,13 +3330,13 @@ func runBankSend(cmd *cobra.Command, args []string) {
 	}
 
 	// For dry-run, print the exact monod command
-	if result != nil && result.Command != nil && !result.Executed {
+	if result != nil && result.Command != nil && !result.Executed && !isExportUnsigned(cmd) {
 		fmt.Println("\n=== DRY-RUN: Command to execute ===")
 		fmt.Printf("%s\n", result.Command.String())
 		fmt.Println("\nTo execute, add --execute flag")
 	}
 
-	printActionResult(result)
+	finishTxAction(cmd, opts, result)
 
 	if result != nil && !result.Success {
 		os.Exit(1)
@@ -2054,7 +3361,7 @@ func runRewardsWithdraw(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	printActionResult(result)
+	finishTxAction(cmd, opts, result)
 
 	if result != nil && !result.Success {
 		os.Exit(1)
@@ -2086,92 +3393,118 @@ func runGovVote(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	printActionResult(result)
+	finishTxAction(cmd, opts, result)
 
 	if result != nil && !result.Success {
 		os.Exit(1)
 	}
 }
 
-// =============================================================================
-// M5: Mesh/Rosetta API Commands
-// =============================================================================
+func runGovDeposit(cmd *cobra.Command, args []string) {
+	opts := getTxOptions(cmd)
 
-func runMeshInstall(cmd *cobra.Command, args []string) {
-	url, _ := cmd.Flags().GetString("url")
-	sha256sum, _ := cmd.Flags().GetString("sha256")
-	version, _ := cmd.Flags().GetString("version")
-	insecure, _ := cmd.Flags().GetBool("insecure")
-	useSystem, _ := cmd.Flags().GetBool("system")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	proposalID, _ := cmd.Flags().GetString("proposal")
+	amount, _ := cmd.Flags().GetString("amount")
 
-	opts := mesh.InstallOptions{
-		URL:           url,
-		SHA256:        sha256sum,
-		Version:       version,
-		UseSystemPath: useSystem,
-		Insecure:      insecure,
-		DryRun:        dryRun,
+	params := core.DepositParams{
+		ProposalID: proposalID,
+		Amount:     amount,
 	}
 
-	result := mesh.Install(opts)
-
-	if jsonOutput {
-		data, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println(string(data))
-		if !result.Success {
-			os.Exit(1)
-		}
-		return
+	ctx := context.Background()
+	result, err := core.DepositAction(ctx, opts, params)
+	if err != nil && result == nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	// Human-readable output
-	fmt.Println("Mesh/Rosetta API Binary Installation")
-	fmt.Println(strings.Repeat("-", 50))
+	finishTxAction(cmd, opts, result)
 
-	if dryRun {
-		fmt.Println("(DRY RUN - no changes will be made)")
-		fmt.Println()
+	if result != nil && !result.Success {
+		os.Exit(1)
 	}
+}
 
-	for _, step := range result.Steps {
-		status := "[ ]"
-		switch step.Status {
-		case "success":
-			status = "[+]"
-		case "failed":
-			status = "[X]"
-		case "skipped":
-			status = "[-]"
+func runGovSubmit(cmd *cobra.Command, args []string) {
+	opts := getTxOptions(cmd)
+	deposit, _ := cmd.Flags().GetString("deposit")
+
+	path := args[0]
+	proposal, err := core.LoadProposalFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// monod reads the deposit from the file, so an override needs a copy.
+	// An executed command only needs it while monod runs; a previewed or
+	// exported command refers to it, so it is kept next to the original.
+	cleanup := func() {}
+	if deposit != "" && deposit != proposal.Deposit {
+		if err := core.ValidateAmount(deposit); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: deposit: %v\n", err)
+			os.Exit(1)
 		}
-		msg := step.Name
-		if step.Message != "" {
-			msg += ": " + step.Message
+		proposal.Deposit = deposit
+		temporary := opts.Execute && !isExportUnsigned(cmd)
+		if temporary {
+			f, err := os.CreateTemp("", "proposal-*.json")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			f.Close()
+			path = f.Name()
+			tmpPath := path
+			cleanup = func() { os.Remove(tmpPath) }
+		} else {
+			path = strings.TrimSuffix(path, filepath.Ext(path)) + ".deposit-" + deposit + ".json"
+		}
+		if err := core.WriteProposalFile(path, proposal); err != nil {
+			cleanup()
+			fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		if !temporary {
+			fmt.Fprintf(os.Stderr, "Wrote the proposal with deposit %s to %s\n", deposit, path)
 		}
-		fmt.Printf("%s %s\n", status, msg)
 	}
 
-	if result.Error != nil {
-		fmt.Fprintf(os.Stderr, "\nError: %v\n", result.Error)
+	params := core.SubmitProposalParams{
+		ProposalPath: path,
+		Proposal:     proposal,
+	}
+
+	ctx := context.Background()
+	result, err := core.SubmitProposalAction(ctx, opts, params)
+	if err != nil && result == nil {
+		cleanup()
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if result.Success && !dryRun {
-		fmt.Printf("\nBinary installed to: %s\n", result.InstallPath)
-		if result.SHA256 != "" {
-			fmt.Printf("SHA256: %s\n", result.SHA256)
-		}
+	finishTxAction(cmd, opts, result)
+	cleanup()
+
+	if result != nil && !result.Success {
+		os.Exit(1)
 	}
 }
 
-func runMeshEnable(cmd *cobra.Command, args []string) {
+// addGovQueryFlags adds the endpoint flags shared by the gov query commands.
+func addGovQueryFlags(cmd *cobra.Command) {
+	cmd.Flags().String("network", "Localnet", "Network name (Localnet, Sprintnet, Testnet, Mainnet)")
+	cmd.Flags().String("host", "localhost", "RPC host")
+	cmd.Flags().Bool("remote", false, "Use remote endpoints")
+	cmd.Flags().String("rest", "", "Override Cosmos REST endpoint")
+}
+
+// govQueryClient returns the Cosmos REST client selected by the gov query flags.
+func govQueryClient(cmd *cobra.Command) *rpc.CosmosClient {
 	networkStr, _ := cmd.Flags().GetString("network")
-	home, _ := cmd.Flags().GetString("home")
-	listen, _ := cmd.Flags().GetString("listen")
-	nodeRPC, _ := cmd.Flags().GetString("node-rpc")
-	nodeGRPC, _ := cmd.Flags().GetString("node-grpc")
-	user, _ := cmd.Flags().GetString("user")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	host, _ := cmd.Flags().GetString("host")
+	useRemote, _ := cmd.Flags().GetBool("remote")
+	rest, _ := cmd.Flags().GetString("rest")
 
 	network, err := core.ParseNetworkName(networkStr)
 	if err != nil {
@@ -2179,405 +3512,538 @@ func runMeshEnable(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	if home == "" {
-		homeDir, _ := os.UserHomeDir()
-		home = homeDir
+	endpoints := resolveEndpoints(string(network), host, useRemote, "", rest, "")
+	return rpc.NewCosmosClient(strings.TrimRight(endpoints.CosmosREST, "/"))
+}
+
+// parseProposalArg parses a proposal ID argument or exits.
+func parseProposalArg(arg string) uint64 {
+	id, err := core.ParseProposalID(arg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
+	return id
+}
 
-	if user == "" {
-		user = os.Getenv("USER")
-		if user == "" {
-			user = "monod"
+// proposalDeadline describes when the current period of a proposal ends.
+func proposalDeadline(p *core.GovProposal) string {
+	switch p.Status {
+	case "voting":
+		return "voting ends in " + core.FormatTimeLeft(time.Until(p.VotingEndTime))
+	case "deposit":
+		return "deposit ends in " + core.FormatTimeLeft(time.Until(p.DepositEndTime))
+	default:
+		if !p.VotingEndTime.IsZero() {
+			return "ended " + p.VotingEndTime.Local().Format("2006-01-02")
 		}
+		return ""
 	}
+}
 
-	// Create/load config
-	var cfg *mesh.Config
-	if mesh.ConfigExists(home, network) {
-		cfg, err = mesh.LoadConfig(home, network)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-			os.Exit(1)
+// formatCoins formats coins, showing alyth amounts in LYTH.
+func formatCoins(coins []core.Coin) string {
+	parts := make([]string, 0, len(coins))
+	for _, c := range coins {
+		if c.Denom == core.BaseDenom {
+			parts = append(parts, core.FormatLYTH(mustBigInt(c.Amount)))
+		} else {
+			parts = append(parts, c.Amount+c.Denom)
 		}
-	} else {
-		cfg = mesh.DefaultConfig(network)
 	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
 
-	// Apply overrides
-	cfg.Merge(mesh.MergeOptions{
-		ListenAddress:   listen,
-		NodeRPCURL:      nodeRPC,
-		NodeGRPCAddress: nodeGRPC,
-	})
-
-	if err := cfg.Validate(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: invalid configuration: %v\n", err)
-		os.Exit(1)
+// mustBigInt parses a decimal integer, returning 0 if it is invalid.
+func mustBigInt(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return new(big.Int)
 	}
+	return v
+}
 
-	// Save config
-	configContent, err := mesh.SaveConfig(home, network, cfg, dryRun)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
-		os.Exit(1)
+func runGovList(cmd *cobra.Command, args []string) {
+	status, _ := cmd.Flags().GetString("status")
+	voter, _ := cmd.Flags().GetString("voter")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	if voter != "" {
+		if err := core.ValidateAddress(voter); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --voter: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	// Generate systemd unit
-	systemdCfg := mesh.DefaultSystemdConfig(string(network), user, home, network)
-	unitPath, unitContent, err := mesh.WriteSystemdUnit(systemdCfg, dryRun)
-	if err != nil && !dryRun {
-		fmt.Fprintf(os.Stderr, "Error writing systemd unit: %v\n", err)
+	proposals, err := core.ListProposals(govQueryClient(cmd), status, voter, limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
 	if jsonOutput {
-		out := map[string]interface{}{
-			"config_path":  mesh.ConfigPath(home, network),
-			"unit_path":    unitPath,
-			"unit_name":    mesh.UnitName(string(network)),
-			"dry_run":      dryRun,
-			"config":       cfg,
-			"unit_content": unitContent,
-		}
-		data, _ := json.MarshalIndent(out, "", "  ")
+		data, _ := json.MarshalIndent(proposals, "", "  ")
 		fmt.Println(string(data))
 		return
 	}
 
-	// Human-readable output
-	fmt.Println("Mesh/Rosetta API Service Enable")
-	fmt.Println(strings.Repeat("-", 50))
-
-	if dryRun {
-		fmt.Println("(DRY RUN - no changes will be made)")
-		fmt.Println()
-
-		fmt.Println("Config file would be written to:")
-		fmt.Printf("  %s\n\n", mesh.ConfigPath(home, network))
-		fmt.Println("Config content:")
-		fmt.Println(configContent)
-		fmt.Println()
-
-		fmt.Println("Systemd unit file would be written to:")
-		fmt.Printf("  %s\n\n", unitPath)
-		fmt.Println("Unit content:")
-		fmt.Println(unitContent)
+	if len(proposals) == 0 {
+		fmt.Println("No proposals found.")
 		return
 	}
 
-	// Enable and start service
-	result, err := mesh.EnableService(string(network), false)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error enabling service: %v\n", err)
-		fmt.Println()
-		fmt.Println("You may need to run the following commands manually:")
-		fmt.Println(mesh.SystemdInstructions(unitPath, mesh.UnitName(string(network))))
-		os.Exit(1)
-	}
-
-	fmt.Printf("[+] Config written to: %s\n", mesh.ConfigPath(home, network))
-	fmt.Printf("[+] Unit file written to: %s\n", unitPath)
-	if result.Enabled {
-		fmt.Printf("[+] Service enabled: %s\n", result.UnitName)
+	if voter != "" {
+		fmt.Printf("%-6s %-9s %-26s %-12s %s\n", "ID", "STATUS", "DEADLINE", "VOTE", "TITLE")
+	} else {
+		fmt.Printf("%-6s %-9s %-26s %s\n", "ID", "STATUS", "DEADLINE", "TITLE")
 	}
-	if result.Started {
-		fmt.Printf("[+] Service started: %s\n", result.UnitName)
+	for i := range proposals {
+		p := &proposals[i]
+		if voter != "" {
+			fmt.Printf("%-6d %-9s %-26s %-12s %s\n", p.ID, p.Status, proposalDeadline(p), p.Vote, p.Title)
+		} else {
+			fmt.Printf("%-6d %-9s %-26s %s\n", p.ID, p.Status, proposalDeadline(p), p.Title)
+		}
 	}
-
-	fmt.Println()
-	fmt.Printf("Mesh/Rosetta API is now running at: %s\n", cfg.ListenAddress)
 }
 
-func runMeshDisable(cmd *cobra.Command, args []string) {
-	networkStr, _ := cmd.Flags().GetString("network")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
+func runGovShow(cmd *cobra.Command, args []string) {
+	voter, _ := cmd.Flags().GetString("voter")
+	id := parseProposalArg(args[0])
 
-	network, err := core.ParseNetworkName(networkStr)
+	p, err := core.GetProposal(govQueryClient(cmd), id, voter)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if jsonOutput && dryRun {
-		out := map[string]interface{}{
-			"unit_name": mesh.UnitName(string(network)),
-			"dry_run":   true,
-		}
-		data, _ := json.MarshalIndent(out, "", "  ")
+	if jsonOutput {
+		data, _ := json.MarshalIndent(p, "", "  ")
 		fmt.Println(string(data))
 		return
 	}
 
-	result, err := mesh.DisableService(string(network), dryRun)
+	fmt.Printf("Proposal #%d: %s\n", p.ID, p.Title)
+	fmt.Println(strings.Repeat("-", 60))
+	status := p.Status
+	if p.Expedited {
+		status += " (expedited)"
+	}
+	fmt.Printf("Status:        %s\n", status)
+	if deadline := proposalDeadline(p); deadline != "" {
+		fmt.Printf("Deadline:      %s\n", deadline)
+	}
+	fmt.Printf("Proposer:      %s\n", p.Proposer)
+	fmt.Printf("Submitted:     %s\n", p.SubmitTime.Local().Format(time.RFC1123))
+	if !p.VotingStartTime.IsZero() {
+		fmt.Printf("Voting:        %s - %s\n", p.VotingStartTime.Local().Format(time.RFC1123), p.VotingEndTime.Local().Format(time.RFC1123))
+	}
+	fmt.Printf("Total deposit: %s\n", formatCoins(p.TotalDeposit))
+	if p.Vote != "" {
+		fmt.Printf("Your vote:     %s\n", p.Vote)
+	}
+	if p.FailedReason != "" {
+		fmt.Printf("Failed:        %s\n", p.FailedReason)
+	}
+	if p.Metadata != "" {
+		fmt.Printf("Metadata:      %s\n", p.Metadata)
+	}
+	if len(p.Messages) > 0 {
+		fmt.Println("Messages:")
+		for _, m := range p.Messages {
+			fmt.Printf("  %s\n", m)
+		}
+	}
+	fmt.Printf("\n%s\n", p.Summary)
+}
+
+func runGovTally(cmd *cobra.Command, args []string) {
+	id := parseProposalArg(args[0])
+
+	t, err := core.TallyProposal(govQueryClient(cmd), id)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	if jsonOutput {
 		out := map[string]interface{}{
-			"unit_name": result.UnitName,
-			"stopped":   result.Stopped,
-			"disabled":  result.Disabled,
-			"dry_run":   dryRun,
-		}
-		if result.Error != nil {
-			out["error"] = result.Error.Error()
+			"tally":   t,
+			"turnout": t.Turnout(),
+			"outcome": t.Outcome(),
 		}
 		data, _ := json.MarshalIndent(out, "", "  ")
 		fmt.Println(string(data))
-		if result.Error != nil {
-			os.Exit(1)
-		}
 		return
 	}
 
-	fmt.Println("Mesh/Rosetta API Service Disable")
-	fmt.Println(strings.Repeat("-", 50))
-
-	if dryRun {
-		fmt.Println("(DRY RUN)")
-		fmt.Printf("Would stop and disable: %s\n", mesh.UnitName(string(network)))
-		return
+	label := "current tally"
+	if t.Final {
+		label = "final tally"
+	}
+	fmt.Printf("Proposal #%d (%s, %s)\n", t.ProposalID, t.Status, label)
+	fmt.Println(strings.Repeat("-", 60))
+	for _, row := range []struct {
+		name  string
+		count *big.Int
+	}{
+		{"Yes", t.Yes},
+		{"No", t.No},
+		{"No with veto", t.NoWithVeto},
+		{"Abstain", t.Abstain},
+	} {
+		fmt.Printf("%-13s %6.2f%%  %s\n", row.name+":", t.Share(row.count)*100, core.FormatLYTH(row.count))
 	}
+	fmt.Printf("%-13s          %s\n", "Total:", core.FormatLYTH(t.Total()))
 
-	if result.Stopped {
-		fmt.Printf("[+] Service stopped: %s\n", result.UnitName)
+	if t.Bonded != nil {
+		fmt.Printf("\nTurnout:      %.2f%% of bonded stake (quorum %.2f%%)\n", t.Turnout()*100, t.Quorum*100)
 	}
-	if result.Disabled {
-		fmt.Printf("[+] Service disabled: %s\n", result.UnitName)
+	if t.Quorum > 0 {
+		fmt.Printf("Threshold:    %.2f%% yes, veto above %.2f%%\n", t.Threshold*100, t.VetoThreshold*100)
 	}
-
-	if result.Error != nil {
-		fmt.Fprintf(os.Stderr, "\nWarning: %v\n", result.Error)
+	if !t.Final && t.Status == "voting" {
+		fmt.Printf("If voting ended now: %s\n", t.Outcome())
 	}
 }
 
-func runMeshStatus(cmd *cobra.Command, args []string) {
+func runGovPending(cmd *cobra.Command, args []string) {
+	validator, _ := cmd.Flags().GetString("validator")
+	sendAlert, _ := cmd.Flags().GetBool("alert")
 	networkStr, _ := cmd.Flags().GetString("network")
-	home, _ := cmd.Flags().GetString("home")
+	apiEndpoint, _ := cmd.Flags().GetString("api")
 
-	network, err := core.ParseNetworkName(networkStr)
+	// The hosted node-monitor has no alert endpoint, so alerts only go to
+	// a self-hosted one that was named explicitly
+	if apiEndpoint == "" {
+		apiEndpoint = os.Getenv("NODEMON_API")
+	}
+	if sendAlert && apiEndpoint == "" {
+		fmt.Fprintln(os.Stderr, "Error: --alert needs a self-hosted node-monitor (monoctl monitor server): pass --api or set NODEMON_API")
+		os.Exit(1)
+	}
+
+	if validator == "" {
+		if cfg, err := tui.LoadConfig(); err == nil {
+			validator = cfg.ValidatorAddress
+		}
+	}
+	if validator == "" {
+		fmt.Fprintln(os.Stderr, "Error: --validator is required (or set validator_address in ~/.mono-commander/config.json)")
+		os.Exit(1)
+	}
+	voter, err := core.GovVoterAddress(validator)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: --validator: %v\n", err)
 		os.Exit(1)
 	}
 
-	if home == "" {
-		homeDir, _ := os.UserHomeDir()
-		home = homeDir
+	pending, err := core.PendingVotes(govQueryClient(cmd), voter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	ctx := context.Background()
-	result := mesh.FullCheck(ctx, string(network), home, network)
+	alerted := false
+	if sendAlert && len(pending) > 0 {
+		home, _ := cmd.Flags().GetString("home")
+		if home == "" {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
+				os.Exit(1)
+			}
+			home = filepath.Join(homeDir, ".monod")
+		}
+
+		keysDir, err := core.GetMonitorKeysDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		keys, err := core.LoadKeys(keysDir, home)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading keys: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Have you registered? Run: monoctl monitor register --network %s --moniker <name>\n", networkStr)
+			os.Exit(1)
+		}
+
+		payload, err := core.SignAlert(keys, networkStr, core.PendingVotesAlert(voter, pending, time.Now()))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error signing alert: %v\n", err)
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := core.SendAlert(ctx, apiEndpoint, payload); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		alerted = true
+	}
 
 	if jsonOutput {
-		data, _ := json.MarshalIndent(result, "", "  ")
+		out := map[string]interface{}{
+			"voter":     voter,
+			"proposals": pending,
+			"alerted":   alerted,
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
 		fmt.Println(string(data))
 		return
 	}
 
-	// Human-readable output
-	fmt.Printf("Mesh/Rosetta API Status - %s\n", network)
-	fmt.Println(strings.Repeat("-", 50))
-
-	// Binary status
-	binaryStatus := "NOT INSTALLED"
-	if result.BinaryExists {
-		binaryStatus = "INSTALLED"
-	}
-	fmt.Printf("Binary:        %s\n", binaryStatus)
-
-	// Config status
-	configStatus := "NOT CONFIGURED"
-	if result.ConfigExists {
-		configStatus = "CONFIGURED"
+	if len(pending) == 0 {
+		fmt.Printf("No pending votes for %s.\n", voter)
+		return
 	}
-	fmt.Printf("Config:        %s\n", configStatus)
 
-	// Systemd status
-	if result.SystemdStatus != nil {
-		fmt.Printf("Service:       %s (%s)\n", result.SystemdStatus.ActiveState, result.SystemdStatus.SubState)
-		if result.SystemdStatus.MainPID > 0 {
-			fmt.Printf("PID:           %d\n", result.SystemdStatus.MainPID)
-		}
-	} else {
-		fmt.Printf("Service:       (systemd not available)\n")
+	fmt.Printf("%s has not voted on %d proposal(s):\n\n", voter, len(pending))
+	fmt.Printf("%-6s %-12s %s\n", "ID", "TIME LEFT", "TITLE")
+	for _, p := range pending {
+		fmt.Printf("%-6d %-12s %s\n", p.ID, core.FormatTimeLeft(time.Until(p.VotingEndTime)), p.Title)
 	}
-
-	// Health check
-	if result.ServiceHealth != nil {
-		healthStatus := "UNHEALTHY"
-		if result.ServiceHealth.Healthy {
-			healthStatus = "HEALTHY"
-		}
-		fmt.Printf("Health:        %s (via %s, %dms)\n",
-			healthStatus,
-			result.ServiceHealth.Method,
-			result.ServiceHealth.ResponseTime)
-		if result.ServiceHealth.Error != "" {
-			fmt.Printf("Error:         %s\n", result.ServiceHealth.Error)
-		}
-		fmt.Printf("Listen:        %s\n", result.ServiceHealth.ListenAddress)
+	if alerted {
+		fmt.Println("\nAlert sent to node-monitor.")
 	}
 }
 
-func runMeshLogs(cmd *cobra.Command, args []string) {
-	networkStr, _ := cmd.Flags().GetString("network")
-	follow, _ := cmd.Flags().GetBool("follow")
-	lines, _ := cmd.Flags().GetInt("lines")
+func runTxSign(cmd *cobra.Command, args []string) {
+	keystorePath, _ := cmd.Flags().GetString("keystore")
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	outPath, _ := cmd.Flags().GetString("out")
+	offline, _ := cmd.Flags().GetBool("offline")
+	rest, _ := cmd.Flags().GetString("rest")
+	multisig, _ := cmd.Flags().GetBool("multisig")
 
-	network, err := core.ParseNetworkName(networkStr)
+	file, err := core.LoadUnsignedTxFile(args[0])
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	opts := mesh.LogsOptions{
-		Network: string(network),
-		Follow:  follow,
-		Lines:   lines,
+	password, err := readPassword(passwordFile, "Enter keystore password: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading password: %v\n", err)
+		os.Exit(1)
 	}
-
-	source, err := mesh.GetLogSource(opts)
+	signer, err := core.LoadKeystoreSigner(keystorePath, password)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	defer source.Close()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Handle interrupt
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
-	go func() {
-		<-sigCh
-		cancel()
-	}()
+	if !offline {
+		if rest == "" {
+			rest = core.DefaultCosmosREST
+		}
+		// A multisig member signs for the multisig account's sequence
+		accountAddr := signer.Address()
+		if multisig {
+			accountAddr = file.Signer
+		}
+		account, err := rpc.NewCosmosClient(strings.TrimRight(rest, "/")).Account(accountAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v (use --offline to sign with the stored sequence)\n", err)
+			os.Exit(1)
+		}
+		file.AccountNumber = account.AccountNumber
+		file.Sequence = account.Sequence
+	}
 
-	linesCh, err := source.Lines(ctx)
+	var signed *core.TxJSON
+	if multisig {
+		signed, err = core.SignMultisigPartial(file, signer)
+	} else {
+		signed, err = core.SignUnsignedTxFile(file, signer)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if err := core.WriteTxFile(outPath, signed); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outPath, err)
+		os.Exit(1)
+	}
 
-	for line := range linesCh {
-		fmt.Println(line)
+	if jsonOutput {
+		data, _ := json.MarshalIndent(signed, "", "  ")
+		fmt.Println(string(data))
+		return
 	}
-}
 
-// =============================================================================
-// M7: Update Commands
-// =============================================================================
+	if multisig {
+		fmt.Printf("Partial signature: %s\n", outPath)
+		fmt.Printf("Member:            %s\n", signer.Address())
+		fmt.Printf("Multisig:          %s\n", file.Signer)
+		fmt.Printf("Sequence:          %d\n", file.Sequence)
+		fmt.Println()
+		fmt.Printf("Combine with:      monoctl tx multisign %s %s <other signatures...>\n", args[0], outPath)
+		return
+	}
 
-func runUpdateCheck(cmd *cobra.Command, args []string) {
-	client := update.NewClient()
-	result, err := client.Check(tui.Version)
+	fmt.Printf("Signed tx:      %s\n", outPath)
+	fmt.Printf("Signer:         %s\n", signer.Address())
+	fmt.Printf("Chain ID:       %s\n", file.ChainID)
+	fmt.Printf("Account number: %d\n", file.AccountNumber)
+	fmt.Printf("Sequence:       %d\n", file.Sequence)
+	fmt.Println()
+	fmt.Printf("Broadcast with: monoctl tx broadcast %s\n", outPath)
+}
+
+func runTxMultisign(cmd *cobra.Command, args []string) {
+	outPath, _ := cmd.Flags().GetString("out")
 
+	file, err := core.LoadUnsignedTxFile(args[0])
 	if err != nil {
-		if jsonOutput {
-			data, _ := json.MarshalIndent(map[string]interface{}{
-				"error": err.Error(),
-			}, "", "  ")
-			fmt.Println(string(data))
-		} else {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	partials := make([]*core.TxJSON, 0, len(args)-1)
+	for _, path := range args[1:] {
+		partial, err := core.LoadPartialSignature(path)
+		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
+		partials = append(partials, partial)
+	}
+
+	signed, err := core.CombineMultisig(file, partials)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := core.WriteTxFile(outPath, signed); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing %s: %v\n", outPath, err)
 		os.Exit(1)
 	}
 
 	if jsonOutput {
-		data, _ := json.MarshalIndent(result, "", "  ")
+		data, _ := json.MarshalIndent(signed, "", "  ")
 		fmt.Println(string(data))
 		return
 	}
 
-	// Human-readable output
-	fmt.Println("Commander Update Check")
-	fmt.Println(strings.Repeat("-", 50))
-	fmt.Printf("Current version:  %s\n", result.CurrentVersion)
-	fmt.Printf("Latest version:   %s\n", result.LatestVersion)
-	fmt.Printf("Published:        %s\n", result.PublishedAt.Format("2006-01-02 15:04:05"))
-	fmt.Printf("Release URL:      %s\n", result.ReleaseURL)
+	fmt.Printf("Signed tx:      %s\n", outPath)
+	fmt.Printf("Multisig:       %s (%d-of-%d)\n", file.Signer, file.Multisig.Threshold, len(file.Multisig.PublicKeys))
+	fmt.Printf("Signatures:     %d\n", len(partials))
 	fmt.Println()
-
-	switch result.Status {
-	case "up-to-date":
-		fmt.Println("Status: ✓ Up to date")
-	case "update-available":
-		fmt.Println("Status: ⚠ Update available")
-		if result.DownloadURL != "" {
-			fmt.Printf("\nDownload: %s\n", result.DownloadURL)
-		}
-		fmt.Println("\nRun 'monoctl update apply' to update.")
-	default:
-		fmt.Println("Status: Unknown")
-		if result.Error != "" {
-			fmt.Printf("Error: %s\n", result.Error)
-		}
-	}
+	fmt.Printf("Broadcast with: monoctl tx broadcast %s\n", outPath)
 }
 
-func runUpdateSelf(cmd *cobra.Command, args []string) {
-	yes, _ := cmd.Flags().GetBool("yes")
-	insecure, _ := cmd.Flags().GetBool("insecure")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
-
-	client := update.NewClient()
+func runTxBroadcast(cmd *cobra.Command, args []string) {
+	rest, _ := cmd.Flags().GetString("rest")
+	if rest == "" {
+		rest = core.DefaultCosmosREST
+	}
+	wait, _ := cmd.Flags().GetBool("wait")
+	waitTimeout, _ := cmd.Flags().GetDuration("wait-timeout")
+	client := rpc.NewCosmosClient(strings.TrimRight(rest, "/"))
 
-	// First check for updates
-	checkResult, err := client.Check(tui.Version)
+	tx, err := core.LoadSignedTx(args[0])
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if !checkResult.UpdateAvailable {
-		fmt.Println("Already up to date.")
-		return
-	}
-
-	// Show what we're about to do
-	fmt.Printf("Update available: %s → %s\n", checkResult.CurrentVersion, checkResult.LatestVersion)
+	summary, err := core.BroadcastSignedTx(client, tx)
 
-	if checkResult.DownloadURL == "" {
-		fmt.Fprintf(os.Stderr, "Error: No matching asset for your OS/architecture\n")
-		fmt.Fprintf(os.Stderr, "Download manually from: %s\n", checkResult.ReleaseURL)
-		os.Exit(1)
+	// A tx accepted into the mempool can still fail in its block
+	var conf *core.TxConfirmation
+	var confErr error
+	if err == nil && wait && waitTimeout > 0 {
+		conf, confErr = core.WaitForTx(context.Background(), client, summary.TxHash, waitTimeout, core.DefaultConfirmInterval)
+		if conf != nil && conf.Code != 0 {
+			err = &core.TxError{TxHash: conf.TxHash, Codespace: conf.Codespace, Code: conf.Code, RawLog: conf.RawLog}
+		}
 	}
 
-	if dryRun {
-		fmt.Println("(DRY RUN - no changes will be made)")
+	if jsonOutput {
+		out := map[string]interface{}{"success": err == nil}
+		if summary != nil {
+			out["txhash"] = summary.TxHash
+			out["code"] = summary.Code
+			out["raw_log"] = summary.RawLog
+		}
+		if conf != nil {
+			out["confirmed"] = true
+			out["height"] = conf.Height
+			out["code"] = conf.Code
+			out["codespace"] = conf.Codespace
+			out["raw_log"] = conf.RawLog
+			out["gas_wanted"] = conf.GasWanted
+			out["gas_used"] = conf.GasUsed
+			out["events"] = conf.Events
+		}
+		if confErr != nil {
+			out["warning"] = confErr.Error()
+		}
+		if err != nil {
+			out["error"] = err.Error()
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		if err != nil {
+			os.Exit(1)
+		}
+		return
 	}
 
-	// Confirm unless --yes
-	if !yes && !dryRun {
-		fmt.Print("\nProceed with update? [y/N]: ")
-		var response string
-		fmt.Scanln(&response)
-		response = strings.ToLower(strings.TrimSpace(response))
-		if response != "y" && response != "yes" {
-			fmt.Println("Update cancelled.")
-			return
+	if err != nil {
+		if conf != nil {
+			fmt.Fprintf(os.Stderr, "Transaction failed at height %d: %v\n", conf.Height, err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Broadcast failed: %v\n", err)
 		}
+		if summary != nil && summary.TxHash != "" {
+			fmt.Fprintf(os.Stderr, "  TxHash: %s\n", summary.TxHash)
+		}
+		os.Exit(1)
 	}
 
-	opts := update.ApplyOptions{
-		CurrentVersion: tui.Version,
-		Yes:            yes,
-		Insecure:       insecure,
-		DryRun:         dryRun,
-		OnProgress: func(step, message string) {
-			if verbose {
-				fmt.Printf("[%s] %s\n", step, message)
-			}
-		},
+	if conf == nil {
+		fmt.Println("Transaction submitted successfully!")
+		fmt.Printf("  TxHash:   %s\n", summary.TxHash)
+		if confErr != nil {
+			fmt.Printf("\nWARNING: could not confirm the transaction: %v; check the hash before retrying\n", confErr)
+		}
+		return
 	}
+	fmt.Println("Transaction confirmed!")
+	fmt.Printf("  TxHash:   %s\n", summary.TxHash)
+	fmt.Printf("  Height:   %d\n", conf.Height)
+	fmt.Printf("  Gas used: %d / %d\n", conf.GasUsed, conf.GasWanted)
+	printTxEvents(conf.Events)
+}
 
-	result, err := client.Apply(opts)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+// =============================================================================
+// M5: Mesh/Rosetta API Commands
+// =============================================================================
+
+func runMeshInstall(cmd *cobra.Command, args []string) {
+	url, _ := cmd.Flags().GetString("url")
+	sha256sum, _ := cmd.Flags().GetString("sha256")
+	version, _ := cmd.Flags().GetString("version")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	useSystem, _ := cmd.Flags().GetBool("system")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	opts := mesh.InstallOptions{
+		URL:           url,
+		SHA256:        sha256sum,
+		Version:       version,
+		UseSystemPath: useSystem,
+		Insecure:      insecure,
+		DryRun:        dryRun,
 	}
 
+	result := mesh.Install(opts)
+
 	if jsonOutput {
 		data, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(data))
@@ -2588,10 +4054,14 @@ func runUpdateSelf(cmd *cobra.Command, args []string) {
 	}
 
 	// Human-readable output
-	fmt.Println()
-	fmt.Println("Commander Update")
+	fmt.Println("Mesh/Rosetta API Binary Installation")
 	fmt.Println(strings.Repeat("-", 50))
 
+	if dryRun {
+		fmt.Println("(DRY RUN - no changes will be made)")
+		fmt.Println()
+	}
+
 	for _, step := range result.Steps {
 		status := "[ ]"
 		switch step.Status {
@@ -2609,138 +4079,93 @@ func runUpdateSelf(cmd *cobra.Command, args []string) {
 		fmt.Printf("%s %s\n", status, msg)
 	}
 
-	if result.Error != "" {
-		fmt.Fprintf(os.Stderr, "\nError: %s\n", result.Error)
-		if result.NeedsSudo {
-			fmt.Println("\nTo complete the update manually:")
-			fmt.Printf("  1. Download: %s\n", checkResult.DownloadURL)
-			fmt.Printf("  2. Verify checksum (if available)\n")
-			fmt.Printf("  3. sudo mv <downloaded-binary> %s\n", result.PreviousPath)
-		}
+	if result.Error != nil {
+		fmt.Fprintf(os.Stderr, "\nError: %v\n", result.Error)
 		os.Exit(1)
 	}
 
-	if result.Success {
-		fmt.Println()
-		if dryRun {
-			fmt.Printf("Would update: %s → %s\n", result.OldVersion, result.NewVersion)
-		} else {
-			fmt.Printf("Update successful: %s → %s\n", result.OldVersion, result.NewVersion)
-			if result.BackupPath != "" {
-				fmt.Printf("Backup stored at: %s\n", result.BackupPath)
-			}
-			if result.ChecksumVerify {
-				fmt.Println("Checksum verified ✓")
-			}
-			fmt.Println("\nPlease restart monoctl to use the new version.")
+	if result.Success && !dryRun {
+		fmt.Printf("\nBinary installed to: %s\n", result.InstallPath)
+		if result.SHA256 != "" {
+			fmt.Printf("SHA256: %s\n", result.SHA256)
 		}
 	}
 }
 
-// =============================================================================
-// Wallet Commands
-// =============================================================================
-
-func runWalletGenerate(cmd *cobra.Command, args []string) {
-	name, _ := cmd.Flags().GetString("name")
-	outPath, _ := cmd.Flags().GetString("out")
-	passwordFile, _ := cmd.Flags().GetString("password-file")
-	showPrivateKey, _ := cmd.Flags().GetBool("show-private-key")
-	insecureShow, _ := cmd.Flags().GetBool("insecure-show")
+func runMeshEnable(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	home, _ := cmd.Flags().GetString("home")
+	listen, _ := cmd.Flags().GetString("listen")
+	nodeRPC, _ := cmd.Flags().GetString("node-rpc")
+	nodeGRPC, _ := cmd.Flags().GetString("node-grpc")
+	user, _ := cmd.Flags().GetString("user")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	// Validate --show-private-key requires --insecure-show
-	if showPrivateKey && !insecureShow {
-		fmt.Fprintln(os.Stderr, "ERROR: --show-private-key requires --insecure-show=true")
-		fmt.Fprintln(os.Stderr, "")
-		fmt.Fprintln(os.Stderr, "This is a dangerous operation. If you proceed, your private key")
-		fmt.Fprintln(os.Stderr, "will be displayed on screen. Never share your private key with anyone.")
-		fmt.Fprintln(os.Stderr, "")
-		fmt.Fprintln(os.Stderr, "To proceed, run:")
-		fmt.Fprintln(os.Stderr, "  monoctl wallet generate --show-private-key --insecure-show=true")
+	network, err := core.ParseNetworkName(networkStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Get password
-	var password string
-	var err error
+	if home == "" {
+		homeDir, _ := os.UserHomeDir()
+		home = homeDir
+	}
 
-	if passwordFile != "" {
-		// Read password from file
-		data, err := os.ReadFile(passwordFile)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading password file: %v\n", err)
-			os.Exit(1)
-		}
-		password = strings.TrimSpace(string(data))
-	} else {
-		// Interactive password prompt
-		password, err = promptPassword("Enter password for keystore: ")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading password: %v\n", err)
-			os.Exit(1)
+	if user == "" {
+		user = os.Getenv("USER")
+		if user == "" {
+			user = "monod"
 		}
+	}
 
-		// Confirm password
-		confirm, err := promptPassword("Confirm password: ")
+	// Create/load config
+	var cfg *mesh.Config
+	if mesh.ConfigExists(home, network) {
+		cfg, err = mesh.LoadConfig(home, network)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading password: %v\n", err)
-			os.Exit(1)
-		}
-
-		if password != confirm {
-			fmt.Fprintln(os.Stderr, "Error: passwords do not match")
+			fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
 			os.Exit(1)
 		}
+	} else {
+		cfg = mesh.DefaultConfig(network)
 	}
 
-	if len(password) < 8 {
-		fmt.Fprintln(os.Stderr, "Error: password must be at least 8 characters")
-		os.Exit(1)
-	}
+	// Apply overrides
+	cfg.Merge(mesh.MergeOptions{
+		ListenAddress:   listen,
+		NodeRPCURL:      nodeRPC,
+		NodeGRPCAddress: nodeGRPC,
+	})
 
-	// Generate keypair
-	kp, err := walletgen.GenerateKeypair()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error generating keypair: %v\n", err)
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid configuration: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Create keystore
-	ks, err := walletgen.CreateKeystore(kp, password)
+	// Save config
+	configContent, err := mesh.SaveConfig(home, network, cfg, dryRun)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating keystore: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Determine output path
-	if outPath == "" {
-		walletDir, err := walletgen.GetDefaultWalletDir()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting wallet directory: %v\n", err)
-			os.Exit(1)
-		}
-		filename := walletgen.GenerateKeystoreFilename(name, kp.EVMAddress())
-		outPath = filepath.Join(walletDir, filename)
-	}
-
-	// Save keystore
-	if err := walletgen.SaveKeystore(ks, outPath); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving keystore: %v\n", err)
+	// Generate systemd unit
+	systemdCfg := mesh.DefaultSystemdConfig(string(network), user, home, network)
+	unitPath, unitContent, err := mesh.WriteSystemdUnit(systemdCfg, dryRun)
+	if err != nil && !dryRun {
+		fmt.Fprintf(os.Stderr, "Error writing systemd unit: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Get addresses
-	evmAddr := kp.EVMAddress()
-	bech32Addr, _ := kp.Bech32Address()
-
 	if jsonOutput {
 		out := map[string]interface{}{
-			"keystore_path":  outPath,
-			"evm_address":    evmAddr,
-			"bech32_address": bech32Addr,
-		}
-		if showPrivateKey && insecureShow {
-			out["private_key"] = kp.PrivateKeyHex()
+			"config_path":  mesh.ConfigPath(home, network),
+			"unit_path":    unitPath,
+			"unit_name":    mesh.UnitName(string(network)),
+			"dry_run":      dryRun,
+			"config":       cfg,
+			"unit_content": unitContent,
 		}
 		data, _ := json.MarshalIndent(out, "", "  ")
 		fmt.Println(string(data))
@@ -2748,534 +4173,2452 @@ func runWalletGenerate(cmd *cobra.Command, args []string) {
 	}
 
 	// Human-readable output
-	fmt.Println()
-	fmt.Println("Wallet Generated Successfully")
+	fmt.Println("Mesh/Rosetta API Service Enable")
 	fmt.Println(strings.Repeat("-", 50))
-	fmt.Printf("Keystore:       %s\n", outPath)
-	fmt.Printf("EVM Address:    %s\n", evmAddr)
-	fmt.Printf("Bech32 Address: %s\n", bech32Addr)
 
-	if showPrivateKey && insecureShow {
-		fmt.Println()
-		fmt.Println(strings.Repeat("!", 60))
-		fmt.Println("!!! WARNING: PRIVATE KEY BELOW - NEVER SHARE THIS !!!")
-		fmt.Println(strings.Repeat("!", 60))
-		fmt.Printf("Private Key:    %s\n", kp.PrivateKeyHex())
-		fmt.Println(strings.Repeat("!", 60))
+	if dryRun {
+		fmt.Println("(DRY RUN - no changes will be made)")
 		fmt.Println()
-		fmt.Println("The private key above gives FULL CONTROL over this wallet.")
-		fmt.Println("Store it securely and NEVER share it with anyone.")
-	}
-
-	fmt.Println()
-	fmt.Println("Keep your password safe - it is required to use this wallet.")
-}
 
-func runWalletList(cmd *cobra.Command, args []string) {
-	dir, _ := cmd.Flags().GetString("dir")
+		fmt.Println("Config file would be written to:")
+		fmt.Printf("  %s\n\n", mesh.ConfigPath(home, network))
+		fmt.Println("Config content:")
+		fmt.Println(configContent)
+		fmt.Println()
 
-	if dir == "" {
-		var err error
-		dir, err = walletgen.GetDefaultWalletDir()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error getting wallet directory: %v\n", err)
-			os.Exit(1)
-		}
+		fmt.Println("Systemd unit file would be written to:")
+		fmt.Printf("  %s\n\n", unitPath)
+		fmt.Println("Unit content:")
+		fmt.Println(unitContent)
+		return
 	}
 
-	infos, err := walletgen.ListKeystores(dir)
+	// Enable and start service
+	result, err := mesh.EnableService(string(network), false)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error listing keystores: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error enabling service: %v\n", err)
+		fmt.Println()
+		fmt.Println("You may need to run the following commands manually:")
+		fmt.Println(mesh.SystemdInstructions(unitPath, mesh.UnitName(string(network))))
 		os.Exit(1)
 	}
 
-	if jsonOutput {
-		data, _ := json.MarshalIndent(infos, "", "  ")
-		fmt.Println(string(data))
-		return
+	fmt.Printf("[+] Config written to: %s\n", mesh.ConfigPath(home, network))
+	fmt.Printf("[+] Unit file written to: %s\n", unitPath)
+	if result.Enabled {
+		fmt.Printf("[+] Service enabled: %s\n", result.UnitName)
 	}
-
-	if len(infos) == 0 {
-		fmt.Printf("No wallets found in %s\n", dir)
-		fmt.Println()
-		fmt.Println("Generate a new wallet with:")
-		fmt.Println("  monoctl wallet generate --name my-wallet")
-		return
+	if result.Started {
+		fmt.Printf("[+] Service started: %s\n", result.UnitName)
 	}
 
-	fmt.Printf("Wallets in %s\n", dir)
-	fmt.Println(strings.Repeat("-", 80))
-	fmt.Printf("%-44s %-48s\n", "EVM ADDRESS", "BECH32 ADDRESS")
-	fmt.Println(strings.Repeat("-", 80))
-
-	for _, info := range infos {
-		fmt.Printf("%-44s %-48s\n", info.EVMAddress, info.Bech32Addr)
-		fmt.Printf("  File: %s (created %s)\n", info.Filename, info.CreatedAt.Format("2006-01-02 15:04"))
-	}
+	fmt.Println()
+	fmt.Printf("Mesh/Rosetta API is now running at: %s\n", cfg.ListenAddress)
 }
 
-func runWalletInfo(cmd *cobra.Command, args []string) {
-	filePath, _ := cmd.Flags().GetString("file")
+func runMeshDisable(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	ks, err := walletgen.LoadKeystore(filePath)
+	network, err := core.ParseNetworkName(networkStr)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading keystore: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	evmAddr := walletgen.GetKeystoreAddress(ks)
-	bech32Addr, _ := walletgen.GetKeystoreBech32Address(ks)
+	if jsonOutput && dryRun {
+		out := map[string]interface{}{
+			"unit_name": mesh.UnitName(string(network)),
+			"dry_run":   true,
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
 
-	// Get file info
-	stat, _ := os.Stat(filePath)
+	result, err := mesh.DisableService(string(network), dryRun)
 
 	if jsonOutput {
 		out := map[string]interface{}{
-			"file":           filePath,
-			"evm_address":    evmAddr,
-			"bech32_address": bech32Addr,
-			"version":        ks.Version,
-			"id":             ks.ID,
-			"cipher":         ks.Crypto.Cipher,
-			"kdf":            ks.Crypto.KDF,
+			"unit_name": result.UnitName,
+			"stopped":   result.Stopped,
+			"disabled":  result.Disabled,
+			"dry_run":   dryRun,
 		}
-		if stat != nil {
-			out["created_at"] = stat.ModTime()
-			out["size_bytes"] = stat.Size()
+		if result.Error != nil {
+			out["error"] = result.Error.Error()
 		}
 		data, _ := json.MarshalIndent(out, "", "  ")
 		fmt.Println(string(data))
+		if result.Error != nil {
+			os.Exit(1)
+		}
 		return
 	}
 
-	fmt.Println("Wallet Information")
+	fmt.Println("Mesh/Rosetta API Service Disable")
 	fmt.Println(strings.Repeat("-", 50))
-	fmt.Printf("File:           %s\n", filePath)
-	fmt.Printf("EVM Address:    %s\n", evmAddr)
-	fmt.Printf("Bech32 Address: %s\n", bech32Addr)
-	fmt.Printf("Keystore ID:    %s\n", ks.ID)
-	fmt.Printf("Version:        %d\n", ks.Version)
-	fmt.Printf("Cipher:         %s\n", ks.Crypto.Cipher)
-	fmt.Printf("KDF:            %s\n", ks.Crypto.KDF)
-	if stat != nil {
-		fmt.Printf("Created:        %s\n", stat.ModTime().Format("2006-01-02 15:04:05"))
+
+	if dryRun {
+		fmt.Println("(DRY RUN)")
+		fmt.Printf("Would stop and disable: %s\n", mesh.UnitName(string(network)))
+		return
+	}
+
+	if result.Stopped {
+		fmt.Printf("[+] Service stopped: %s\n", result.UnitName)
+	}
+	if result.Disabled {
+		fmt.Printf("[+] Service disabled: %s\n", result.UnitName)
+	}
+
+	if result.Error != nil {
+		fmt.Fprintf(os.Stderr, "\nWarning: %v\n", result.Error)
 	}
 }
 
-// =============================================================================
-// Monod Commands
-// =============================================================================
+func runMeshStatus(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	home, _ := cmd.Flags().GetString("home")
 
-func runMonodInstall(cmd *cobra.Command, args []string) {
-	url, _ := cmd.Flags().GetString("url")
-	sha256sum, _ := cmd.Flags().GetString("sha256")
-	version, _ := cmd.Flags().GetString("version")
-	insecure, _ := cmd.Flags().GetBool("insecure")
-	useSystem, _ := cmd.Flags().GetBool("system")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	network, err := core.ParseNetworkName(networkStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	opts := monod.InstallOptions{
-		URL:           url,
-		SHA256:        sha256sum,
-		Version:       version,
-		UseSystemPath: useSystem,
-		Insecure:      insecure,
-		DryRun:        dryRun,
+	if home == "" {
+		homeDir, _ := os.UserHomeDir()
+		home = homeDir
 	}
 
-	result := monod.Install(opts)
+	ctx := context.Background()
+	result := mesh.FullCheck(ctx, string(network), home, network)
 
 	if jsonOutput {
 		data, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(data))
-		if !result.Success {
-			os.Exit(1)
-		}
 		return
 	}
 
-	fmt.Println("Monod Binary Installation")
+	// Human-readable output
+	fmt.Printf("Mesh/Rosetta API Status - %s\n", network)
 	fmt.Println(strings.Repeat("-", 50))
 
-	if dryRun {
-		fmt.Println("(DRY RUN - no changes will be made)")
-		fmt.Println()
+	// Binary status
+	binaryStatus := "NOT INSTALLED"
+	if result.BinaryExists {
+		binaryStatus = "INSTALLED"
 	}
+	fmt.Printf("Binary:        %s\n", binaryStatus)
 
-	for _, step := range result.Steps {
-		status := "[ ]"
-		switch step.Status {
-		case "success":
-			status = "[+]"
-		case "failed":
-			status = "[X]"
-		case "skipped":
-			status = "[-]"
+	// Config status
+	configStatus := "NOT CONFIGURED"
+	if result.ConfigExists {
+		configStatus = "CONFIGURED"
+	}
+	fmt.Printf("Config:        %s\n", configStatus)
+
+	// Systemd status
+	if result.SystemdStatus != nil {
+		fmt.Printf("Service:       %s (%s)\n", result.SystemdStatus.ActiveState, result.SystemdStatus.SubState)
+		if result.SystemdStatus.MainPID > 0 {
+			fmt.Printf("PID:           %d\n", result.SystemdStatus.MainPID)
 		}
-		msg := step.Name
-		if step.Message != "" {
-			msg += ": " + step.Message
+	} else {
+		fmt.Printf("Service:       (systemd not available)\n")
+	}
+
+	// Health check
+	if result.ServiceHealth != nil {
+		healthStatus := "UNHEALTHY"
+		if result.ServiceHealth.Healthy {
+			healthStatus = "HEALTHY"
 		}
-		fmt.Printf("%s %s\n", status, msg)
+		fmt.Printf("Health:        %s (via %s, %dms)\n",
+			healthStatus,
+			result.ServiceHealth.Method,
+			result.ServiceHealth.ResponseTime)
+		if result.ServiceHealth.Error != "" {
+			fmt.Printf("Error:         %s\n", result.ServiceHealth.Error)
+		}
+		fmt.Printf("Listen:        %s\n", result.ServiceHealth.ListenAddress)
 	}
+}
 
-	if result.Error != nil {
-		fmt.Fprintf(os.Stderr, "\nError: %v\n", result.Error)
+func runMeshLogs(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	follow, _ := cmd.Flags().GetBool("follow")
+	lines, _ := cmd.Flags().GetInt("lines")
+
+	network, err := core.ParseNetworkName(networkStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if result.Success && !dryRun {
-		fmt.Printf("\nBinary installed to: %s\n", result.InstallPath)
-		if result.SHA256 != "" {
-			fmt.Printf("SHA256: %s\n", result.SHA256)
-		}
-		fmt.Printf("Version: %s\n", result.Version)
+	opts := mesh.LogsOptions{
+		Network: string(network),
+		Follow:  follow,
+		Lines:   lines,
+	}
 
-		if !opts.UseSystemPath {
-			fmt.Println("\nMake sure ~/.local/bin is in your PATH:")
-			fmt.Println("  export PATH=\"$HOME/.local/bin:$PATH\"")
-		}
+	source, err := mesh.GetLogSource(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	defer source.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle interrupt
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	linesCh, err := source.Lines(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	for line := range linesCh {
+		fmt.Println(line)
 	}
 }
 
-func runMonodStatus(cmd *cobra.Command, args []string) {
-	useSystem, _ := cmd.Flags().GetBool("system")
+// =============================================================================
+// M7: Update Commands
+// =============================================================================
 
-	installPath := monod.BinaryInstallPath(useSystem)
-	exists := monod.BinaryExists(useSystem)
+func runUpdateCheck(cmd *cobra.Command, args []string) {
+	client := update.NewClient()
+	result, err := client.Check(tui.Version)
 
-	if jsonOutput {
-		out := map[string]interface{}{
-			"install_path": installPath,
-			"installed":    exists,
-		}
-		if exists {
-			version, _ := monod.GetInstalledVersion(useSystem)
-			out["version"] = version
+	if err != nil {
+		if jsonOutput {
+			data, _ := json.MarshalIndent(map[string]interface{}{
+				"error": err.Error(),
+			}, "", "  ")
+			fmt.Println(string(data))
+		} else {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		}
-		data, _ := json.MarshalIndent(out, "", "  ")
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(result, "", "  ")
 		fmt.Println(string(data))
 		return
 	}
 
-	fmt.Println("Monod Binary Status")
+	// Human-readable output
+	fmt.Println("Commander Update Check")
 	fmt.Println(strings.Repeat("-", 50))
-	fmt.Printf("Install path:  %s\n", installPath)
+	fmt.Printf("Current version:  %s\n", result.CurrentVersion)
+	fmt.Printf("Latest version:   %s\n", result.LatestVersion)
+	fmt.Printf("Published:        %s\n", result.PublishedAt.Format("2006-01-02 15:04:05"))
+	fmt.Printf("Release URL:      %s\n", result.ReleaseURL)
+	fmt.Println()
 
-	if exists {
-		fmt.Printf("Status:        INSTALLED\n")
-		version, err := monod.GetInstalledVersion(useSystem)
-		if err == nil {
-			fmt.Printf("Version:       %s\n", version)
+	switch result.Status {
+	case "up-to-date":
+		fmt.Println("Status: ✓ Up to date")
+	case "update-available":
+		fmt.Println("Status: ⚠ Update available")
+		if result.DownloadURL != "" {
+			fmt.Printf("\nDownload: %s\n", result.DownloadURL)
+		}
+		fmt.Println("\nRun 'monoctl update apply' to update.")
+	default:
+		fmt.Println("Status: Unknown")
+		if result.Error != "" {
+			fmt.Printf("Error: %s\n", result.Error)
 		}
-	} else {
-		fmt.Printf("Status:        NOT INSTALLED\n")
-		fmt.Println()
-		fmt.Println("To install, run:")
-		fmt.Println("  monoctl monod install")
 	}
 }
 
-// =============================================================================
-// Doctor Command
-// =============================================================================
+func runUpdateSelf(cmd *cobra.Command, args []string) {
+	yes, _ := cmd.Flags().GetBool("yes")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-func runDoctor(cmd *cobra.Command, args []string) {
-	homeDir, _ := os.UserHomeDir()
+	client := update.NewClient()
 
-	if jsonOutput {
-		// Detect deployment mode
-		deployMode := "host-native"
-		nodeHome := filepath.Join(homeDir, ".monod")
-		if _, err := os.Stat(filepath.Join(nodeHome, "docker-compose.yml")); err == nil {
-			deployMode = "docker"
-		}
+	// First check for updates
+	checkResult, err := client.Check(tui.Version)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
+		os.Exit(1)
+	}
 
-		// Load commander config for network info
-		cfgPath := filepath.Join(homeDir, ".mono-commander", "config.json")
-		var network string
-		var rpcEndpoint string
-		if data, err := os.ReadFile(cfgPath); err == nil {
-			var cfg map[string]interface{}
-			if json.Unmarshal(data, &cfg) == nil {
-				if n, ok := cfg["selected_network"].(string); ok {
-					network = n
-				}
-			}
+	if !checkResult.UpdateAvailable {
+		fmt.Println("Already up to date.")
+		return
+	}
+
+	// Show what we're about to do
+	fmt.Printf("Update available: %s → %s\n", checkResult.CurrentVersion, checkResult.LatestVersion)
+
+	if checkResult.DownloadURL == "" {
+		fmt.Fprintf(os.Stderr, "Error: No matching asset for your OS/architecture\n")
+		fmt.Fprintf(os.Stderr, "Download manually from: %s\n", checkResult.ReleaseURL)
+		os.Exit(1)
+	}
+
+	if dryRun {
+		fmt.Println("(DRY RUN - no changes will be made)")
+	}
+
+	// Confirm unless --yes
+	if !yes && !dryRun {
+		fmt.Print("\nProceed with update? [y/N]: ")
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Println("Update cancelled.")
+			return
 		}
-		if network == "" {
-			network = "unknown"
+	}
+
+	opts := update.ApplyOptions{
+		CurrentVersion: tui.Version,
+		Yes:            yes,
+		Insecure:       insecure,
+		DryRun:         dryRun,
+		OnProgress: func(step, message string) {
+			if verbose {
+				fmt.Printf("[%s] %s\n", step, message)
+			}
+		},
+	}
+
+	result, err := client.Apply(opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		if !result.Success {
+			os.Exit(1)
 		}
+		return
+	}
 
-		// Determine RPC endpoint
-		rpcEndpoint = "http://localhost:26657"
-		if deployMode == "docker" {
-			rpcEndpoint = "http://localhost:26657"
+	// Human-readable output
+	fmt.Println()
+	fmt.Println("Commander Update")
+	fmt.Println(strings.Repeat("-", 50))
+
+	for _, step := range result.Steps {
+		status := "[ ]"
+		switch step.Status {
+		case "success":
+			status = "[+]"
+		case "failed":
+			status = "[X]"
+		case "skipped":
+			status = "[-]"
+		}
+		msg := step.Name
+		if step.Message != "" {
+			msg += ": " + step.Message
 		}
+		fmt.Printf("%s %s\n", status, msg)
+	}
 
-		// Check RPC status
-		rpcReachable := false
-		var height int64
-		var catchingUp bool
-		var peerCount int
+	if result.Error != "" {
+		fmt.Fprintf(os.Stderr, "\nError: %s\n", result.Error)
+		if result.NeedsSudo {
+			fmt.Println("\nTo complete the update manually:")
+			fmt.Printf("  1. Download: %s\n", checkResult.DownloadURL)
+			fmt.Printf("  2. Verify checksum (if available)\n")
+			fmt.Printf("  3. sudo mv <downloaded-binary> %s\n", result.PreviousPath)
+		}
+		os.Exit(1)
+	}
 
-		conn, _ := gonet.DialTimeout("tcp", "localhost:26657", 3*time.Second)
-		if conn != nil {
-			conn.Close()
-			rpcReachable = true
-			// Try to get status from RPC
-			opts := core.StatusOptions{
-				Endpoints: core.Endpoints{
-					CometRPC: rpcEndpoint,
-				},
+	if result.Success {
+		fmt.Println()
+		if dryRun {
+			fmt.Printf("Would update: %s → %s\n", result.OldVersion, result.NewVersion)
+		} else {
+			fmt.Printf("Update successful: %s → %s\n", result.OldVersion, result.NewVersion)
+			if result.BackupPath != "" {
+				fmt.Printf("Backup stored at: %s\n", result.BackupPath)
 			}
-			if status, err := core.GetNodeStatus(opts); err == nil {
-				height = status.LatestHeight
-				catchingUp = status.CatchingUp
-				peerCount = status.PeersCount
+			if result.ChecksumVerify {
+				fmt.Println("Checksum verified ✓")
 			}
+			fmt.Println("\nPlease restart monoctl to use the new version.")
 		}
+	}
+}
 
-		// Check installation status
-		monodInstalled := false
-		monodUserPath := filepath.Join(homeDir, ".local", "bin", "monod")
-		monodSysPath := "/usr/local/bin/monod"
-		if _, err := os.Stat(monodUserPath); err == nil {
-			monodInstalled = true
-		} else if _, err := os.Stat(monodSysPath); err == nil {
-			monodInstalled = true
-		}
+// =============================================================================
+// Wallet Commands
+// =============================================================================
 
-		nodeHomeExists := false
-		if _, err := os.Stat(nodeHome); err == nil {
-			nodeHomeExists = true
-		}
+func runWalletGenerate(cmd *cobra.Command, args []string) {
+	name, _ := cmd.Flags().GetString("name")
+	outPath, _ := cmd.Flags().GetString("out")
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	showPrivateKey, _ := cmd.Flags().GetBool("show-private-key")
+	insecureShow, _ := cmd.Flags().GetBool("insecure-show")
+	useMnemonic, _ := cmd.Flags().GetBool("mnemonic")
+	words, _ := cmd.Flags().GetInt("words")
+	index, _ := cmd.Flags().GetUint32("index")
+	askPassphrase, _ := cmd.Flags().GetBool("bip39-passphrase")
 
-		out := map[string]interface{}{
-			"deployment_mode": deployMode,
-			"network":         network,
-			"rpc_endpoint":    rpcEndpoint,
-			"rpc_reachable":   rpcReachable,
-			"height":          height,
-			"catching_up":     catchingUp,
-			"peer_count":      peerCount,
-			"status": map[string]bool{
-				"monod_installed":  monodInstalled,
-				"node_home_exists": nodeHomeExists,
-			},
-			"locations": map[string]string{
-				"monod_user":       monodUserPath,
-				"monod_system":     monodSysPath,
-				"node_home":        nodeHome,
-				"commander_config": filepath.Join(homeDir, ".mono-commander"),
-				"systemd_units":    "/etc/systemd/system/",
-			},
+	// Validate --show-private-key requires --insecure-show
+	if showPrivateKey && !insecureShow {
+		fmt.Fprintln(os.Stderr, "ERROR: --show-private-key requires --insecure-show=true")
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, "This is a dangerous operation. If you proceed, your private key")
+		fmt.Fprintln(os.Stderr, "will be displayed on screen. Never share your private key with anyone.")
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, "To proceed, run:")
+		fmt.Fprintln(os.Stderr, "  monoctl wallet generate --show-private-key --insecure-show=true")
+		os.Exit(1)
+	}
+	if !useMnemonic && (cmd.Flags().Changed("words") || cmd.Flags().Changed("index") || askPassphrase) {
+		fmt.Fprintln(os.Stderr, "Error: --words, --index and --bip39-passphrase require --mnemonic")
+		os.Exit(1)
+	}
+	for _, f := range []string{"count", "manifest", "prefix", "suffix"} {
+		if cmd.Flags().Changed(f) {
+			runWalletGenerateBatch(cmd)
+			return
 		}
+	}
 
-		// Add role validation if node home exists
-		configPath := filepath.Join(nodeHome, "config", "config.toml")
-		if _, err := os.Stat(configPath); err == nil {
-			role, err := core.DetectCurrentRole(nodeHome)
-			if err == nil {
-				roleInfo := map[string]interface{}{
-					"detected_role": role,
-					"description":   core.RoleDescription(role),
-				}
+	password := readKeystorePassword(passwordFile)
 
-				validation, err := core.ValidateRoleConfig(nodeHome, role)
-				if err == nil {
-					issues := make([]map[string]string, len(validation.Issues))
-					for i, issue := range validation.Issues {
-						issues[i] = map[string]string{
-							"severity": issue.Severity,
-							"field":    issue.Field,
-							"expected": issue.Expected,
-							"actual":   issue.Actual,
-							"message":  issue.Message,
-						}
-					}
-					roleInfo["valid"] = validation.Valid
-					roleInfo["issues"] = issues
-					roleInfo["suggestions"] = validation.Suggestions
-				}
-				out["role_validation"] = roleInfo
-			}
+	// Generate keypair
+	var kp *walletgen.Keypair
+	var mnemonic, hdPath string
+	var err error
+	if useMnemonic {
+		mnemonic, err = walletgen.NewMnemonic(words)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating mnemonic: %v\n", err)
+			os.Exit(1)
 		}
+		passphrase := readBIP39Passphrase(askPassphrase, true)
+		hdPath = walletgen.HDPathForIndex(index)
+		kp, err = walletgen.FromMnemonic(mnemonic, passphrase, hdPath)
+	} else {
+		kp, err = walletgen.GenerateKeypair()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating keypair: %v\n", err)
+		os.Exit(1)
+	}
 
+	outPath = saveWalletKeystore(kp, password, name, outPath)
+
+	// Get addresses
+	evmAddr := kp.EVMAddress()
+	bech32Addr, _ := kp.Bech32Address()
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"keystore_path":  outPath,
+			"evm_address":    evmAddr,
+			"bech32_address": bech32Addr,
+		}
+		if useMnemonic {
+			out["mnemonic"] = mnemonic
+			out["hd_path"] = hdPath
+		}
+		if showPrivateKey && insecureShow {
+			out["private_key"] = kp.PrivateKeyHex()
+		}
 		data, _ := json.MarshalIndent(out, "", "  ")
 		fmt.Println(string(data))
 		return
 	}
 
-	fmt.Println("Mono Commander - Deployment Mode")
-	fmt.Println(strings.Repeat("=", 60))
-	fmt.Println()
-
-	fmt.Println("DEPLOYMENT MODE")
-	fmt.Println(strings.Repeat("-", 40))
-	fmt.Println("  Mode:            HOST-NATIVE")
-	fmt.Println("  Container:       NO (no Docker/docker-compose)")
-	fmt.Println("  Process Manager: systemd (Linux)")
+	// Human-readable output
 	fmt.Println()
+	fmt.Println("Wallet Generated Successfully")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("Keystore:       %s\n", outPath)
+	fmt.Printf("EVM Address:    %s\n", evmAddr)
+	fmt.Printf("Bech32 Address: %s\n", bech32Addr)
 
-	fmt.Println("FILE LOCATIONS")
-	fmt.Println(strings.Repeat("-", 40))
-	fmt.Printf("  monod binary (user):   %s\n", filepath.Join(homeDir, ".local", "bin", "monod"))
-	fmt.Printf("  monod binary (system): /usr/local/bin/monod\n")
-	fmt.Printf("  Node home:             %s\n", filepath.Join(homeDir, ".monod"))
-	fmt.Printf("  Commander config:      %s\n", filepath.Join(homeDir, ".mono-commander"))
-	fmt.Printf("  Systemd units:         /etc/systemd/system/\n")
-	fmt.Println()
+	if useMnemonic {
+		fmt.Printf("HD Path:        %s\n", hdPath)
+		printRecoveryPhrase(mnemonic)
+	}
+
+	if showPrivateKey && insecureShow {
+		fmt.Println()
+		fmt.Println(strings.Repeat("!", 60))
+		fmt.Println("!!! WARNING: PRIVATE KEY BELOW - NEVER SHARE THIS !!!")
+		fmt.Println(strings.Repeat("!", 60))
+		fmt.Printf("Private Key:    %s\n", kp.PrivateKeyHex())
+		fmt.Println(strings.Repeat("!", 60))
+		fmt.Println()
+		fmt.Println("The private key above gives FULL CONTROL over this wallet.")
+		fmt.Println("Store it securely and NEVER share it with anyone.")
+	}
 
-	fmt.Println("COMMAND → ARTIFACT MAPPING")
-	fmt.Println(strings.Repeat("-", 40))
-	fmt.Println("  monoctl monod install   → ~/.local/bin/monod")
-	fmt.Println("  monoctl join            → ~/.monod/config/genesis.json")
-	fmt.Println("                          → ~/.monod/config/config-patch.toml")
-	fmt.Println("  monoctl systemd install → /etc/systemd/system/monod-<network>.service")
-	fmt.Println("  monoctl mesh enable     → ~/.mono-commander/mesh-<network>.toml")
-	fmt.Println("                          → /etc/systemd/system/mesh-<network>.service")
-	fmt.Println("  monoctl wallet generate → ~/.mono-commander/wallets/<name>.json")
 	fmt.Println()
+	fmt.Println("Keep your password safe - it is required to use this wallet.")
+}
 
-	// Check current installation status
-	fmt.Println("CURRENT STATUS")
-	fmt.Println(strings.Repeat("-", 40))
+// printRecoveryPhrase shows a newly generated mnemonic with its warnings.
+func printRecoveryPhrase(mnemonic string) {
+	fmt.Println()
+	fmt.Println(strings.Repeat("!", 60))
+	fmt.Println("!!! RECOVERY PHRASE BELOW - WRITE IT DOWN, NEVER SHARE IT !!!")
+	fmt.Println(strings.Repeat("!", 60))
+	fmt.Println(mnemonic)
+	fmt.Println(strings.Repeat("!", 60))
+	fmt.Println()
+	fmt.Println("Anyone with this phrase controls every account derived from it.")
+	fmt.Println("It restores this wallet in MetaMask, 'monod keys add --recover'")
+	fmt.Println("and 'monoctl wallet recover'. It will not be shown again.")
+}
 
-	// Check monod
-	monodUserPath := filepath.Join(homeDir, ".local", "bin", "monod")
-	monodSysPath := "/usr/local/bin/monod"
+// runWalletGenerateBatch handles wallet generate with --count, --manifest
+// or a vanity --prefix/--suffix.
+func runWalletGenerateBatch(cmd *cobra.Command) {
+	name, _ := cmd.Flags().GetString("name")
+	outPath, _ := cmd.Flags().GetString("out")
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	showPrivateKey, _ := cmd.Flags().GetBool("show-private-key")
+	useMnemonic, _ := cmd.Flags().GetBool("mnemonic")
+	words, _ := cmd.Flags().GetInt("words")
+	index, _ := cmd.Flags().GetUint32("index")
+	askPassphrase, _ := cmd.Flags().GetBool("bip39-passphrase")
+	count, _ := cmd.Flags().GetInt("count")
+	manifestPath, _ := cmd.Flags().GetString("manifest")
+	prefix, _ := cmd.Flags().GetString("prefix")
+	suffix, _ := cmd.Flags().GetString("suffix")
+	vanityEVM, _ := cmd.Flags().GetBool("vanity-evm")
+	workers, _ := cmd.Flags().GetInt("workers")
+
+	if count < 1 {
+		fmt.Fprintln(os.Stderr, "Error: --count must be at least 1")
+		os.Exit(1)
+	}
+	if showPrivateKey {
+		fmt.Fprintln(os.Stderr, "Error: --show-private-key cannot be combined with --count, --manifest, --prefix or --suffix")
+		os.Exit(1)
+	}
+	vanity := prefix != "" || suffix != ""
+	if vanity && useMnemonic {
+		fmt.Fprintln(os.Stderr, "Error: --prefix/--suffix cannot be combined with --mnemonic")
+		os.Exit(1)
+	}
+	if !vanity && (vanityEVM || cmd.Flags().Changed("workers")) {
+		fmt.Fprintln(os.Stderr, "Error: --vanity-evm and --workers require --prefix or --suffix")
+		os.Exit(1)
+	}
+	if useMnemonic && uint64(index)+uint64(count) > 1<<31 {
+		fmt.Fprintln(os.Stderr, "Error: --index + --count exceeds the largest BIP44 index")
+		os.Exit(1)
+	}
+	opts := walletgen.VanityOptions{Prefix: prefix, Suffix: suffix, EVM: vanityEVM, Workers: workers}
+	if vanity {
+		if err := opts.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if manifestPath != "" {
+		if _, err := walletgen.ManifestFormat(manifestPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-	if _, err := os.Stat(monodUserPath); err == nil {
-		fmt.Printf("  monod (user):   INSTALLED at %s\n", monodUserPath)
-	} else if _, err := os.Stat(monodSysPath); err == nil {
-		fmt.Printf("  monod (system): INSTALLED at %s\n", monodSysPath)
-	} else {
-		fmt.Println("  monod:          NOT INSTALLED")
+	password := readKeystorePassword(passwordFile)
+
+	var mnemonic, passphrase string
+	if useMnemonic {
+		var err error
+		if mnemonic, err = walletgen.NewMnemonic(words); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating mnemonic: %v\n", err)
+			os.Exit(1)
+		}
+		passphrase = readBIP39Passphrase(askPassphrase, true)
 	}
 
-	// Check node home
-	nodeHome := filepath.Join(homeDir, ".monod")
-	if _, err := os.Stat(nodeHome); err == nil {
-		fmt.Printf("  Node home:      EXISTS at %s\n", nodeHome)
-	} else {
-		fmt.Println("  Node home:      NOT INITIALIZED")
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if vanity {
+		target := "mono1" + strings.TrimPrefix(strings.ToLower(prefix), "mono1")
+		if vanityEVM {
+			target = "0x" + strings.TrimPrefix(strings.ToLower(prefix), "0x")
+		}
+		fmt.Fprintf(os.Stderr, "Searching for %s...%s (about %s keys per wallet, Ctrl-C to stop)\n",
+			target, strings.ToLower(suffix), formatCount(opts.Difficulty()))
 	}
 
-	// Check commander config
-	cmdConfig := filepath.Join(homeDir, ".mono-commander")
-	if _, err := os.Stat(cmdConfig); err == nil {
-		fmt.Printf("  Commander:      CONFIGURED at %s\n", cmdConfig)
-	} else {
-		fmt.Println("  Commander:      NOT CONFIGURED (will be created on first use)")
+	var entries []walletgen.ManifestEntry
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		walletName := name
+		if count > 1 {
+			if walletName == "" {
+				walletName = "wallet"
+			}
+			walletName = fmt.Sprintf("%s-%d", walletName, i+1)
+		}
+
+		var kp *walletgen.Keypair
+		var hdPath string
+		var err error
+		switch {
+		case vanity:
+			kp, err = findVanityKeypair(ctx, opts)
+		case useMnemonic:
+			hdPath = walletgen.HDPathForIndex(index + uint32(i))
+			kp, err = walletgen.FromMnemonic(mnemonic, passphrase, hdPath)
+		default:
+			kp, err = walletgen.GenerateKeypair()
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			fmt.Fprintf(os.Stderr, "Error generating keypair: %v\n", err)
+			os.Exit(1)
+		}
+
+		// With --count, --out names a directory
+		path := outPath
+		if path != "" && count > 1 {
+			path = filepath.Join(path, walletgen.GenerateKeystoreFilename(walletName, kp.EVMAddress()))
+		}
+		path = saveWalletKeystore(kp, password, walletName, path)
+
+		bech32Addr, _ := kp.Bech32Address()
+		entries = append(entries, walletgen.ManifestEntry{
+			Name:          walletName,
+			EVMAddress:    kp.EVMAddress(),
+			Bech32Address: bech32Addr,
+			HDPath:        hdPath,
+			Keystore:      path,
+		})
+		if count > 1 {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s\n", i+1, count, bech32Addr)
+		}
 	}
+	interrupted := len(entries) < count
 
-	// Role validation (only if node home exists)
-	configPath := filepath.Join(nodeHome, "config", "config.toml")
-	if _, err := os.Stat(configPath); err == nil {
+	if manifestPath != "" && len(entries) > 0 {
+		if err := walletgen.WriteManifest(manifestPath, entries); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"wallets": entries,
+		}
+		if manifestPath != "" && len(entries) > 0 {
+			out["manifest"] = manifestPath
+		}
+		if useMnemonic {
+			out["mnemonic"] = mnemonic
+		}
+		if interrupted {
+			out["interrupted"] = true
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+	} else if len(entries) > 0 {
 		fmt.Println()
-		fmt.Println("NODE ROLE VALIDATION")
-		fmt.Println(strings.Repeat("-", 40))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		if useMnemonic {
+			fmt.Fprintln(w, "NAME\tEVM ADDRESS\tBECH32 ADDRESS\tHD PATH\tKEYSTORE")
+		} else {
+			fmt.Fprintln(w, "NAME\tEVM ADDRESS\tBECH32 ADDRESS\tKEYSTORE")
+		}
+		for _, e := range entries {
+			name := e.Name
+			if name == "" {
+				name = "-"
+			}
+			if useMnemonic {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", name, e.EVMAddress, e.Bech32Address, e.HDPath, e.Keystore)
+			} else {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, e.EVMAddress, e.Bech32Address, e.Keystore)
+			}
+		}
+		w.Flush()
+		if manifestPath != "" {
+			fmt.Printf("\nManifest written to %s\n", manifestPath)
+		}
+		if useMnemonic {
+			printRecoveryPhrase(mnemonic)
+		}
+		fmt.Println()
+		fmt.Println("Keep your password safe - it is required to use these wallets.")
+	}
 
-		role, err := core.DetectCurrentRole(nodeHome)
+	if interrupted {
+		fmt.Fprintf(os.Stderr, "Interrupted: generated %d of %d wallets\n", len(entries), count)
+		os.Exit(1)
+	}
+}
+
+// findVanityKeypair runs a vanity search, reporting progress on stderr.
+func findVanityKeypair(ctx context.Context, opts walletgen.VanityOptions) (*walletgen.Keypair, error) {
+	// Rewrite one line on a terminal, otherwise log every 10 seconds
+	tty := term.IsTerminal(int(os.Stderr.Fd()))
+	opts.ProgressInterval = time.Second
+	if !tty {
+		opts.ProgressInterval = 10 * time.Second
+	}
+	start := time.Now()
+	expected := opts.Difficulty()
+	opts.Progress = func(attempts uint64) {
+		rate := float64(attempts) / time.Since(start).Seconds()
+		line := fmt.Sprintf("  %s keys tried (%s/s)", formatCount(float64(attempts)), formatCount(rate))
+		if rate > 0 && float64(attempts) < expected {
+			eta := time.Duration((expected - float64(attempts)) / rate * float64(time.Second))
+			line += fmt.Sprintf(", expected in ~%s", eta.Round(time.Second))
+		}
+		if tty {
+			fmt.Fprintf(os.Stderr, "\r\033[K%s", line)
+		} else {
+			fmt.Fprintln(os.Stderr, line)
+		}
+	}
+
+	kp, attempts, err := walletgen.FindVanityKeypair(ctx, opts)
+	if tty && time.Since(start) >= opts.ProgressInterval {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+	if err == nil {
+		fmt.Fprintf(os.Stderr, "  found after %s keys in %s\n", formatCount(float64(attempts)), time.Since(start).Round(time.Millisecond))
+	}
+	return kp, err
+}
+
+// formatCount formats n with a k/M/G/T suffix.
+func formatCount(n float64) string {
+	switch {
+	case n >= 1e12:
+		return fmt.Sprintf("%.1fT", n/1e12)
+	case n >= 1e9:
+		return fmt.Sprintf("%.1fG", n/1e9)
+	case n >= 1e6:
+		return fmt.Sprintf("%.1fM", n/1e6)
+	case n >= 1e3:
+		return fmt.Sprintf("%.1fk", n/1e3)
+	}
+	return fmt.Sprintf("%.0f", n)
+}
+
+func runWalletRecover(cmd *cobra.Command, args []string) {
+	name, _ := cmd.Flags().GetString("name")
+	outPath, _ := cmd.Flags().GetString("out")
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	mnemonicFile, _ := cmd.Flags().GetString("mnemonic-file")
+	index, _ := cmd.Flags().GetUint32("index")
+	askPassphrase, _ := cmd.Flags().GetBool("bip39-passphrase")
+
+	mnemonic := readMnemonic(mnemonicFile)
+	passphrase := readBIP39Passphrase(askPassphrase, false)
+
+	hdPath := walletgen.HDPathForIndex(index)
+	kp, err := walletgen.FromMnemonic(mnemonic, passphrase, hdPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error deriving key: %v\n", err)
+		os.Exit(1)
+	}
+
+	password := readKeystorePassword(passwordFile)
+	outPath = saveWalletKeystore(kp, password, name, outPath)
+
+	evmAddr := kp.EVMAddress()
+	bech32Addr, _ := kp.Bech32Address()
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"keystore_path":  outPath,
+			"evm_address":    evmAddr,
+			"bech32_address": bech32Addr,
+			"hd_path":        hdPath,
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("Wallet Recovered Successfully")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("Keystore:       %s\n", outPath)
+	fmt.Printf("EVM Address:    %s\n", evmAddr)
+	fmt.Printf("Bech32 Address: %s\n", bech32Addr)
+	fmt.Printf("HD Path:        %s\n", hdPath)
+}
+
+func runWalletDerive(cmd *cobra.Command, args []string) {
+	mnemonicFile, _ := cmd.Flags().GetString("mnemonic-file")
+	index, _ := cmd.Flags().GetUint32("index")
+	count, _ := cmd.Flags().GetUint32("count")
+	askPassphrase, _ := cmd.Flags().GetBool("bip39-passphrase")
+
+	if count == 0 {
+		fmt.Fprintln(os.Stderr, "Error: --count must be at least 1")
+		os.Exit(1)
+	}
+
+	mnemonic := readMnemonic(mnemonicFile)
+	passphrase := readBIP39Passphrase(askPassphrase, false)
+
+	type derivedAccount struct {
+		Index         uint32 `json:"index"`
+		HDPath        string `json:"hd_path"`
+		EVMAddress    string `json:"evm_address"`
+		Bech32Address string `json:"bech32_address"`
+	}
+	var accounts []derivedAccount
+	for i := index; i < index+count; i++ {
+		hdPath := walletgen.HDPathForIndex(i)
+		kp, err := walletgen.FromMnemonic(mnemonic, passphrase, hdPath)
 		if err != nil {
-			fmt.Printf("  Error detecting role: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error deriving key: %v\n", err)
+			os.Exit(1)
+		}
+		bech32Addr, _ := kp.Bech32Address()
+		accounts = append(accounts, derivedAccount{Index: i, HDPath: hdPath, EVMAddress: kp.EVMAddress(), Bech32Address: bech32Addr})
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(accounts, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "INDEX\tHD PATH\tEVM ADDRESS\tBECH32 ADDRESS")
+	for _, a := range accounts {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", a.Index, a.HDPath, a.EVMAddress, a.Bech32Address)
+	}
+	w.Flush()
+}
+
+func runWalletPasswd(cmd *cobra.Command, args []string) {
+	path := args[0]
+	oldPasswordFile, _ := cmd.Flags().GetString("old-password-file")
+	newPasswordFile, _ := cmd.Flags().GetString("new-password-file")
+
+	ks, err := walletgen.LoadKeystore(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading keystore: %v\n", err)
+		os.Exit(1)
+	}
+	oldPassword := readExistingPassword(oldPasswordFile, "Enter current password: ")
+	if _, err := walletgen.DecryptKeystore(ks, oldPassword); err != nil {
+		fmt.Fprintf(os.Stderr, "Error decrypting keystore: %v\n", err)
+		os.Exit(1)
+	}
+	newPassword := readKeystorePassword(newPasswordFile)
+
+	out, err := walletgen.ReencryptKeystore(ks, oldPassword, newPassword)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error re-encrypting keystore: %v\n", err)
+		os.Exit(1)
+	}
+	backup, err := walletgen.ReplaceKeystore(out, path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"file":        path,
+			"backup":      backup,
+			"evm_address": walletgen.GetKeystoreAddress(ks),
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("Password changed for %s\n", walletgen.GetKeystoreAddress(ks))
+	fmt.Printf("Backup of the old keystore: %s\n", backup)
+	fmt.Println("Delete the backup once you have checked the new password; it still opens with the old one.")
+}
+
+func runWalletRekey(cmd *cobra.Command, args []string) {
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	force, _ := cmd.Flags().GetBool("force")
+
+	type rekeyResult struct {
+		File       string `json:"file"`
+		EVMAddress string `json:"evm_address,omitempty"`
+		Status     string `json:"status"` // "rekeyed", "skipped" or "failed"
+		OldKDF     string `json:"old_kdf,omitempty"`
+		Backup     string `json:"backup,omitempty"`
+		Error      string `json:"error,omitempty"`
+	}
+	var results []rekeyResult
+	failed := false
+	for _, path := range args {
+		r := rekeyResult{File: path}
+		ks, err := walletgen.LoadKeystore(path)
+		if err != nil {
+			r.Status, r.Error, failed = "failed", err.Error(), true
+			results = append(results, r)
+			continue
+		}
+		r.EVMAddress = walletgen.GetKeystoreAddress(ks)
+		r.OldKDF = ks.Crypto.KDF
+		if ks.Crypto.KDF == "scrypt" {
+			r.OldKDF = fmt.Sprintf("scrypt n=%d", ks.Crypto.KDFParams.N)
+		}
+		if !walletgen.IsWeakKDF(ks) && !force {
+			r.Status = "skipped"
+			results = append(results, r)
+			continue
+		}
+
+		password := readExistingPassword(passwordFile, fmt.Sprintf("Enter password for %s: ", filepath.Base(path)))
+		out, err := walletgen.ReencryptKeystore(ks, password, password)
+		if err == nil {
+			r.Backup, err = walletgen.ReplaceKeystore(out, path)
+		}
+		if err != nil {
+			r.Status, r.Error, failed = "failed", err.Error(), true
 		} else {
-			fmt.Printf("  Detected Role: %s\n", role)
-			fmt.Printf("  Description:   %s\n", core.RoleDescription(role))
+			r.Status = "rekeyed"
+		}
+		results = append(results, r)
+	}
 
-			validation, err := core.ValidateRoleConfig(nodeHome, role)
-			if err != nil {
-				fmt.Printf("  Validation error: %v\n", err)
-			} else {
-				hasCritical := false
-				hasWarning := false
-				for _, issue := range validation.Issues {
-					if issue.Severity == "CRITICAL" {
-						hasCritical = true
-					}
-					if issue.Severity == "WARNING" {
-						hasWarning = true
-					}
-				}
+	if jsonOutput {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "FILE\tADDRESS\tOLD KDF\tSTATUS")
+		for _, r := range results {
+			status := r.Status
+			if r.Error != "" {
+				status += ": " + r.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", filepath.Base(r.File), r.EVMAddress, r.OldKDF, status)
+		}
+		w.Flush()
+		if slices.ContainsFunc(results, func(r rekeyResult) bool { return r.Backup != "" }) {
+			fmt.Println()
+			fmt.Println("Old keystores are kept as <file>.<timestamp>.bak; delete them once the new files are checked.")
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func runWalletImportToKeyring(cmd *cobra.Command, args []string) {
+	filePath, _ := cmd.Flags().GetString("file")
+	name, _ := cmd.Flags().GetString("name")
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+
+	ks, err := walletgen.LoadKeystore(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading keystore: %v\n", err)
+		os.Exit(1)
+	}
+	password := readExistingPassword(passwordFile, "Enter keystore password: ")
+	privKey, err := walletgen.DecryptKeystore(ks, password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error decrypting keystore: %v\n", err)
+		os.Exit(1)
+	}
+	want, _ := walletgen.GetKeystoreBech32Address(ks)
+
+	opts := keyringOptions(cmd)
+	addr, err := core.ImportKeyToKeyring(cmd.Context(), opts, name, privKey)
+	clear(privKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if addr != want {
+		fmt.Fprintf(os.Stderr, "Error: keyring address %s does not match keystore address %s; monod may not use eth_secp256k1 keys\n", addr, want)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"name":            name,
+			"bech32_address":  addr,
+			"keyring_backend": opts.Backend,
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Printf("Imported %s into the keyring as %q\n", addr, name)
+	fmt.Println()
+	fmt.Println("Use it in tx commands with:")
+	if opts.Backend != "" {
+		fmt.Printf("  --from %s --keyring-backend %s\n", name, opts.Backend)
+	} else {
+		fmt.Printf("  --from %s\n", name)
+	}
+}
+
+func runWalletExportFromKeyring(cmd *cobra.Command, args []string) {
+	name, _ := cmd.Flags().GetString("name")
+	walletName, _ := cmd.Flags().GetString("wallet-name")
+	outPath, _ := cmd.Flags().GetString("out")
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+
+	opts := keyringOptions(cmd)
+	addr, err := core.KeyringAddress(cmd.Context(), opts, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	privKey, err := core.ExportKeyFromKeyring(cmd.Context(), opts, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	kp, err := walletgen.FromPrivateKeyBytes(privKey)
+	clear(privKey)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	bech32Addr, _ := kp.Bech32Address()
+	if bech32Addr != addr {
+		fmt.Fprintf(os.Stderr, "Error: exported key derives %s, but the keyring reports %s\n", bech32Addr, addr)
+		os.Exit(1)
+	}
+
+	password := readKeystorePassword(passwordFile)
+	if walletName == "" {
+		walletName = name
+	}
+	outPath = saveWalletKeystore(kp, password, walletName, outPath)
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"keystore_path":  outPath,
+			"evm_address":    kp.EVMAddress(),
+			"bech32_address": bech32Addr,
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("Key Exported Successfully")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("Keystore:       %s\n", outPath)
+	fmt.Printf("EVM Address:    %s\n", kp.EVMAddress())
+	fmt.Printf("Bech32 Address: %s\n", bech32Addr)
+}
+
+// addKeyringFlags registers the monod keyring flags of the wallet keyring
+// commands.
+func addKeyringFlags(cmd *cobra.Command) {
+	cmd.Flags().String("home", "", "monod home directory (default: monod's default)")
+	cmd.Flags().String("keyring-backend", "", "Keyring backend (os|file|test, default: monod's default)")
+	cmd.Flags().String("keyring-passphrase-file", "", "Path to file containing the file backend's keyring passphrase")
+	cmd.Flags().String("monod-path", "", "Path to monod binary (auto-detected if not specified)")
+}
+
+// keyringOptions returns the keyring selected by addKeyringFlags, prompting
+// for the file backend's passphrase if no file is given.
+func keyringOptions(cmd *cobra.Command) core.KeyringOptions {
+	opts := core.KeyringOptions{}
+	opts.Home, _ = cmd.Flags().GetString("home")
+	opts.Backend, _ = cmd.Flags().GetString("keyring-backend")
+	opts.MonodPath, _ = cmd.Flags().GetString("monod-path")
+	passphraseFile, _ := cmd.Flags().GetString("keyring-passphrase-file")
+
+	switch opts.Backend {
+	case "", "os", "test":
+	case "file":
+		opts.Passphrase = readExistingPassword(passphraseFile, "Enter keyring passphrase: ")
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported keyring backend %q (valid: os, file, test)\n", opts.Backend)
+		os.Exit(1)
+	}
+	return opts
+}
+
+// readExistingPassword reads a password from passwordFile, or prompts for
+// it once.
+func readExistingPassword(passwordFile, prompt string) string {
+	if passwordFile != "" {
+		data, err := os.ReadFile(passwordFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading password file: %v\n", err)
+			os.Exit(1)
+		}
+		return strings.TrimSpace(string(data))
+	}
+	password, err := promptPassword(prompt)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading password: %v\n", err)
+		os.Exit(1)
+	}
+	return password
+}
+
+// readKeystorePassword reads a new keystore password from passwordFile, or
+// prompts for it twice. It exits on error or a password under 8 characters.
+func readKeystorePassword(passwordFile string) string {
+	var password string
+	var err error
+
+	if passwordFile != "" {
+		// Read password from file
+		data, err := os.ReadFile(passwordFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading password file: %v\n", err)
+			os.Exit(1)
+		}
+		password = strings.TrimSpace(string(data))
+	} else {
+		// Interactive password prompt
+		password, err = promptPassword("Enter password for keystore: ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading password: %v\n", err)
+			os.Exit(1)
+		}
+
+		// Confirm password
+		confirm, err := promptPassword("Confirm password: ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading password: %v\n", err)
+			os.Exit(1)
+		}
+
+		if password != confirm {
+			fmt.Fprintln(os.Stderr, "Error: passwords do not match")
+			os.Exit(1)
+		}
+	}
+
+	if len(password) < 8 {
+		fmt.Fprintln(os.Stderr, "Error: password must be at least 8 characters")
+		os.Exit(1)
+	}
+	return password
+}
+
+// saveWalletKeystore encrypts kp with password and saves it to outPath, or
+// to a file named after name in the default wallet directory. It returns
+// the path written.
+func saveWalletKeystore(kp *walletgen.Keypair, password, name, outPath string) string {
+	// Create keystore
+	ks, err := walletgen.CreateKeystore(kp, password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating keystore: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Determine output path
+	if outPath == "" {
+		walletDir, err := walletgen.GetDefaultWalletDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting wallet directory: %v\n", err)
+			os.Exit(1)
+		}
+		filename := walletgen.GenerateKeystoreFilename(name, kp.EVMAddress())
+		outPath = filepath.Join(walletDir, filename)
+	}
+
+	// Save keystore
+	if err := walletgen.SaveKeystore(ks, outPath); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving keystore: %v\n", err)
+		os.Exit(1)
+	}
+	return outPath
+}
+
+// readMnemonic reads a BIP39 mnemonic from mnemonicFile, or prompts for it
+// without echo, and exits if it is invalid.
+func readMnemonic(mnemonicFile string) string {
+	var mnemonic string
+	if mnemonicFile != "" {
+		data, err := os.ReadFile(mnemonicFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading mnemonic file: %v\n", err)
+			os.Exit(1)
+		}
+		mnemonic = string(data)
+	} else {
+		var err error
+		mnemonic, err = promptPassword("Enter mnemonic (12 or 24 words): ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading mnemonic: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if err := walletgen.ValidateMnemonic(mnemonic); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return walletgen.NormalizeMnemonic(mnemonic)
+}
+
+// readBIP39Passphrase prompts for a BIP39 passphrase if ask is set, twice
+// when confirm is set. A forgotten passphrase makes the mnemonic useless.
+func readBIP39Passphrase(ask, confirm bool) string {
+	if !ask {
+		return ""
+	}
+	passphrase, err := promptPassword("Enter BIP39 passphrase: ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading passphrase: %v\n", err)
+		os.Exit(1)
+	}
+	if confirm {
+		again, err := promptPassword("Confirm BIP39 passphrase: ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading passphrase: %v\n", err)
+			os.Exit(1)
+		}
+		if passphrase != again {
+			fmt.Fprintln(os.Stderr, "Error: passphrases do not match")
+			os.Exit(1)
+		}
+	}
+	return passphrase
+}
+
+func runWalletList(cmd *cobra.Command, args []string) {
+	dir, _ := cmd.Flags().GetString("dir")
+
+	if dir == "" {
+		var err error
+		dir, err = walletgen.GetDefaultWalletDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting wallet directory: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	infos, err := walletgen.ListKeystores(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing keystores: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(infos, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	if len(infos) == 0 {
+		fmt.Printf("No wallets found in %s\n", dir)
+		fmt.Println()
+		fmt.Println("Generate a new wallet with:")
+		fmt.Println("  monoctl wallet generate --name my-wallet")
+		return
+	}
+
+	fmt.Printf("Wallets in %s\n", dir)
+	fmt.Println(strings.Repeat("-", 80))
+	fmt.Printf("%-44s %-48s\n", "EVM ADDRESS", "BECH32 ADDRESS")
+	fmt.Println(strings.Repeat("-", 80))
+
+	for _, info := range infos {
+		fmt.Printf("%-44s %-48s\n", info.EVMAddress, info.Bech32Addr)
+		fmt.Printf("  File: %s (created %s)\n", info.Filename, info.CreatedAt.Format("2006-01-02 15:04"))
+	}
+}
+
+func runWalletInfo(cmd *cobra.Command, args []string) {
+	filePath, _ := cmd.Flags().GetString("file")
+
+	ks, err := walletgen.LoadKeystore(filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading keystore: %v\n", err)
+		os.Exit(1)
+	}
+
+	evmAddr := walletgen.GetKeystoreAddress(ks)
+	bech32Addr, _ := walletgen.GetKeystoreBech32Address(ks)
+
+	// Get file info
+	stat, _ := os.Stat(filePath)
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"file":           filePath,
+			"evm_address":    evmAddr,
+			"bech32_address": bech32Addr,
+			"version":        ks.Version,
+			"id":             ks.ID,
+			"cipher":         ks.Crypto.Cipher,
+			"kdf":            ks.Crypto.KDF,
+		}
+		if stat != nil {
+			out["created_at"] = stat.ModTime()
+			out["size_bytes"] = stat.Size()
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println("Wallet Information")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("File:           %s\n", filePath)
+	fmt.Printf("EVM Address:    %s\n", evmAddr)
+	fmt.Printf("Bech32 Address: %s\n", bech32Addr)
+	fmt.Printf("Keystore ID:    %s\n", ks.ID)
+	fmt.Printf("Version:        %d\n", ks.Version)
+	fmt.Printf("Cipher:         %s\n", ks.Crypto.Cipher)
+	fmt.Printf("KDF:            %s\n", ks.Crypto.KDF)
+	if stat != nil {
+		fmt.Printf("Created:        %s\n", stat.ModTime().Format("2006-01-02 15:04:05"))
+	}
+}
+
+// =============================================================================
+// Monod Commands
+// =============================================================================
+
+func runMonodInstall(cmd *cobra.Command, args []string) {
+	url, _ := cmd.Flags().GetString("url")
+	sha256sum, _ := cmd.Flags().GetString("sha256")
+	version, _ := cmd.Flags().GetString("version")
+	insecure, _ := cmd.Flags().GetBool("insecure")
+	useSystem, _ := cmd.Flags().GetBool("system")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	opts := monod.InstallOptions{
+		URL:           url,
+		SHA256:        sha256sum,
+		Version:       version,
+		UseSystemPath: useSystem,
+		Insecure:      insecure,
+		DryRun:        dryRun,
+	}
+
+	result := monod.Install(opts)
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		if !result.Success {
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Println("Monod Binary Installation")
+	fmt.Println(strings.Repeat("-", 50))
+
+	if dryRun {
+		fmt.Println("(DRY RUN - no changes will be made)")
+		fmt.Println()
+	}
+
+	for _, step := range result.Steps {
+		status := "[ ]"
+		switch step.Status {
+		case "success":
+			status = "[+]"
+		case "failed":
+			status = "[X]"
+		case "skipped":
+			status = "[-]"
+		}
+		msg := step.Name
+		if step.Message != "" {
+			msg += ": " + step.Message
+		}
+		fmt.Printf("%s %s\n", status, msg)
+	}
+
+	if result.Error != nil {
+		fmt.Fprintf(os.Stderr, "\nError: %v\n", result.Error)
+		os.Exit(1)
+	}
+
+	if result.Success && !dryRun {
+		fmt.Printf("\nBinary installed to: %s\n", result.InstallPath)
+		if result.SHA256 != "" {
+			fmt.Printf("SHA256: %s\n", result.SHA256)
+		}
+		fmt.Printf("Version: %s\n", result.Version)
+
+		if !opts.UseSystemPath {
+			fmt.Println("\nMake sure ~/.local/bin is in your PATH:")
+			fmt.Println("  export PATH=\"$HOME/.local/bin:$PATH\"")
+		}
+	}
+}
+
+func runMonodStatus(cmd *cobra.Command, args []string) {
+	useSystem, _ := cmd.Flags().GetBool("system")
+
+	installPath := monod.BinaryInstallPath(useSystem)
+	exists := monod.BinaryExists(useSystem)
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"install_path": installPath,
+			"installed":    exists,
+		}
+		if exists {
+			version, _ := monod.GetInstalledVersion(useSystem)
+			out["version"] = version
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println("Monod Binary Status")
+	fmt.Println(strings.Repeat("-", 50))
+	fmt.Printf("Install path:  %s\n", installPath)
+
+	if exists {
+		fmt.Printf("Status:        INSTALLED\n")
+		version, err := monod.GetInstalledVersion(useSystem)
+		if err == nil {
+			fmt.Printf("Version:       %s\n", version)
+		}
+	} else {
+		fmt.Printf("Status:        NOT INSTALLED\n")
+		fmt.Println()
+		fmt.Println("To install, run:")
+		fmt.Println("  monoctl monod install")
+	}
+}
+
+// =============================================================================
+// Doctor Command
+// =============================================================================
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	homeDir, _ := os.UserHomeDir()
+
+	if jsonOutput {
+		// Detect deployment mode
+		deployMode := "host-native"
+		nodeHome := filepath.Join(homeDir, ".monod")
+		if _, err := os.Stat(filepath.Join(nodeHome, "docker-compose.yml")); err == nil {
+			deployMode = "docker"
+		}
+
+		// Load commander config for network info
+		cfgPath := filepath.Join(homeDir, ".mono-commander", "config.json")
+		var network string
+		var rpcEndpoint string
+		if data, err := os.ReadFile(cfgPath); err == nil {
+			var cfg map[string]interface{}
+			if json.Unmarshal(data, &cfg) == nil {
+				if n, ok := cfg["selected_network"].(string); ok {
+					network = n
+				}
+			}
+		}
+		if network == "" {
+			network = "unknown"
+		}
+
+		// Determine RPC endpoint
+		rpcEndpoint = "http://localhost:26657"
+		if deployMode == "docker" {
+			rpcEndpoint = "http://localhost:26657"
+		}
+
+		// Check RPC status
+		rpcReachable := false
+		var height int64
+		var catchingUp bool
+		var peerCount int
+
+		conn, _ := gonet.DialTimeout("tcp", "localhost:26657", 3*time.Second)
+		if conn != nil {
+			conn.Close()
+			rpcReachable = true
+			// Try to get status from RPC
+			opts := core.StatusOptions{
+				Endpoints: core.Endpoints{
+					CometRPC: rpcEndpoint,
+				},
+			}
+			if status, err := core.GetNodeStatus(opts); err == nil {
+				height = status.LatestHeight
+				catchingUp = status.CatchingUp
+				peerCount = status.PeersCount
+			}
+		}
+
+		// Check installation status
+		monodInstalled := false
+		monodUserPath := filepath.Join(homeDir, ".local", "bin", "monod")
+		monodSysPath := "/usr/local/bin/monod"
+		if _, err := os.Stat(monodUserPath); err == nil {
+			monodInstalled = true
+		} else if _, err := os.Stat(monodSysPath); err == nil {
+			monodInstalled = true
+		}
+
+		nodeHomeExists := false
+		if _, err := os.Stat(nodeHome); err == nil {
+			nodeHomeExists = true
+		}
+
+		out := map[string]interface{}{
+			"deployment_mode": deployMode,
+			"network":         network,
+			"rpc_endpoint":    rpcEndpoint,
+			"rpc_reachable":   rpcReachable,
+			"height":          height,
+			"catching_up":     catchingUp,
+			"peer_count":      peerCount,
+			"status": map[string]bool{
+				"monod_installed":  monodInstalled,
+				"node_home_exists": nodeHomeExists,
+			},
+			"locations": map[string]string{
+				"monod_user":       monodUserPath,
+				"monod_system":     monodSysPath,
+				"node_home":        nodeHome,
+				"commander_config": filepath.Join(homeDir, ".mono-commander"),
+				"systemd_units":    "/etc/systemd/system/",
+			},
+		}
+
+		// Add role validation if node home exists
+		configPath := filepath.Join(nodeHome, "config", "config.toml")
+		if _, err := os.Stat(configPath); err == nil {
+			role, err := core.DetectCurrentRole(nodeHome)
+			if err == nil {
+				roleInfo := map[string]interface{}{
+					"detected_role": role,
+					"description":   core.RoleDescription(role),
+				}
+
+				validation, err := core.ValidateRoleConfig(nodeHome, role)
+				if err == nil {
+					issues := make([]map[string]string, len(validation.Issues))
+					for i, issue := range validation.Issues {
+						issues[i] = map[string]string{
+							"severity": issue.Severity,
+							"field":    issue.Field,
+							"expected": issue.Expected,
+							"actual":   issue.Actual,
+							"message":  issue.Message,
+						}
+					}
+					roleInfo["valid"] = validation.Valid
+					roleInfo["issues"] = issues
+					roleInfo["suggestions"] = validation.Suggestions
+				}
+				out["role_validation"] = roleInfo
+			}
+		}
+
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+
+	fmt.Println("Mono Commander - Deployment Mode")
+	fmt.Println(strings.Repeat("=", 60))
+	fmt.Println()
+
+	fmt.Println("DEPLOYMENT MODE")
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Println("  Mode:            HOST-NATIVE")
+	fmt.Println("  Container:       NO (no Docker/docker-compose)")
+	fmt.Println("  Process Manager: systemd (Linux)")
+	fmt.Println()
+
+	fmt.Println("FILE LOCATIONS")
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Printf("  monod binary (user):   %s\n", filepath.Join(homeDir, ".local", "bin", "monod"))
+	fmt.Printf("  monod binary (system): /usr/local/bin/monod\n")
+	fmt.Printf("  Node home:             %s\n", filepath.Join(homeDir, ".monod"))
+	fmt.Printf("  Commander config:      %s\n", filepath.Join(homeDir, ".mono-commander"))
+	fmt.Printf("  Systemd units:         /etc/systemd/system/\n")
+	fmt.Println()
+
+	fmt.Println("COMMAND → ARTIFACT MAPPING")
+	fmt.Println(strings.Repeat("-", 40))
+	fmt.Println("  monoctl monod install   → ~/.local/bin/monod")
+	fmt.Println("  monoctl join            → ~/.monod/config/genesis.json")
+	fmt.Println("                          → ~/.monod/config/config-patch.toml")
+	fmt.Println("  monoctl systemd install → /etc/systemd/system/monod-<network>.service")
+	fmt.Println("  monoctl mesh enable     → ~/.mono-commander/mesh-<network>.toml")
+	fmt.Println("                          → /etc/systemd/system/mesh-<network>.service")
+	fmt.Println("  monoctl wallet generate → ~/.mono-commander/wallets/<name>.json")
+	fmt.Println()
+
+	// Check current installation status
+	fmt.Println("CURRENT STATUS")
+	fmt.Println(strings.Repeat("-", 40))
+
+	// Check monod
+	monodUserPath := filepath.Join(homeDir, ".local", "bin", "monod")
+	monodSysPath := "/usr/local/bin/monod"
+
+	if _, err := os.Stat(monodUserPath); err == nil {
+		fmt.Printf("  monod (user):   INSTALLED at %s\n", monodUserPath)
+	} else if _, err := os.Stat(monodSysPath); err == nil {
+		fmt.Printf("  monod (system): INSTALLED at %s\n", monodSysPath)
+	} else {
+		fmt.Println("  monod:          NOT INSTALLED")
+	}
+
+	// Check node home
+	nodeHome := filepath.Join(homeDir, ".monod")
+	if _, err := os.Stat(nodeHome); err == nil {
+		fmt.Printf("  Node home:      EXISTS at %s\n", nodeHome)
+	} else {
+		fmt.Println("  Node home:      NOT INITIALIZED")
+	}
+
+	// Check commander config
+	cmdConfig := filepath.Join(homeDir, ".mono-commander")
+	if _, err := os.Stat(cmdConfig); err == nil {
+		fmt.Printf("  Commander:      CONFIGURED at %s\n", cmdConfig)
+	} else {
+		fmt.Println("  Commander:      NOT CONFIGURED (will be created on first use)")
+	}
+
+	// Role validation (only if node home exists)
+	configPath := filepath.Join(nodeHome, "config", "config.toml")
+	if _, err := os.Stat(configPath); err == nil {
+		fmt.Println()
+		fmt.Println("NODE ROLE VALIDATION")
+		fmt.Println(strings.Repeat("-", 40))
+
+		role, err := core.DetectCurrentRole(nodeHome)
+		if err != nil {
+			fmt.Printf("  Error detecting role: %v\n", err)
+		} else {
+			fmt.Printf("  Detected Role: %s\n", role)
+			fmt.Printf("  Description:   %s\n", core.RoleDescription(role))
+
+			validation, err := core.ValidateRoleConfig(nodeHome, role)
+			if err != nil {
+				fmt.Printf("  Validation error: %v\n", err)
+			} else {
+				hasCritical := false
+				hasWarning := false
+				for _, issue := range validation.Issues {
+					if issue.Severity == "CRITICAL" {
+						hasCritical = true
+					}
+					if issue.Severity == "WARNING" {
+						hasWarning = true
+					}
+				}
+
+				if validation.Valid {
+					fmt.Println("  Status:        VALID")
+				} else if hasCritical {
+					fmt.Println("  Status:        CRITICAL ISSUES DETECTED")
+				} else if hasWarning {
+					fmt.Println("  Status:        WARNINGS")
+				}
+
+				for _, issue := range validation.Issues {
+					var marker string
+					switch issue.Severity {
+					case "CRITICAL":
+						marker = "CRITICAL"
+					case "WARNING":
+						marker = "WARNING"
+					default:
+						marker = "INFO"
+					}
+					fmt.Printf("\n  [%s] %s\n", marker, issue.Message)
+					if issue.Severity == "CRITICAL" {
+						fmt.Printf("    Expected: %s\n", issue.Expected)
+						fmt.Printf("    Actual:   %s\n", issue.Actual)
+					}
+				}
+
+				// Special warning for seed nodes
+				if role == core.RoleSeedNode {
+					fmt.Println()
+					fmt.Println("  SEED NODE REQUIREMENTS:")
+					fmt.Println("    - Must have pruning=nothing")
+					fmt.Println("    - Must be synced from genesis (earliest_block_height=1)")
+					fmt.Println("    - Must be able to serve historical blocks")
+					fmt.Println()
+					fmt.Println("  Run: monoctl node role --home ~/.monod")
+					fmt.Println("  to verify earliest_block_height via RPC")
+				}
+			}
+		}
+	}
+
+	fmt.Println()
+	fmt.Println("TIP: Use --dry-run on any write command to preview changes.")
+	fmt.Println("     Use 'monoctl node role' to check node role and configuration.")
+}
+
+// readPassword reads a password from passwordFile, or prompts for it when
+// passwordFile is empty.
+func readPassword(passwordFile, prompt string) (string, error) {
+	if passwordFile != "" {
+		data, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	return promptPassword(prompt)
+}
+
+// promptPassword prompts for a password without echoing
+func promptPassword(prompt string) (string, error) {
+	fmt.Print(prompt)
+
+	// Check if stdin is a terminal
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		// Read password without echo
+		password, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println() // Add newline after password input
+		if err != nil {
+			return "", err
+		}
+		return string(password), nil
+	}
+
+	// Fallback for non-terminal (e.g., piped input)
+	reader := bufio.NewReader(os.Stdin)
+	password, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(password), nil
+}
+
+// Docker command handlers
+
+func runDockerInit(cmd *cobra.Command, args []string) {
+	networkName, _ := cmd.Flags().GetString("network")
+	home, _ := cmd.Flags().GetString("home")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	// Resolve home directory
+	if home == "" {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, ".monod")
+	} else if strings.HasPrefix(home, "~") {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
+	}
+	home, _ = filepath.Abs(home)
+
+	network, err := core.GetNetwork(core.NetworkName(networkName))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid network %s: %v\n", networkName, err)
+		os.Exit(1)
+	}
+
+	// Create logger
+	logger := slog.Default()
+
+	// Step 1: Run join to download genesis and configure peers
+	fmt.Println("Docker Init: " + networkName)
+	if dryRun {
+		fmt.Println("(DRY RUN - no changes will be made)")
+	}
+	fmt.Println()
+
+	joinOpts := core.JoinOptions{
+		Network: network.Name,
+		Home:    home,
+		DryRun:  dryRun,
+		Logger:  logger,
+	}
+
+	fetcher := net.NewHTTPFetcher()
+	result, err := core.Join(joinOpts, fetcher)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error during join: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Print join steps
+	for _, step := range result.Steps {
+		status := "[+]"
+		if step.Status == "failed" {
+			status = "[X]"
+		} else if step.Status == "skipped" {
+			status = "[-]"
+		}
+		msg := step.Name
+		if step.Message != "" {
+			msg += ": " + step.Message
+		}
+		fmt.Printf("%s %s\n", status, msg)
+	}
+
+	// Step 2: Generate docker-compose.yml
+	fmt.Println()
+	fmt.Println("[+] Generating docker-compose.yml")
+
+	composeContent := generateDockerCompose(network, home, result.ConfigPatch)
+	composePath := filepath.Join(home, "docker-compose.yml")
+
+	if dryRun {
+		fmt.Printf("Would write to: %s\n", composePath)
+		fmt.Println("\nContent preview:")
+		fmt.Println(composeContent)
+	} else {
+		// Ensure directory exists
+		if err := os.MkdirAll(home, 0755); err != nil {
+			fmt.Fprintf(os.Stderr, "Error creating directory: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(composePath, []byte(composeContent), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing docker-compose.yml: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Written to: %s\n", composePath)
+	}
+
+	fmt.Println()
+	fmt.Println("Docker environment initialized!")
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  cd %s\n", home)
+	fmt.Println("  monoctl docker up")
+}
+
+func runDockerUp(cmd *cobra.Command, args []string) {
+	home := getDockerHome(cmd)
+	composePath := filepath.Join(home, "docker-compose.yml")
+
+	if _, err := os.Stat(composePath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: docker-compose.yml not found at %s\n", composePath)
+		fmt.Fprintf(os.Stderr, "Run 'monoctl docker init --network <network>' first\n")
+		os.Exit(1)
+	}
+
+	fmt.Println("Starting container...")
+	if err := runDockerCompose(home, "up", "-d"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Container started successfully")
+}
+
+func runDockerDown(cmd *cobra.Command, args []string) {
+	home := getDockerHome(cmd)
+
+	fmt.Println("Stopping container...")
+	if err := runDockerCompose(home, "down"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Container stopped")
+}
+
+func runDockerRestart(cmd *cobra.Command, args []string) {
+	home := getDockerHome(cmd)
+
+	fmt.Println("Restarting container...")
+	if err := runDockerCompose(home, "restart"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("Container restarted")
+}
+
+func runDockerLogs(cmd *cobra.Command, args []string) {
+	home := getDockerHome(cmd)
+	follow, _ := cmd.Flags().GetBool("follow")
+	lines, _ := cmd.Flags().GetInt("lines")
+
+	dockerArgs := []string{"logs", fmt.Sprintf("--tail=%d", lines)}
+	if follow {
+		dockerArgs = append(dockerArgs, "-f")
+	}
+
+	if err := runDockerCompose(home, dockerArgs...); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runDockerStatus(cmd *cobra.Command, args []string) {
+	home := getDockerHome(cmd)
+	composePath := filepath.Join(home, "docker-compose.yml")
+
+	if _, err := os.Stat(composePath); os.IsNotExist(err) {
+		fmt.Println("Status: NOT INITIALIZED")
+		fmt.Printf("No docker-compose.yml found at %s\n", home)
+		return
+	}
+
+	fmt.Printf("Docker Compose: %s\n", composePath)
+	fmt.Println()
+
+	if err := runDockerCompose(home, "ps"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runDockerUpgrade(cmd *cobra.Command, args []string) {
+	home := getDockerHome(cmd)
+	version, _ := cmd.Flags().GetString("version")
+
+	composePath := filepath.Join(home, "docker-compose.yml")
+	if _, err := os.Stat(composePath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: docker-compose.yml not found at %s\n", composePath)
+		os.Exit(1)
+	}
+
+	// Read existing compose file to get current config
+	content, err := os.ReadFile(composePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading docker-compose.yml: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Update image version in compose file
+	oldImage := "monolythium/monod:latest"
+	newImage := fmt.Sprintf("monolythium/monod:%s", version)
+	newContent := strings.ReplaceAll(string(content), oldImage, newImage)
+
+	// Also handle if there's already a versioned image
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, "image: monolythium/monod:") {
+			lines[i] = fmt.Sprintf("    image: %s", newImage)
+		}
+	}
+	newContent = strings.Join(lines, "\n")
+
+	fmt.Printf("Upgrading to version: %s\n", version)
+
+	// Write updated compose file
+	if err := os.WriteFile(composePath, []byte(newContent), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing docker-compose.yml: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Pull new image
+	fmt.Println("Pulling new image...")
+	if err := runDockerCompose(home, "pull"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error pulling image: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Restart with new image
+	fmt.Println("Restarting container...")
+	if err := runDockerCompose(home, "up", "-d"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error restarting: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Upgraded to %s successfully\n", version)
+}
+
+// Helper functions for Docker commands
+
+func getDockerHome(cmd *cobra.Command) string {
+	home, _ := cmd.Flags().GetString("home")
+	if home == "" {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, ".monod")
+	} else if strings.HasPrefix(home, "~") {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
+	}
+	home, _ = filepath.Abs(home)
+	return home
+}
+
+func runDockerCompose(workDir string, args ...string) error {
+	// Try docker compose (v2) first, fall back to docker-compose (v1)
+	fullArgs := append([]string{"compose"}, args...)
+
+	cmd := exec.Command("docker", fullArgs...)
+	cmd.Dir = workDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		// Try docker-compose as fallback
+		cmd = exec.Command("docker-compose", args...)
+		cmd.Dir = workDir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+	return nil
+}
+
+func generateDockerCompose(network core.Network, home, configPatch string) string {
+	// Extract seeds and persistent_peers from config patch if available
+	var seeds, persistentPeers string
+	for _, line := range strings.Split(configPatch, "\n") {
+		if strings.HasPrefix(line, "seeds = ") {
+			seeds = strings.Trim(strings.TrimPrefix(line, "seeds = "), "\"")
+		}
+		if strings.HasPrefix(line, "persistent_peers = ") {
+			persistentPeers = strings.Trim(strings.TrimPrefix(line, "persistent_peers = "), "\"")
+		}
+	}
+
+	// Build environment variables
+	var envVars strings.Builder
+	if seeds != "" {
+		envVars.WriteString(fmt.Sprintf("      - MONOD_P2P_SEEDS=%s\n", seeds))
+	}
+	if persistentPeers != "" {
+		envVars.WriteString(fmt.Sprintf("      - MONOD_P2P_PERSISTENT_PEERS=%s\n", persistentPeers))
+	}
+
+	template := `# Monolythium Node Docker Compose
+# Network: %s
+# Generated by monoctl
+
+services:
+  monod:
+    image: monolythium/monod:latest
+    container_name: monod-%s
+    restart: unless-stopped
+    volumes:
+      - %s:/root/.monod
+    ports:
+      - "26656:26656"  # P2P
+      - "26657:26657"  # RPC
+      - "1317:1317"    # REST API
+      - "9090:9090"    # gRPC
+      - "8545:8545"    # EVM JSON-RPC
+      - "8546:8546"    # EVM WebSocket
+    environment:
+      - MONOD_CHAIN_ID=%s
+%s    command: start --home /root/.monod
+`
+
+	return fmt.Sprintf(template,
+		network.Name,
+		strings.ToLower(string(network.Name)),
+		home,
+		network.ChainID,
+		envVars.String(),
+	)
+}
+
+// PeerValidationResult holds the result of validating a single peer
+type PeerValidationResult struct {
+	Peer          string `json:"peer"`
+	Valid         bool   `json:"valid"`
+	NodeID        string `json:"node_id,omitempty"`
+	Host          string `json:"host,omitempty"`
+	Port          int    `json:"port,omitempty"`
+	Error         string `json:"error,omitempty"`
+	DNSResolved   *bool  `json:"dns_resolved,omitempty"`
+	TCPReachable  *bool  `json:"tcp_reachable,omitempty"`
+	ReachableNote string `json:"reachable_note,omitempty"`
+}
+
+// peerRegex validates nodeID@host:port format
+var peerRegex = regexp.MustCompile(`^([a-f0-9]{40})@([a-zA-Z0-9.\-]+):(\d+)$`)
+
+func runPeersValidate(cmd *cobra.Command, args []string) {
+	input := args[0]
+	checkConn, _ := cmd.Flags().GetBool("check-connectivity")
+
+	var peers []string
+
+	// Check if input is a file
+	if _, err := os.Stat(input); err == nil {
+		data, err := os.ReadFile(input)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			os.Exit(1)
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && !strings.HasPrefix(line, "#") {
+				peers = append(peers, line)
+			}
+		}
+	} else {
+		// Treat as comma-separated string
+		for _, p := range strings.Split(input, ",") {
+			p = strings.TrimSpace(p)
+			if p != "" {
+				peers = append(peers, p)
+			}
+		}
+	}
+
+	if len(peers) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no peers to validate\n")
+		os.Exit(1)
+	}
+
+	var results []PeerValidationResult
+	allValid := true
+
+	for _, peer := range peers {
+		result := validatePeer(peer, checkConn)
+		results = append(results, result)
+		if !result.Valid {
+			allValid = false
+		}
+	}
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		fmt.Printf("Validating %d peer(s)...\n\n", len(peers))
+		for _, r := range results {
+			if r.Valid {
+				fmt.Printf("✓ %s\n", r.Peer)
+				fmt.Printf("  NodeID: %s\n", r.NodeID)
+				fmt.Printf("  Host:   %s\n", r.Host)
+				fmt.Printf("  Port:   %d\n", r.Port)
+				if r.DNSResolved != nil {
+					if *r.DNSResolved {
+						fmt.Printf("  DNS:    resolved\n")
+					} else {
+						fmt.Printf("  DNS:    FAILED to resolve\n")
+					}
+				}
+				if r.TCPReachable != nil {
+					if *r.TCPReachable {
+						fmt.Printf("  TCP:    reachable\n")
+					} else {
+						fmt.Printf("  TCP:    NOT reachable (%s)\n", r.ReachableNote)
+					}
+				}
+			} else {
+				fmt.Printf("✗ %s\n", r.Peer)
+				fmt.Printf("  Error: %s\n", r.Error)
+			}
+			fmt.Println()
+		}
+
+		if allValid {
+			fmt.Println("All peers are valid.")
+		} else {
+			fmt.Println("Some peers have errors.")
+			os.Exit(1)
+		}
+	}
+}
+
+func validatePeer(peer string, checkConn bool) PeerValidationResult {
+	result := PeerValidationResult{Peer: peer}
+
+	matches := peerRegex.FindStringSubmatch(peer)
+	if matches == nil {
+		result.Valid = false
+		result.Error = "invalid format: expected nodeID@host:port (nodeID must be 40 hex chars)"
+		return result
+	}
+
+	result.NodeID = matches[1]
+	result.Host = matches[2]
+	port, err := strconv.Atoi(matches[3])
+	if err != nil || port < 1 || port > 65535 {
+		result.Valid = false
+		result.Error = fmt.Sprintf("invalid port: %s (must be 1-65535)", matches[3])
+		return result
+	}
+	result.Port = port
+	result.Valid = true
+
+	if checkConn {
+		// DNS resolution check
+		_, err := gonet.LookupHost(result.Host)
+		dnsOk := err == nil
+		result.DNSResolved = &dnsOk
+
+		// TCP connectivity check (3 second timeout)
+		addr := fmt.Sprintf("%s:%d", result.Host, result.Port)
+		conn, err := gonet.DialTimeout("tcp", addr, 3*time.Second)
+		tcpOk := err == nil
+		result.TCPReachable = &tcpOk
+		if err != nil {
+			result.ReachableNote = err.Error()
+		}
+		if conn != nil {
+			conn.Close()
+		}
+	}
+
+	return result
+}
+
+// =============================================================================
+// Node Command Handlers
+// =============================================================================
+
+func runNodeConfigure(cmd *cobra.Command, args []string) {
+	roleStr, _ := cmd.Flags().GetString("role")
+	home, _ := cmd.Flags().GetString("home")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	// Parse role
+	role, err := core.ParseNodeRole(roleStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Default home
+	if home == "" {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, ".monod")
+	} else if strings.HasPrefix(home, "~") {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
+	}
+	home, _ = filepath.Abs(home)
+
+	// Check that home directory exists
+	configPath := filepath.Join(home, "config", "config.toml")
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: config.toml not found at %s\n", configPath)
+		fmt.Fprintf(os.Stderr, "Initialize the node first with: monod init <moniker> --home %s\n", home)
+		os.Exit(1)
+	}
+
+	appPath := filepath.Join(home, "config", "app.toml")
+	if _, err := os.Stat(appPath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: app.toml not found at %s\n", appPath)
+		fmt.Fprintf(os.Stderr, "Initialize the node first with: monod init <moniker> --home %s\n", home)
+		os.Exit(1)
+	}
+
+	// For seed_node, check if pruning is already nothing (or we need to set it)
+	if role == core.RoleSeedNode {
+		allowed, reason := core.IsSeedModeAllowed(home)
+		if !allowed && !dryRun {
+			// We'll set pruning=nothing as part of configuration
+			fmt.Println("Note: seed_node role requires pruning=nothing. Will configure accordingly.")
+		}
+		_ = allowed
+		_ = reason
+	}
+
+	expectedConfig := core.GetRoleConfig(role)
+
+	if jsonOutput {
+		out := map[string]interface{}{
+			"home":    home,
+			"role":    role,
+			"dry_run": dryRun,
+			"config": map[string]interface{}{
+				"seed_mode":           expectedConfig.SeedMode,
+				"pruning":             expectedConfig.Pruning,
+				"pruning_keep_recent": expectedConfig.PruningKeepRecent,
+				"pruning_interval":    expectedConfig.PruningInterval,
+			},
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		if dryRun {
+			return
+		}
+	}
+
+	if !jsonOutput {
+		fmt.Printf("Node Role Configuration\n")
+		fmt.Println(strings.Repeat("=", 50))
+		fmt.Printf("Home:     %s\n", home)
+		fmt.Printf("Role:     %s\n", role)
+		fmt.Printf("Dry Run:  %t\n", dryRun)
+		fmt.Println()
+		fmt.Println("Configuration to apply:")
+		fmt.Printf("  seed_mode:            %t\n", expectedConfig.SeedMode)
+		fmt.Printf("  pruning:              %s\n", expectedConfig.Pruning)
+		fmt.Printf("  pruning-keep-recent:  %s\n", expectedConfig.PruningKeepRecent)
+		fmt.Printf("  pruning-interval:     %s\n", expectedConfig.PruningInterval)
+		fmt.Println()
+
+		if dryRun {
+			fmt.Println("(DRY RUN - no changes made)")
+			return
+		}
+	}
+
+	// Apply configuration
+	if err := core.ApplyRoleConfig(home, role, dryRun); err != nil {
+		fmt.Fprintf(os.Stderr, "Error applying configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !jsonOutput {
+		fmt.Println("Configuration applied successfully!")
+		fmt.Println()
+		fmt.Println("IMPORTANT: Restart the node for changes to take effect:")
+		fmt.Println("  sudo systemctl restart monod")
+		if role == core.RoleSeedNode {
+			fmt.Println()
+			fmt.Println("WARNING: As a seed node, ensure this node:")
+			fmt.Println("  1. Was synced from genesis (not state-synced)")
+			fmt.Println("  2. Has earliest_block_height = 1")
+			fmt.Println("  3. Can serve historical blocks to new nodes")
+		}
+	}
+}
+
+func runNodeRole(cmd *cobra.Command, args []string) {
+	home, _ := cmd.Flags().GetString("home")
+
+	// Default home
+	if home == "" {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, ".monod")
+	} else if strings.HasPrefix(home, "~") {
+		homeDir, _ := os.UserHomeDir()
+		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
+	}
+	home, _ = filepath.Abs(home)
+
+	// Check that home directory exists
+	configPath := filepath.Join(home, "config", "config.toml")
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: config.toml not found at %s\n", configPath)
+		fmt.Fprintf(os.Stderr, "Initialize the node first with: monod init <moniker> --home %s\n", home)
+		os.Exit(1)
+	}
+
+	// Detect current role
+	role, err := core.DetectCurrentRole(home)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error detecting role: %v\n", err)
+		os.Exit(1)
+	}
 
-				if validation.Valid {
-					fmt.Println("  Status:        VALID")
-				} else if hasCritical {
-					fmt.Println("  Status:        CRITICAL ISSUES DETECTED")
-				} else if hasWarning {
-					fmt.Println("  Status:        WARNINGS")
-				}
+	// Validate configuration for detected role
+	validation, err := core.ValidateRoleConfig(home, role)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error validating configuration: %v\n", err)
+		os.Exit(1)
+	}
 
-				for _, issue := range validation.Issues {
-					var marker string
-					switch issue.Severity {
-					case "CRITICAL":
-						marker = "CRITICAL"
-					case "WARNING":
-						marker = "WARNING"
-					default:
-						marker = "INFO"
-					}
-					fmt.Printf("\n  [%s] %s\n", marker, issue.Message)
-					if issue.Severity == "CRITICAL" {
-						fmt.Printf("    Expected: %s\n", issue.Expected)
-						fmt.Printf("    Actual:   %s\n", issue.Actual)
-					}
-				}
+	if jsonOutput {
+		type issueJSON struct {
+			Severity string `json:"severity"`
+			Field    string `json:"field"`
+			Expected string `json:"expected"`
+			Actual   string `json:"actual"`
+			Message  string `json:"message"`
+		}
 
-				// Special warning for seed nodes
-				if role == core.RoleSeedNode {
-					fmt.Println()
-					fmt.Println("  SEED NODE REQUIREMENTS:")
-					fmt.Println("    - Must have pruning=nothing")
-					fmt.Println("    - Must be synced from genesis (earliest_block_height=1)")
-					fmt.Println("    - Must be able to serve historical blocks")
-					fmt.Println()
-					fmt.Println("  Run: monoctl node role --home ~/.monod")
-					fmt.Println("  to verify earliest_block_height via RPC")
-				}
+		issues := make([]issueJSON, len(validation.Issues))
+		for i, issue := range validation.Issues {
+			issues[i] = issueJSON{
+				Severity: issue.Severity,
+				Field:    issue.Field,
+				Expected: issue.Expected,
+				Actual:   issue.Actual,
+				Message:  issue.Message,
 			}
 		}
+
+		out := map[string]interface{}{
+			"home":        home,
+			"role":        role,
+			"description": core.RoleDescription(role),
+			"valid":       validation.Valid,
+			"issues":      issues,
+			"suggestions": validation.Suggestions,
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
 	}
 
+	fmt.Printf("Node Role Detection\n")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Home:        %s\n", home)
+	fmt.Printf("Role:        %s\n", role)
+	fmt.Printf("Description: %s\n", core.RoleDescription(role))
 	fmt.Println()
-	fmt.Println("TIP: Use --dry-run on any write command to preview changes.")
-	fmt.Println("     Use 'monoctl node role' to check node role and configuration.")
-}
 
-// promptPassword prompts for a password without echoing
-func promptPassword(prompt string) (string, error) {
-	fmt.Print(prompt)
+	if len(validation.Issues) == 0 {
+		fmt.Println("Status: VALID")
+		fmt.Println("Configuration is consistent with detected role.")
+	} else {
+		fmt.Println("Status: ISSUES DETECTED")
+		fmt.Println()
+		for _, issue := range validation.Issues {
+			var prefix string
+			switch issue.Severity {
+			case "CRITICAL":
+				prefix = "CRITICAL"
+			case "WARNING":
+				prefix = "WARNING"
+			default:
+				prefix = "INFO"
+			}
+			fmt.Printf("[%s] %s\n", prefix, issue.Message)
+			fmt.Printf("  Field:    %s\n", issue.Field)
+			fmt.Printf("  Expected: %s\n", issue.Expected)
+			fmt.Printf("  Actual:   %s\n", issue.Actual)
+			fmt.Println()
+		}
 
-	// Check if stdin is a terminal
-	if term.IsTerminal(int(os.Stdin.Fd())) {
-		// Read password without echo
-		password, err := term.ReadPassword(int(os.Stdin.Fd()))
-		fmt.Println() // Add newline after password input
-		if err != nil {
-			return "", err
+		if len(validation.Suggestions) > 0 {
+			fmt.Println("Suggestions:")
+			for _, s := range validation.Suggestions {
+				fmt.Printf("  - %s\n", s)
+			}
 		}
-		return string(password), nil
 	}
 
-	// Fallback for non-terminal (e.g., piped input)
-	reader := bufio.NewReader(os.Stdin)
-	password, err := reader.ReadString('\n')
-	if err != nil {
-		return "", err
+	// Exit with error code if there are critical issues
+	for _, issue := range validation.Issues {
+		if issue.Severity == "CRITICAL" {
+			os.Exit(1)
+		}
 	}
-	return strings.TrimSpace(password), nil
 }
 
-// Docker command handlers
-
-func runDockerInit(cmd *cobra.Command, args []string) {
-	networkName, _ := cmd.Flags().GetString("network")
+func runNodeReset(cmd *cobra.Command, args []string) {
 	home, _ := cmd.Flags().GetString("home")
+	preserveKeys, _ := cmd.Flags().GetBool("preserve-keys")
+	force, _ := cmd.Flags().GetBool("force")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	// Resolve home directory
+	// Refuse to run as root
+	if os.Geteuid() == 0 {
+		fmt.Fprintf(os.Stderr, "Error: monoctl must not be run as root\n")
+		fmt.Fprintf(os.Stderr, "Run as the user that owns the node home directory.\n")
+		os.Exit(1)
+	}
+
+	// Default home
 	if home == "" {
 		homeDir, _ := os.UserHomeDir()
 		home = filepath.Join(homeDir, ".monod")
@@ -3285,992 +6628,1013 @@ func runDockerInit(cmd *cobra.Command, args []string) {
 	}
 	home, _ = filepath.Abs(home)
 
-	network, err := core.GetNetwork(core.NetworkName(networkName))
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: invalid network %s: %v\n", networkName, err)
+	// Check that home directory exists
+	if _, err := os.Stat(home); os.IsNotExist(err) {
+		fmt.Fprintf(os.Stderr, "Error: node home directory does not exist: %s\n", home)
+		fmt.Fprintf(os.Stderr, "Nothing to reset.\n")
 		os.Exit(1)
 	}
 
-	// Create logger
-	logger := slog.Default()
-
-	// Step 1: Run join to download genesis and configure peers
-	fmt.Println("Docker Init: " + networkName)
-	if dryRun {
-		fmt.Println("(DRY RUN - no changes will be made)")
+	// Verify this looks like a monod home directory
+	dataDir := filepath.Join(home, "data")
+	if !core.IsNodeHome(core.OSFS{}, home) {
+		fmt.Fprintf(os.Stderr, "Error: %s does not look like a monod home directory\n", home)
+		fmt.Fprintf(os.Stderr, "Expected to find config/ or data/ subdirectory.\n")
+		os.Exit(1)
 	}
+
+	fmt.Println("Node Reset")
+	fmt.Println(strings.Repeat("=", 50))
+	fmt.Printf("Home: %s\n", home)
 	fmt.Println()
 
-	joinOpts := core.JoinOptions{
-		Network: network.Name,
-		Home:    home,
-		DryRun:  dryRun,
-		Logger:  logger,
+	// List what will be deleted
+	fmt.Println("The following will be DELETED:")
+	fmt.Printf("  - %s/* (blockchain data)\n", dataDir)
+	fmt.Printf("  - %s/config.toml\n", home)
+	fmt.Printf("  - %s/app.toml\n", home)
+	fmt.Printf("  - %s/client.toml\n", home)
+	fmt.Printf("  - %s/genesis.json\n", home)
+	fmt.Printf("  - %s/addrbook.json\n", home)
+
+	if preserveKeys {
+		fmt.Println()
+		fmt.Println("The following will be PRESERVED:")
+		fmt.Printf("  - %s/config/node_key.json\n", home)
+		fmt.Printf("  - %s/config/priv_validator_key.json\n", home)
+	} else {
+		fmt.Printf("  - %s/config/node_key.json\n", home)
+		fmt.Printf("  - %s/config/priv_validator_key.json\n", home)
 	}
+	fmt.Println()
 
-	fetcher := net.NewHTTPFetcher()
-	result, err := core.Join(joinOpts, fetcher)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error during join: %v\n", err)
-		os.Exit(1)
+	if dryRun {
+		fmt.Println("(DRY RUN - no changes will be made)")
+		return
 	}
 
-	// Print join steps
-	for _, step := range result.Steps {
-		status := "[+]"
-		if step.Status == "failed" {
-			status = "[X]"
-		} else if step.Status == "skipped" {
-			status = "[-]"
-		}
-		msg := step.Name
-		if step.Message != "" {
-			msg += ": " + step.Message
+	// Confirmation
+	if !force {
+		fmt.Print("This action is DESTRUCTIVE and cannot be undone.\n")
+		fmt.Print("Type 'yes' to confirm: ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(response)
+		if response != "yes" {
+			fmt.Println("Aborted.")
+			os.Exit(0)
 		}
-		fmt.Printf("%s %s\n", status, msg)
 	}
 
-	// Step 2: Generate docker-compose.yml
 	fmt.Println()
-	fmt.Println("[+] Generating docker-compose.yml")
+	fmt.Println("Resetting node...")
 
-	composeContent := generateDockerCompose(network, home, result.ConfigPatch)
-	composePath := filepath.Join(home, "docker-compose.yml")
+	// Step 1: Stop monod service
+	fmt.Print("[1/3] Stopping monod service... ")
+	stopCmd := exec.Command("systemctl", "--user", "stop", "monod")
+	if err := stopCmd.Run(); err != nil {
+		// Try system-level service
+		stopCmd = exec.Command("sudo", "systemctl", "stop", "monod")
+		stopCmd.Run() // Ignore errors - service might not exist
+	}
+	// Also kill any running monod processes
+	exec.Command("pkill", "-f", "monod start").Run()
+	time.Sleep(time.Second)
+	fmt.Println("done")
 
-	if dryRun {
-		fmt.Printf("Would write to: %s\n", composePath)
-		fmt.Println("\nContent preview:")
-		fmt.Println(composeContent)
-	} else {
-		// Ensure directory exists
-		if err := os.MkdirAll(home, 0755); err != nil {
-			fmt.Fprintf(os.Stderr, "Error creating directory: %v\n", err)
-			os.Exit(1)
-		}
-		if err := os.WriteFile(composePath, []byte(composeContent), 0644); err != nil {
-			fmt.Fprintf(os.Stderr, "Error writing docker-compose.yml: %v\n", err)
-			os.Exit(1)
-		}
-		fmt.Printf("Written to: %s\n", composePath)
+	// Step 2: Delete data and config, keeping keys if asked
+	fmt.Print("[2/3] Resetting node home... ")
+	result, err := core.ResetNodeHome(core.OSFS{}, home, preserveKeys)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\nError: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println("done")
+
+	// Step 3: Report what happened to the keys
+	fmt.Print("[3/3] Keys: ")
+	if len(result.KeysPreserved) > 0 {
+		fmt.Printf("preserved %s\n", strings.Join(result.KeysPreserved, ", "))
+	} else if preserveKeys {
+		fmt.Println("none found to preserve")
+	} else {
+		fmt.Println("removed")
 	}
 
 	fmt.Println()
-	fmt.Println("Docker environment initialized!")
+	fmt.Println("Reset complete!")
 	fmt.Println()
 	fmt.Println("Next steps:")
-	fmt.Printf("  cd %s\n", home)
-	fmt.Println("  monoctl docker up")
+	fmt.Printf("  monoctl join --network <network> --home %s\n", home)
 }
 
-func runDockerUp(cmd *cobra.Command, args []string) {
-	home := getDockerHome(cmd)
-	composePath := filepath.Join(home, "docker-compose.yml")
+// =============================================================================
+// Node Profile Commands
+// =============================================================================
 
-	if _, err := os.Stat(composePath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: docker-compose.yml not found at %s\n", composePath)
-		fmt.Fprintf(os.Stderr, "Run 'monoctl docker init --network <network>' first\n")
-		os.Exit(1)
-	}
+// profileFlags returns the node home and profiles directory of a node
+// profile command.
+func profileFlags(cmd *cobra.Command) (string, string) {
+	home, _ := cmd.Flags().GetString("home")
+	dir, _ := cmd.Flags().GetString("profiles-dir")
 
-	fmt.Println("Starting container...")
-	if err := runDockerCompose(home, "up", "-d"); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	homeDir, _ := os.UserHomeDir()
+	if home == "" {
+		home = filepath.Join(homeDir, ".monod")
+	} else if strings.HasPrefix(home, "~") {
+		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
 	}
-	fmt.Println("Container started successfully")
-}
-
-func runDockerDown(cmd *cobra.Command, args []string) {
-	home := getDockerHome(cmd)
-
-	fmt.Println("Stopping container...")
-	if err := runDockerCompose(home, "down"); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	if dir == "" {
+		var err error
+		if dir, err = core.DefaultProfilesDir(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
-	fmt.Println("Container stopped")
+	return home, dir
 }
 
-func runDockerRestart(cmd *cobra.Command, args []string) {
-	home := getDockerHome(cmd)
+func runNodeProfileList(cmd *cobra.Command, args []string) {
+	home, dir := profileFlags(cmd)
 
-	fmt.Println("Restarting container...")
-	if err := runDockerCompose(home, "restart"); err != nil {
+	profiles, err := core.ListProfiles(dir)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Println("Container restarted")
-}
-
-func runDockerLogs(cmd *cobra.Command, args []string) {
-	home := getDockerHome(cmd)
-	follow, _ := cmd.Flags().GetBool("follow")
-	lines, _ := cmd.Flags().GetInt("lines")
-
-	dockerArgs := []string{"logs", fmt.Sprintf("--tail=%d", lines)}
-	if follow {
-		dockerArgs = append(dockerArgs, "-f")
-	}
-
-	if err := runDockerCompose(home, dockerArgs...); err != nil {
+	active, err := core.ActiveProfile(home)
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-}
-
-func runDockerStatus(cmd *cobra.Command, args []string) {
-	home := getDockerHome(cmd)
-	composePath := filepath.Join(home, "docker-compose.yml")
 
-	if _, err := os.Stat(composePath); os.IsNotExist(err) {
-		fmt.Println("Status: NOT INITIALIZED")
-		fmt.Printf("No docker-compose.yml found at %s\n", home)
+	if jsonOutput {
+		out := map[string]interface{}{
+			"profiles_dir": dir,
+			"profiles":     profiles,
+			"active":       active,
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
 		return
 	}
 
-	fmt.Printf("Docker Compose: %s\n", composePath)
+	if len(profiles) == 0 {
+		fmt.Printf("No profiles in %s\n", dir)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSETTINGS\tDESCRIPTION")
+		for _, p := range profiles {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", p.Name, len(p.Settings), p.Description)
+		}
+		w.Flush()
+	}
 	fmt.Println()
-
-	if err := runDockerCompose(home, "ps"); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	if active == nil {
+		fmt.Printf("Active profile for %s: none\n", home)
+	} else {
+		fmt.Printf("Active profile for %s: %s (%d settings, applied %s)\n",
+			home, active.Name, len(active.Settings), active.AppliedAt.Format(time.RFC3339))
 	}
 }
 
-func runDockerUpgrade(cmd *cobra.Command, args []string) {
-	home := getDockerHome(cmd)
-	version, _ := cmd.Flags().GetString("version")
+func runNodeProfileApply(cmd *cobra.Command, args []string) {
+	home, dir := profileFlags(cmd)
+	network, _ := cmd.Flags().GetString("network")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	composePath := filepath.Join(home, "docker-compose.yml")
-	if _, err := os.Stat(composePath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: docker-compose.yml not found at %s\n", composePath)
+	profile, err := core.LoadProfile(dir, args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if network != "" {
+		name, err := core.ParseNetworkName(network)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		network = string(name)
+	}
 
-	// Read existing compose file to get current config
-	content, err := os.ReadFile(composePath)
+	changes, err := core.ApplyProfile(home, profile, core.ProfileContext{Network: network}, dryRun)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading docker-compose.yml: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Update image version in compose file
-	oldImage := "monolythium/monod:latest"
-	newImage := fmt.Sprintf("monolythium/monod:%s", version)
-	newContent := strings.ReplaceAll(string(content), oldImage, newImage)
-
-	// Also handle if there's already a versioned image
-	lines := strings.Split(string(content), "\n")
-	for i, line := range lines {
-		if strings.Contains(line, "image: monolythium/monod:") {
-			lines[i] = fmt.Sprintf("    image: %s", newImage)
+	if jsonOutput {
+		out := map[string]interface{}{
+			"home":    home,
+			"profile": profile.Name,
+			"dry_run": dryRun,
+			"changes": changes,
 		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
+		return
 	}
-	newContent = strings.Join(lines, "\n")
-
-	fmt.Printf("Upgrading to version: %s\n", version)
 
-	// Write updated compose file
-	if err := os.WriteFile(composePath, []byte(newContent), 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing docker-compose.yml: %v\n", err)
-		os.Exit(1)
+	fmt.Printf("Profile: %s\n", profile.Name)
+	fmt.Printf("Home:    %s\n", home)
+	fmt.Println()
+	if len(changes) == 0 {
+		fmt.Println("No changes: the node already matches the profile.")
+	}
+	file, section := "", "-"
+	for _, c := range changes {
+		if c.File != file {
+			fmt.Printf("--- %s\n+++ %s\n", c.File, c.File)
+			file, section = c.File, "-"
+		}
+		if c.Section != section {
+			if c.Section != "" {
+				fmt.Printf("  [%s]\n", c.Section)
+			}
+			section = c.Section
+		}
+		if !c.Missing {
+			fmt.Printf("- %s = %s\n", c.Key, c.Old)
+		}
+		fmt.Printf("+ %s = %s\n", c.Key, c.New)
 	}
+	fmt.Println()
 
-	// Pull new image
-	fmt.Println("Pulling new image...")
-	if err := runDockerCompose(home, "pull"); err != nil {
-		fmt.Fprintf(os.Stderr, "Error pulling image: %v\n", err)
-		os.Exit(1)
+	if dryRun {
+		fmt.Println("(DRY RUN - no changes made)")
+		return
+	}
+	fmt.Printf("Profile %s is now active; config doctor reports drift from it.\n", profile.Name)
+	if len(changes) > 0 {
+		fmt.Println()
+		fmt.Println("IMPORTANT: Restart the node for changes to take effect:")
+		fmt.Println("  sudo systemctl restart monod")
 	}
+}
 
-	// Restart with new image
-	fmt.Println("Restarting container...")
-	if err := runDockerCompose(home, "up", "-d"); err != nil {
-		fmt.Fprintf(os.Stderr, "Error restarting: %v\n", err)
+func runNodeProfileClear(cmd *cobra.Command, args []string) {
+	home, _ := profileFlags(cmd)
+	if err := core.ClearActiveProfile(home); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-
-	fmt.Printf("Upgraded to %s successfully\n", version)
+	fmt.Printf("No active profile for %s; config values were left as they are.\n", home)
 }
 
-// Helper functions for Docker commands
+// =============================================================================
+// Config Doctor Command - Drift Detection
+// =============================================================================
 
-func getDockerHome(cmd *cobra.Command) string {
+func runConfigDoctor(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
 	home, _ := cmd.Flags().GetString("home")
+
+	// Default home directory
 	if home == "" {
-		homeDir, _ := os.UserHomeDir()
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
+			os.Exit(1)
+		}
 		home = filepath.Join(homeDir, ".monod")
-	} else if strings.HasPrefix(home, "~") {
-		homeDir, _ := os.UserHomeDir()
-		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
 	}
-	home, _ = filepath.Abs(home)
-	return home
-}
-
-func runDockerCompose(workDir string, args ...string) error {
-	// Try docker compose (v2) first, fall back to docker-compose (v1)
-	fullArgs := append([]string{"compose"}, args...)
 
-	cmd := exec.Command("docker", fullArgs...)
-	cmd.Dir = workDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	// Parse network name
+	networkName, err := core.ParseNetworkName(networkStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-	err := cmd.Run()
+	// Fetch canonical config
+	network, err := core.GetNetworkFromCanonical(networkName, "main")
 	if err != nil {
-		// Try docker-compose as fallback
-		cmd = exec.Command("docker-compose", args...)
-		cmd.Dir = workDir
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
+		fmt.Fprintf(os.Stderr, "Error fetching canonical config: %v\n", err)
+		os.Exit(1)
 	}
-	return nil
-}
 
-func generateDockerCompose(network core.Network, home, configPatch string) string {
-	// Extract seeds and persistent_peers from config patch if available
-	var seeds, persistentPeers string
-	for _, line := range strings.Split(configPatch, "\n") {
-		if strings.HasPrefix(line, "seeds = ") {
-			seeds = strings.Trim(strings.TrimPrefix(line, "seeds = "), "\"")
-		}
-		if strings.HasPrefix(line, "persistent_peers = ") {
-			persistentPeers = strings.Trim(strings.TrimPrefix(line, "persistent_peers = "), "\"")
-		}
+	// Create drift config from canonical network
+	driftConfig := &core.DriftConfig{
+		CosmosChainID:  network.ChainID,
+		EVMChainID:     network.EVMChainID,
+		Seeds:          []string{}, // Seeds come from canonical config
+		BootstrapPeers: []string{}, // Peers come from canonical config
+	}
+	if err := applyDriftCheckFlags(cmd, networkName, home, driftConfig, false); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	// Build environment variables
-	var envVars strings.Builder
-	if seeds != "" {
-		envVars.WriteString(fmt.Sprintf("      - MONOD_P2P_SEEDS=%s\n", seeds))
+	// Detect drift
+	results, err := core.DetectDrift(home, driftConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error detecting drift: %v\n", err)
+		os.Exit(1)
 	}
-	if persistentPeers != "" {
-		envVars.WriteString(fmt.Sprintf("      - MONOD_P2P_PERSISTENT_PEERS=%s\n", persistentPeers))
+
+	if jsonOutput {
+		// JSON output
+		type driftOutput struct {
+			Network     string             `json:"network"`
+			Home        string             `json:"home"`
+			DriftCount  int                `json:"drift_count"`
+			HasCritical bool               `json:"has_critical"`
+			Results     []core.DriftResult `json:"results"`
+		}
+		out := driftOutput{
+			Network:     string(networkName),
+			Home:        home,
+			DriftCount:  len(results),
+			HasCritical: core.HasCriticalDrift(results),
+			Results:     results,
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(out)
+		if core.HasCriticalDrift(results) {
+			os.Exit(1)
+		}
+		return
 	}
 
-	template := `# Monolythium Node Docker Compose
-# Network: %s
-# Generated by monoctl
+	// Human-readable output
+	fmt.Printf("Network: %s\n", networkName)
+	fmt.Printf("Home: %s\n", home)
+	fmt.Println()
 
-services:
-  monod:
-    image: monolythium/monod:latest
-    container_name: monod-%s
-    restart: unless-stopped
-    volumes:
-      - %s:/root/.monod
-    ports:
-      - "26656:26656"  # P2P
-      - "26657:26657"  # RPC
-      - "1317:1317"    # REST API
-      - "9090:9090"    # gRPC
-      - "8545:8545"    # EVM JSON-RPC
-      - "8546:8546"    # EVM WebSocket
-    environment:
-      - MONOD_CHAIN_ID=%s
-%s    command: start --home /root/.monod
-`
+	if len(results) == 0 {
+		fmt.Println("No drift detected. Configuration matches canonical values.")
+		return
+	}
 
-	return fmt.Sprintf(template,
-		network.Name,
-		strings.ToLower(string(network.Name)),
-		home,
-		network.ChainID,
-		envVars.String(),
-	)
-}
+	fmt.Println(core.FormatDriftReport(results))
 
-// PeerValidationResult holds the result of validating a single peer
-type PeerValidationResult struct {
-	Peer          string `json:"peer"`
-	Valid         bool   `json:"valid"`
-	NodeID        string `json:"node_id,omitempty"`
-	Host          string `json:"host,omitempty"`
-	Port          int    `json:"port,omitempty"`
-	Error         string `json:"error,omitempty"`
-	DNSResolved   *bool  `json:"dns_resolved,omitempty"`
-	TCPReachable  *bool  `json:"tcp_reachable,omitempty"`
-	ReachableNote string `json:"reachable_note,omitempty"`
+	if core.HasCriticalDrift(results) {
+		fmt.Println()
+		fmt.Printf("Run: monoctl config repair --network %s --home %s\n", networkStr, home)
+		os.Exit(1)
+	}
 }
 
-// peerRegex validates nodeID@host:port format
-var peerRegex = regexp.MustCompile(`^([a-f0-9]{40})@([a-zA-Z0-9.\-]+):(\d+)$`)
-
-func runPeersValidate(cmd *cobra.Command, args []string) {
-	input := args[0]
-	checkConn, _ := cmd.Flags().GetBool("check-connectivity")
+// addDriftCheckFlags registers the optional drift checks of config doctor
+// and repair.
+func addDriftCheckFlags(cmd *cobra.Command) {
+	cmd.Flags().String("role", "", "Check role settings (full_node, archive_node, seed_node)")
+	cmd.Flags().String("validator-name", "default", "Port scheme entry of this node (non-seed roles)")
+	cmd.Flags().String("public-ip", "auto", "Public IP external_address must use (auto detects it, off skips the check)")
+	cmd.Flags().String("min-gas-prices", "", "Expected minimum-gas-prices (e.g. 0.025"+core.BaseDenom+")")
+	cmd.Flags().StringSlice("apis", nil, "App servers that should be enabled (api, grpc, json-rpc, or none)")
+}
 
-	var peers []string
+// applyDriftCheckFlags fills the optional expectations of config from the
+// drift check flags. The port scheme comes from the network's peers
+// registry and depends on the role: --role, or the role detected in home.
+// If neither the registry nor the role is available the port check is
+// skipped. With explicitOnly (repair) the port and external address checks
+// only run when their flags are given, so repair never rewrites them on its
+// own.
+func applyDriftCheckFlags(cmd *cobra.Command, networkName core.NetworkName, home string, config *core.DriftConfig, explicitOnly bool) error {
+	roleStr, _ := cmd.Flags().GetString("role")
+	validatorName, _ := cmd.Flags().GetString("validator-name")
+	publicIP, _ := cmd.Flags().GetString("public-ip")
+	config.MinGasPrices, _ = cmd.Flags().GetString("min-gas-prices")
 
-	// Check if input is a file
-	if _, err := os.Stat(input); err == nil {
-		data, err := os.ReadFile(input)
+	if roleStr != "" {
+		role, err := core.ParseNodeRole(roleStr)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
-			os.Exit(1)
-		}
-		for _, line := range strings.Split(string(data), "\n") {
-			line = strings.TrimSpace(line)
-			if line != "" && !strings.HasPrefix(line, "#") {
-				peers = append(peers, line)
-			}
+			return err
 		}
-	} else {
-		// Treat as comma-separated string
-		for _, p := range strings.Split(input, ",") {
-			p = strings.TrimSpace(p)
-			if p != "" {
-				peers = append(peers, p)
+		config.Role = role
+	}
+
+	if cmd.Flags().Changed("apis") {
+		apis, _ := cmd.Flags().GetStringSlice("apis")
+		config.APIs = []string{}
+		for _, api := range apis {
+			switch {
+			case api == "none":
+			case slices.Contains(core.AppServers, api):
+				config.APIs = append(config.APIs, api)
+			default:
+				return fmt.Errorf("unknown app server %q (valid: %s, none)", api, strings.Join(core.AppServers, ", "))
 			}
 		}
 	}
 
-	if len(peers) == 0 {
-		fmt.Fprintf(os.Stderr, "Error: no peers to validate\n")
-		os.Exit(1)
+	if explicitOnly && !cmd.Flags().Changed("public-ip") {
+		publicIP = "off"
 	}
-
-	var results []PeerValidationResult
-	allValid := true
-
-	for _, peer := range peers {
-		result := validatePeer(peer, checkConn)
-		results = append(results, result)
-		if !result.Valid {
-			allValid = false
+	switch publicIP {
+	case "off":
+	case "auto":
+		if config.PublicIP = net.DetectPublicIP(); config.PublicIP == "" {
+			fmt.Fprintln(os.Stderr, "Warning: could not detect public IP, skipping external_address check")
+		}
+	default:
+		if gonet.ParseIP(publicIP) == nil {
+			return fmt.Errorf("invalid --public-ip %q", publicIP)
 		}
+		config.PublicIP = publicIP
 	}
 
-	if jsonOutput {
-		data, _ := json.MarshalIndent(results, "", "  ")
-		fmt.Println(string(data))
-	} else {
-		fmt.Printf("Validating %d peer(s)...\n\n", len(peers))
-		for _, r := range results {
-			if r.Valid {
-				fmt.Printf("✓ %s\n", r.Peer)
-				fmt.Printf("  NodeID: %s\n", r.NodeID)
-				fmt.Printf("  Host:   %s\n", r.Host)
-				fmt.Printf("  Port:   %d\n", r.Port)
-				if r.DNSResolved != nil {
-					if *r.DNSResolved {
-						fmt.Printf("  DNS:    resolved\n")
-					} else {
-						fmt.Printf("  DNS:    FAILED to resolve\n")
-					}
-				}
-				if r.TCPReachable != nil {
-					if *r.TCPReachable {
-						fmt.Printf("  TCP:    reachable\n")
-					} else {
-						fmt.Printf("  TCP:    NOT reachable (%s)\n", r.ReachableNote)
-					}
-				}
-			} else {
-				fmt.Printf("✗ %s\n", r.Peer)
-				fmt.Printf("  Error: %s\n", r.Error)
-			}
-			fmt.Println()
+	if explicitOnly && !cmd.Flags().Changed("role") && !cmd.Flags().Changed("validator-name") {
+		return nil
+	}
+	// Seeds and validators use different ports, so guessing would report
+	// drift on correctly configured nodes
+	portRole := config.Role
+	if portRole == "" {
+		role, err := core.DetectCurrentRole(home)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping port check: node role unknown (pass --role): %v\n", err)
+			return nil
 		}
-
-		if allValid {
-			fmt.Println("All peers are valid.")
+		portRole = role
+	}
+	if network, err := core.GetNetwork(networkName); err == nil && network.PeersURL != "" {
+		data, err := net.NewHTTPFetcher().Fetch(network.PeersURL)
+		var reg *core.PeersRegistry
+		if err == nil {
+			reg, err = core.ParsePeersRegistry(data)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: skipping port check: %v\n", err)
 		} else {
-			fmt.Println("Some peers have errors.")
-			os.Exit(1)
+			config.Ports = reg.GetPorts(portRole, validatorName)
 		}
 	}
+	return nil
 }
 
-func validatePeer(peer string, checkConn bool) PeerValidationResult {
-	result := PeerValidationResult{Peer: peer}
+// =============================================================================
+// Config Repair Command - Drift Repair
+// =============================================================================
 
-	matches := peerRegex.FindStringSubmatch(peer)
-	if matches == nil {
-		result.Valid = false
-		result.Error = "invalid format: expected nodeID@host:port (nodeID must be 40 hex chars)"
-		return result
+func runConfigRepair(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	home, _ := cmd.Flags().GetString("home")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	// Default home directory
+	if home == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
+			os.Exit(1)
+		}
+		home = filepath.Join(homeDir, ".monod")
 	}
 
-	result.NodeID = matches[1]
-	result.Host = matches[2]
-	port, err := strconv.Atoi(matches[3])
-	if err != nil || port < 1 || port > 65535 {
-		result.Valid = false
-		result.Error = fmt.Sprintf("invalid port: %s (must be 1-65535)", matches[3])
-		return result
+	// Parse network name
+	networkName, err := core.ParseNetworkName(networkStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	result.Port = port
-	result.Valid = true
 
-	if checkConn {
-		// DNS resolution check
-		_, err := gonet.LookupHost(result.Host)
-		dnsOk := err == nil
-		result.DNSResolved = &dnsOk
+	// Fetch canonical config
+	network, err := core.GetNetworkFromCanonical(networkName, "main")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching canonical config: %v\n", err)
+		os.Exit(1)
+	}
 
-		// TCP connectivity check (3 second timeout)
-		addr := fmt.Sprintf("%s:%d", result.Host, result.Port)
-		conn, err := gonet.DialTimeout("tcp", addr, 3*time.Second)
-		tcpOk := err == nil
-		result.TCPReachable = &tcpOk
-		if err != nil {
-			result.ReachableNote = err.Error()
+	// Create drift config from canonical network
+	driftConfig := &core.DriftConfig{
+		CosmosChainID:  network.ChainID,
+		EVMChainID:     network.EVMChainID,
+		Seeds:          []string{}, // Seeds come from canonical config
+		BootstrapPeers: []string{}, // Peers come from canonical config
+	}
+	if err := applyDriftCheckFlags(cmd, networkName, home, driftConfig, true); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	driftConfig.ApplySecurityFixes, _ = cmd.Flags().GetBool("apply-security-fixes")
+
+	// Perform repair
+	results, err := core.Repair(home, driftConfig, dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error repairing configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		// JSON output
+		type repairOutput struct {
+			Network string              `json:"network"`
+			Home    string              `json:"home"`
+			DryRun  bool                `json:"dry_run"`
+			Results []core.RepairResult `json:"results"`
 		}
-		if conn != nil {
-			conn.Close()
+		out := repairOutput{
+			Network: string(networkName),
+			Home:    home,
+			DryRun:  dryRun,
+			Results: results,
 		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(out)
+		return
 	}
 
-	return result
+	// Human-readable output
+	fmt.Println(core.FormatRepairReport(results, dryRun))
+
+	if !dryRun && len(results) > 0 {
+		fmt.Println()
+		fmt.Printf("Run: monoctl config doctor --network %s --home %s\n", networkStr, home)
+		fmt.Println("to verify the repair was successful.")
+	}
 }
 
 // =============================================================================
-// Node Command Handlers
+// Monitor Commands - Opt-in Node Monitoring
 // =============================================================================
 
-func runNodeConfigure(cmd *cobra.Command, args []string) {
-	roleStr, _ := cmd.Flags().GetString("role")
+func runMonitorRegister(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	moniker, _ := cmd.Flags().GetString("moniker")
+	role, _ := cmd.Flags().GetString("role")
+	apiEndpoint, _ := cmd.Flags().GetString("api")
 	home, _ := cmd.Flags().GetString("home")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
-
-	// Parse role
-	role, err := core.ParseNodeRole(roleStr)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
 
-	// Default home
-	if home == "" {
-		homeDir, _ := os.UserHomeDir()
-		home = filepath.Join(homeDir, ".monod")
-	} else if strings.HasPrefix(home, "~") {
-		homeDir, _ := os.UserHomeDir()
-		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
+	if apiEndpoint == "" {
+		apiEndpoint = core.MonitorAPIEndpointForNetwork(networkStr)
 	}
-	home, _ = filepath.Abs(home)
+	fmt.Printf("Using API endpoint: %s\n", apiEndpoint)
 
-	// Check that home directory exists
-	configPath := filepath.Join(home, "config", "config.toml")
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: config.toml not found at %s\n", configPath)
-		fmt.Fprintf(os.Stderr, "Initialize the node first with: monod init <moniker> --home %s\n", home)
+	// Get keys directory
+	keysDir, err := core.GetMonitorKeysDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	appPath := filepath.Join(home, "config", "app.toml")
-	if _, err := os.Stat(appPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: app.toml not found at %s\n", appPath)
-		fmt.Fprintf(os.Stderr, "Initialize the node first with: monod init <moniker> --home %s\n", home)
+	// Load or create keys
+	fmt.Println("Loading or creating monitor keypair...")
+	keys, err := core.LoadOrCreateKeys(keysDir, home)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading/creating keys: %v\n", err)
 		os.Exit(1)
 	}
 
-	// For seed_node, check if pruning is already nothing (or we need to set it)
-	if role == core.RoleSeedNode {
-		allowed, reason := core.IsSeedModeAllowed(home)
-		if !allowed && !dryRun {
-			// We'll set pruning=nothing as part of configuration
-			fmt.Println("Note: seed_node role requires pruning=nothing. Will configure accordingly.")
-		}
-		_ = allowed
-		_ = reason
-	}
-
-	expectedConfig := core.GetRoleConfig(role)
-
-	if jsonOutput {
-		out := map[string]interface{}{
-			"home":    home,
-			"role":    role,
-			"dry_run": dryRun,
-			"config": map[string]interface{}{
-				"seed_mode":           expectedConfig.SeedMode,
-				"pruning":             expectedConfig.Pruning,
-				"pruning_keep_recent": expectedConfig.PruningKeepRecent,
-				"pruning_interval":    expectedConfig.PruningInterval,
-			},
-		}
-		data, _ := json.MarshalIndent(out, "", "  ")
-		fmt.Println(string(data))
-		if dryRun {
-			return
-		}
-	}
-
-	if !jsonOutput {
-		fmt.Printf("Node Role Configuration\n")
-		fmt.Println(strings.Repeat("=", 50))
-		fmt.Printf("Home:     %s\n", home)
-		fmt.Printf("Role:     %s\n", role)
-		fmt.Printf("Dry Run:  %t\n", dryRun)
-		fmt.Println()
-		fmt.Println("Configuration to apply:")
-		fmt.Printf("  seed_mode:            %t\n", expectedConfig.SeedMode)
-		fmt.Printf("  pruning:              %s\n", expectedConfig.Pruning)
-		fmt.Printf("  pruning-keep-recent:  %s\n", expectedConfig.PruningKeepRecent)
-		fmt.Printf("  pruning-interval:     %s\n", expectedConfig.PruningInterval)
-		fmt.Println()
+	fmt.Printf("Node ID: %s\n", keys.NodeID)
 
-		if dryRun {
-			fmt.Println("(DRY RUN - no changes made)")
-			return
-		}
-	}
+	// Start registration
+	fmt.Println("Registering with node-monitor API...")
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	// Apply configuration
-	if err := core.ApplyRoleConfig(home, role, dryRun); err != nil {
-		fmt.Fprintf(os.Stderr, "Error applying configuration: %v\n", err)
+	resp, err := core.StartRegistration(ctx, apiEndpoint, keys, networkStr, moniker, role)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if !jsonOutput {
-		fmt.Println("Configuration applied successfully!")
-		fmt.Println()
-		fmt.Println("IMPORTANT: Restart the node for changes to take effect:")
-		fmt.Println("  sudo systemctl restart monod")
-		if role == core.RoleSeedNode {
-			fmt.Println()
-			fmt.Println("WARNING: As a seed node, ensure this node:")
-			fmt.Println("  1. Was synced from genesis (not state-synced)")
-			fmt.Println("  2. Has earliest_block_height = 1")
-			fmt.Println("  3. Can serve historical blocks to new nodes")
-		}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(map[string]interface{}{
+			"node_id":    keys.NodeID,
+			"network":    networkStr,
+			"moniker":    moniker,
+			"role":       role,
+			"link_token": resp.LinkToken,
+			"expires_at": resp.ExpiresAt,
+		})
+		return
 	}
+
+	fmt.Println()
+	fmt.Println("Registration started successfully!")
+	fmt.Println()
+	fmt.Printf("Link Token: %s\n", resp.LinkToken)
+	fmt.Printf("Expires At: %s\n", resp.ExpiresAt.Format(time.RFC3339))
+	fmt.Println()
+	fmt.Println("To complete registration, send this token to the Monolythium Telegram bot.")
+	fmt.Println("The token expires in 15 minutes.")
 }
 
-func runNodeRole(cmd *cobra.Command, args []string) {
+func runMonitorHeartbeat(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
 	home, _ := cmd.Flags().GetString("home")
+	apiEndpoint, _ := cmd.Flags().GetString("api")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	// Default home
+	// Default home directory
 	if home == "" {
-		homeDir, _ := os.UserHomeDir()
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
+			os.Exit(1)
+		}
 		home = filepath.Join(homeDir, ".monod")
-	} else if strings.HasPrefix(home, "~") {
-		homeDir, _ := os.UserHomeDir()
-		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
 	}
-	home, _ = filepath.Abs(home)
 
-	// Check that home directory exists
-	configPath := filepath.Join(home, "config", "config.toml")
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: config.toml not found at %s\n", configPath)
-		fmt.Fprintf(os.Stderr, "Initialize the node first with: monod init <moniker> --home %s\n", home)
+	if apiEndpoint == "" {
+		apiEndpoint = core.MonitorAPIEndpointForNetwork(networkStr)
+	}
+
+	// Get keys directory
+	keysDir, err := core.GetMonitorKeysDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Detect current role
-	role, err := core.DetectCurrentRole(home)
+	// Load keys
+	keys, err := core.LoadKeys(keysDir, home)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error detecting role: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error loading keys: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Have you registered? Run: monoctl monitor register --network %s --moniker <name>\n", networkStr)
 		os.Exit(1)
 	}
 
-	// Validate configuration for detected role
-	validation, err := core.ValidateRoleConfig(home, role)
+	// Get local status
+	status, err := core.GetLocalStatus(home, tui.Version)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error validating configuration: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error getting node status: %v\n", err)
 		os.Exit(1)
 	}
 
-	if jsonOutput {
-		type issueJSON struct {
-			Severity string `json:"severity"`
-			Field    string `json:"field"`
-			Expected string `json:"expected"`
-			Actual   string `json:"actual"`
-			Message  string `json:"message"`
-		}
+	// Get capabilities
+	caps, err := core.GetLocalCapabilities(home)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not get full capabilities: %v\n", err)
+		caps = &core.MonitorCapabilities{}
+	}
 
-		issues := make([]issueJSON, len(validation.Issues))
-		for i, issue := range validation.Issues {
-			issues[i] = issueJSON{
-				Severity: issue.Severity,
-				Field:    issue.Field,
-				Expected: issue.Expected,
-				Actual:   issue.Actual,
-				Message:  issue.Message,
-			}
-		}
+	// Sign heartbeat
+	payload, err := core.SignHeartbeat(keys, networkStr, status, caps)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error signing heartbeat: %v\n", err)
+		os.Exit(1)
+	}
 
-		out := map[string]interface{}{
-			"home":        home,
-			"role":        role,
-			"description": core.RoleDescription(role),
-			"valid":       validation.Valid,
-			"issues":      issues,
-			"suggestions": validation.Suggestions,
-		}
-		data, _ := json.MarshalIndent(out, "", "  ")
-		fmt.Println(string(data))
+	if dryRun {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(payload)
 		return
 	}
 
-	fmt.Printf("Node Role Detection\n")
-	fmt.Println(strings.Repeat("=", 50))
-	fmt.Printf("Home:        %s\n", home)
-	fmt.Printf("Role:        %s\n", role)
-	fmt.Printf("Description: %s\n", core.RoleDescription(role))
-	fmt.Println()
+	// Send heartbeat
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	if len(validation.Issues) == 0 {
-		fmt.Println("Status: VALID")
-		fmt.Println("Configuration is consistent with detected role.")
-	} else {
-		fmt.Println("Status: ISSUES DETECTED")
-		fmt.Println()
-		for _, issue := range validation.Issues {
-			var prefix string
-			switch issue.Severity {
-			case "CRITICAL":
-				prefix = "CRITICAL"
-			case "WARNING":
-				prefix = "WARNING"
-			default:
-				prefix = "INFO"
-			}
-			fmt.Printf("[%s] %s\n", prefix, issue.Message)
-			fmt.Printf("  Field:    %s\n", issue.Field)
-			fmt.Printf("  Expected: %s\n", issue.Expected)
-			fmt.Printf("  Actual:   %s\n", issue.Actual)
-			fmt.Println()
-		}
+	resp, err := core.SendHeartbeat(ctx, apiEndpoint, payload)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error sending heartbeat: %v\n", err)
+		os.Exit(1)
+	}
 
-		if len(validation.Suggestions) > 0 {
-			fmt.Println("Suggestions:")
-			for _, s := range validation.Suggestions {
-				fmt.Printf("  - %s\n", s)
-			}
-		}
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(resp)
+		return
 	}
 
-	// Exit with error code if there are critical issues
-	for _, issue := range validation.Issues {
-		if issue.Severity == "CRITICAL" {
-			os.Exit(1)
-		}
-	}
+	fmt.Printf("Heartbeat sent successfully!\n")
+	fmt.Printf("  Health: %s\n", resp.Health)
+	fmt.Printf("  Height: %d (canonical: %d, lag: %d)\n", status.Height, resp.CanonicalHeight, resp.LagBlocks)
 }
 
-func runNodeReset(cmd *cobra.Command, args []string) {
+func runMonitorVisibility(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
 	home, _ := cmd.Flags().GetString("home")
-	preserveKeys, _ := cmd.Flags().GetBool("preserve-keys")
-	force, _ := cmd.Flags().GetBool("force")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	apiEndpoint, _ := cmd.Flags().GetString("api")
+	makePublic, _ := cmd.Flags().GetBool("public")
+	makePrivate, _ := cmd.Flags().GetBool("private")
 
-	// Refuse to run as root
-	if os.Geteuid() == 0 {
-		fmt.Fprintf(os.Stderr, "Error: monoctl must not be run as root\n")
-		fmt.Fprintf(os.Stderr, "Run as the user that owns the node home directory.\n")
+	// Validate flags
+	if !makePublic && !makePrivate {
+		fmt.Fprintf(os.Stderr, "Error: must specify --public or --private\n")
+		os.Exit(1)
+	}
+	if makePublic && makePrivate {
+		fmt.Fprintf(os.Stderr, "Error: cannot specify both --public and --private\n")
 		os.Exit(1)
 	}
 
-	// Default home
+	visibility := "private"
+	if makePublic {
+		visibility = "public"
+	}
+
+	// Default home directory
 	if home == "" {
-		homeDir, _ := os.UserHomeDir()
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
+			os.Exit(1)
+		}
 		home = filepath.Join(homeDir, ".monod")
-	} else if strings.HasPrefix(home, "~") {
-		homeDir, _ := os.UserHomeDir()
-		home = filepath.Join(homeDir, strings.TrimPrefix(home, "~"))
 	}
-	home, _ = filepath.Abs(home)
 
-	// Check that home directory exists
-	if _, err := os.Stat(home); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: node home directory does not exist: %s\n", home)
-		fmt.Fprintf(os.Stderr, "Nothing to reset.\n")
-		os.Exit(1)
+	if apiEndpoint == "" {
+		apiEndpoint = core.MonitorAPIEndpointForNetwork(networkStr)
 	}
 
-	// Verify this looks like a monod home directory
-	configDir := filepath.Join(home, "config")
-	dataDir := filepath.Join(home, "data")
-
-	hasConfig := false
-	hasData := false
-	if _, err := os.Stat(configDir); err == nil {
-		hasConfig = true
-	}
-	if _, err := os.Stat(dataDir); err == nil {
-		hasData = true
+	// Get keys directory
+	keysDir, err := core.GetMonitorKeysDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	if !hasConfig && !hasData {
-		fmt.Fprintf(os.Stderr, "Error: %s does not look like a monod home directory\n", home)
-		fmt.Fprintf(os.Stderr, "Expected to find config/ or data/ subdirectory.\n")
+	// Load keys
+	keys, err := core.LoadKeys(keysDir, home)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading keys: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Have you registered? Run: monoctl monitor register --network %s --moniker <name>\n", networkStr)
 		os.Exit(1)
 	}
 
-	fmt.Println("Node Reset")
-	fmt.Println(strings.Repeat("=", 50))
-	fmt.Printf("Home: %s\n", home)
-	fmt.Println()
-
-	// List what will be deleted
-	fmt.Println("The following will be DELETED:")
-	fmt.Printf("  - %s/* (blockchain data)\n", dataDir)
-	fmt.Printf("  - %s/config.toml\n", home)
-	fmt.Printf("  - %s/app.toml\n", home)
-	fmt.Printf("  - %s/client.toml\n", home)
-	fmt.Printf("  - %s/genesis.json\n", home)
-	fmt.Printf("  - %s/addrbook.json\n", home)
+	// Set visibility
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 
-	if preserveKeys {
-		fmt.Println()
-		fmt.Println("The following will be PRESERVED:")
-		fmt.Printf("  - %s/config/node_key.json\n", home)
-		fmt.Printf("  - %s/config/priv_validator_key.json\n", home)
-	} else {
-		fmt.Printf("  - %s/config/node_key.json\n", home)
-		fmt.Printf("  - %s/config/priv_validator_key.json\n", home)
+	resp, err := core.SetVisibility(ctx, apiEndpoint, keys, networkStr, visibility)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error setting visibility: %v\n", err)
+		os.Exit(1)
 	}
-	fmt.Println()
 
-	if dryRun {
-		fmt.Println("(DRY RUN - no changes will be made)")
+	if jsonOutput {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(resp)
 		return
 	}
 
-	// Confirmation
-	if !force {
-		fmt.Print("This action is DESTRUCTIVE and cannot be undone.\n")
-		fmt.Print("Type 'yes' to confirm: ")
-		reader := bufio.NewReader(os.Stdin)
-		response, _ := reader.ReadString('\n')
-		response = strings.TrimSpace(response)
-		if response != "yes" {
-			fmt.Println("Aborted.")
-			os.Exit(0)
-		}
-	}
-
-	fmt.Println()
-	fmt.Println("Resetting node...")
-
-	// Step 1: Stop monod service
-	fmt.Print("[1/5] Stopping monod service... ")
-	stopCmd := exec.Command("systemctl", "--user", "stop", "monod")
-	if err := stopCmd.Run(); err != nil {
-		// Try system-level service
-		stopCmd = exec.Command("sudo", "systemctl", "stop", "monod")
-		stopCmd.Run() // Ignore errors - service might not exist
+	fmt.Printf("Visibility updated successfully!\n")
+	fmt.Printf("  Node ID: %s\n", resp.NodeID)
+	fmt.Printf("  Visibility: %s\n", resp.Visibility)
+	if visibility == "public" {
+		fmt.Printf("\nYour node will now appear on the public explorer.\n")
+	} else {
+		fmt.Printf("\nYour node will no longer appear on the public explorer.\n")
 	}
-	// Also kill any running monod processes
-	exec.Command("pkill", "-f", "monod start").Run()
-	time.Sleep(time.Second)
-	fmt.Println("done")
+}
 
-	// Step 2: Backup keys if preserving
-	var nodeKeyBackup, privValKeyBackup []byte
-	if preserveKeys {
-		fmt.Print("[2/5] Backing up keys... ")
-		nodeKeyPath := filepath.Join(configDir, "node_key.json")
-		privValKeyPath := filepath.Join(configDir, "priv_validator_key.json")
+func runMonitorVerify(cmd *cobra.Command, args []string) {
+	pubKeyB64, _ := cmd.Flags().GetString("pubkey")
+	maxSkew, _ := cmd.Flags().GetDuration("max-skew")
+	ignoreTimestamp, _ := cmd.Flags().GetBool("ignore-timestamp")
 
-		if data, err := os.ReadFile(nodeKeyPath); err == nil {
-			nodeKeyBackup = data
-		}
-		if data, err := os.ReadFile(privValKeyPath); err == nil {
-			privValKeyBackup = data
-		}
-		fmt.Println("done")
+	var data []byte
+	var err error
+	if args[0] == "-" {
+		data, err = io.ReadAll(os.Stdin)
 	} else {
-		fmt.Println("[2/5] Not preserving keys")
+		data, err = os.ReadFile(args[0])
 	}
-
-	// Step 3: Delete everything
-	fmt.Print("[3/5] Deleting node data... ")
-
-	// Delete ENTIRE data directory (not just contents) - this ensures all *.db directories are removed
-	if err := os.RemoveAll(dataDir); err != nil {
-		fmt.Fprintf(os.Stderr, "\nError: failed to remove data dir: %v\n", err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to read payload: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Delete config files
-	filesToDelete := []string{
-		filepath.Join(configDir, "config.toml"),
-		filepath.Join(configDir, "app.toml"),
-		filepath.Join(configDir, "client.toml"),
-		filepath.Join(configDir, "genesis.json"),
-		filepath.Join(configDir, "addrbook.json"),
-		filepath.Join(configDir, "config_patch.toml"),
-	}
-
-	if !preserveKeys {
-		filesToDelete = append(filesToDelete,
-			filepath.Join(configDir, "node_key.json"),
-			filepath.Join(configDir, "priv_validator_key.json"),
-		)
-	}
-
-	for _, f := range filesToDelete {
-		os.Remove(f)
+	var payload core.HeartbeatPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to parse payload: %v\n", err)
+		os.Exit(1)
 	}
-	fmt.Println("done")
 
-	// Step 4: Recreate directories and restore keys
-	fmt.Print("[4/5] Recreating directories... ")
-
-	if err := os.MkdirAll(configDir, 0755); err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating config dir: %v\n", err)
-		os.Exit(1)
+	var pubKey ed25519.PublicKey
+	if pubKeyB64 != "" {
+		pubKey, err = core.DecodeMonitorPublicKey(pubKeyB64)
+	} else {
+		var keysDir string
+		if keysDir, err = core.GetMonitorKeysDir(); err == nil {
+			pubKey, err = core.LoadMonitorPublicKey(keysDir)
+		}
 	}
-	if err := os.MkdirAll(dataDir, 0700); err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating data dir: %v\n", err)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Create empty priv_validator_state.json
-	privValState := `{"height": "0", "round": 0, "step": 0}`
-	if err := os.WriteFile(filepath.Join(dataDir, "priv_validator_state.json"), []byte(privValState), 0600); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to create priv_validator_state.json: %v\n", err)
+	if ignoreTimestamp {
+		maxSkew = -1
 	}
+	verifyErr := core.VerifyHeartbeat(&payload, core.VerifyOptions{PublicKey: pubKey, MaxSkew: maxSkew})
 
-	// Restore keys if backed up
-	if preserveKeys {
-		if len(nodeKeyBackup) > 0 {
-			os.WriteFile(filepath.Join(configDir, "node_key.json"), nodeKeyBackup, 0600)
+	if jsonOutput {
+		result := map[string]interface{}{
+			"valid":     verifyErr == nil,
+			"node_id":   payload.NodeID,
+			"network":   payload.Network,
+			"timestamp": time.Unix(payload.TimestampUnix, 0).UTC(),
 		}
-		if len(privValKeyBackup) > 0 {
-			os.WriteFile(filepath.Join(configDir, "priv_validator_key.json"), privValKeyBackup, 0600)
+		if verifyErr != nil {
+			result["error"] = verifyErr.Error()
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(result)
+	} else {
+		fmt.Printf("Node ID:   %s\n", payload.NodeID)
+		fmt.Printf("Network:   %s\n", payload.Network)
+		fmt.Printf("Signed at: %s\n", time.Unix(payload.TimestampUnix, 0).UTC().Format(time.RFC3339))
+		if verifyErr == nil {
+			fmt.Println("Result:    VALID")
+		} else {
+			fmt.Printf("Result:    INVALID (%v)\n", verifyErr)
 		}
 	}
-	fmt.Println("done")
+	if verifyErr != nil {
+		os.Exit(1)
+	}
+}
 
-	// Step 5: Verify reset was successful
-	fmt.Print("[5/5] Verifying reset... ")
+func runMonitorServer(cmd *cobra.Command, args []string) {
+	listen, _ := cmd.Flags().GetString("listen")
+	storePath, _ := cmd.Flags().GetString("store")
+	maxSkew, _ := cmd.Flags().GetDuration("max-skew")
+	lagThreshold, _ := cmd.Flags().GetInt64("lag-threshold")
 
-	// Check that data directory is clean (only priv_validator_state.json should exist)
-	dirtyFiles := []string{"state.db", "application.db", "blockstore.db", "tx_index.db", "evidence.db"}
-	for _, df := range dirtyFiles {
-		checkPath := filepath.Join(dataDir, df)
-		if _, err := os.Stat(checkPath); err == nil {
-			fmt.Fprintf(os.Stderr, "\nError: reset verification failed - %s still exists\n", checkPath)
-			fmt.Fprintf(os.Stderr, "Data directory was not properly cleaned.\n")
+	if storePath == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
 			os.Exit(1)
 		}
+		storePath = filepath.Join(homeDir, ".mono-commander", "nodemon.json")
 	}
 
-	// Check that addrbook.json is removed
-	addrbookPath := filepath.Join(configDir, "addrbook.json")
-	if _, err := os.Stat(addrbookPath); err == nil {
-		fmt.Fprintf(os.Stderr, "\nError: reset verification failed - addrbook.json still exists\n")
+	store, err := nodemon.OpenFileStore(storePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	srv := nodemon.NewServer(store)
+	srv.MaxClockSkew = maxSkew
+	srv.LagThreshold = lagThreshold
+	srv.Log = os.Stdout
 
-	fmt.Println("done")
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
 
-	fmt.Println()
-	fmt.Println("Reset complete!")
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  monoctl join --network <network> --home %s\n", home)
-}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
 
-// =============================================================================
-// Config Doctor Command - Drift Detection
-// =============================================================================
+	fmt.Printf("Serving node-monitor API on http://%s (store: %s)\n", listen, storePath)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
 
-func runConfigDoctor(cmd *cobra.Command, args []string) {
+func runMonitorInstall(cmd *cobra.Command, args []string) {
 	networkStr, _ := cmd.Flags().GetString("network")
 	home, _ := cmd.Flags().GetString("home")
+	user, _ := cmd.Flags().GetString("user")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
 	// Default home directory
 	if home == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
-			os.Exit(1)
-		}
-		home = filepath.Join(homeDir, ".monod")
+		fmt.Fprintf(os.Stderr, "Error: --home is required for systemd install\n")
+		os.Exit(1)
 	}
 
-	// Parse network name
-	networkName, err := core.ParseNetworkName(networkStr)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	networkLower := strings.ToLower(networkStr)
+	serviceFile := fmt.Sprintf("/etc/systemd/system/monoctl-monitor@.service")
+	timerFile := fmt.Sprintf("/etc/systemd/system/monoctl-monitor@.timer")
+
+	service, timer := core.GenerateSystemdTimer(networkStr, home, user)
+
+	if dryRun {
+		fmt.Println("Would create the following files:")
+		fmt.Println()
+		fmt.Printf("=== %s ===\n", serviceFile)
+		fmt.Println(service)
+		fmt.Printf("=== %s ===\n", timerFile)
+		fmt.Println(timer)
+		fmt.Println()
+		fmt.Printf("Would enable and start: monoctl-monitor@%s.timer\n", networkLower)
+		return
 	}
 
-	// Fetch canonical config
-	network, err := core.GetNetworkFromCanonical(networkName, "main")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching canonical config: %v\n", err)
+	// Check if running as root
+	if os.Geteuid() != 0 {
+		fmt.Fprintf(os.Stderr, "Error: systemd install requires root privileges\n")
+		fmt.Fprintf(os.Stderr, "Run: sudo monoctl monitor install --network %s --user %s --home %s\n", networkStr, user, home)
 		os.Exit(1)
 	}
 
-	// Create drift config from canonical network
-	driftConfig := &core.DriftConfig{
-		CosmosChainID:  network.ChainID,
-		EVMChainID:     network.EVMChainID,
-		Seeds:          []string{}, // Seeds come from canonical config
-		BootstrapPeers: []string{}, // Peers come from canonical config
+	// Write service file
+	if err := os.WriteFile(serviceFile, []byte(service), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing service file: %v\n", err)
+		os.Exit(1)
 	}
+	fmt.Printf("Created %s\n", serviceFile)
 
-	// Detect drift
-	results, err := core.DetectDrift(home, driftConfig)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error detecting drift: %v\n", err)
+	// Write timer file
+	if err := os.WriteFile(timerFile, []byte(timer), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing timer file: %v\n", err)
 		os.Exit(1)
 	}
+	fmt.Printf("Created %s\n", timerFile)
+
+	// Reload systemd
+	exec.Command("systemctl", "daemon-reload").Run()
 
-	if jsonOutput {
-		// JSON output
-		type driftOutput struct {
-			Network     string             `json:"network"`
-			Home        string             `json:"home"`
-			DriftCount  int                `json:"drift_count"`
-			HasCritical bool               `json:"has_critical"`
-			Results     []core.DriftResult `json:"results"`
-		}
-		out := driftOutput{
-			Network:     string(networkName),
-			Home:        home,
-			DriftCount:  len(results),
-			HasCritical: core.HasCriticalDrift(results),
-			Results:     results,
-		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		enc.Encode(out)
-		if core.HasCriticalDrift(results) {
-			os.Exit(1)
-		}
-		return
+	// Enable and start timer
+	timerName := fmt.Sprintf("monoctl-monitor@%s.timer", networkLower)
+	if err := exec.Command("systemctl", "enable", timerName).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to enable timer: %v\n", err)
+	}
+	if err := exec.Command("systemctl", "start", timerName).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to start timer: %v\n", err)
 	}
 
-	// Human-readable output
-	fmt.Printf("Network: %s\n", networkName)
-	fmt.Printf("Home: %s\n", home)
 	fmt.Println()
+	fmt.Printf("Monitor timer installed and started: %s\n", timerName)
+	fmt.Println("Heartbeats will be sent every 30 seconds.")
+}
 
-	if len(results) == 0 {
-		fmt.Println("No drift detected. Configuration matches canonical values.")
+func runMonitorUninstall(cmd *cobra.Command, args []string) {
+	networkStr, _ := cmd.Flags().GetString("network")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	networkLower := strings.ToLower(networkStr)
+	timerName := fmt.Sprintf("monoctl-monitor@%s.timer", networkLower)
+	serviceName := fmt.Sprintf("monoctl-monitor@%s.service", networkLower)
+
+	if dryRun {
+		fmt.Printf("Would stop and disable: %s\n", timerName)
+		fmt.Println("Would remove:")
+		fmt.Println("  /etc/systemd/system/monoctl-monitor@.service")
+		fmt.Println("  /etc/systemd/system/monoctl-monitor@.timer")
 		return
 	}
 
-	fmt.Println(core.FormatDriftReport(results))
-
-	if core.HasCriticalDrift(results) {
-		fmt.Println()
-		fmt.Printf("Run: monoctl config repair --network %s --home %s\n", networkStr, home)
+	// Check if running as root
+	if os.Geteuid() != 0 {
+		fmt.Fprintf(os.Stderr, "Error: systemd uninstall requires root privileges\n")
+		fmt.Fprintf(os.Stderr, "Run: sudo monoctl monitor uninstall --network %s\n", networkStr)
 		os.Exit(1)
 	}
+
+	// Stop and disable
+	exec.Command("systemctl", "stop", timerName).Run()
+	exec.Command("systemctl", "stop", serviceName).Run()
+	exec.Command("systemctl", "disable", timerName).Run()
+
+	// Remove files
+	os.Remove("/etc/systemd/system/monoctl-monitor@.service")
+	os.Remove("/etc/systemd/system/monoctl-monitor@.timer")
+
+	// Reload systemd
+	exec.Command("systemctl", "daemon-reload").Run()
+
+	fmt.Println("Monitor timer uninstalled.")
 }
 
 // =============================================================================
-// Config Repair Command - Drift Repair
+// Snapshot Restore Command
 // =============================================================================
 
-func runConfigRepair(cmd *cobra.Command, args []string) {
+// printSnapshotProgress renders snapshot download/extract progress on stderr.
+// RestoreSnapshot calls it at most every 250ms per phase.
+func printSnapshotProgress(phase string, done, total int64) {
+	const mb = 1024 * 1024
+	if total > 0 {
+		fmt.Fprintf(os.Stderr, "\r  %-8s %6.1f%% (%d/%d MB)", phase, float64(done)*100/float64(total), done/mb, total/mb)
+		if done >= total {
+			fmt.Fprintln(os.Stderr)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r  %-8s %d MB", phase, done/mb)
+}
+
+func runSnapshotRestore(cmd *cobra.Command, args []string) {
 	networkStr, _ := cmd.Flags().GetString("network")
 	home, _ := cmd.Flags().GetString("home")
+	url, _ := cmd.Flags().GetString("url")
+	sha, _ := cmd.Flags().GetString("sha256")
+	insecure, _ := cmd.Flags().GetBool("insecure")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
+	// Refuse to run as root
+	if os.Geteuid() == 0 {
+		fmt.Fprintf(os.Stderr, "Error: monoctl must not be run as root\n")
+		fmt.Fprintf(os.Stderr, "Run as the user that owns the node home directory.\n")
+		os.Exit(1)
+	}
+
 	// Default home directory
 	if home == "" {
 		homeDir, err := os.UserHomeDir()
@@ -4281,390 +7645,501 @@ func runConfigRepair(cmd *cobra.Command, args []string) {
 		home = filepath.Join(homeDir, ".monod")
 	}
 
-	// Parse network name
-	networkName, err := core.ParseNetworkName(networkStr)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	// Resolve snapshot source from the registry unless fully overridden
+	if url == "" || sha == "" {
+		if networkStr == "" {
+			fmt.Fprintf(os.Stderr, "Error: --network is required unless both --url and --sha256 are given\n")
+			os.Exit(1)
+		}
+		networkName, err := core.ParseNetworkName(networkStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		netConfig, err := core.GetNetworkConfigWithCache(strings.ToLower(string(networkName)), core.DefaultRef)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to load network registry: %v\n", err)
+			os.Exit(1)
+		}
+		regURL, regSHA, err := core.ResolveSnapshotSource(netConfig)
+		if err != nil && url == "" {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		// Only take the registry checksum when it describes the same URL
+		if url == "" {
+			url = regURL
+		}
+		if sha == "" && url == regURL {
+			sha = regSHA
+		}
 	}
 
-	// Fetch canonical config
-	network, err := core.GetNetworkFromCanonical(networkName, "main")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error fetching canonical config: %v\n", err)
-		os.Exit(1)
+	opts := core.SnapshotOptions{
+		Home:     home,
+		URL:      url,
+		SHA256:   sha,
+		Insecure: insecure,
+		DryRun:   dryRun,
+	}
+	if !jsonOutput {
+		opts.OnProgress = printSnapshotProgress
+		fmt.Printf("Snapshot: %s\n", url)
+		fmt.Printf("Home: %s\n", home)
+		if sha != "" {
+			fmt.Printf("SHA256: %s\n", sha)
+		} else {
+			fmt.Println("SHA256: (not verified - --insecure)")
+		}
+		if dryRun {
+			fmt.Println("(DRY RUN - no changes will be made)")
+		}
+		fmt.Println()
 	}
 
-	// Create drift config from canonical network
-	driftConfig := &core.DriftConfig{
-		CosmosChainID:  network.ChainID,
-		EVMChainID:     network.EVMChainID,
-		Seeds:          []string{}, // Seeds come from canonical config
-		BootstrapPeers: []string{}, // Peers come from canonical config
+	result, err := core.RestoreSnapshot(opts)
+
+	if jsonOutput {
+		data, _ := json.MarshalIndent(result, "", "  ")
+		fmt.Println(string(data))
+		if err != nil {
+			os.Exit(1)
+		}
+		return
 	}
 
-	// Perform repair
-	results, err := core.Repair(home, driftConfig, dryRun)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error repairing configuration: %v\n", err)
+		fmt.Fprintf(os.Stderr, "\nError: %v\n", err)
 		os.Exit(1)
 	}
 
-	if jsonOutput {
-		// JSON output
-		type repairOutput struct {
-			Network string              `json:"network"`
-			Home    string              `json:"home"`
-			DryRun  bool                `json:"dry_run"`
-			Results []core.RepairResult `json:"results"`
-		}
-		out := repairOutput{
-			Network: string(networkName),
-			Home:    home,
-			DryRun:  dryRun,
-			Results: results,
-		}
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		enc.Encode(out)
+	if dryRun {
+		fmt.Printf("Would download and extract into %s\n", result.DataDir)
 		return
 	}
 
-	// Human-readable output
-	fmt.Println(core.FormatRepairReport(results, dryRun))
-
-	if !dryRun && len(results) > 0 {
-		fmt.Println()
-		fmt.Printf("Run: monoctl config doctor --network %s --home %s\n", networkStr, home)
-		fmt.Println("to verify the repair was successful.")
+	fmt.Println()
+	fmt.Printf("[+] Downloaded %d MB\n", result.DownloadedBytes/(1024*1024))
+	if result.Verified {
+		fmt.Println("[+] SHA256 verified")
+	} else {
+		fmt.Println("[-] SHA256 not verified (--insecure)")
+	}
+	fmt.Printf("[+] Extracted %d files into %s\n", result.FilesExtracted, result.DataDir)
+	if result.StatePreserved {
+		fmt.Println("[+] priv_validator_state.json preserved")
+	} else {
+		fmt.Println("[+] priv_validator_state.json not taken from snapshot (CometBFT will create it)")
 	}
 }
 
 // =============================================================================
-// Monitor Commands - Opt-in Node Monitoring
+// Fleet Commands
 // =============================================================================
 
-func runMonitorRegister(cmd *cobra.Command, args []string) {
-	networkStr, _ := cmd.Flags().GetString("network")
-	moniker, _ := cmd.Flags().GetString("moniker")
+// loadFleetNodes loads the inventory and applies the fleet selection flags.
+func loadFleetNodes(cmd *cobra.Command) ([]fleet.Node, int) {
+	path, _ := cmd.Flags().GetString("inventory")
+	names, _ := cmd.Flags().GetStringSlice("node")
+	network, _ := cmd.Flags().GetString("network")
 	role, _ := cmd.Flags().GetString("role")
-	apiEndpoint, _ := cmd.Flags().GetString("api")
-	home, _ := cmd.Flags().GetString("home")
+	tag, _ := cmd.Flags().GetString("tag")
+	concurrency, _ := cmd.Flags().GetInt("concurrency")
 
-	if apiEndpoint == "" {
-		apiEndpoint = core.MonitorAPIEndpointForNetwork(networkStr)
+	if path == "" {
+		var err error
+		if path, err = fleet.DefaultInventoryPath(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
-	fmt.Printf("Using API endpoint: %s\n", apiEndpoint)
-
-	// Get keys directory
-	keysDir, err := core.GetMonitorKeysDir()
+	inv, err := fleet.LoadInventory(path)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Load or create keys
-	fmt.Println("Loading or creating monitor keypair...")
-	keys, err := core.LoadOrCreateKeys(keysDir, home)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading/creating keys: %v\n", err)
-		os.Exit(1)
-	}
-
-	fmt.Printf("Node ID: %s\n", keys.NodeID)
-
-	// Start registration
-	fmt.Println("Registering with node-monitor API...")
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	resp, err := core.StartRegistration(ctx, apiEndpoint, keys, networkStr, moniker, role)
+	nodes, err := inv.Select(fleet.Filter{Names: names, Network: network, Role: role, Tag: tag})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-
-	if jsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		enc.Encode(map[string]interface{}{
-			"node_id":    keys.NodeID,
-			"network":    networkStr,
-			"moniker":    moniker,
-			"role":       role,
-			"link_token": resp.LinkToken,
-			"expires_at": resp.ExpiresAt,
-		})
-		return
-	}
-
-	fmt.Println()
-	fmt.Println("Registration started successfully!")
-	fmt.Println()
-	fmt.Printf("Link Token: %s\n", resp.LinkToken)
-	fmt.Printf("Expires At: %s\n", resp.ExpiresAt.Format(time.RFC3339))
-	fmt.Println()
-	fmt.Println("To complete registration, send this token to the Monolythium Telegram bot.")
-	fmt.Println("The token expires in 15 minutes.")
+	return nodes, concurrency
 }
 
-func runMonitorHeartbeat(cmd *cobra.Command, args []string) {
-	networkStr, _ := cmd.Flags().GetString("network")
-	home, _ := cmd.Flags().GetString("home")
-	apiEndpoint, _ := cmd.Flags().GetString("api")
-
-	// Default home directory
-	if home == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
-			os.Exit(1)
-		}
-		home = filepath.Join(homeDir, ".monod")
+// dialFleetNode connects to a node's ssh target with the --ssh-key and
+// --ssh-known-hosts settings.
+func dialFleetNode(target remote.Target) (*remote.Client, error) {
+	cfg := remote.Config{Target: target, KnownHostsFile: sshKnownHosts}
+	if sshKey != "" {
+		cfg.IdentityFiles = []string{sshKey}
 	}
+	return remote.Dial(cfg)
+}
 
-	if apiEndpoint == "" {
-		apiEndpoint = core.MonitorAPIEndpointForNetwork(networkStr)
-	}
+func printFleetJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
 
-	// Get keys directory
-	keysDir, err := core.GetMonitorKeysDir()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
-	}
+func runFleetStatus(cmd *cobra.Command, args []string) {
+	nodes, concurrency := loadFleetNodes(cmd)
+	results := fleet.Status(nodes, concurrency)
 
-	// Load keys
-	keys, err := core.LoadKeys(keysDir, home)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading keys: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Have you registered? Run: monoctl monitor register --network %s --moniker <name>\n", networkStr)
-		os.Exit(1)
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
 	}
 
-	// Get local status
-	status, err := core.GetLocalStatus(home, tui.Version)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting node status: %v\n", err)
+	if jsonOutput {
+		printFleetJSON(results)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE\tNETWORK\tROLE\tHEIGHT\tCATCHING UP\tPEERS\tSERVICE\tERROR")
+		for _, r := range results {
+			height, catchingUp, peers := "-", "-", "-"
+			if r.Status != nil {
+				height = strconv.FormatInt(r.Status.LatestHeight, 10)
+				catchingUp = strconv.FormatBool(r.Status.CatchingUp)
+				peers = strconv.Itoa(r.Status.PeersCount)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				r.Node, r.Network, orDash(r.Role), height, catchingUp, peers, orDash(r.Service), r.Error)
+		}
+		w.Flush()
+		fmt.Printf("\n%d/%d nodes reachable\n", len(results)-failed, len(results))
+	}
+	if failed > 0 {
 		os.Exit(1)
 	}
+}
 
-	// Get capabilities
-	caps, err := core.GetLocalCapabilities(home)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: could not get full capabilities: %v\n", err)
-		caps = &core.MonitorCapabilities{}
+func runFleetHealth(cmd *cobra.Command, args []string) {
+	nodes, concurrency := loadFleetNodes(cmd)
+	results := fleet.Health(nodes, concurrency)
+
+	unhealthy := 0
+	for _, r := range results {
+		if !r.Healthy {
+			unhealthy++
+		}
 	}
 
-	// Sign heartbeat
-	payload, err := core.SignHeartbeat(keys, networkStr, status, caps)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error signing heartbeat: %v\n", err)
+	if jsonOutput {
+		printFleetJSON(results)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE\tNETWORK\tHEALTHY\tRPC\tVALIDATOR\tDETAILS")
+		for _, r := range results {
+			passed := 0
+			var failedChecks []string
+			for _, c := range r.RPC.Results {
+				if c.Status == "PASS" {
+					passed++
+				} else {
+					failedChecks = append(failedChecks, c.Type)
+				}
+			}
+			validator := "-"
+			details := strings.Join(failedChecks, ", ")
+			switch {
+			case r.ValidatorError != "":
+				validator = "error"
+				details = joinNonEmpty(details, r.ValidatorError)
+			case r.Validator != nil && r.Validator.Jailed:
+				validator = "jailed"
+			case r.Validator != nil:
+				validator = r.Validator.Status
+			}
+			fmt.Fprintf(w, "%s\t%s\t%t\t%d/%d\t%s\t%s\n",
+				r.Node, r.Network, r.Healthy, passed, len(r.RPC.Results), validator, details)
+		}
+		w.Flush()
+		fmt.Printf("\n%d/%d nodes healthy\n", len(results)-unhealthy, len(results))
+	}
+	if unhealthy > 0 {
 		os.Exit(1)
 	}
+}
 
-	// Send heartbeat
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func runFleetDoctor(cmd *cobra.Command, args []string) {
+	nodes, concurrency := loadFleetNodes(cmd)
 
-	resp, err := core.SendHeartbeat(ctx, apiEndpoint, payload)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error sending heartbeat: %v\n", err)
-		os.Exit(1)
+	// Prefer the canonical config; fall back to the built-in network
+	results := fleet.Doctor(nodes, concurrency, func(network core.NetworkName) (*core.DriftConfig, error) {
+		netCfg, err := core.GetNetworkFromCanonical(network, "main")
+		if err != nil {
+			if netCfg, err = core.GetNetwork(network); err != nil {
+				return nil, err
+			}
+		}
+		return &core.DriftConfig{CosmosChainID: netCfg.ChainID, EVMChainID: netCfg.EVMChainID}, nil
+	}, dialFleetNode)
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" || r.HasCritical {
+			failed++
+		}
 	}
 
 	if jsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		enc.Encode(resp)
-		return
+		printFleetJSON(results)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE\tNETWORK\tHOME\tDRIFTS\tCRITICAL\tDETAILS")
+		for _, r := range results {
+			var details []string
+			for _, d := range r.Drifts {
+				details = append(details, fmt.Sprintf("%s: %s", d.Field, d.Actual))
+			}
+			if r.Error != "" {
+				details = append(details, r.Error)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%t\t%s\n",
+				r.Node, r.Network, r.Home, len(r.Drifts), r.HasCritical, strings.Join(details, "; "))
+		}
+		w.Flush()
+		fmt.Printf("\n%d/%d nodes without critical drift\n", len(results)-failed, len(results))
+	}
+	if failed > 0 {
+		os.Exit(1)
 	}
-
-	fmt.Printf("Heartbeat sent successfully!\n")
-	fmt.Printf("  Health: %s\n", resp.Health)
-	fmt.Printf("  Height: %d (canonical: %d, lag: %d)\n", status.Height, resp.CanonicalHeight, resp.LagBlocks)
 }
 
-func runMonitorVisibility(cmd *cobra.Command, args []string) {
-	networkStr, _ := cmd.Flags().GetString("network")
-	home, _ := cmd.Flags().GetString("home")
-	apiEndpoint, _ := cmd.Flags().GetString("api")
-	makePublic, _ := cmd.Flags().GetBool("public")
-	makePrivate, _ := cmd.Flags().GetBool("private")
+func runFleetPeersUpdate(cmd *cobra.Command, args []string) {
+	nodes, concurrency := loadFleetNodes(cmd)
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	// Validate flags
-	if !makePublic && !makePrivate {
-		fmt.Fprintf(os.Stderr, "Error: must specify --public or --private\n")
-		os.Exit(1)
+	results := fleet.UpdatePeers(nodes, concurrency, net.NewHTTPFetcher(), dryRun, dialFleetNode)
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
 	}
-	if makePublic && makePrivate {
-		fmt.Fprintf(os.Stderr, "Error: cannot specify both --public and --private\n")
+
+	if jsonOutput {
+		printFleetJSON(results)
+	} else {
+		if dryRun {
+			fmt.Println("(DRY RUN - no changes will be made)")
+			fmt.Println()
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NODE\tNETWORK\tPEERS\tPATCH\tERROR")
+		for _, r := range results {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", r.Node, r.Network, r.PeersCount, orDash(r.PatchPath), r.Error)
+		}
+		w.Flush()
+		fmt.Printf("\n%d/%d nodes updated\n", len(results)-failed, len(results))
+	}
+	if failed > 0 {
 		os.Exit(1)
 	}
+}
 
-	visibility := "private"
-	if makePublic {
-		visibility = "public"
+func orDash(s string) string {
+	if s == "" {
+		return "-"
 	}
+	return s
+}
 
-	// Default home directory
-	if home == "" {
-		homeDir, err := os.UserHomeDir()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: could not determine home directory: %v\n", err)
-			os.Exit(1)
-		}
-		home = filepath.Join(homeDir, ".monod")
+func joinNonEmpty(a, b string) string {
+	if a == "" {
+		return b
 	}
+	return a + "; " + b
+}
 
-	if apiEndpoint == "" {
-		apiEndpoint = core.MonitorAPIEndpointForNetwork(networkStr)
-	}
+// =============================================================================
+// Address Book Commands
+// =============================================================================
 
-	// Get keys directory
-	keysDir, err := core.GetMonitorKeysDir()
+// loadAddressBook loads the default address book, exiting on error.
+func loadAddressBook() (*core.AddressBook, string) {
+	path, err := core.DefaultAddressBookPath()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	book, err := core.LoadAddressBook(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return book, path
+}
 
-	// Load keys
-	keys, err := core.LoadKeys(keysDir, home)
+// resolveAddressArg resolves a tx address flag holding a bech32 or EVM
+// address or an address book label, exiting on error. Labels and EVM
+// addresses are echoed with the address they resolve to.
+func resolveAddressArg(value string, validator bool) string {
+	book, _ := loadAddressBook()
+	var addr string
+	var err error
+	if validator {
+		addr, err = book.ResolveValoperAddress(value)
+	} else {
+		addr, err = book.ResolveAccountAddress(value)
+	}
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading keys: %v\n", err)
-		fmt.Fprintf(os.Stderr, "Have you registered? Run: monoctl monitor register --network %s --moniker <name>\n", networkStr)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	if !strings.EqualFold(addr, value) {
+		fmt.Fprintf(os.Stderr, "Using %s for %s\n", addr, value)
+	}
+	return addr
+}
 
-	// Set visibility
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
+func runAddrbookAdd(cmd *cobra.Command, args []string) {
+	validator, _ := cmd.Flags().GetBool("validator")
+	note, _ := cmd.Flags().GetString("note")
 
-	resp, err := core.SetVisibility(ctx, apiEndpoint, keys, networkStr, visibility)
+	book, path := loadAddressBook()
+	entry, err := book.Add(args[0], args[1], note, validator)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error setting visibility: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := book.Save(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
 	if jsonOutput {
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		enc.Encode(resp)
+		out := map[string]interface{}{
+			"label":       entry.Label,
+			"address":     entry.Address,
+			"evm_address": entry.EVMAddress(),
+			"validator":   entry.IsValidator(),
+		}
+		data, _ := json.MarshalIndent(out, "", "  ")
+		fmt.Println(string(data))
 		return
 	}
-
-	fmt.Printf("Visibility updated successfully!\n")
-	fmt.Printf("  Node ID: %s\n", resp.NodeID)
-	fmt.Printf("  Visibility: %s\n", resp.Visibility)
-	if visibility == "public" {
-		fmt.Printf("\nYour node will now appear on the public explorer.\n")
-	} else {
-		fmt.Printf("\nYour node will no longer appear on the public explorer.\n")
-	}
+	fmt.Printf("Added %s\n", entry.Label)
+	fmt.Printf("  Address:     %s\n", entry.Address)
+	fmt.Printf("  EVM Address: %s\n", entry.EVMAddress())
 }
 
-func runMonitorInstall(cmd *cobra.Command, args []string) {
-	networkStr, _ := cmd.Flags().GetString("network")
-	home, _ := cmd.Flags().GetString("home")
-	user, _ := cmd.Flags().GetString("user")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
+func runAddrbookList(cmd *cobra.Command, args []string) {
+	book, _ := loadAddressBook()
 
-	// Default home directory
-	if home == "" {
-		fmt.Fprintf(os.Stderr, "Error: --home is required for systemd install\n")
-		os.Exit(1)
+	if jsonOutput {
+		type listedEntry struct {
+			Label      string `json:"label"`
+			Address    string `json:"address"`
+			EVMAddress string `json:"evm_address"`
+			Validator  bool   `json:"validator"`
+			Note       string `json:"note,omitempty"`
+		}
+		entries := []listedEntry{}
+		for _, e := range book.Entries {
+			entries = append(entries, listedEntry{e.Label, e.Address, e.EVMAddress(), e.IsValidator(), e.Note})
+		}
+		data, _ := json.MarshalIndent(entries, "", "  ")
+		fmt.Println(string(data))
+		return
 	}
 
-	networkLower := strings.ToLower(networkStr)
-	serviceFile := fmt.Sprintf("/etc/systemd/system/monoctl-monitor@.service")
-	timerFile := fmt.Sprintf("/etc/systemd/system/monoctl-monitor@.timer")
-
-	service, timer := core.GenerateSystemdTimer(networkStr, home, user)
-
-	if dryRun {
-		fmt.Println("Would create the following files:")
-		fmt.Println()
-		fmt.Printf("=== %s ===\n", serviceFile)
-		fmt.Println(service)
-		fmt.Printf("=== %s ===\n", timerFile)
-		fmt.Println(timer)
-		fmt.Println()
-		fmt.Printf("Would enable and start: monoctl-monitor@%s.timer\n", networkLower)
+	if len(book.Entries) == 0 {
+		fmt.Println("The address book is empty. Add an address with 'monoctl addrbook add <label> <address>'.")
 		return
 	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LABEL\tTYPE\tADDRESS\tEVM ADDRESS\tNOTE")
+	for _, e := range book.Entries {
+		kind := "account"
+		if e.IsValidator() {
+			kind = "validator"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", e.Label, kind, e.Address, e.EVMAddress(), e.Note)
+	}
+	w.Flush()
+}
 
-	// Check if running as root
-	if os.Geteuid() != 0 {
-		fmt.Fprintf(os.Stderr, "Error: systemd install requires root privileges\n")
-		fmt.Fprintf(os.Stderr, "Run: sudo monoctl monitor install --network %s --user %s --home %s\n", networkStr, user, home)
+func runAddrbookRemove(cmd *cobra.Command, args []string) {
+	book, path := loadAddressBook()
+	entry, err := book.Remove(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Write service file
-	if err := os.WriteFile(serviceFile, []byte(service), 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing service file: %v\n", err)
+	if err := book.Save(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-	fmt.Printf("Created %s\n", serviceFile)
+	fmt.Printf("Removed %s (%s)\n", entry.Label, entry.Address)
+}
 
-	// Write timer file
-	if err := os.WriteFile(timerFile, []byte(timer), 0644); err != nil {
-		fmt.Fprintf(os.Stderr, "Error writing timer file: %v\n", err)
+// =============================================================================
+// Remote Execution
+// =============================================================================
+
+// runRemote runs the invoked command on the --ssh host with the same
+// arguments and exits with its status. This monoctl binary is copied
+// there first unless --ssh-binary is set.
+func runRemote(cmd *cobra.Command) {
+	if cmd == rootCmd {
+		fmt.Fprintf(os.Stderr, "Error: the TUI cannot run over --ssh; pass a command\n")
 		os.Exit(1)
 	}
-	fmt.Printf("Created %s\n", timerFile)
 
-	// Reload systemd
-	exec.Command("systemctl", "daemon-reload").Run()
-
-	// Enable and start timer
-	timerName := fmt.Sprintf("monoctl-monitor@%s.timer", networkLower)
-	if err := exec.Command("systemctl", "enable", timerName).Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to enable timer: %v\n", err)
+	target, err := remote.ParseTarget(sshTarget)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
-	if err := exec.Command("systemctl", "start", timerName).Run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: failed to start timer: %v\n", err)
+	cfg := remote.Config{Target: target, KnownHostsFile: sshKnownHosts}
+	if sshKey != "" {
+		cfg.IdentityFiles = []string{sshKey}
+	}
+	client, err := remote.Dial(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
 	}
 
-	fmt.Println()
-	fmt.Printf("Monitor timer installed and started: %s\n", timerName)
-	fmt.Println("Heartbeats will be sent every 30 seconds.")
-}
-
-func runMonitorUninstall(cmd *cobra.Command, args []string) {
-	networkStr, _ := cmd.Flags().GetString("network")
-	dryRun, _ := cmd.Flags().GetBool("dry-run")
-
-	networkLower := strings.ToLower(networkStr)
-	timerName := fmt.Sprintf("monoctl-monitor@%s.timer", networkLower)
-	serviceName := fmt.Sprintf("monoctl-monitor@%s.service", networkLower)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	if dryRun {
-		fmt.Printf("Would stop and disable: %s\n", timerName)
-		fmt.Println("Would remove:")
-		fmt.Println("  /etc/systemd/system/monoctl-monitor@.service")
-		fmt.Println("  /etc/systemd/system/monoctl-monitor@.timer")
-		return
+	binary := sshBinary
+	if binary == "" {
+		self, err := os.Executable()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not locate monoctl: %v\n", err)
+			os.Exit(1)
+		}
+		if binary, err = remote.EnsureBinary(ctx, client, self); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
 
-	// Check if running as root
-	if os.Geteuid() != 0 {
-		fmt.Fprintf(os.Stderr, "Error: systemd uninstall requires root privileges\n")
-		fmt.Fprintf(os.Stderr, "Run: sudo monoctl monitor uninstall --network %s\n", networkStr)
-		os.Exit(1)
+	// Piped input (e.g. 'monitor verify -') is forwarded; there is no
+	// remote terminal for interactive prompts
+	var stdin io.Reader
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		stdin = os.Stdin
 	}
 
-	// Stop and disable
-	exec.Command("systemctl", "stop", timerName).Run()
-	exec.Command("systemctl", "stop", serviceName).Run()
-	exec.Command("systemctl", "disable", timerName).Run()
-
-	// Remove files
-	os.Remove("/etc/systemd/system/monoctl-monitor@.service")
-	os.Remove("/etc/systemd/system/monoctl-monitor@.timer")
-
-	// Reload systemd
-	exec.Command("systemctl", "daemon-reload").Run()
+	args := remote.StripFlags(os.Args[1:], "ssh", "ssh-key", "ssh-known-hosts", "ssh-binary")
+	err = remote.RunMonoctl(ctx, client, binary, args, stdin, os.Stdout, os.Stderr)
+	client.Close()
 
-	fmt.Println("Monitor timer uninstalled.")
+	var exitErr *remote.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		os.Exit(exitErr.Code)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
 }
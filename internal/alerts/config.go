@@ -0,0 +1,188 @@
+// Package alerts evaluates alert rules over node and validator health and
+// sends notifications to webhooks, email and local commands.
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+)
+
+// DefaultInterval is how often the rules are evaluated.
+const DefaultInterval = 30 * time.Second
+
+// Config is the alerts configuration file (JSON).
+type Config struct {
+	Network string `json:"network"`
+	// Home is the node home directory checked for configuration drift
+	// (default: ~/.monod)
+	Home string `json:"home,omitempty"`
+	// CometRPC and CosmosREST default to the local node
+	CometRPC   string `json:"comet_rpc,omitempty"`
+	CosmosREST string `json:"cosmos_rest,omitempty"`
+	// Validator is the operator address (monovaloper1...) watched by the
+	// validator rules; an account address (mono1...) is converted
+	Validator string   `json:"validator,omitempty"`
+	Interval  Duration `json:"interval,omitempty"`
+	// RepeatInterval re-sends a still-firing alert this often. Zero sends
+	// it once, plus once when it resolves.
+	RepeatInterval Duration         `json:"repeat_interval,omitempty"`
+	Rules          []RuleConfig     `json:"rules"`
+	Notifiers      []NotifierConfig `json:"notifiers"`
+}
+
+// RuleConfig configures one rule. Which fields apply depends on Type.
+type RuleConfig struct {
+	Type string `json:"type"` // see the Rule* constants
+	// Name identifies the alert in notifications (default: Type)
+	Name     string `json:"name,omitempty"`
+	Severity string `json:"severity,omitempty"` // warning or critical
+	// Threshold is the peer minimum for min_peers and the allowed increase
+	// for missed_blocks
+	Threshold int64 `json:"threshold,omitempty"`
+	// For is how long the height must not move for height_stalled, and the
+	// window the increase is measured over for missed_blocks
+	For Duration `json:"for,omitempty"`
+}
+
+// NotifierConfig configures one notification sink. Which fields apply
+// depends on Type.
+type NotifierConfig struct {
+	Type string `json:"type"` // webhook, smtp or command
+
+	// webhook
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// smtp; the password is read from the environment variable named by
+	// PasswordEnv so it does not sit in the file
+	Host        string   `json:"host,omitempty"`
+	Port        int      `json:"port,omitempty"`
+	Username    string   `json:"username,omitempty"`
+	PasswordEnv string   `json:"password_env,omitempty"`
+	From        string   `json:"from,omitempty"`
+	To          []string `json:"to,omitempty"`
+
+	// command
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// Duration is a time.Duration written as a string such as "5m" in JSON.
+type Duration time.Duration
+
+// MarshalJSON implements json.Marshaler.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"5m\"")
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// DefaultConfigPath returns ~/.mono-commander/alerts.json.
+func DefaultConfigPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".mono-commander", "alerts.json"), nil
+}
+
+// LoadConfig reads and validates an alerts configuration file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks the rules and notifiers and fills in defaults.
+func (c *Config) Validate() error {
+	if c.Network == "" {
+		return fmt.Errorf("network is required")
+	}
+	network, err := core.ParseNetworkName(c.Network)
+	if err != nil {
+		return err
+	}
+	c.Network = string(network)
+	if c.Validator != "" {
+		if core.ValidateAddress(c.Validator) == nil {
+			c.Validator, _ = core.AccountToValoperAddress(c.Validator)
+		}
+		if err := core.ValidateValoperAddress(c.Validator); err != nil {
+			return fmt.Errorf("validator: %w", err)
+		}
+	}
+	if c.Interval <= 0 {
+		c.Interval = Duration(DefaultInterval)
+	}
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("no rules configured")
+	}
+
+	names := map[string]bool{}
+	for i := range c.Rules {
+		r := &c.Rules[i]
+		if err := r.validate(c.Validator != ""); err != nil {
+			return fmt.Errorf("rule %d (%s): %w", i+1, r.Type, err)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("rule %d: duplicate name %q", i+1, r.Name)
+		}
+		names[r.Name] = true
+	}
+
+	for i := range c.Notifiers {
+		if err := c.Notifiers[i].validate(); err != nil {
+			return fmt.Errorf("notifier %d (%s): %w", i+1, c.Notifiers[i].Type, err)
+		}
+	}
+	return nil
+}
+
+func (n *NotifierConfig) validate() error {
+	switch n.Type {
+	case "webhook":
+		if n.URL == "" {
+			return fmt.Errorf("url is required")
+		}
+	case "smtp":
+		if n.Host == "" || n.From == "" || len(n.To) == 0 {
+			return fmt.Errorf("host, from and to are required")
+		}
+		if n.Port == 0 {
+			n.Port = 587
+		}
+	case "command":
+		if n.Command == "" {
+			return fmt.Errorf("command is required")
+		}
+	default:
+		return fmt.Errorf("unknown notifier type (want webhook, smtp or command)")
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// Alert statuses.
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Alert is a notification about a rule that started firing, is still
+// firing (when repeats are enabled) or resolved.
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Type      string    `json:"type"`
+	Severity  string    `json:"severity"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message"`
+	Network   string    `json:"network"`
+	Validator string    `json:"validator,omitempty"`
+	Time      time.Time `json:"time"`
+	// Since is when the rule started firing
+	Since time.Time `json:"since"`
+}
+
+// Summary returns a one-line description of the alert.
+func (a Alert) Summary() string {
+	return fmt.Sprintf("[%s] %s %s on %s: %s",
+		strings.ToUpper(a.Status), a.Severity, a.Rule, a.Network, a.Message)
+}
+
+// Collector gathers the health data for one evaluation.
+type Collector func(ctx context.Context) *Snapshot
+
+// NewCollector returns a Collector that reads node status over Comet RPC,
+// validator health over Cosmos REST, and configuration drift from
+// cfg.Home. Drift is only checked if a critical_drift rule is configured;
+// drift may be nil otherwise.
+func NewCollector(cfg *Config, network core.NetworkName, drift *core.DriftConfig) Collector {
+	cometRPC := cfg.CometRPC
+	if cometRPC == "" {
+		cometRPC = "http://localhost:26657"
+	}
+	cosmosREST := cfg.CosmosREST
+	if cosmosREST == "" {
+		cosmosREST = core.DefaultCosmosREST
+	}
+	client := rpc.NewCosmosClient(strings.TrimRight(cosmosREST, "/"))
+
+	checkDrift := false
+	for _, r := range cfg.Rules {
+		if r.Type == RuleCriticalDrift {
+			checkDrift = true
+		}
+	}
+
+	return func(ctx context.Context) *Snapshot {
+		s := &Snapshot{Time: time.Now()}
+		s.Node, s.NodeErr = core.GetNodeStatus(core.StatusOptions{
+			Network:   network,
+			Endpoints: core.Endpoints{CometRPC: cometRPC, CosmosREST: cosmosREST},
+		})
+		if cfg.Validator != "" {
+			s.Validator, s.ValidatorErr = core.GetValidatorHealth(client, cfg.Validator)
+		}
+		if checkDrift {
+			if drift == nil {
+				s.DriftErr = fmt.Errorf("no canonical config to compare against")
+			} else {
+				s.Drifts, s.DriftErr = core.DetectDrift(cfg.Home, drift)
+			}
+		}
+		return s
+	}
+}
+
+// alertState tracks one rule between evaluations. It only changes once an
+// alert was delivered, so undelivered alerts are sent again on the next
+// evaluation.
+type alertState struct {
+	firing   bool
+	since    time.Time
+	lastSent time.Time
+	// pendingSince is when the rule started firing, while no notifier has
+	// accepted the firing alert yet
+	pendingSince time.Time
+}
+
+// Engine evaluates the configured rules and sends an alert to every
+// notifier when a rule starts firing or resolves.
+type Engine struct {
+	cfg       *Config
+	collect   Collector
+	notifiers []Notifier
+	rules     []*rule
+	states    map[string]*alertState
+}
+
+// NewEngine creates an engine. cfg must have been validated.
+func NewEngine(cfg *Config, collect Collector, notifiers []Notifier) *Engine {
+	e := &Engine{
+		cfg:       cfg,
+		collect:   collect,
+		notifiers: notifiers,
+		states:    map[string]*alertState{},
+	}
+	for _, rc := range cfg.Rules {
+		e.rules = append(e.rules, &rule{cfg: rc})
+		e.states[rc.Name] = &alertState{}
+	}
+	return e
+}
+
+// Evaluate collects one snapshot, evaluates every rule and sends the
+// resulting alerts. It returns the alerts sent; failed notifications are
+// returned as a joined error and do not stop the others. An alert that no
+// notifier accepted does not change the rule's state, so it is sent again
+// on the next evaluation.
+func (e *Engine) Evaluate(ctx context.Context) ([]Alert, error) {
+	s := e.collect(ctx)
+
+	var alerts []Alert
+	for _, r := range e.rules {
+		firing, message, known := r.check(s)
+		if !known {
+			continue
+		}
+		state := e.states[r.cfg.Name]
+
+		var status string
+		since := state.since
+		switch {
+		case firing && !state.firing:
+			if state.pendingSince.IsZero() {
+				state.pendingSince = s.Time
+			}
+			since = state.pendingSince
+			status = StatusFiring
+		case firing && e.cfg.RepeatInterval > 0 && s.Time.Sub(state.lastSent) >= time.Duration(e.cfg.RepeatInterval):
+			status = StatusFiring
+		case !firing && state.firing:
+			status = StatusResolved
+		default:
+			if !firing {
+				// Resolved before the firing alert got through
+				state.pendingSince = time.Time{}
+			}
+			continue
+		}
+
+		alerts = append(alerts, Alert{
+			Rule:      r.cfg.Name,
+			Type:      r.cfg.Type,
+			Severity:  r.cfg.Severity,
+			Status:    status,
+			Message:   message,
+			Network:   e.cfg.Network,
+			Validator: e.cfg.Validator,
+			Time:      s.Time,
+			Since:     since,
+		})
+	}
+
+	var errs []error
+	for _, a := range alerts {
+		delivered := len(e.notifiers) == 0
+		for _, n := range e.notifiers {
+			if err := n.Notify(ctx, a); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", n.Name(), a.Rule, err))
+			} else {
+				delivered = true
+			}
+		}
+		if !delivered {
+			errs = append(errs, fmt.Errorf("%s: no notifier accepted the %s alert, retrying on the next evaluation", a.Rule, a.Status))
+			continue
+		}
+		state := e.states[a.Rule]
+		state.firing = a.Status == StatusFiring
+		state.since = a.Since
+		state.lastSent = a.Time
+		state.pendingSince = time.Time{}
+	}
+	return alerts, errors.Join(errs...)
+}
+
+// Run evaluates the rules every cfg.Interval until ctx is cancelled,
+// logging alerts and notification failures to log.
+func (e *Engine) Run(ctx context.Context, log io.Writer) error {
+	ticker := time.NewTicker(time.Duration(e.cfg.Interval))
+	defer ticker.Stop()
+
+	for {
+		alerts, err := e.Evaluate(ctx)
+		now := time.Now().Format(time.RFC3339)
+		for _, a := range alerts {
+			fmt.Fprintf(log, "%s %s\n", now, a.Summary())
+		}
+		if err != nil {
+			fmt.Fprintf(log, "%s notification failed: %v\n", now, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+)
+
+// recorder is a Notifier that keeps the alerts it receives. While fail is
+// set it rejects them instead.
+type recorder struct {
+	alerts []Alert
+	fail   bool
+}
+
+func (r *recorder) Name() string { return "recorder" }
+
+func (r *recorder) Notify(ctx context.Context, a Alert) error {
+	if r.fail {
+		return fmt.Errorf("unreachable")
+	}
+	r.alerts = append(r.alerts, a)
+	return nil
+}
+
+// sequence returns a Collector that hands out snapshots in order.
+func sequence(snapshots ...*Snapshot) Collector {
+	i := 0
+	return func(ctx context.Context) *Snapshot {
+		s := snapshots[i]
+		i++
+		return s
+	}
+}
+
+const testValoper = "monovaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq5nfrmp"
+
+var t0 = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func nodeAt(minute int, height int64, peers int) *Snapshot {
+	return &Snapshot{
+		Time: t0.Add(time.Duration(minute) * time.Minute),
+		Node: &core.NodeStatus{LatestHeight: height, PeersCount: peers},
+	}
+}
+
+func validatorAt(minute int, jailed bool, missed int64) *Snapshot {
+	s := nodeAt(minute, int64(minute+1), 10)
+	s.Validator = &core.ValidatorHealth{
+		ValoperAddr:    testValoper,
+		Jailed:         jailed,
+		HasSigningInfo: true,
+		MissedBlocks:   missed,
+	}
+	return s
+}
+
+func newTestEngine(t *testing.T, cfg *Config, snapshots ...*Snapshot) (*Engine, *recorder) {
+	t.Helper()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	rec := &recorder{}
+	return NewEngine(cfg, sequence(snapshots...), []Notifier{rec}), rec
+}
+
+// run evaluates n times and returns the status of every alert sent, such as
+// "min_peers:firing".
+func run(t *testing.T, e *Engine, rec *recorder, n int) []string {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if _, err := e.Evaluate(context.Background()); err != nil {
+			t.Fatalf("Evaluate() error = %v", err)
+		}
+	}
+	var got []string
+	for _, a := range rec.alerts {
+		got = append(got, a.Rule+":"+a.Status)
+	}
+	return got
+}
+
+func TestEngine_Transitions(t *testing.T) {
+	cfg := &Config{Network: "localnet", Rules: []RuleConfig{
+		{Type: RuleNodeDown},
+		{Type: RuleMinPeers, Threshold: 3},
+	}}
+	down := &Snapshot{Time: t0.Add(time.Minute), NodeErr: fmt.Errorf("connection refused")}
+	e, rec := newTestEngine(t, cfg,
+		nodeAt(0, 1, 5),
+		nodeAt(1, 2, 2), // peers drop
+		nodeAt(2, 3, 2), // still low, no repeat
+		down,            // node down; peers unknown, stays firing
+		nodeAt(4, 4, 5), // node back with peers
+	)
+
+	got := run(t, e, rec, 5)
+	want := []string{"min_peers:firing", "node_down:firing", "node_down:resolved", "min_peers:resolved"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("alerts = %v, want %v", got, want)
+	}
+	if rec.alerts[0].Severity != SeverityWarning || rec.alerts[0].Network != "Localnet" ||
+		!strings.Contains(rec.alerts[0].Message, "only 2 peers") {
+		t.Errorf("unexpected alert %+v", rec.alerts[0])
+	}
+	if !rec.alerts[3].Since.Equal(t0.Add(time.Minute)) {
+		t.Errorf("resolved alert Since = %v, want when it started firing", rec.alerts[3].Since)
+	}
+}
+
+func TestEngine_RepeatInterval(t *testing.T) {
+	cfg := &Config{Network: "localnet", RepeatInterval: Duration(2 * time.Minute),
+		Rules: []RuleConfig{{Type: RuleMinPeers}}}
+	e, rec := newTestEngine(t, cfg, nodeAt(0, 1, 0), nodeAt(1, 2, 0), nodeAt(2, 3, 0), nodeAt(3, 4, 0))
+
+	got := run(t, e, rec, 4)
+	if len(got) != 2 || got[0] != "min_peers:firing" || got[1] != "min_peers:firing" {
+		t.Errorf("alerts = %v, want firing at minutes 0 and 2", got)
+	}
+}
+
+func TestEngine_RetriesUndelivered(t *testing.T) {
+	cfg := &Config{Network: "localnet", Rules: []RuleConfig{{Type: RuleMinPeers, Threshold: 3}}}
+	e, rec := newTestEngine(t, cfg,
+		nodeAt(0, 1, 2), // fires, notifier down
+		nodeAt(1, 2, 2), // retried and delivered
+		nodeAt(2, 3, 5), // resolves, notifier down
+		nodeAt(3, 4, 5), // retried and delivered
+	)
+
+	for i, fail := range []bool{true, false, true, false} {
+		rec.fail = fail
+		_, err := e.Evaluate(context.Background())
+		if (err != nil) != fail {
+			t.Fatalf("Evaluate() #%d error = %v", i, err)
+		}
+	}
+	var got []string
+	for _, a := range rec.alerts {
+		got = append(got, a.Rule+":"+a.Status)
+	}
+	if strings.Join(got, " ") != "min_peers:firing min_peers:resolved" {
+		t.Errorf("alerts = %v", got)
+	}
+	if !rec.alerts[0].Since.Equal(t0) || !rec.alerts[1].Since.Equal(t0) {
+		t.Errorf("Since = %v, %v, want when the rule first fired", rec.alerts[0].Since, rec.alerts[1].Since)
+	}
+}
+
+func TestEngine_HeightStalled(t *testing.T) {
+	cfg := &Config{Network: "localnet", Rules: []RuleConfig{{Type: RuleHeightStalled, For: Duration(3 * time.Minute)}}}
+	e, rec := newTestEngine(t, cfg,
+		nodeAt(0, 100, 5),
+		nodeAt(2, 100, 5),
+		nodeAt(3, 100, 5), // stalled for 3m
+		nodeAt(4, 101, 5),
+	)
+
+	got := run(t, e, rec, 4)
+	if strings.Join(got, " ") != "height_stalled:firing height_stalled:resolved" {
+		t.Errorf("alerts = %v", got)
+	}
+	if !strings.Contains(rec.alerts[0].Message, "height 100 has not changed for 3m0s") {
+		t.Errorf("message = %q", rec.alerts[0].Message)
+	}
+}
+
+func TestEngine_Validator(t *testing.T) {
+	cfg := &Config{Network: "localnet", Validator: testValoper, Rules: []RuleConfig{
+		{Type: RuleValidatorJailed},
+		{Type: RuleMissedBlocks, Threshold: 10, For: Duration(5 * time.Minute)},
+	}}
+	unreachable := nodeAt(3, 4, 10)
+	unreachable.ValidatorErr = fmt.Errorf("timeout")
+	e, rec := newTestEngine(t, cfg,
+		validatorAt(0, false, 0),
+		validatorAt(2, false, 8),
+		validatorAt(4, false, 15), // +15 within 5m
+		unreachable,               // no data, nothing changes
+		validatorAt(6, true, 16),  // jailed; baseline now minute 2 (8), +8
+		validatorAt(10, false, 16),
+	)
+
+	got := run(t, e, rec, 6)
+	want := []string{"missed_blocks:firing", "validator_jailed:firing", "missed_blocks:resolved", "validator_jailed:resolved"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("alerts = %v, want %v", got, want)
+	}
+}
+
+func TestEngine_CriticalDrift(t *testing.T) {
+	cfg := &Config{Network: "localnet", Rules: []RuleConfig{{Type: RuleCriticalDrift}}}
+	s1 := nodeAt(0, 1, 5)
+	s1.Drifts = []core.DriftResult{{Field: "timeout_commit", File: "config.toml", Severity: core.SeverityWarning}}
+	s2 := nodeAt(1, 2, 5)
+	s2.Drifts = []core.DriftResult{{Field: "chain-id", File: "client.toml", Expected: "a", Actual: "b", Severity: core.SeverityCritical}}
+	e, rec := newTestEngine(t, cfg, s1, s2)
+
+	got := run(t, e, rec, 2)
+	if len(got) != 1 || got[0] != "critical_drift:firing" || !strings.Contains(rec.alerts[0].Message, "chain-id") {
+		t.Errorf("alerts = %v", rec.alerts)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{"no network", Config{Rules: []RuleConfig{{Type: RuleNodeDown}}}, "network is required"},
+		{"no rules", Config{Network: "localnet"}, "no rules"},
+		{"unknown rule", Config{Network: "localnet", Rules: []RuleConfig{{Type: "disk_full"}}}, "unknown rule type"},
+		{"validator rule without validator", Config{Network: "localnet", Rules: []RuleConfig{{Type: RuleValidatorJailed}}}, "requires a validator"},
+		{"duplicate name", Config{Network: "localnet", Rules: []RuleConfig{{Type: RuleMinPeers}, {Type: RuleMinPeers}}}, "duplicate name"},
+		{"bad severity", Config{Network: "localnet", Rules: []RuleConfig{{Type: RuleNodeDown, Severity: "page"}}}, "severity"},
+		{"webhook without url", Config{Network: "localnet", Rules: []RuleConfig{{Type: RuleNodeDown}},
+			Notifiers: []NotifierConfig{{Type: "webhook"}}}, "url is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	cfg := Config{Network: "sprintnet", Rules: []RuleConfig{{Type: RuleMinPeers}, {Type: RuleMinPeers, Name: "few_peers", Threshold: 1}}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if cfg.Interval != Duration(DefaultInterval) || cfg.Rules[0].Threshold != 3 || cfg.Rules[0].Name != RuleMinPeers ||
+		cfg.Rules[1].Threshold != 1 || cfg.Rules[0].Severity != SeverityWarning {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+}
@@ -0,0 +1,219 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// notifyTimeout bounds each webhook request and command hook.
+const notifyTimeout = 30 * time.Second
+
+// Notifier delivers alerts to one sink.
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, a Alert) error
+}
+
+// NewNotifiers creates the notifiers of a validated config.
+func NewNotifiers(cfgs []NotifierConfig) []Notifier {
+	var notifiers []Notifier
+	for _, c := range cfgs {
+		switch c.Type {
+		case "webhook":
+			notifiers = append(notifiers, &WebhookNotifier{URL: c.URL, Headers: c.Headers})
+		case "smtp":
+			notifiers = append(notifiers, &SMTPNotifier{
+				Host:     c.Host,
+				Port:     c.Port,
+				Username: c.Username,
+				Password: os.Getenv(c.PasswordEnv),
+				From:     c.From,
+				To:       c.To,
+			})
+		case "command":
+			notifiers = append(notifiers, &CommandNotifier{Command: c.Command, Args: c.Args})
+		}
+	}
+	return notifiers
+}
+
+// WebhookNotifier POSTs each alert as JSON. The body also carries a "text"
+// field with the summary line, which chat services such as Slack and
+// Mattermost display as the message.
+type WebhookNotifier struct {
+	URL     string
+	Headers map[string]string
+}
+
+// Name implements Notifier.
+func (n *WebhookNotifier) Name() string { return "webhook " + n.URL }
+
+// Notify implements Notifier.
+func (n *WebhookNotifier) Notify(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(struct {
+		Alert
+		Text string `json:"text"`
+	}{a, a.Summary()})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range n.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// SMTPNotifier emails each alert. It authenticates with PLAIN auth when a
+// username is set.
+type SMTPNotifier struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// Name implements Notifier.
+func (n *SMTPNotifier) Name() string { return fmt.Sprintf("smtp %s:%d", n.Host, n.Port) }
+
+// Notify implements Notifier. Like smtp.SendMail it uses STARTTLS when the
+// server offers it, but the whole exchange is bounded by ctx and
+// notifyTimeout.
+func (n *SMTPNotifier) Notify(ctx context.Context, a Alert) error {
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(n.Host, strconv.Itoa(n.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	// Abort a stalled exchange as soon as ctx is cancelled
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	c, err := smtp.NewClient(conn, n.Host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: n.Host}); err != nil {
+			return err
+		}
+	}
+	if n.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.Username, n.Password, n.Host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(n.From); err != nil {
+		return err
+	}
+	for _, to := range n.To {
+		if err := c.Rcpt(to); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(n.message(a)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func (n *SMTPNotifier) message(a Alert) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", a.Summary())
+	fmt.Fprintf(&b, "Date: %s\r\n", a.Time.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&b, "Rule:     %s (%s)\r\n", a.Rule, a.Type)
+	fmt.Fprintf(&b, "Status:   %s\r\n", a.Status)
+	fmt.Fprintf(&b, "Severity: %s\r\n", a.Severity)
+	fmt.Fprintf(&b, "Network:  %s\r\n", a.Network)
+	if a.Validator != "" {
+		fmt.Fprintf(&b, "Validator: %s\r\n", a.Validator)
+	}
+	fmt.Fprintf(&b, "Since:    %s\r\n", a.Since.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "\r\n%s\r\n", a.Message)
+	return []byte(b.String())
+}
+
+// CommandNotifier runs a local command for each alert. The alert is written
+// to its stdin as JSON and its fields are also set as MONO_ALERT_*
+// environment variables.
+type CommandNotifier struct {
+	Command string
+	Args    []string
+}
+
+// Name implements Notifier.
+func (n *CommandNotifier) Name() string { return "command " + n.Command }
+
+// Notify implements Notifier.
+func (n *CommandNotifier) Notify(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, n.Command, n.Args...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"MONO_ALERT_RULE="+a.Rule,
+		"MONO_ALERT_TYPE="+a.Type,
+		"MONO_ALERT_SEVERITY="+a.Severity,
+		"MONO_ALERT_STATUS="+a.Status,
+		"MONO_ALERT_MESSAGE="+a.Message,
+		"MONO_ALERT_NETWORK="+a.Network,
+		"MONO_ALERT_VALIDATOR="+a.Validator,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
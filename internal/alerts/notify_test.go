@@ -0,0 +1,119 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testAlert = Alert{
+	Rule:     "min_peers",
+	Type:     RuleMinPeers,
+	Severity: SeverityWarning,
+	Status:   StatusFiring,
+	Message:  "only 1 peers connected (minimum 3)",
+	Network:  "Localnet",
+	Time:     time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var body map[string]interface{}
+	var token string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token = r.Header.Get("Authorization")
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer srv.Close()
+
+	n := &WebhookNotifier{URL: srv.URL, Headers: map[string]string{"Authorization": "Bearer x"}}
+	if err := n.Notify(context.Background(), testAlert); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	if token != "Bearer x" {
+		t.Errorf("Authorization = %q", token)
+	}
+	if body["rule"] != "min_peers" || body["status"] != "firing" ||
+		body["text"] != "[FIRING] warning min_peers on Localnet: only 1 peers connected (minimum 3)" {
+		t.Errorf("body = %v", body)
+	}
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	if err := (&WebhookNotifier{URL: failing.URL}).Notify(context.Background(), testAlert); err == nil {
+		t.Error("expected an error for a 500 response")
+	}
+}
+
+func TestCommandNotifier(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	n := &CommandNotifier{Command: "sh", Args: []string{"-c", `{ echo "$MONO_ALERT_RULE $MONO_ALERT_STATUS"; cat; } > "$0"`, out}}
+	if err := n.Notify(context.Background(), testAlert); err != nil {
+		t.Fatalf("Notify() error = %v", err)
+	}
+	data, _ := os.ReadFile(out)
+	if !strings.HasPrefix(string(data), "min_peers firing\n{") || !strings.Contains(string(data), `"severity":"warning"`) {
+		t.Errorf("command saw %q", data)
+	}
+
+	failing := &CommandNotifier{Command: "sh", Args: []string{"-c", "echo boom; exit 3"}}
+	if err := failing.Notify(context.Background(), testAlert); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Notify() error = %v, want the command output", err)
+	}
+}
+
+func TestSMTPNotifier_Cancel(t *testing.T) {
+	// A server that accepts connections but never greets
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	addr := l.Addr().(*net.TCPAddr)
+	n := &SMTPNotifier{Host: "127.0.0.1", Port: addr.Port, From: "alerts@example.com", To: []string{"ops@example.com"}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := n.Notify(ctx, testAlert); err == nil {
+		t.Fatal("Notify() should fail when the server stalls")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Notify() took %v, want it to stop with ctx", elapsed)
+	}
+}
+
+func TestSMTPNotifier_Message(t *testing.T) {
+	n := &SMTPNotifier{From: "alerts@example.com", To: []string{"ops@example.com", "oncall@example.com"}}
+	msg := string(n.message(testAlert))
+	for _, want := range []string{
+		"To: ops@example.com, oncall@example.com\r\n",
+		"Subject: [FIRING] warning min_peers on Localnet: only 1 peers connected (minimum 3)\r\n",
+		"\r\n\r\nRule:     min_peers (min_peers)\r\n",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("message missing %q:\n%s", want, msg)
+		}
+	}
+}
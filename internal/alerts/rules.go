@@ -0,0 +1,180 @@
+package alerts
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+)
+
+// Rule types.
+const (
+	RuleNodeDown        = "node_down"        // Comet RPC unreachable
+	RuleHeightStalled   = "height_stalled"   // height unchanged for For
+	RuleMinPeers        = "min_peers"        // fewer than Threshold peers
+	RuleValidatorJailed = "validator_jailed" // validator is jailed
+	RuleMissedBlocks    = "missed_blocks"    // missed blocks grew by more than Threshold within For
+	RuleCriticalDrift   = "critical_drift"   // DetectDrift found a critical drift
+)
+
+// Severities.
+const (
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// ruleDefaults holds the default severity, threshold and duration of each
+// rule type.
+var ruleDefaults = map[string]RuleConfig{
+	RuleNodeDown:        {Severity: SeverityCritical},
+	RuleHeightStalled:   {Severity: SeverityCritical, For: Duration(5 * time.Minute)},
+	RuleMinPeers:        {Severity: SeverityWarning, Threshold: 3},
+	RuleValidatorJailed: {Severity: SeverityCritical},
+	RuleMissedBlocks:    {Severity: SeverityWarning, Threshold: 50, For: Duration(10 * time.Minute)},
+	RuleCriticalDrift:   {Severity: SeverityCritical},
+}
+
+// validate fills in defaults and checks the rule can be evaluated.
+func (r *RuleConfig) validate(hasValidator bool) error {
+	defaults, ok := ruleDefaults[r.Type]
+	if !ok {
+		return fmt.Errorf("unknown rule type")
+	}
+	if r.Name == "" {
+		r.Name = r.Type
+	}
+	if r.Severity == "" {
+		r.Severity = defaults.Severity
+	}
+	if r.Severity != SeverityWarning && r.Severity != SeverityCritical {
+		return fmt.Errorf("severity must be %s or %s", SeverityWarning, SeverityCritical)
+	}
+	if r.Threshold == 0 {
+		r.Threshold = defaults.Threshold
+	}
+	if r.For == 0 {
+		r.For = defaults.For
+	}
+	if (r.Type == RuleValidatorJailed || r.Type == RuleMissedBlocks) && !hasValidator {
+		return fmt.Errorf("requires a validator address in the config")
+	}
+	return nil
+}
+
+// Snapshot is the health data one evaluation looks at.
+type Snapshot struct {
+	Time time.Time
+	// Node is nil if the node could not be reached
+	Node    *core.NodeStatus
+	NodeErr error
+	// Validator is nil if no validator is configured or it could not be
+	// fetched
+	Validator    *core.ValidatorHealth
+	ValidatorErr error
+	// Drifts is only collected if a critical_drift rule is configured
+	Drifts   []core.DriftResult
+	DriftErr error
+}
+
+// missedSample is the missed blocks counter at one point in time.
+type missedSample struct {
+	time   time.Time
+	missed int64
+}
+
+// rule evaluates one RuleConfig over successive snapshots. Some rules keep
+// state between evaluations.
+type rule struct {
+	cfg RuleConfig
+
+	// height_stalled
+	lastHeight int64
+	lastChange time.Time
+
+	// missed_blocks
+	history []missedSample
+}
+
+// check reports whether the rule's condition holds in s, with a message
+// describing it. known is false if s lacks the data the rule needs, in
+// which case the alert keeps its current state.
+func (r *rule) check(s *Snapshot) (firing bool, message string, known bool) {
+	switch r.cfg.Type {
+	case RuleNodeDown:
+		if s.Node == nil {
+			return true, fmt.Sprintf("node is unreachable: %v", s.NodeErr), true
+		}
+		return false, "node is reachable", true
+
+	case RuleHeightStalled:
+		if s.Node == nil {
+			return false, "", false
+		}
+		if r.lastChange.IsZero() || s.Node.LatestHeight != r.lastHeight {
+			r.lastHeight = s.Node.LatestHeight
+			r.lastChange = s.Time
+		}
+		stalled := s.Time.Sub(r.lastChange)
+		if stalled >= time.Duration(r.cfg.For) {
+			return true, fmt.Sprintf("height %d has not changed for %s", r.lastHeight, stalled.Round(time.Second)), true
+		}
+		return false, fmt.Sprintf("height is %d", s.Node.LatestHeight), true
+
+	case RuleMinPeers:
+		if s.Node == nil {
+			return false, "", false
+		}
+		if int64(s.Node.PeersCount) < r.cfg.Threshold {
+			return true, fmt.Sprintf("only %d peers connected (minimum %d)", s.Node.PeersCount, r.cfg.Threshold), true
+		}
+		return false, fmt.Sprintf("%d peers connected", s.Node.PeersCount), true
+
+	case RuleValidatorJailed:
+		if s.Validator == nil {
+			return false, "", false
+		}
+		if s.Validator.Jailed {
+			msg := fmt.Sprintf("validator %s is jailed", s.Validator.ValoperAddr)
+			if s.Validator.Tombstoned {
+				msg += " and tombstoned"
+			} else if s.Validator.JailedUntil.After(s.Time) {
+				msg += fmt.Sprintf(" until %s", s.Validator.JailedUntil.UTC().Format(time.RFC3339))
+			}
+			return true, msg, true
+		}
+		return false, fmt.Sprintf("validator %s is not jailed", s.Validator.ValoperAddr), true
+
+	case RuleMissedBlocks:
+		if s.Validator == nil || !s.Validator.HasSigningInfo {
+			return false, "", false
+		}
+		// Keep the samples within the window; the oldest is the baseline
+		window := time.Duration(r.cfg.For)
+		kept := r.history[:0]
+		for _, h := range r.history {
+			if s.Time.Sub(h.time) <= window {
+				kept = append(kept, h)
+			}
+		}
+		r.history = append(kept, missedSample{time: s.Time, missed: s.Validator.MissedBlocks})
+		increase := s.Validator.MissedBlocks - r.history[0].missed
+		if increase > r.cfg.Threshold {
+			return true, fmt.Sprintf("validator missed %d blocks in the last %s (%d in the signing window)",
+				increase, window, s.Validator.MissedBlocks), true
+		}
+		return false, fmt.Sprintf("%d missed blocks in the signing window", s.Validator.MissedBlocks), true
+
+	case RuleCriticalDrift:
+		if s.DriftErr != nil {
+			return false, "", false
+		}
+		for _, d := range s.Drifts {
+			if d.Severity == core.SeverityCritical {
+				return true, fmt.Sprintf("critical config drift: %s in %s is %q, expected %q",
+					d.Field, d.File, d.Actual, d.Expected), true
+			}
+		}
+		return false, "no critical config drift", true
+	}
+	return false, "", false
+}
@@ -0,0 +1,263 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/monolythium/mono-commander/internal/walletgen"
+)
+
+// AddressBookEntry is a labeled account or validator operator address.
+type AddressBookEntry struct {
+	Label string `json:"label"`
+	// Address is the checked mono1... or monovaloper1... form of the
+	// address it was added with
+	Address string `json:"address"`
+	Note    string `json:"note,omitempty"`
+}
+
+// IsValidator reports whether the entry is a validator operator address.
+func (e AddressBookEntry) IsValidator() bool {
+	return strings.HasPrefix(e.Address, Bech32PrefixValAddr+"1")
+}
+
+// EVMAddress returns the EIP-55 EVM form of the entry's address.
+func (e AddressBookEntry) EVMAddress() string {
+	evm, err := walletgen.Bech32ToEVMAddress(e.Address)
+	if err != nil {
+		return ""
+	}
+	return common.HexToAddress(evm).Hex()
+}
+
+// AddressBook is the local address book file (JSON).
+type AddressBook struct {
+	Entries []AddressBookEntry `json:"entries"`
+}
+
+// labelPattern restricts labels to characters that are safe in shells and
+// can never be mistaken for an address.
+var labelPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]{0,63}$`)
+
+// DefaultAddressBookPath returns ~/.mono-commander/addrbook.json.
+func DefaultAddressBookPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".mono-commander", "addrbook.json"), nil
+}
+
+// LoadAddressBook reads an address book. A missing file is an empty book.
+func LoadAddressBook(path string) (*AddressBook, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &AddressBook{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read address book: %w", err)
+	}
+	var book AddressBook
+	if err := json.Unmarshal(data, &book); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &book, nil
+}
+
+// Save writes the address book to path, sorted by label.
+func (b *AddressBook) Save(path string) error {
+	sort.Slice(b.Entries, func(i, j int) bool {
+		return strings.ToLower(b.Entries[i].Label) < strings.ToLower(b.Entries[j].Label)
+	})
+	if b.Entries == nil {
+		b.Entries = []AddressBookEntry{}
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write address book: %w", err)
+	}
+	return nil
+}
+
+// Add labels an address. addr may be bech32 or EVM (0x...); with validator
+// an EVM address is stored as the monovaloper1... address of the same key.
+func (b *AddressBook) Add(label, addr, note string, validator bool) (AddressBookEntry, error) {
+	if !labelPattern.MatchString(label) {
+		return AddressBookEntry{}, fmt.Errorf("invalid label %q: use up to 64 letters, digits, '.', '_' or '-', starting with a letter", label)
+	}
+	if looksLikeAddress(label) {
+		return AddressBookEntry{}, fmt.Errorf("invalid label %q: labels must not look like addresses", label)
+	}
+	if _, ok := b.Lookup(label); ok {
+		return AddressBookEntry{}, fmt.Errorf("label %q already exists (remove it first)", label)
+	}
+
+	var err error
+	if validator || strings.HasPrefix(strings.ToLower(addr), Bech32PrefixValAddr+"1") {
+		addr, err = NormalizeValoperAddress(addr)
+	} else {
+		addr, err = NormalizeAccountAddress(addr)
+	}
+	if err != nil {
+		return AddressBookEntry{}, err
+	}
+
+	e := AddressBookEntry{Label: label, Address: addr, Note: note}
+	b.Entries = append(b.Entries, e)
+	return e, nil
+}
+
+// Remove deletes and returns the entry labeled label.
+func (b *AddressBook) Remove(label string) (AddressBookEntry, error) {
+	for i, e := range b.Entries {
+		if strings.EqualFold(e.Label, label) {
+			b.Entries = append(b.Entries[:i], b.Entries[i+1:]...)
+			return e, nil
+		}
+	}
+	return AddressBookEntry{}, fmt.Errorf("label %q is not in the address book", label)
+}
+
+// Lookup returns the entry labeled label. Labels are case-insensitive.
+func (b *AddressBook) Lookup(label string) (AddressBookEntry, bool) {
+	if b == nil {
+		return AddressBookEntry{}, false
+	}
+	for _, e := range b.Entries {
+		if strings.EqualFold(e.Label, label) {
+			return e, true
+		}
+	}
+	return AddressBookEntry{}, false
+}
+
+// ResolveAccountAddress returns the mono1... address s names: an account
+// address in bech32 or EVM form, or the label of an account entry.
+func (b *AddressBook) ResolveAccountAddress(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if looksLikeAddress(s) {
+		return NormalizeAccountAddress(s)
+	}
+	e, err := b.resolveLabel(s)
+	if err != nil {
+		return "", err
+	}
+	if e.IsValidator() {
+		return "", fmt.Errorf("label %q is a validator operator address (%s), not an account", e.Label, e.Address)
+	}
+	return e.Address, nil
+}
+
+// ResolveValoperAddress returns the monovaloper1... address s names: an
+// operator address in bech32 or EVM form, or the label of a validator entry.
+func (b *AddressBook) ResolveValoperAddress(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if looksLikeAddress(s) {
+		return NormalizeValoperAddress(s)
+	}
+	e, err := b.resolveLabel(s)
+	if err != nil {
+		return "", err
+	}
+	if !e.IsValidator() {
+		return "", fmt.Errorf("label %q is an account address (%s), not a validator operator", e.Label, e.Address)
+	}
+	return e.Address, nil
+}
+
+func (b *AddressBook) resolveLabel(label string) (AddressBookEntry, error) {
+	if label == "" {
+		return AddressBookEntry{}, fmt.Errorf("address is required")
+	}
+	e, ok := b.Lookup(label)
+	if !ok {
+		return AddressBookEntry{}, fmt.Errorf("%q is neither an address nor an address book label (see monoctl addrbook list)", label)
+	}
+	return e, nil
+}
+
+// NormalizeAccountAddress converts an account address in bech32 or EVM
+// form to a checked, lowercase mono1... address.
+func NormalizeAccountAddress(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if isEVMAddress(addr) {
+		return evmToBech32(addr, Bech32PrefixAccAddr)
+	}
+	if strings.HasPrefix(strings.ToLower(addr), Bech32PrefixValAddr+"1") {
+		return "", fmt.Errorf("%s is a validator operator address, not an account address", addr)
+	}
+	addr = strings.ToLower(addr)
+	if err := ValidateAddress(addr); err != nil {
+		return "", err
+	}
+	if err := verifyBech32Checksum(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// NormalizeValoperAddress converts a validator operator address in bech32
+// or EVM form to a checked, lowercase monovaloper1... address.
+func NormalizeValoperAddress(addr string) (string, error) {
+	addr = strings.TrimSpace(addr)
+	if isEVMAddress(addr) {
+		return evmToBech32(addr, Bech32PrefixValAddr)
+	}
+	addr = strings.ToLower(addr)
+	if err := ValidateValoperAddress(addr); err != nil {
+		return "", err
+	}
+	if err := verifyBech32Checksum(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// looksLikeAddress reports whether s is meant as an address rather than a
+// label.
+func looksLikeAddress(s string) bool {
+	s = strings.ToLower(s)
+	return strings.HasPrefix(s, "0x") || strings.HasPrefix(s, Bech32PrefixAccAddr+"1") ||
+		strings.HasPrefix(s, Bech32PrefixValAddr+"1")
+}
+
+func isEVMAddress(s string) bool {
+	return strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")
+}
+
+// evmToBech32 converts an EVM address, checking its EIP-55 checksum when it
+// is mixed-case.
+func evmToBech32(addr, prefix string) (string, error) {
+	if !common.IsHexAddress(addr) {
+		return "", fmt.Errorf("invalid EVM address %s: must be 0x followed by 40 hex characters", addr)
+	}
+	hexPart := addr[2:]
+	if hexPart != strings.ToLower(hexPart) && hexPart != strings.ToUpper(hexPart) &&
+		common.HexToAddress(addr).Hex() != "0x"+hexPart {
+		return "", fmt.Errorf("invalid EVM address %s: checksum mismatch (typo?)", addr)
+	}
+	return walletgen.EVMToBech32Address(strings.ToLower(hexPart), prefix)
+}
+
+// verifyBech32Checksum catches typos the address patterns let through.
+func verifyBech32Checksum(addr string) error {
+	if _, data, err := walletgen.Bech32Decode(addr); err != nil {
+		return fmt.Errorf("invalid address %s: %v (typo?)", addr, err)
+	} else if len(data) != 20 {
+		return fmt.Errorf("invalid address %s: expected 20 bytes, got %d", addr, len(data))
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The account and operator addresses of the all-"abandon" test mnemonic.
+const (
+	testBookEVM     = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+	testBookAccount = "mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u"
+	testBookValoper = "monovaloper1npvwllfr9dqr8erajqqr6s0vxnk2ak55ednplc"
+)
+
+func TestNormalizeAccountAddress(t *testing.T) {
+	valid := []string{
+		testBookAccount,
+		strings.ToUpper(testBookAccount),
+		" " + testBookAccount + "\n",
+		testBookEVM,
+		strings.ToLower(testBookEVM),
+	}
+	for _, in := range valid {
+		got, err := NormalizeAccountAddress(in)
+		if err != nil || got != testBookAccount {
+			t.Errorf("NormalizeAccountAddress(%q) = %s, %v", in, got, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4v", // last character changed
+		"mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4",  // one character short
+		"0x9858EfFD232B4033E47d90003D41EC34EcaEda95",  // EIP-55 checksum mismatch
+		"0x9858effd232b4033e47d90003d41ec34ecaeda9",   // 39 hex characters
+		testBookValoper,
+	}
+	for _, in := range invalid {
+		if got, err := NormalizeAccountAddress(in); err == nil {
+			t.Errorf("NormalizeAccountAddress(%q) = %s, expected an error", in, got)
+		}
+	}
+}
+
+func TestNormalizeValoperAddress(t *testing.T) {
+	for _, in := range []string{testBookValoper, testBookEVM} {
+		got, err := NormalizeValoperAddress(in)
+		if err != nil || got != testBookValoper {
+			t.Errorf("NormalizeValoperAddress(%q) = %s, %v", in, got, err)
+		}
+	}
+	for _, in := range []string{testBookAccount, "monovaloper1npvwllfr9dqr8erajqqr6s0vxnk2ak55ednpla"} {
+		if _, err := NormalizeValoperAddress(in); err == nil {
+			t.Errorf("NormalizeValoperAddress(%q) expected an error", in)
+		}
+	}
+}
+
+func TestAddressBook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "addrbook.json")
+
+	book, err := LoadAddressBook(path)
+	if err != nil || len(book.Entries) != 0 {
+		t.Fatalf("LoadAddressBook(missing) = %v, %v", book, err)
+	}
+
+	if _, err := book.Add("treasury", testBookEVM, "ops", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := book.Add("val-eu", testBookEVM, "", true); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct{ label, addr string }{
+		{"Treasury", testBookAccount},  // duplicate label
+		{"mono1abc", testBookAccount},  // looks like an address
+		{"0xfaucet", testBookAccount},  // looks like an address
+		{"has space", testBookAccount}, // invalid character
+		{"faucet", "mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4v"},
+	} {
+		if _, err := book.Add(tt.label, tt.addr, "", false); err == nil {
+			t.Errorf("Add(%q, %q) expected an error", tt.label, tt.addr)
+		}
+	}
+
+	if err := book.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	book, err = LoadAddressBook(path)
+	if err != nil || len(book.Entries) != 2 {
+		t.Fatalf("LoadAddressBook() = %v, %v", book, err)
+	}
+	e, ok := book.Lookup("TREASURY")
+	if !ok || e.Address != testBookAccount || e.Note != "ops" || e.IsValidator() || e.EVMAddress() != testBookEVM {
+		t.Errorf("Lookup(TREASURY) = %+v, %v", e, ok)
+	}
+
+	resolve := []struct {
+		in        string
+		validator bool
+		want      string
+	}{
+		{"treasury", false, testBookAccount},
+		{testBookEVM, false, testBookAccount},
+		{testBookAccount, false, testBookAccount},
+		{"val-eu", true, testBookValoper},
+		{testBookEVM, true, testBookValoper},
+	}
+	for _, tt := range resolve {
+		var got string
+		if tt.validator {
+			got, err = book.ResolveValoperAddress(tt.in)
+		} else {
+			got, err = book.ResolveAccountAddress(tt.in)
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("resolve(%q, validator=%v) = %s, %v, want %s", tt.in, tt.validator, got, err, tt.want)
+		}
+	}
+	if _, err := book.ResolveAccountAddress("val-eu"); err == nil {
+		t.Error("expected an error resolving a validator label as an account")
+	}
+	if _, err := book.ResolveValoperAddress("treasury"); err == nil {
+		t.Error("expected an error resolving an account label as a validator")
+	}
+	if _, err := book.ResolveAccountAddress("unknown"); err == nil || !strings.Contains(err.Error(), "label") {
+		t.Errorf("ResolveAccountAddress(unknown) error = %v", err)
+	}
+
+	// A nil book still resolves addresses
+	var empty *AddressBook
+	if got, err := empty.ResolveAccountAddress(testBookEVM); err != nil || got != testBookAccount {
+		t.Errorf("nil book ResolveAccountAddress() = %s, %v", got, err)
+	}
+
+	if _, err := book.Remove("Val-EU"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := book.Remove("val-eu"); err == nil {
+		t.Error("expected an error removing a missing label")
+	}
+}
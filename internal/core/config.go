@@ -14,7 +14,8 @@ import (
 type ConfigPatch struct {
 	Seeds           string
 	PersistentPeers string
-	PEX             *bool // nil = don't change, true/false = set value
+	PEX             *bool            // nil = don't change, true/false = set value
+	StateSync       *StateSyncConfig // nil = don't change [statesync] section
 }
 
 // CometConfig represents the structure of config.toml for safe TOML editing.
@@ -55,6 +56,11 @@ func GenerateBootstrapConfigPatch(bootstrapPeers []Peer) *ConfigPatch {
 // WriteConfigPatch writes a config patch reference file (for documentation/review).
 // Note: monoctl join now applies config directly; this function is kept for reference.
 func WriteConfigPatch(home string, patch *ConfigPatch, dryRun bool) (string, string, error) {
+	return WriteConfigPatchFS(OSFS{}, home, patch, dryRun)
+}
+
+// WriteConfigPatchFS is WriteConfigPatch on fsys.
+func WriteConfigPatchFS(fsys FS, home string, patch *ConfigPatch, dryRun bool) (string, string, error) {
 	configDir := filepath.Join(home, "config")
 	patchPath := filepath.Join(configDir, "config_patch.toml")
 
@@ -75,11 +81,11 @@ persistent_peers = "%s"
 		return patchPath, content, nil
 	}
 
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := fsys.MkdirAll(configDir, 0755); err != nil {
 		return "", "", fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	if err := os.WriteFile(patchPath, []byte(content), 0644); err != nil {
+	if err := fsys.WriteFile(patchPath, []byte(content), 0644); err != nil {
 		return "", "", fmt.Errorf("failed to write config patch: %w", err)
 	}
 
@@ -87,117 +93,69 @@ persistent_peers = "%s"
 }
 
 // ApplyConfigPatch safely modifies config.toml using TOML-aware parsing.
-// This preserves all existing config values and only updates the specified fields.
+// This preserves all existing config values and only updates the specified fields;
+// fields missing from config.toml are added.
 func ApplyConfigPatch(configPath string, patch *ConfigPatch, dryRun bool) error {
+	return ApplyConfigPatchFS(OSFS{}, configPath, patch, dryRun)
+}
+
+// ApplyConfigPatchFS is ApplyConfigPatch on fsys.
+func ApplyConfigPatchFS(fsys FS, configPath string, patch *ConfigPatch, dryRun bool) error {
 	if dryRun {
 		return nil
 	}
 
-	// Read existing config
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read config.toml: %w", err)
-	}
-
-	// Use line-by-line parsing to preserve comments and formatting
-	// This is safer than full TOML unmarshaling which loses comments
-	lines := strings.Split(string(data), "\n")
-	var result []string
-	inP2PSection := false
-	seedsReplaced := false
-	peersReplaced := false
-	pexReplaced := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Track which section we're in
-		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
-			inP2PSection = trimmed == "[p2p]"
-		}
-
-		// Replace seeds, persistent_peers, and pex in [p2p] section only
-		if inP2PSection {
-			if isConfigKey(trimmed, "seeds") {
-				// Preserve leading whitespace
-				leadingWS := getLeadingWhitespace(line)
-				result = append(result, fmt.Sprintf(`%sseeds = "%s"`, leadingWS, patch.Seeds))
-				seedsReplaced = true
-				continue
-			}
-			if isConfigKey(trimmed, "persistent_peers") {
-				leadingWS := getLeadingWhitespace(line)
-				result = append(result, fmt.Sprintf(`%spersistent_peers = "%s"`, leadingWS, patch.PersistentPeers))
-				peersReplaced = true
-				continue
-			}
-			if patch.PEX != nil && isConfigKey(trimmed, "pex") {
-				leadingWS := getLeadingWhitespace(line)
-				result = append(result, fmt.Sprintf(`%spex = %v`, leadingWS, *patch.PEX))
-				pexReplaced = true
-				continue
-			}
-		}
-
-		result = append(result, line)
-	}
-
-	// If we didn't find the keys to replace, we might need to add them
-	// This handles malformed configs but shouldn't happen normally
-	if !seedsReplaced {
-		return fmt.Errorf("could not find 'seeds' key in [p2p] section of config.toml")
+	values := []configValue{
+		{"p2p", "seeds", patch.Seeds},
+		{"p2p", "persistent_peers", patch.PersistentPeers},
 	}
-	if !peersReplaced {
-		return fmt.Errorf("could not find 'persistent_peers' key in [p2p] section of config.toml")
+	if patch.PEX != nil {
+		values = append(values, configValue{"p2p", "pex", *patch.PEX})
 	}
-	if patch.PEX != nil && !pexReplaced {
-		return fmt.Errorf("could not find 'pex' key in [p2p] section of config.toml")
+	if ss := patch.StateSync; ss != nil {
+		values = append(values,
+			configValue{"statesync", "enable", ss.Enable},
+			configValue{"statesync", "rpc_servers", strings.Join(ss.RPCServers, ",")},
+			configValue{"statesync", "trust_height", ss.TrustHeight},
+			configValue{"statesync", "trust_hash", ss.TrustHash},
+		)
 	}
-
-	// Write back
-	output := strings.Join(result, "\n")
-	if err := os.WriteFile(configPath, []byte(output), 0644); err != nil {
-		return fmt.Errorf("failed to write config.toml: %w", err)
+	err := setConfigValues(fsys, configPath, values)
+	if err != nil {
+		return fmt.Errorf("failed to update config.toml: %w", err)
 	}
 
 	return nil
 }
 
-// isConfigKey checks if a line is a TOML key assignment for the given key name.
-// This carefully avoids matching keys like "experimental_max_gossip_connections_to_persistent_peers"
-// when looking for "persistent_peers".
-func isConfigKey(line, key string) bool {
-	// Remove leading/trailing whitespace
-	trimmed := strings.TrimSpace(line)
-
-	// Skip comments
-	if strings.HasPrefix(trimmed, "#") {
-		return false
-	}
-
-	// Check for exact key match: must be "key =" or "key="
-	// The key must be at the start of the line (after trimming)
-	if !strings.HasPrefix(trimmed, key) {
-		return false
-	}
-
-	// Get what comes after the key name
-	rest := trimmed[len(key):]
-
-	// Must be followed by whitespace and/or equals sign
-	rest = strings.TrimLeft(rest, " \t")
-	return strings.HasPrefix(rest, "=")
+// configValue is a value to set in a TOML config file.
+type configValue struct {
+	table, key string
+	value      any
 }
 
-// getLeadingWhitespace returns the leading whitespace of a line.
-func getLeadingWhitespace(line string) string {
-	trimmed := strings.TrimLeft(line, " \t")
-	return line[:len(line)-len(trimmed)]
+// setConfigValues sets values in the TOML file at path. Comments and
+// formatting are preserved, and keys missing from the file are added to
+// their table.
+func setConfigValues(fsys FS, path string, values []configValue) error {
+	return updateTOMLFile(fsys, path, func(doc *TOMLDocument) error {
+		for _, v := range values {
+			if err := doc.Set(v.table, v.key, v.value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // ValidateConfigTOML validates that a config.toml file is valid TOML.
 func ValidateConfigTOML(configPath string) error {
-	data, err := os.ReadFile(configPath)
+	return ValidateConfigTOMLFS(OSFS{}, configPath)
+}
+
+// ValidateConfigTOMLFS is ValidateConfigTOML on fsys.
+func ValidateConfigTOMLFS(fsys FS, configPath string) error {
+	data, err := fsys.ReadFile(configPath)
 	if err != nil {
 		return fmt.Errorf("failed to read config: %w", err)
 	}
@@ -212,30 +170,33 @@ func ValidateConfigTOML(configPath string) error {
 	return nil
 }
 
-// GetConfigValue reads a specific value from config.toml using TOML parsing.
+// GetConfigValue reads a specific value from a TOML config file. The
+// section "" is the root table (e.g. chain-id in client.toml).
 func GetConfigValue(configPath, section, key string) (string, error) {
-	data, err := os.ReadFile(configPath)
+	return GetConfigValueFS(OSFS{}, configPath, section, key)
+}
+
+// GetConfigValueFS is GetConfigValue on fsys.
+func GetConfigValueFS(fsys FS, configPath, section, key string) (string, error) {
+	data, err := fsys.ReadFile(configPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read config: %w", err)
 	}
 
-	var parsed map[string]interface{}
-	decoder := toml.NewDecoder(bytes.NewReader(data))
-	if err := decoder.Decode(&parsed); err != nil {
-		return "", fmt.Errorf("invalid TOML: %w", err)
-	}
-
-	sectionData, ok := parsed[section].(map[string]interface{})
-	if !ok {
-		return "", fmt.Errorf("section [%s] not found", section)
+	doc, err := ParseTOMLDocument(data)
+	if err != nil {
+		return "", err
 	}
 
-	value, ok := sectionData[key]
+	value, ok := doc.GetString(section, key)
 	if !ok {
+		if section == "" {
+			return "", fmt.Errorf("key '%s' not found", key)
+		}
 		return "", fmt.Errorf("key '%s' not found in section [%s]", key, section)
 	}
 
-	return fmt.Sprintf("%v", value), nil
+	return value, nil
 }
 
 // SetClientChainID sets the chain-id in client.toml.
@@ -244,6 +205,11 @@ func GetConfigValue(configPath, section, key string) (string, error) {
 // The chain-id in client.toml is what monod uses to validate the genesis chain-id
 // during the ABCI handshake.
 func SetClientChainID(home string, chainID string, dryRun bool) error {
+	return SetClientChainIDFS(OSFS{}, home, chainID, dryRun)
+}
+
+// SetClientChainIDFS is SetClientChainID on fsys.
+func SetClientChainIDFS(fsys FS, home string, chainID string, dryRun bool) error {
 	if dryRun {
 		return nil
 	}
@@ -251,43 +217,12 @@ func SetClientChainID(home string, chainID string, dryRun bool) error {
 	clientPath := filepath.Join(home, "config", "client.toml")
 
 	// Check if file exists
-	if _, err := os.Stat(clientPath); os.IsNotExist(err) {
+	if _, err := fsys.Stat(clientPath); os.IsNotExist(err) {
 		return fmt.Errorf("client.toml not found at %s - run 'monod init' first", clientPath)
 	}
 
-	// Read existing config
-	data, err := os.ReadFile(clientPath)
-	if err != nil {
-		return fmt.Errorf("failed to read client.toml: %w", err)
-	}
-
-	// Use line-by-line parsing to preserve comments and formatting
-	lines := strings.Split(string(data), "\n")
-	var result []string
-	chainIDReplaced := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Replace chain-id line
-		if isConfigKey(trimmed, "chain-id") {
-			leadingWS := getLeadingWhitespace(line)
-			result = append(result, fmt.Sprintf(`%schain-id = "%s"`, leadingWS, chainID))
-			chainIDReplaced = true
-			continue
-		}
-
-		result = append(result, line)
-	}
-
-	if !chainIDReplaced {
-		return fmt.Errorf("could not find 'chain-id' key in client.toml")
-	}
-
-	// Write back
-	output := strings.Join(result, "\n")
-	if err := os.WriteFile(clientPath, []byte(output), 0644); err != nil {
-		return fmt.Errorf("failed to write client.toml: %w", err)
+	if err := setConfigValues(fsys, clientPath, []configValue{{"", "chain-id", chainID}}); err != nil {
+		return fmt.Errorf("failed to update client.toml: %w", err)
 	}
 
 	return nil
@@ -297,6 +232,11 @@ func SetClientChainID(home string, chainID string, dryRun bool) error {
 // This is CRITICAL for EVM transaction processing - without the correct evm-chain-id,
 // nodes will compute different state for EVM transactions, causing AppHash mismatches.
 func SetEVMChainID(home string, evmChainID uint64, dryRun bool) error {
+	return SetEVMChainIDFS(OSFS{}, home, evmChainID, dryRun)
+}
+
+// SetEVMChainIDFS is SetEVMChainID on fsys.
+func SetEVMChainIDFS(fsys FS, home string, evmChainID uint64, dryRun bool) error {
 	if dryRun {
 		return nil
 	}
@@ -304,49 +244,12 @@ func SetEVMChainID(home string, evmChainID uint64, dryRun bool) error {
 	appPath := filepath.Join(home, "config", "app.toml")
 
 	// Check if file exists
-	if _, err := os.Stat(appPath); os.IsNotExist(err) {
+	if _, err := fsys.Stat(appPath); os.IsNotExist(err) {
 		return fmt.Errorf("app.toml not found at %s - run 'monod init' first", appPath)
 	}
 
-	// Read existing config
-	data, err := os.ReadFile(appPath)
-	if err != nil {
-		return fmt.Errorf("failed to read app.toml: %w", err)
-	}
-
-	// Use line-by-line parsing to preserve comments and formatting
-	lines := strings.Split(string(data), "\n")
-	var result []string
-	inEVMSection := false
-	evmChainIDReplaced := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Track which section we're in
-		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
-			inEVMSection = trimmed == "[evm]"
-		}
-
-		// Replace evm-chain-id in [evm] section only
-		if inEVMSection && isConfigKey(trimmed, "evm-chain-id") {
-			leadingWS := getLeadingWhitespace(line)
-			result = append(result, fmt.Sprintf("%sevm-chain-id = %d", leadingWS, evmChainID))
-			evmChainIDReplaced = true
-			continue
-		}
-
-		result = append(result, line)
-	}
-
-	if !evmChainIDReplaced {
-		return fmt.Errorf("could not find 'evm-chain-id' key in [evm] section of app.toml")
-	}
-
-	// Write back
-	output := strings.Join(result, "\n")
-	if err := os.WriteFile(appPath, []byte(output), 0644); err != nil {
-		return fmt.Errorf("failed to write app.toml: %w", err)
+	if err := setConfigValues(fsys, appPath, []configValue{{"evm", "evm-chain-id", evmChainID}}); err != nil {
+		return fmt.Errorf("failed to update app.toml: %w", err)
 	}
 
 	return nil
@@ -355,10 +258,15 @@ func SetEVMChainID(home string, evmChainID uint64, dryRun bool) error {
 // ClearAddrbook removes the addrbook.json file to ensure fresh peer discovery.
 // This is useful when switching sync strategies to avoid poisoned peers.
 func ClearAddrbook(home string, dryRun bool) error {
+	return ClearAddrbookFS(OSFS{}, home, dryRun)
+}
+
+// ClearAddrbookFS is ClearAddrbook on fsys.
+func ClearAddrbookFS(fsys FS, home string, dryRun bool) error {
 	addrbookPath := filepath.Join(home, "config", "addrbook.json")
 
 	// Check if file exists
-	if _, err := os.Stat(addrbookPath); os.IsNotExist(err) {
+	if _, err := fsys.Stat(addrbookPath); os.IsNotExist(err) {
 		return nil // Nothing to clear
 	}
 
@@ -366,7 +274,7 @@ func ClearAddrbook(home string, dryRun bool) error {
 		return nil
 	}
 
-	if err := os.Remove(addrbookPath); err != nil {
+	if err := fsys.Remove(addrbookPath); err != nil {
 		return fmt.Errorf("failed to remove addrbook.json: %w", err)
 	}
 
@@ -377,6 +285,11 @@ func ClearAddrbook(home string, dryRun bool) error {
 // This is CRITICAL for validators - without it, other validators cannot connect
 // to receive block proposals, causing the node to sign but never propose blocks.
 func SetExternalAddress(home string, externalAddr string, dryRun bool) error {
+	return SetExternalAddressFS(OSFS{}, home, externalAddr, dryRun)
+}
+
+// SetExternalAddressFS is SetExternalAddress on fsys.
+func SetExternalAddressFS(fsys FS, home string, externalAddr string, dryRun bool) error {
 	if dryRun {
 		return nil
 	}
@@ -384,49 +297,12 @@ func SetExternalAddress(home string, externalAddr string, dryRun bool) error {
 	configPath := filepath.Join(home, "config", "config.toml")
 
 	// Check if file exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	if _, err := fsys.Stat(configPath); os.IsNotExist(err) {
 		return fmt.Errorf("config.toml not found at %s - run 'monod init' first", configPath)
 	}
 
-	// Read existing config
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return fmt.Errorf("failed to read config.toml: %w", err)
-	}
-
-	// Use line-by-line parsing to preserve comments and formatting
-	lines := strings.Split(string(data), "\n")
-	var result []string
-	inP2PSection := false
-	externalAddrReplaced := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Track which section we're in
-		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
-			inP2PSection = trimmed == "[p2p]"
-		}
-
-		// Replace external_address in [p2p] section only
-		if inP2PSection && isConfigKey(trimmed, "external_address") {
-			leadingWS := getLeadingWhitespace(line)
-			result = append(result, fmt.Sprintf(`%sexternal_address = "%s"`, leadingWS, externalAddr))
-			externalAddrReplaced = true
-			continue
-		}
-
-		result = append(result, line)
-	}
-
-	if !externalAddrReplaced {
-		return fmt.Errorf("could not find 'external_address' key in [p2p] section of config.toml")
-	}
-
-	// Write back
-	output := strings.Join(result, "\n")
-	if err := os.WriteFile(configPath, []byte(output), 0644); err != nil {
-		return fmt.Errorf("failed to write config.toml: %w", err)
+	if err := setConfigValues(fsys, configPath, []configValue{{"p2p", "external_address", externalAddr}}); err != nil {
+		return fmt.Errorf("failed to update config.toml: %w", err)
 	}
 
 	return nil
@@ -434,6 +310,11 @@ func SetExternalAddress(home string, externalAddr string, dryRun bool) error {
 
 // GetExternalAddress reads the current external_address from config.toml.
 func GetExternalAddress(home string) (string, error) {
+	return GetExternalAddressFS(OSFS{}, home)
+}
+
+// GetExternalAddressFS is GetExternalAddress on fsys.
+func GetExternalAddressFS(fsys FS, home string) (string, error) {
 	configPath := filepath.Join(home, "config", "config.toml")
-	return GetConfigValue(configPath, "p2p", "external_address")
+	return GetConfigValueFS(fsys, configPath, "p2p", "external_address")
 }
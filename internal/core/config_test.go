@@ -7,7 +7,7 @@ import (
 	"testing"
 )
 
-func TestIsConfigKey(t *testing.T) {
+func TestConfigKeyMatching(t *testing.T) {
 	tests := []struct {
 		line     string
 		key      string
@@ -35,9 +35,13 @@ func TestIsConfigKey(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.line, func(t *testing.T) {
-			result := isConfigKey(tt.line, tt.key)
+			doc, err := ParseTOMLDocument([]byte("[p2p]\n" + tt.line + "\n"))
+			if err != nil {
+				t.Fatalf("ParseTOMLDocument() error = %v", err)
+			}
+			_, result := doc.Get("p2p", tt.key)
 			if result != tt.expected {
-				t.Errorf("isConfigKey(%q, %q) = %v, want %v", tt.line, tt.key, result, tt.expected)
+				t.Errorf("Get(p2p, %q) in %q found = %v, want %v", tt.key, tt.line, result, tt.expected)
 			}
 		})
 	}
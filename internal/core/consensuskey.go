@@ -0,0 +1,60 @@
+package core
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LoadConsensusPubKey reads a validator's ed25519 consensus public key for
+// create-validator. path is the output of `monod comet show-validator`; if
+// it is empty, the key is read from config/priv_validator_key.json in home
+// (default: $MONOD_HOME or ~/.monod), as monod does.
+func LoadConsensusPubKey(path, home string) (*pubKeyJSON, error) {
+	if path == "" {
+		if home == "" {
+			home = os.Getenv("MONOD_HOME")
+		}
+		if home == "" {
+			userHome, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("could not determine home directory: %w", err)
+			}
+			home = filepath.Join(userHome, ".monod")
+		}
+		path = filepath.Join(home, "config", "priv_validator_key.json")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read consensus key (pass --pubkey with the output of 'monod comet show-validator'): %w", err)
+	}
+
+	// show-validator prints the key as an Any; priv_validator_key.json
+	// holds it in amino JSON form
+	var key struct {
+		Type   string `json:"@type"`
+		Key    string `json:"key"`
+		PubKey *struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"pub_key"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if key.PubKey != nil {
+		if key.PubKey.Type != "tendermint/PubKeyEd25519" {
+			return nil, fmt.Errorf("%s: consensus key type %s is not supported", path, key.PubKey.Type)
+		}
+		key.Type, key.Key = TypeURLEd25519PubKey, key.PubKey.Value
+	}
+	if key.Type != TypeURLEd25519PubKey {
+		return nil, fmt.Errorf("%s: consensus key type %q is not supported, want %s", path, key.Type, TypeURLEd25519PubKey)
+	}
+	if raw, err := base64.StdEncoding.DecodeString(key.Key); err != nil || len(raw) != 32 {
+		return nil, fmt.Errorf("%s: consensus key must be 32 base64-encoded bytes", path)
+	}
+	return &pubKeyJSON{Type: key.Type, Key: key.Key}, nil
+}
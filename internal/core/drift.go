@@ -23,6 +23,8 @@ type DriftResult struct {
 	Actual   string        // Actual value on disk
 	File     string        // "client.toml", "app.toml", "config.toml"
 	Severity DriftSeverity // How critical this drift is
+	Message  string        // Why the value matters, if not obvious
+	Fix      *DriftFix     // Change that repairs the drift; nil if it needs an operator
 }
 
 // DriftConfig holds the expected configuration for drift detection.
@@ -32,17 +34,33 @@ type DriftConfig struct {
 	EVMChainID     uint64
 	Seeds          []string // In node_id@host:port format
 	BootstrapPeers []string // In node_id@host:port format
+
+	// Optional expectations; zero values are not checked
+	Role         NodeRole  // RoleConfig values (seed_mode, pruning, tx indexer)
+	Ports        *PortPair // P2P and RPC listen ports from the network's PortScheme
+	PublicIP     string    // Public IP external_address must point to
+	MinGasPrices string    // Expected minimum-gas-prices; if empty only an empty value is reported
+	APIs         []string  // AppServers that should be enabled; nil is not checked
+
+	// ApplySecurityFixes lets repair bind listen addresses to localhost and
+	// turn off CORS and unsafe RPC; otherwise those findings are report-only
+	ApplySecurityFixes bool
 }
 
 // DetectDrift compares on-disk configuration against canonical config
 func DetectDrift(home string, config *DriftConfig) ([]DriftResult, error) {
+	return DetectDriftFS(OSFS{}, home, config)
+}
+
+// DetectDriftFS is DetectDrift on fsys.
+func DetectDriftFS(fsys FS, home string, config *DriftConfig) ([]DriftResult, error) {
 	var drifts []DriftResult
 
 	configDir := filepath.Join(home, "config")
 
 	// Check client.toml chain-id
 	clientPath := filepath.Join(configDir, "client.toml")
-	chainID, err := GetConfigValue(clientPath, "", "chain-id")
+	chainID, err := GetConfigValueFS(fsys, clientPath, "", "chain-id")
 	if err == nil {
 		// Remove quotes if present
 		chainID = strings.Trim(chainID, "\"")
@@ -53,13 +71,14 @@ func DetectDrift(home string, config *DriftConfig) ([]DriftResult, error) {
 				Actual:   chainID,
 				File:     "client.toml",
 				Severity: SeverityCritical,
+				Fix:      &DriftFix{File: "client.toml", Key: "chain-id", Value: config.CosmosChainID},
 			})
 		}
 	}
 
 	// Check app.toml evm-chain-id
 	appPath := filepath.Join(configDir, "app.toml")
-	evmChainIDStr, err := GetConfigValue(appPath, "evm", "evm-chain-id")
+	evmChainIDStr, err := GetConfigValueFS(fsys, appPath, "evm", "evm-chain-id")
 	if err == nil {
 		evmChainID, _ := strconv.ParseUint(evmChainIDStr, 10, 64)
 		if evmChainID != config.EVMChainID {
@@ -69,13 +88,14 @@ func DetectDrift(home string, config *DriftConfig) ([]DriftResult, error) {
 				Actual:   evmChainIDStr,
 				File:     "app.toml",
 				Severity: SeverityCritical,
+				Fix:      &DriftFix{File: "app.toml", Section: "evm", Key: "evm-chain-id", Value: config.EVMChainID},
 			})
 		}
 	}
 
 	// Check config.toml seeds
 	configPath := filepath.Join(configDir, "config.toml")
-	seeds, err := GetConfigValue(configPath, "p2p", "seeds")
+	seeds, err := GetConfigValueFS(fsys, configPath, "p2p", "seeds")
 	if err == nil {
 		seeds = strings.Trim(seeds, "\"")
 		expectedSeeds := strings.Join(config.Seeds, ",")
@@ -86,12 +106,13 @@ func DetectDrift(home string, config *DriftConfig) ([]DriftResult, error) {
 				Actual:   seeds,
 				File:     "config.toml",
 				Severity: SeverityWarning,
+				Fix:      &DriftFix{File: "config.toml", Section: "p2p", Key: "seeds", Value: expectedSeeds},
 			})
 		}
 	}
 
 	// Check config.toml persistent_peers (for bootstrap mode)
-	peers, err := GetConfigValue(configPath, "p2p", "persistent_peers")
+	peers, err := GetConfigValueFS(fsys, configPath, "p2p", "persistent_peers")
 	if err == nil {
 		peers = strings.Trim(peers, "\"")
 		expectedPeers := strings.Join(config.BootstrapPeers, ",")
@@ -102,10 +123,18 @@ func DetectDrift(home string, config *DriftConfig) ([]DriftResult, error) {
 				Actual:   peers,
 				File:     "config.toml",
 				Severity: SeverityWarning,
+				Fix:      &DriftFix{File: "config.toml", Section: "p2p", Key: "persistent_peers", Value: expectedPeers},
 			})
 		}
 	}
 
+	// Check roles, ports, security settings and the active profile
+	extended, err := detectExtendedDriftFS(fsys, home, config)
+	if err != nil {
+		return nil, err
+	}
+	drifts = append(drifts, extended...)
+
 	return drifts, nil
 }
 
@@ -131,6 +160,9 @@ func FormatDriftReport(drifts []DriftResult) string {
 	for _, d := range drifts {
 		sb.WriteString(fmt.Sprintf("  [%s] %s %s: expected '%s', got '%s'\n",
 			d.Severity, d.File, d.Field, d.Expected, d.Actual))
+		if d.Message != "" {
+			sb.WriteString(fmt.Sprintf("    %s\n", d.Message))
+		}
 	}
 
 	if HasCriticalDrift(drifts) {
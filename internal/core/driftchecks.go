@@ -0,0 +1,418 @@
+package core
+
+import (
+	"fmt"
+	gonet "net"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/monolythium/mono-commander/internal/net"
+)
+
+// DriftFix is the config change that repairs a drift.
+type DriftFix struct {
+	File    string // "client.toml", "app.toml", "config.toml"
+	Section string // "" is the root table
+	Key     string
+	Value   any
+}
+
+// AppServers are the app.toml servers DriftConfig.APIs refers to.
+var AppServers = []string{"api", "grpc", "json-rpc"}
+
+// driftChecker collects drifts of the node's config files.
+type driftChecker struct {
+	docs   map[string]*TOMLDocument
+	drifts []DriftResult
+	// securityFixes gives listen address, CORS and unsafe RPC findings a
+	// fix; otherwise they are report-only, as public RPC and sentry nodes
+	// listen on every interface on purpose
+	securityFixes bool
+}
+
+// get returns a value, or false if the file or key is missing.
+func (c *driftChecker) get(file, section, key string) (any, bool) {
+	doc, ok := c.docs[file]
+	if !ok {
+		return nil, false
+	}
+	return doc.Get(section, key)
+}
+
+func (c *driftChecker) getString(file, section, key string) (string, bool) {
+	v, ok := c.get(file, section, key)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%v", v), true
+}
+
+// pending returns the value a fix recorded so far will set, or the value
+// on disk, so fixes of the same key build on each other.
+func (c *driftChecker) pending(file, section, key string) (string, bool) {
+	for i := len(c.drifts) - 1; i >= 0; i-- {
+		if f := c.drifts[i].Fix; f != nil && f.File == file && f.Section == section && f.Key == key {
+			return fmt.Sprintf("%v", f.Value), true
+		}
+	}
+	return c.getString(file, section, key)
+}
+
+// add records a drift of file's section.key. A nil fix value means the
+// drift is report-only and repair leaves it alone.
+func (c *driftChecker) add(severity DriftSeverity, file, section, key, actual string, fix any, message string) {
+	d := DriftResult{
+		Field:    dottedKey(section, key),
+		Actual:   actual,
+		File:     file,
+		Severity: severity,
+		Message:  message,
+	}
+	if fix != nil {
+		d.Expected = formatCurrentValue(fix)
+		d.Fix = &DriftFix{File: file, Section: section, Key: key, Value: fix}
+	}
+	c.drifts = append(c.drifts, d)
+}
+
+// detectExtendedDriftFS runs the config checks and the active profile
+// check. Keys the active profile sets are left to the profile, so doctor
+// and repair never fight an operator's tuning.
+func detectExtendedDriftFS(fsys FS, home string, config *DriftConfig) ([]DriftResult, error) {
+	checks, err := detectConfigChecksFS(fsys, home, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check config: %w", err)
+	}
+	applied, err := ActiveProfileFS(fsys, home)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check active profile: %w", err)
+	}
+	var drifts []DriftResult
+	for _, d := range checks {
+		if applied != nil && slices.ContainsFunc(applied.Settings, func(s ProfileSetting) bool {
+			return s.File == d.File && dottedKey(s.Section, s.Key) == d.Field
+		}) {
+			continue
+		}
+		drifts = append(drifts, d)
+	}
+	profileDrifts, err := DetectProfileDriftFS(fsys, home)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check active profile: %w", err)
+	}
+	return append(drifts, profileDrifts...), nil
+}
+
+// detectConfigChecksFS runs the role, port, external address, security,
+// gas price and API checks of config against the node at home. Missing
+// config files are skipped.
+func detectConfigChecksFS(fsys FS, home string, config *DriftConfig) ([]DriftResult, error) {
+	c := &driftChecker{docs: map[string]*TOMLDocument{}, securityFixes: config.ApplySecurityFixes}
+	for _, file := range []string{"config.toml", "app.toml"} {
+		data, err := fsys.ReadFile(filepath.Join(home, "config", file))
+		if err != nil {
+			continue
+		}
+		doc, err := ParseTOMLDocument(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		c.docs[file] = doc
+	}
+
+	if config.Role != "" {
+		c.checkRole(config.Role)
+	}
+	c.checkPorts(config.Ports)
+	if config.PublicIP != "" {
+		c.checkExternalAddress(config.PublicIP, config.Ports)
+	}
+	c.checkListenAddresses()
+	c.checkCORS()
+	c.checkMinGasPrices(config.MinGasPrices)
+	if config.APIs != nil {
+		c.checkAPIs(config.APIs)
+	}
+	return c.drifts, nil
+}
+
+// checkRole compares the RoleConfig values of role.
+func (c *driftChecker) checkRole(role NodeRole) {
+	rc := GetRoleConfig(role)
+
+	if v, ok := c.getString("config.toml", "p2p", "seed_mode"); ok && v != strconv.FormatBool(rc.SeedMode) {
+		severity := SeverityWarning
+		if role == RoleSeedNode {
+			severity = SeverityCritical
+		}
+		c.add(severity, "config.toml", "p2p", "seed_mode", v, rc.SeedMode,
+			fmt.Sprintf("seed_mode should be %t for %s role", rc.SeedMode, role))
+	}
+
+	if v, ok := c.getString("app.toml", "", "pruning"); ok && v != rc.Pruning {
+		severity := SeverityWarning
+		if role == RoleSeedNode {
+			severity = SeverityCritical
+		}
+		c.add(severity, "app.toml", "", "pruning", v, rc.Pruning,
+			fmt.Sprintf("pruning should be '%s' for %s role", rc.Pruning, role))
+	}
+	if rc.Pruning == "custom" {
+		for key, want := range map[string]string{"pruning-keep-recent": rc.PruningKeepRecent, "pruning-interval": rc.PruningInterval} {
+			if v, ok := c.getString("app.toml", "", key); ok && v != want {
+				c.add(SeverityInfo, "app.toml", "", key, v, nil, fmt.Sprintf("%s role uses %s = %s", role, key, want))
+			}
+		}
+	}
+
+	// Archives must keep every block
+	if v, ok := c.get("app.toml", "", "min-retain-blocks"); ok && rc.Pruning == "nothing" && v != int64(rc.MinRetainBlocks) {
+		severity := SeverityWarning
+		if rc.EarliestHeightCheck {
+			severity = SeverityCritical
+		}
+		c.add(severity, "app.toml", "", "min-retain-blocks", fmt.Sprintf("%v", v), int64(rc.MinRetainBlocks),
+			fmt.Sprintf("min-retain-blocks prunes old blocks, but a %s must keep all of them", role))
+	}
+
+	if v, ok := c.getString("config.toml", "tx_index", "indexer"); ok {
+		switch {
+		case rc.IndexerEnabled && v == "null":
+			c.add(SeverityWarning, "config.toml", "tx_index", "indexer", v, "kv", "tx indexing is disabled; tx queries will fail")
+		case !rc.IndexerEnabled && v != "null":
+			c.add(SeverityInfo, "config.toml", "tx_index", "indexer", v, nil, fmt.Sprintf("%s role does not need a tx index (indexer = \"null\")", role))
+		}
+	}
+}
+
+// checkPorts compares the P2P and RPC listen ports with the PortScheme.
+func (c *driftChecker) checkPorts(ports *PortPair) {
+	if ports == nil {
+		return
+	}
+	for _, l := range []struct {
+		section string
+		port    int
+	}{{"p2p", ports.P2P}, {"rpc", ports.RPC}} {
+		v, ok := c.getString("config.toml", l.section, "laddr")
+		if !ok || l.port == 0 {
+			continue
+		}
+		addr, ok := parseListenAddr(v)
+		if !ok || addr.port == strconv.Itoa(l.port) {
+			continue
+		}
+		addr.port = strconv.Itoa(l.port)
+		c.add(SeverityWarning, "config.toml", l.section, "laddr", v, addr.String(),
+			fmt.Sprintf("the network's port scheme uses port %d", l.port))
+	}
+}
+
+// checkExternalAddress checks that peers are told to dial publicIP on the
+// P2P port.
+func (c *driftChecker) checkExternalAddress(publicIP string, ports *PortPair) {
+	v, ok := c.getString("config.toml", "p2p", "external_address")
+	if !ok {
+		return
+	}
+	port := 26656
+	if ports != nil && ports.P2P != 0 {
+		port = ports.P2P
+	} else if laddr, ok := c.getString("config.toml", "p2p", "laddr"); ok {
+		if addr, ok := parseListenAddr(laddr); ok {
+			port, _ = strconv.Atoi(addr.port)
+		}
+	}
+	want := net.FormatExternalAddress(publicIP, port)
+
+	actual, _ := parseListenAddr(v)
+	expected, _ := parseListenAddr(want)
+	switch {
+	case v == "":
+		c.add(SeverityWarning, "config.toml", "p2p", "external_address", v, want,
+			"peers cannot dial this node; a validator without it signs but never proposes blocks")
+	case actual.host != expected.host || actual.port != expected.port:
+		c.add(SeverityWarning, "config.toml", "p2p", "external_address", v, want,
+			fmt.Sprintf("external_address does not match the detected public IP %s", publicIP))
+	}
+}
+
+// checkListenAddresses reports RPC servers listening on every interface.
+func (c *driftChecker) checkListenAddresses() {
+	listeners := []struct {
+		file, section, key string
+		enable             string // section whose enable flag turns the server on
+	}{
+		{"config.toml", "rpc", "laddr", ""},
+		{"app.toml", "api", "address", "api"},
+		{"app.toml", "grpc", "address", "grpc"},
+		{"app.toml", "json-rpc", "address", "json-rpc"},
+		{"app.toml", "json-rpc", "ws-address", "json-rpc"},
+	}
+	for _, l := range listeners {
+		if l.enable != "" {
+			if enabled, _ := c.getString(l.file, l.enable, "enable"); enabled != "true" {
+				continue
+			}
+		}
+		v, ok := c.getString(l.file, l.section, l.key)
+		if !ok {
+			continue
+		}
+		current, _ := c.pending(l.file, l.section, l.key)
+		addr, ok := parseListenAddr(current)
+		if !ok || !addr.public() {
+			continue
+		}
+		addr.host = "127.0.0.1"
+		c.add(SeverityWarning, l.file, l.section, l.key, v, c.securityFix(addr.String()),
+			"listens on all interfaces; put a reverse proxy or firewall in front, or bind to localhost")
+	}
+}
+
+// checkCORS reports CORS and unsafe RPC settings.
+func (c *driftChecker) checkCORS() {
+	if v, ok := c.get("config.toml", "rpc", "cors_allowed_origins"); ok {
+		if origins, _ := v.([]any); slices.Contains(origins, any("*")) {
+			c.add(SeverityWarning, "config.toml", "rpc", "cors_allowed_origins", formatCurrentValue(v), c.securityFix([]string{}),
+				"any website can call the RPC from a visitor's browser")
+		}
+	}
+	if v, _ := c.getString("app.toml", "api", "enabled-unsafe-cors"); v == "true" {
+		c.add(SeverityWarning, "app.toml", "api", "enabled-unsafe-cors", v, c.securityFix(false),
+			"any website can call the REST API from a visitor's browser")
+	}
+	if v, _ := c.getString("config.toml", "rpc", "unsafe"); v == "true" {
+		severity := SeverityWarning
+		if laddr, _ := c.getString("config.toml", "rpc", "laddr"); laddr != "" {
+			if addr, ok := parseListenAddr(laddr); ok && addr.public() {
+				severity = SeverityCritical
+			}
+		}
+		c.add(severity, "config.toml", "rpc", "unsafe", v, c.securityFix(false),
+			"unsafe RPC methods (dial_peers, unsafe_flush_mempool) are enabled")
+	}
+}
+
+// securityFix returns fix if security fixes were asked for, else nil.
+func (c *driftChecker) securityFix(fix any) any {
+	if !c.securityFixes {
+		return nil
+	}
+	return fix
+}
+
+// checkMinGasPrices compares minimum-gas-prices with want, or, when want
+// is empty, reports an empty value.
+func (c *driftChecker) checkMinGasPrices(want string) {
+	v, ok := c.getString("app.toml", "", "minimum-gas-prices")
+	if !ok {
+		return
+	}
+	switch {
+	case want != "" && v != want:
+		c.add(SeverityWarning, "app.toml", "", "minimum-gas-prices", v, want, "")
+	case want == "" && v == "":
+		c.add(SeverityWarning, "app.toml", "", "minimum-gas-prices", v, nil,
+			"the node accepts zero-fee transactions; set minimum-gas-prices (e.g. 0.025"+BaseDenom+")")
+	}
+}
+
+// checkAPIs compares the app servers' enable flags with enabled.
+func (c *driftChecker) checkAPIs(enabled []string) {
+	for _, server := range AppServers {
+		want := slices.Contains(enabled, server)
+		v, ok := c.getString("app.toml", server, "enable")
+		if !ok {
+			if _, hasFile := c.docs["app.toml"]; !hasFile {
+				continue
+			}
+			v = "(missing)"
+		}
+		if v == strconv.FormatBool(want) {
+			continue
+		}
+		state := "disabled"
+		if want {
+			state = "enabled"
+		}
+		c.add(SeverityWarning, "app.toml", server, "enable", v, want, fmt.Sprintf("the %s server should be %s", server, state))
+	}
+}
+
+// listenAddr is a listen address like tcp://0.0.0.0:26657 or [::]:9090.
+type listenAddr struct {
+	scheme, host, port string
+}
+
+func parseListenAddr(s string) (listenAddr, bool) {
+	var a listenAddr
+	if i := strings.Index(s, "://"); i >= 0 {
+		a.scheme, s = s[:i], s[i+3:]
+	}
+	host, port, err := gonet.SplitHostPort(s)
+	if err != nil || a.scheme == "unix" {
+		return listenAddr{}, false
+	}
+	a.host, a.port = host, port
+	return a, true
+}
+
+// public reports whether the address listens on every interface.
+func (a listenAddr) public() bool {
+	return a.host == "" || a.host == "0.0.0.0" || a.host == "::"
+}
+
+func (a listenAddr) String() string {
+	s := gonet.JoinHostPort(a.host, a.port)
+	if a.scheme != "" {
+		s = a.scheme + "://" + s
+	}
+	return s
+}
+
+func dottedKey(section, key string) string {
+	if section == "" {
+		return key
+	}
+	return section + "." + key
+}
+
+// repairDriftsFS applies the fixes of drifts, one write per file.
+// Report-only drifts are returned as skipped.
+func repairDriftsFS(fsys FS, home string, drifts []DriftResult, dryRun bool) []RepairResult {
+	var results []RepairResult
+	values := map[string][]configValue{}
+	var files []string
+	for _, d := range drifts {
+		r := RepairResult{Field: d.Field, OldValue: d.Actual, NewValue: d.Expected, File: d.File, Success: true}
+		if d.Fix == nil {
+			r.Skipped = true
+			r.Note = "not repaired automatically: " + d.Message
+		} else {
+			if _, ok := values[d.Fix.File]; !ok {
+				files = append(files, d.Fix.File)
+			}
+			values[d.Fix.File] = append(values[d.Fix.File], configValue{d.Fix.Section, d.Fix.Key, d.Fix.Value})
+		}
+		results = append(results, r)
+	}
+	if dryRun {
+		return results
+	}
+	for _, file := range files {
+		err := setConfigValues(fsys, filepath.Join(home, "config", file), values[file])
+		if err == nil {
+			continue
+		}
+		for i := range results {
+			if results[i].File == file && results[i].Success && !results[i].Skipped {
+				results[i].Success = false
+				results[i].Error = err.Error()
+			}
+		}
+	}
+	return results
+}
@@ -0,0 +1,265 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// insecureHome creates a seed node home in a MemFS whose config fails
+// every extended drift check.
+func insecureHome(t *testing.T) (*MemFS, string) {
+	t.Helper()
+	home := "/srv/monod"
+	m := NewMemFS()
+	m.MkdirAll(filepath.Join(home, "config"), 0755)
+	files := map[string]string{
+		"client.toml": "chain-id = \"mono-sprint-1\"\n",
+		"config.toml": `[rpc]
+laddr = "tcp://0.0.0.0:26657"
+cors_allowed_origins = ["*"]
+unsafe = true
+
+[p2p]
+laddr = "tcp://0.0.0.0:26656"
+external_address = ""
+seed_mode = false
+
+[tx_index]
+indexer = "kv"
+`,
+		"app.toml": `# Minimum gas prices
+minimum-gas-prices = ""
+pruning = "default"
+min-retain-blocks = 100
+
+[evm]
+evm-chain-id = 262146
+
+[api]
+enable = true
+address = "tcp://0.0.0.0:1317"
+enabled-unsafe-cors = true
+
+[grpc]
+enable = true
+address = "localhost:9090"
+`,
+	}
+	for name, content := range files {
+		m.WriteFile(filepath.Join(home, "config", name), []byte(content), 0644)
+	}
+	return m, home
+}
+
+func insecureDriftConfig() *DriftConfig {
+	return &DriftConfig{
+		CosmosChainID:      "mono-sprint-1",
+		EVMChainID:         262146,
+		Role:               RoleSeedNode,
+		Ports:              &PortPair{P2P: 26656, RPC: 26667},
+		PublicIP:           "203.0.113.7",
+		APIs:               []string{"grpc"},
+		ApplySecurityFixes: true,
+	}
+}
+
+func TestDetectDrift_ConfigChecks(t *testing.T) {
+	m, home := insecureHome(t)
+	drifts, err := DetectDriftFS(m, home, insecureDriftConfig())
+	if err != nil {
+		t.Fatalf("DetectDriftFS() error = %v", err)
+	}
+
+	want := []struct {
+		file, field string
+		severity    DriftSeverity
+		expected    string
+		fixed       bool
+	}{
+		{"config.toml", "p2p.seed_mode", SeverityCritical, "true", true},
+		{"app.toml", "pruning", SeverityCritical, `"nothing"`, true},
+		{"app.toml", "min-retain-blocks", SeverityCritical, "0", true},
+		{"config.toml", "tx_index.indexer", SeverityInfo, "", false},
+		{"config.toml", "rpc.laddr", SeverityWarning, `"tcp://0.0.0.0:26667"`, true},
+		{"config.toml", "p2p.external_address", SeverityWarning, `"tcp://203.0.113.7:26656"`, true},
+		{"config.toml", "rpc.laddr", SeverityWarning, `"tcp://127.0.0.1:26667"`, true},
+		{"app.toml", "api.address", SeverityWarning, `"tcp://127.0.0.1:1317"`, true},
+		{"config.toml", "rpc.cors_allowed_origins", SeverityWarning, "[]", true},
+		{"app.toml", "api.enabled-unsafe-cors", SeverityWarning, "false", true},
+		{"config.toml", "rpc.unsafe", SeverityCritical, "false", true},
+		{"app.toml", "minimum-gas-prices", SeverityWarning, "", false},
+		{"app.toml", "api.enable", SeverityWarning, "false", true},
+		{"app.toml", "json-rpc.enable", SeverityWarning, "false", true},
+	}
+	if len(drifts) != len(want) {
+		t.Fatalf("got %d drifts, want %d: %+v", len(drifts), len(want), drifts)
+	}
+	for i, w := range want {
+		d := drifts[i]
+		if d.File != w.file || d.Field != w.field || d.Severity != w.severity || d.Expected != w.expected || (d.Fix != nil) != w.fixed {
+			t.Errorf("drift %d = %+v, want %+v", i, d, w)
+		}
+	}
+
+	report := FormatDriftReport(drifts)
+	if !strings.Contains(report, "zero-fee transactions") {
+		t.Errorf("report does not explain the drifts:\n%s", report)
+	}
+
+	// Without ApplySecurityFixes the listener, CORS and unsafe RPC findings
+	// are report-only
+	config := insecureDriftConfig()
+	config.ApplySecurityFixes = false
+	drifts, err = DetectDriftFS(m, home, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range drifts {
+		switch d.Field {
+		case "rpc.laddr", "api.address", "rpc.cors_allowed_origins", "api.enabled-unsafe-cors", "rpc.unsafe":
+			if d.Fix != nil && d.Message != "the network's port scheme uses port 26667" {
+				t.Errorf("security finding has a fix: %+v", d)
+			}
+		}
+	}
+}
+
+func TestDetectDrift_ConfigChecksSkipProfileKeys(t *testing.T) {
+	m, home := insecureHome(t)
+	p := &Profile{Name: "public-rpc", Settings: []ProfileSetting{
+		{File: "config.toml", Section: "rpc", Key: "cors_allowed_origins", Value: []any{"*"}},
+	}}
+	if _, err := ApplyProfileFS(m, home, p, ProfileContext{Role: RoleFullNode}, false); err != nil {
+		t.Fatal(err)
+	}
+	drifts, err := DetectDriftFS(m, home, &DriftConfig{CosmosChainID: "mono-sprint-1", EVMChainID: 262146})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, d := range drifts {
+		if d.Field == "rpc.cors_allowed_origins" {
+			t.Errorf("reported a key the active profile sets: %+v", d)
+		}
+	}
+}
+
+func TestRepair_ConfigChecks(t *testing.T) {
+	m, home := insecureHome(t)
+	config := insecureDriftConfig()
+	config.MinGasPrices = "0.025alyth"
+
+	results, err := RepairFS(m, home, config, false)
+	if err != nil {
+		t.Fatalf("RepairFS() error = %v", err)
+	}
+	for _, r := range results {
+		if !r.Success {
+			t.Errorf("repair failed: %+v", r)
+		}
+	}
+
+	// Only the report-only tx_index hint remains
+	drifts, err := DetectDriftFS(m, home, config)
+	if err != nil || len(drifts) != 1 || drifts[0].Field != "tx_index.indexer" {
+		t.Errorf("drift after repair = %+v, %v", drifts, err)
+	}
+	indexer, _ := GetConfigValueFS(m, filepath.Join(home, "config", "config.toml"), "tx_index", "indexer")
+	if indexer != "kv" {
+		t.Errorf("tx_index.indexer = %s, want it left alone", indexer)
+	}
+
+	// The port and the listen address fixes of rpc.laddr combine, and
+	// comments survive
+	laddr, _ := GetConfigValueFS(m, filepath.Join(home, "config", "config.toml"), "rpc", "laddr")
+	if laddr != "tcp://127.0.0.1:26667" {
+		t.Errorf("rpc.laddr = %s", laddr)
+	}
+	app, _ := m.ReadFile(filepath.Join(home, "config", "app.toml"))
+	if !strings.HasPrefix(string(app), "# Minimum gas prices\nminimum-gas-prices = \"0.025alyth\"\n") {
+		t.Errorf("app.toml = %s", app)
+	}
+}
+
+func TestRepair_ConfigChecksWithoutFix(t *testing.T) {
+	m, home := insecureHome(t)
+	results, err := RepairFS(m, home, &DriftConfig{CosmosChainID: "mono-sprint-1", EVMChainID: 262146}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, r := range results {
+		if r.Field == "minimum-gas-prices" {
+			found = true
+			if !r.Skipped || !r.Success || !strings.Contains(r.Note, "not repaired") {
+				t.Errorf("minimum-gas-prices result = %+v", r)
+			}
+		}
+	}
+	if !found {
+		t.Error("no result for minimum-gas-prices")
+	}
+}
+
+// TestRepair_KeepsPublicListeners checks that a plain repair (canonical
+// drifts only) leaves a public RPC node reachable.
+func TestRepair_KeepsPublicListeners(t *testing.T) {
+	m, home := insecureHome(t)
+	config := &DriftConfig{CosmosChainID: "mono-sprint-1", EVMChainID: 262146}
+	results, err := RepairFS(m, home, config, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if !r.Success {
+			t.Errorf("repair failed: %+v", r)
+		}
+		if r.Field == "rpc.laddr" && !r.Skipped {
+			t.Errorf("rpc.laddr result = %+v, want skipped", r)
+		}
+	}
+
+	configPath := filepath.Join(home, "config", "config.toml")
+	for _, key := range []struct{ section, key, want string }{
+		{"rpc", "laddr", "tcp://0.0.0.0:26657"},
+		{"rpc", "unsafe", "true"},
+		{"tx_index", "indexer", "kv"},
+	} {
+		if v, _ := GetConfigValueFS(m, configPath, key.section, key.key); v != key.want {
+			t.Errorf("%s.%s = %s, want %s", key.section, key.key, v, key.want)
+		}
+	}
+	if v, _ := GetConfigValueFS(m, filepath.Join(home, "config", "app.toml"), "api", "address"); v != "tcp://0.0.0.0:1317" {
+		t.Errorf("api.address = %s", v)
+	}
+
+	report := FormatRepairReport(results, false)
+	if !strings.Contains(report, "not repaired automatically") || !strings.Contains(report, "All repairs successful") {
+		t.Errorf("report:\n%s", report)
+	}
+}
+
+func TestParseListenAddr(t *testing.T) {
+	tests := []struct {
+		in         string
+		ok, public bool
+	}{
+		{"tcp://0.0.0.0:26657", true, true},
+		{"0.0.0.0:9090", true, true},
+		{"[::]:8545", true, true},
+		{":1317", true, true},
+		{"tcp://127.0.0.1:26657", true, false},
+		{"localhost:9090", true, false},
+		{"unix:///var/run/monod.sock", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		a, ok := parseListenAddr(tt.in)
+		if ok != tt.ok || (ok && a.public() != tt.public) {
+			t.Errorf("parseListenAddr(%q) = %+v, %v", tt.in, a, ok)
+		}
+		if ok && a.String() != tt.in && !strings.HasPrefix(tt.in, "[") && !strings.HasPrefix(tt.in, ":") {
+			t.Errorf("String() = %q, want %q", a.String(), tt.in)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package core
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FS is the filesystem node-home operations read and write through.
+// This allows node homes on remote hosts and hermetic tests.
+//
+// Errors for missing files must satisfy errors.Is(err, fs.ErrNotExist).
+type FS interface {
+	ReadFile(name string) ([]byte, error)
+	WriteFile(name string, data []byte, perm os.FileMode) error
+	Stat(name string) (os.FileInfo, error)
+	MkdirAll(path string, perm os.FileMode) error
+	Remove(name string) error
+	RemoveAll(path string) error
+}
+
+// OSFS is the local filesystem. Functions that take a home path without
+// an FS use it.
+type OSFS struct{}
+
+func (OSFS) ReadFile(name string) ([]byte, error) { return os.ReadFile(name) }
+func (OSFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	return os.WriteFile(name, data, perm)
+}
+func (OSFS) Stat(name string) (os.FileInfo, error)        { return os.Stat(name) }
+func (OSFS) MkdirAll(path string, perm os.FileMode) error { return os.MkdirAll(path, perm) }
+func (OSFS) Remove(name string) error                     { return os.Remove(name) }
+func (OSFS) RemoveAll(path string) error                  { return os.RemoveAll(path) }
+
+// MemFS is an in-memory FS for testing. Like the OS, writing a file
+// requires its directory to exist. The zero value is not usable; use
+// NewMemFS.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string]*memEntry
+}
+
+type memEntry struct {
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+}
+
+// NewMemFS creates an empty MemFS containing only the root directory.
+func NewMemFS() *MemFS {
+	return &MemFS{files: map[string]*memEntry{
+		string(filepath.Separator): {mode: fs.ModeDir | 0755},
+	}}
+}
+
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.files[memPath(name)]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if e.mode.IsDir() {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: errIsDir}
+	}
+	return append([]byte(nil), e.data...), nil
+}
+
+func (m *MemFS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := memPath(name)
+	if parent, ok := m.files[filepath.Dir(p)]; !ok || !parent.mode.IsDir() {
+		return &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	if e, ok := m.files[p]; ok {
+		if e.mode.IsDir() {
+			return &fs.PathError{Op: "open", Path: name, Err: errIsDir}
+		}
+		perm = e.mode // like the OS, an existing file keeps its mode
+	}
+	m.files[p] = &memEntry{data: append([]byte(nil), data...), mode: perm.Perm(), modTime: time.Now()}
+	return nil
+}
+
+func (m *MemFS) Stat(name string) (os.FileInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := memPath(name)
+	e, ok := m.files[p]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return memFileInfo{name: filepath.Base(p), size: int64(len(e.data)), mode: e.mode, modTime: e.modTime}, nil
+}
+
+func (m *MemFS) MkdirAll(path string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := memPath(path)
+	var missing []string
+	for ; ; p = filepath.Dir(p) {
+		if e, ok := m.files[p]; ok {
+			if !e.mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: path, Err: errNotDir}
+			}
+			break
+		}
+		missing = append(missing, p)
+	}
+	for _, dir := range missing {
+		m.files[dir] = &memEntry{mode: fs.ModeDir | perm.Perm(), modTime: time.Now()}
+	}
+	return nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := memPath(name)
+	e, ok := m.files[p]
+	if !ok {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	}
+	if e.mode.IsDir() && len(m.children(p)) > 0 {
+		return &fs.PathError{Op: "remove", Path: name, Err: errNotEmpty}
+	}
+	delete(m.files, p)
+	return nil
+}
+
+func (m *MemFS) RemoveAll(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	p := memPath(path)
+	for _, child := range m.children(p) {
+		delete(m.files, child)
+	}
+	delete(m.files, p)
+	return nil
+}
+
+// Files returns the paths of all regular files, sorted.
+func (m *MemFS) Files() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var paths []string
+	for p, e := range m.files {
+		if !e.mode.IsDir() {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// children returns every path below dir.
+func (m *MemFS) children(dir string) []string {
+	prefix := strings.TrimSuffix(dir, string(filepath.Separator)) + string(filepath.Separator)
+	var paths []string
+	for p := range m.files {
+		if strings.HasPrefix(p, prefix) {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// memPath makes name absolute and clean, so relative and absolute
+// spellings of a path are the same entry.
+func memPath(name string) string {
+	return filepath.Join(string(filepath.Separator), name)
+}
+
+type memError string
+
+func (e memError) Error() string { return string(e) }
+
+const (
+	errIsDir    memError = "is a directory"
+	errNotDir   memError = "not a directory"
+	errNotEmpty memError = "directory not empty"
+)
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return fi.size }
+func (fi memFileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi memFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi memFileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi memFileInfo) Sys() interface{}   { return nil }
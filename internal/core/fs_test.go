@@ -0,0 +1,161 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMemFS(t *testing.T) {
+	m := NewMemFS()
+
+	if err := m.WriteFile("/home/config/a.toml", []byte("x"), 0644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WriteFile() without parent error = %v, want ErrNotExist", err)
+	}
+	if err := m.MkdirAll("/home/config", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteFile("/home/config/a.toml", []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := m.ReadFile("home/config/../config/a.toml"); err != nil || string(data) != "x" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+	if info, err := m.Stat("/home/config/a.toml"); err != nil || info.Mode().Perm() != 0600 || info.Size() != 1 {
+		t.Errorf("Stat() = %v, %v", info, err)
+	}
+	if info, err := m.Stat("/home/config"); err != nil || !info.IsDir() {
+		t.Errorf("Stat(dir) = %v, %v", info, err)
+	}
+	if _, err := m.Stat("/missing"); !os.IsNotExist(err) {
+		t.Errorf("Stat(missing) error = %v, want not exist", err)
+	}
+	if err := m.Remove("/home/config"); err == nil {
+		t.Error("Remove() of a non-empty directory should fail")
+	}
+	if err := m.RemoveAll("/home"); err != nil {
+		t.Fatal(err)
+	}
+	if files := m.Files(); len(files) != 0 {
+		t.Errorf("Files() after RemoveAll = %v", files)
+	}
+}
+
+// TestNodeHomeLifecycle_MemFS runs join, drift detection, repair and reset
+// against an in-memory node home.
+func TestNodeHomeLifecycle_MemFS(t *testing.T) {
+	home := "/srv/monod"
+	configDir := filepath.Join(home, "config")
+	m := NewMemFS()
+	m.MkdirAll(configDir, 0755)
+	m.MkdirAll(filepath.Join(home, "data"), 0700)
+	files := map[string]string{
+		"config.toml":             "[p2p]\nexternal_address = \"\"\nseeds = \"\"\npersistent_peers = \"\"\npex = true\nseed_mode = false\n",
+		"app.toml":                "pruning = \"default\"\npruning-keep-recent = \"0\"\npruning-interval = \"0\"\n\n[evm]\nevm-chain-id = 1\n",
+		"client.toml":             "chain-id = \"\"\n",
+		"node_key.json":           `{"node":"key"}`,
+		"priv_validator_key.json": `{"validator":"key"}`,
+	}
+	for name, content := range files {
+		m.WriteFile(filepath.Join(configDir, name), []byte(content), 0644)
+	}
+
+	genesisData := []byte(`{"chain_id":"mono-sprint-1"}`)
+	sum := sha256.Sum256(genesisData)
+	fetcher := NewMockFetcher()
+	fetcher.AddResponse("https://example.com/genesis.json", genesisData)
+	fetcher.AddResponse("https://example.com/peers.json", []byte(`{"chain_id":"mono-sprint-1","peers":[
+		{"node_id":"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa","address":"peer1.example.com","port":26656}]}`))
+
+	result, err := Join(JoinOptions{
+		Network:    NetworkSprintnet,
+		Home:       home,
+		GenesisURL: "https://example.com/genesis.json",
+		GenesisSHA: hex.EncodeToString(sum[:]),
+		PeersURL:   "https://example.com/peers.json",
+		FS:         m,
+	}, fetcher)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	if last := result.Steps[len(result.Steps)-1]; last.Name != "Set external address" || last.Status != "skipped" {
+		t.Errorf("external address step = %+v, want skipped for a non-local home", last)
+	}
+	if chainID, _ := ParseGenesisChainIDFS(m, filepath.Join(configDir, "genesis.json")); chainID != "mono-sprint-1" {
+		t.Errorf("genesis chain_id = %q", chainID)
+	}
+	if peers, _ := GetConfigValueFS(m, filepath.Join(configDir, "config.toml"), "p2p", "persistent_peers"); !strings.Contains(peers, "peer1.example.com") {
+		t.Errorf("persistent_peers = %q", peers)
+	}
+	expected := &DriftConfig{CosmosChainID: "mono-sprint-1", EVMChainID: 262146}
+	if drifts, _ := DetectDriftFS(m, home, expected); len(drifts) != 0 {
+		t.Errorf("drift after join = %+v", drifts)
+	}
+
+	// Drift is detected and repaired
+	appPath := filepath.Join(configDir, "app.toml")
+	app, _ := m.ReadFile(appPath)
+	m.WriteFile(appPath, []byte(strings.Replace(string(app), "evm-chain-id = 262146", "evm-chain-id = 7", 1)), 0644)
+	if drifts, _ := DetectDriftFS(m, home, expected); !HasCriticalDrift(drifts) {
+		t.Errorf("expected critical drift, got %+v", drifts)
+	}
+	if _, err := RepairFS(m, home, expected, false); err != nil {
+		t.Fatalf("RepairFS() error = %v", err)
+	}
+	if drifts, _ := DetectDriftFS(m, home, expected); len(drifts) != 0 {
+		t.Errorf("drift after repair = %+v", drifts)
+	}
+
+	// Role changes go through the same FS
+	if err := ApplyRoleConfigFS(m, home, RoleArchiveNode, false); err != nil {
+		t.Fatalf("ApplyRoleConfigFS() error = %v", err)
+	}
+	if role, _ := DetectCurrentRoleFS(m, home); role != RoleArchiveNode {
+		t.Errorf("role = %s, want archive_node", role)
+	}
+
+	// Reset keeps only the keys and a fresh validator state
+	m.MkdirAll(filepath.Join(home, "data", "state.db"), 0755)
+	if !HasStaleDataFS(m, home) {
+		t.Fatal("expected stale data before reset")
+	}
+	reset, err := ResetNodeHome(m, home, true)
+	if err != nil {
+		t.Fatalf("ResetNodeHome() error = %v", err)
+	}
+	if !reflect.DeepEqual(reset.KeysPreserved, []string{"node_key.json", "priv_validator_key.json"}) {
+		t.Errorf("KeysPreserved = %v", reset.KeysPreserved)
+	}
+	want := []string{
+		"/srv/monod/config/node_key.json",
+		"/srv/monod/config/priv_validator_key.json",
+		"/srv/monod/data/priv_validator_state.json",
+	}
+	if got := m.Files(); !reflect.DeepEqual(got, want) {
+		t.Errorf("files after reset = %v, want %v", got, want)
+	}
+	if data, _ := m.ReadFile(filepath.Join(configDir, "priv_validator_key.json")); string(data) != `{"validator":"key"}` {
+		t.Errorf("validator key = %s", data)
+	}
+}
+
+func TestJoin_NonLocalHomeMustBeInitialized(t *testing.T) {
+	fetcher := NewMockFetcher()
+	fetcher.AddResponse("https://example.com/genesis.json", []byte(`{"chain_id":"mono-sprint-1"}`))
+
+	_, err := Join(JoinOptions{
+		Network:    NetworkSprintnet,
+		Home:       "/srv/monod",
+		GenesisURL: "https://example.com/genesis.json",
+		FS:         NewMemFS(),
+	}, fetcher)
+	if err == nil || !strings.Contains(err.Error(), "monod init") {
+		t.Errorf("Join() error = %v, want an uninitialized home error", err)
+	}
+}
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// DefaultGasAdjustment multiplies simulated gas to leave headroom for state
+// changes between simulation and inclusion in a block.
+const DefaultGasAdjustment = 1.5
+
+// GasEstimate is the result of simulating a transaction.
+type GasEstimate struct {
+	GasUsed    uint64  // gas consumed in simulation
+	GasLimit   uint64  // gas limit the transaction will use
+	Adjustment float64 // multiplier applied to GasUsed; 0 for an explicit limit
+	Fee        []Coin  // fee at GasLimit; empty without --fees or --gas-prices
+}
+
+// FeeLYTH returns the estimated fee in LYTH, or "" if no fee is configured.
+func (e *GasEstimate) FeeLYTH() string {
+	total := new(big.Int)
+	for _, c := range e.Fee {
+		if c.Denom != BaseDenom {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(c.Amount, 10)
+		if ok {
+			total.Add(total, amount)
+		}
+	}
+	if total.Sign() == 0 {
+		return ""
+	}
+	return FormatLYTH(total)
+}
+
+// isAutoGas reports whether gas asks for the limit to be estimated.
+func isAutoGas(gas string) bool {
+	return gas == "" || gas == "auto"
+}
+
+// EstimateGas simulates the transaction for cmd over Cosmos REST and applies
+// the gas adjustment. opts.From must be an address, since simulation needs
+// the account's sequence. Natively encodable messages are simulated from
+// their protobuf encoding; anything else is generated with monod and
+// simulated from JSON.
+//
+// An explicit numeric opts.Gas is kept as the limit; simulation then only
+// reports usage and the fee.
+func EstimateGas(ctx context.Context, opts ValidatorActionOptions, cmd *TxCommand) (*GasEstimate, error) {
+	if err := ValidateAddress(opts.From); err != nil {
+		return nil, fmt.Errorf("gas simulation requires --from to be an address")
+	}
+
+	network, err := GetNetwork(opts.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx *TxJSON
+	if len(cmd.Msgs) > 0 {
+		fee, err := NativeFee(opts.Fees, "", "", len(cmd.Msgs))
+		if err != nil {
+			return nil, err
+		}
+		if tx, err = NewUnsignedTx(cmd.Msgs, "", fee); err != nil {
+			return nil, err
+		}
+	} else {
+		if tx, err = generateUnsignedWithMonod(ctx, opts, network, cmd); err != nil {
+			return nil, err
+		}
+	}
+
+	client := opts.cosmosClient()
+	account, err := client.Account(opts.From)
+	if err != nil {
+		return nil, err
+	}
+
+	var pubKey *pubKeyJSON
+	if opts.Signer != nil {
+		pubKey = opts.Signer.pubKeyJSON()
+	}
+	gasUsed, err := simulateGas(client, tx, account.Sequence, pubKey, opts.Multisig)
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment := opts.GasAdjustment
+	if adjustment <= 0 {
+		adjustment = DefaultGasAdjustment
+	}
+	estimate := &GasEstimate{
+		GasUsed:    gasUsed,
+		GasLimit:   uint64(math.Ceil(float64(gasUsed) * adjustment)),
+		Adjustment: adjustment,
+	}
+	if !isAutoGas(opts.Gas) {
+		if estimate.GasLimit, err = strconv.ParseUint(opts.Gas, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid gas limit: %s", opts.Gas)
+		}
+		estimate.Adjustment = 0
+	}
+
+	fee, err := NativeFee(opts.Fees, opts.GasPrices, strconv.FormatUint(estimate.GasLimit, 10), len(tx.Body.Messages))
+	if err != nil {
+		return nil, err
+	}
+	estimate.Fee = fee.Amount
+	return estimate, nil
+}
+
+// simulateGas returns the gas used by tx in simulation. The simulate endpoint
+// skips signature verification but still needs one signer info with the
+// current sequence and one (empty) signature per signer. pubKey may be nil if
+// the account's key is unknown; the node then assumes a secp256k1 key.
+func simulateGas(client *rpc.CosmosClient, tx *TxJSON, sequence uint64, pubKey *pubKeyJSON, multisig *MultisigPubKey) (uint64, error) {
+	si := signerInfoJSON{
+		PublicKey: pubKey,
+		ModeInfo:  singleModeInfo(SignModeDirect),
+		Sequence:  strconv.FormatUint(sequence, 10),
+	}
+	var sig []byte
+
+	// A multisig pays verification gas for each of its threshold signatures
+	if multisig != nil {
+		n := len(multisig.PublicKeys)
+		elems := make([]byte, (n+7)/8)
+		var mode modeInfoJSON
+		mode.Multi = &struct {
+			Bitarray  compactBitArrayJSON `json:"bitarray"`
+			ModeInfos []modeInfoJSON      `json:"mode_infos"`
+		}{}
+		var multiSig protoWriter
+		for i := 0; i < int(multisig.Threshold); i++ {
+			elems[i/8] |= 1 << (7 - uint(i%8))
+			mode.Multi.ModeInfos = append(mode.Multi.ModeInfos, singleModeInfo(SignModeLegacyAminoJSON))
+			multiSig.message(1, nil)
+		}
+		mode.Multi.Bitarray = compactBitArrayJSON{
+			ExtraBitsStored: uint32(n % 8),
+			Elems:           base64.StdEncoding.EncodeToString(elems),
+		}
+		si.PublicKey = multisig.pubKeyJSON()
+		si.ModeInfo = mode
+		sig = multiSig.buf
+	}
+
+	rawInfo, err := json.Marshal(si)
+	if err != nil {
+		return 0, err
+	}
+	sim := *tx
+	sim.AuthInfo.SignerInfos = []json.RawMessage{rawInfo}
+	sim.Signatures = []string{base64.StdEncoding.EncodeToString(sig)}
+
+	var info *rpc.GasInfo
+	if txBytes, encErr := EncodeTxRaw(&sim); encErr == nil {
+		info, err = client.SimulateTx(txBytes)
+	} else {
+		// Messages without a native encoding are decoded by the node
+		raw, jsonErr := json.Marshal(sim)
+		if jsonErr != nil {
+			return 0, jsonErr
+		}
+		info, err = client.SimulateTxJSON(raw)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return info.GasUsed, nil
+}
+
+// setGasLimit replaces the --gas value of the command and of its multi-msg
+// sub-commands. --gas-adjustment only applies to --gas auto and is dropped.
+func (c *TxCommand) setGasLimit(gas string) {
+	c.Args = setGasArg(c.Args, gas)
+	for _, sub := range c.MultiMsgCommands {
+		sub.Args = setGasArg(sub.Args, gas)
+	}
+}
+
+func setGasArg(args []string, gas string) []string {
+	out := make([]string, 0, len(args)+2)
+	found := false
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--gas" && i+1 < len(args):
+			out = append(out, "--gas", gas)
+			found = true
+			i++
+		case args[i] == "--gas-adjustment" && i+1 < len(args):
+			i++
+		default:
+			out = append(out, args[i])
+		}
+	}
+	if !found {
+		out = append(out, "--gas", gas)
+	}
+	return out
+}
+
+// simulateGasStep estimates gas for result.Command and records it on the
+// result. With automatic gas the adjusted limit replaces --gas in the command
+// and is returned in opts for native signing. A failed simulation is only a
+// warning: the node may be unreachable during a dry-run.
+func simulateGasStep(ctx context.Context, opts ValidatorActionOptions, result *ValidatorActionResult) ValidatorActionOptions {
+	// The account is looked up by address, so a key name is resolved with
+	// monod keys show first
+	simOpts := opts
+	if ValidateAddress(opts.From) != nil {
+		addr, err := KeyringAddress(ctx, KeyringOptions{Home: opts.Home, Backend: opts.KeyringBackend}, opts.From)
+		if err != nil {
+			result.Steps = append(result.Steps, ActionStep{
+				Name:    "Simulate gas",
+				Status:  "skipped",
+				Message: fmt.Sprintf("could not resolve key %q to an address (%v); monod estimates gas when executing", opts.From, err),
+			})
+			return opts
+		}
+		simOpts.From = addr
+	}
+
+	result.Steps = append(result.Steps, ActionStep{Name: "Simulate gas", Status: "pending"})
+
+	estimate, err := EstimateGas(ctx, simOpts, result.Command)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Warnings = append(result.Warnings, fmt.Sprintf("gas simulation failed; the transaction may fail: %v", err))
+		return opts
+	}
+	result.GasEstimate = estimate
+
+	msg := fmt.Sprintf("gas used %d, limit %d", estimate.GasUsed, estimate.GasLimit)
+	if fee := estimate.FeeLYTH(); fee != "" {
+		msg += ", fee " + fee
+	}
+	if simOpts.From != opts.From {
+		msg += fmt.Sprintf(" (key %s is %s)", opts.From, simOpts.From)
+	}
+	result.Steps[len(result.Steps)-1].Status = "success"
+	result.Steps[len(result.Steps)-1].Message = msg
+
+	if !isAutoGas(opts.Gas) {
+		if estimate.GasUsed > estimate.GasLimit {
+			result.Warnings = append(result.Warnings, fmt.Sprintf(
+				"gas limit %d is below the simulated usage of %d; the transaction will run out of gas", estimate.GasLimit, estimate.GasUsed))
+		}
+		return opts
+	}
+
+	opts.Gas = strconv.FormatUint(estimate.GasLimit, 10)
+	result.Command.setGasLimit(opts.Gas)
+	return opts
+}
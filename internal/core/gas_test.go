@@ -0,0 +1,198 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// mockSimulateREST serves the account and simulate endpoints, reporting
+// gasUsed, and records the last simulate request body.
+func mockSimulateREST(t *testing.T, gasUsed string, request *map[string]json.RawMessage) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/cosmos/auth/v1beta1/accounts/"):
+			w.Write([]byte(`{"account":{"address":"x","account_number":"7","sequence":"3"}}`))
+		case r.URL.Path == "/cosmos/tx/v1beta1/simulate":
+			json.NewDecoder(r.Body).Decode(request)
+			if gasUsed == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"code":5,"message":"insufficient funds"}`))
+				return
+			}
+			w.Write([]byte(`{"gas_info":{"gas_wanted":"0","gas_used":"` + gasUsed + `"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestDelegateAction_SimulatesGas(t *testing.T) {
+	var request map[string]json.RawMessage
+	srv := mockSimulateREST(t, "100000", &request)
+
+	opts := ValidatorActionOptions{
+		Network:       NetworkLocalnet,
+		From:          testSigner(t).Address(),
+		GasPrices:     "0.025alyth",
+		Gas:           "auto",
+		GasAdjustment: 1.2,
+		REST:          srv.URL,
+		Simulate:      true,
+	}
+	result, err := DelegateAction(context.Background(), opts, DelegateParams{ValidatorAddr: testValoper, Amount: "1000alyth"})
+	if err != nil {
+		t.Fatalf("DelegateAction() error = %v", err)
+	}
+
+	est := result.GasEstimate
+	if est == nil || est.GasUsed != 100000 || est.GasLimit != 120000 {
+		t.Fatalf("GasEstimate = %+v", est)
+	}
+	if fee := est.FeeLYTH(); fee != "0.000000000000003 LYTH" {
+		t.Errorf("FeeLYTH() = %q", fee)
+	}
+	if cmd := result.Command.String(); !strings.Contains(cmd, "--gas 120000") || strings.Contains(cmd, "--gas-adjustment") {
+		t.Errorf("command does not use the simulated gas: %s", cmd)
+	}
+	if _, ok := request["tx_bytes"]; !ok {
+		t.Errorf("native messages should be simulated from tx_bytes, got %v", request)
+	}
+}
+
+func TestSimulateGasStep_ExplicitGas(t *testing.T) {
+	var request map[string]json.RawMessage
+	srv := mockSimulateREST(t, "90000", &request)
+
+	opts := ValidatorActionOptions{
+		Network:  NetworkLocalnet,
+		From:     testSigner(t).Address(),
+		Fees:     "5000alyth",
+		Gas:      "80000",
+		REST:     srv.URL,
+		Simulate: true,
+	}
+	result, _ := VoteAction(context.Background(), opts, VoteParams{ProposalID: "1", Option: VoteYes})
+
+	if est := result.GasEstimate; est == nil || est.GasLimit != 80000 || est.Adjustment != 0 {
+		t.Fatalf("GasEstimate = %+v", result.GasEstimate)
+	}
+	if !strings.Contains(result.Command.String(), "--gas 80000") {
+		t.Errorf("explicit gas was replaced: %s", result.Command)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "run out of gas") {
+		t.Errorf("Warnings = %v", result.Warnings)
+	}
+}
+
+func TestSimulateGasStep_FailureIsWarning(t *testing.T) {
+	var request map[string]json.RawMessage
+	srv := mockSimulateREST(t, "", &request)
+
+	opts := ValidatorActionOptions{
+		Network:  NetworkLocalnet,
+		From:     testSigner(t).Address(),
+		Gas:      "auto",
+		REST:     srv.URL,
+		Simulate: true,
+	}
+	result, err := BankSendAction(context.Background(), opts, BankSendParams{ToAddress: testSigner(t).Address(), Amount: "1alyth"})
+	if err != nil {
+		t.Fatalf("BankSendAction() error = %v", err)
+	}
+	if result.GasEstimate != nil {
+		t.Errorf("GasEstimate = %+v, want nil", result.GasEstimate)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "insufficient funds") {
+		t.Errorf("Warnings = %v", result.Warnings)
+	}
+	if !strings.Contains(result.Command.String(), "--gas auto") {
+		t.Errorf("gas should stay auto: %s", result.Command)
+	}
+}
+
+func TestSimulateGas_Multisig(t *testing.T) {
+	var request map[string]json.RawMessage
+	srv := mockSimulateREST(t, "120000", &request)
+	_, ms := testMultisig(t)
+
+	opts := ValidatorActionOptions{
+		Network:  NetworkLocalnet,
+		From:     "mono1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+		Gas:      "auto",
+		REST:     srv.URL,
+		Simulate: true,
+		Multisig: ms,
+	}
+	result, _ := DelegateAction(context.Background(), opts, DelegateParams{ValidatorAddr: testValoper, Amount: "1alyth"})
+	if result.GasEstimate == nil {
+		t.Fatalf("simulation failed: %v", result.Warnings)
+	}
+	if _, ok := request["tx_bytes"]; !ok {
+		t.Errorf("multisig tx should encode natively, got %v", request)
+	}
+}
+
+// fakeTxMonod answers keys show for the key "ops" and prints an unsigned
+// bank send for --generate-only.
+const fakeTxMonod = `#!/bin/sh
+case "$1 $2" in
+"keys show")
+  [ "$3" = ops ] || { echo "$3.info: key not found" >&2; exit 1; }
+  echo mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u ;;
+tx\ *)
+  echo '{"body":{"messages":[{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u","to_address":"mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u","amount":[{"denom":"alyth","amount":"1"}]}],"memo":""},"auth_info":{"signer_infos":[],"fee":{"amount":[],"gas_limit":"200000"}},"signatures":[]}' ;;
+esac
+`
+
+func TestSimulateGasStep_KeyName(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake monod is a shell script")
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "monod"), []byte(fakeTxMonod), 0755)
+	t.Setenv("HOME", dir)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	var request map[string]json.RawMessage
+	srv := mockSimulateREST(t, "100000", &request)
+	opts := ValidatorActionOptions{
+		Network:        NetworkLocalnet,
+		From:           "ops",
+		KeyringBackend: "test",
+		Gas:            "auto",
+		REST:           srv.URL,
+		Simulate:       true,
+	}
+	result, err := BankSendAction(context.Background(), opts, BankSendParams{ToAddress: testSigner(t).Address(), Amount: "1alyth"})
+	if err != nil {
+		t.Fatalf("BankSendAction() error = %v", err)
+	}
+	if result.GasEstimate == nil || result.GasEstimate.GasUsed != 100000 {
+		t.Fatalf("GasEstimate = %+v, warnings %v", result.GasEstimate, result.Warnings)
+	}
+	if cmd := result.Command.String(); !strings.Contains(cmd, "--from ops") || !strings.Contains(cmd, "--gas 150000") {
+		t.Errorf("command = %s, want the key name and the simulated gas", cmd)
+	}
+
+	opts.From = "missing"
+	result, _ = BankSendAction(context.Background(), opts, BankSendParams{ToAddress: testSigner(t).Address(), Amount: "1alyth"})
+	var step ActionStep
+	for _, s := range result.Steps {
+		if s.Name == "Simulate gas" {
+			step = s
+		}
+	}
+	if step.Status != "skipped" || !strings.Contains(step.Message, `could not resolve key "missing"`) {
+		t.Errorf("simulate step = %+v, want skipped with the reason", step)
+	}
+}
@@ -17,7 +17,12 @@ type GenesisDoc struct {
 
 // VerifyGenesisSHA256 verifies that the genesis file matches the expected hash.
 func VerifyGenesisSHA256(genesisPath, expectedSHA string) error {
-	data, err := os.ReadFile(genesisPath)
+	return VerifyGenesisSHA256FS(OSFS{}, genesisPath, expectedSHA)
+}
+
+// VerifyGenesisSHA256FS is VerifyGenesisSHA256 on fsys.
+func VerifyGenesisSHA256FS(fsys FS, genesisPath, expectedSHA string) error {
+	data, err := fsys.ReadFile(genesisPath)
 	if err != nil {
 		return fmt.Errorf("failed to read genesis file: %w", err)
 	}
@@ -50,7 +55,12 @@ func ComputeSHA256(path string) (string, error) {
 
 // ParseGenesisChainID extracts the chain_id from a genesis file.
 func ParseGenesisChainID(genesisPath string) (string, error) {
-	data, err := os.ReadFile(genesisPath)
+	return ParseGenesisChainIDFS(OSFS{}, genesisPath)
+}
+
+// ParseGenesisChainIDFS is ParseGenesisChainID on fsys.
+func ParseGenesisChainIDFS(fsys FS, genesisPath string) (string, error) {
+	data, err := fsys.ReadFile(genesisPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read genesis file: %w", err)
 	}
@@ -69,6 +79,11 @@ func ParseGenesisChainID(genesisPath string) (string, error) {
 
 // WriteGenesis writes genesis data to the specified home directory.
 func WriteGenesis(home string, data []byte, dryRun bool) (string, error) {
+	return WriteGenesisFS(OSFS{}, home, data, dryRun)
+}
+
+// WriteGenesisFS is WriteGenesis on fsys.
+func WriteGenesisFS(fsys FS, home string, data []byte, dryRun bool) (string, error) {
 	configDir := filepath.Join(home, "config")
 	genesisPath := filepath.Join(configDir, "genesis.json")
 
@@ -76,11 +91,11 @@ func WriteGenesis(home string, data []byte, dryRun bool) (string, error) {
 		return genesisPath, nil
 	}
 
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	if err := fsys.MkdirAll(configDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create config directory: %w", err)
 	}
 
-	if err := os.WriteFile(genesisPath, data, 0644); err != nil {
+	if err := fsys.WriteFile(genesisPath, data, 0644); err != nil {
 		return "", fmt.Errorf("failed to write genesis file: %w", err)
 	}
 
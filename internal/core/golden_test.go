@@ -1,6 +1,7 @@
 package core
 
 import (
+	"encoding/hex"
 	"os"
 	"path/filepath"
 	"strings"
@@ -109,6 +110,46 @@ func TestGolden_VoteCommand(t *testing.T) {
 	}
 }
 
+func TestGolden_CreateValidatorMsgs(t *testing.T) {
+	pubKeyPath := filepath.Join(t.TempDir(), "pubkey.json")
+	os.WriteFile(pubKeyPath, []byte(`{"@type":"/cosmos.crypto.ed25519.PubKey","key":"Jtn3cPtAjPhRXJMH8WnkB5vg3YS1wMG9MfJgfX3wRPY="}`), 0644)
+
+	opts := TxBuilderOptions{
+		Network: NetworkSprintnet,
+		From:    testSigner(t).Address(),
+		Fees:    "10000alyth",
+	}
+	params := CreateValidatorParams{
+		Moniker:             "golden-val",
+		Website:             "https://example.com",
+		CommissionRate:      "0.10",
+		CommissionMaxRate:   "0.20",
+		CommissionMaxChange: "0.01",
+		MinSelfDelegation:   "100000000000000000000000alyth",
+		Amount:              "200000000000000000000000alyth",
+		PubKeyPath:          pubKeyPath,
+	}
+
+	cmd, err := BuildCreateValidatorTx(opts, params)
+	if err != nil {
+		t.Fatalf("BuildCreateValidatorTx() error = %v", err)
+	}
+
+	if len(cmd.Msgs) != 2 {
+		t.Fatalf("got %d messages, want create-validator and burn", len(cmd.Msgs))
+	}
+
+	// One line per message: type URL and protobuf hex
+	var lines []string
+	for _, msg := range cmd.Msgs {
+		lines = append(lines, msg.TypeURL()+" "+hex.EncodeToString(msg.MarshalProto()))
+	}
+	got := strings.Join(lines, "\n")
+	if golden := loadGolden(t, "create_validator_msgs.txt"); got != golden {
+		t.Errorf("create-validator messages mismatch:\ngot:\n%s\n\nwant:\n%s", got, golden)
+	}
+}
+
 // loadGolden loads a golden file from testdata/golden/
 func loadGolden(t *testing.T, filename string) string {
 	t.Helper()
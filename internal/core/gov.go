@@ -0,0 +1,503 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// ProposalFile is the proposal JSON accepted by `monod tx gov submit-proposal`.
+type ProposalFile struct {
+	Messages  []json.RawMessage `json:"messages"`
+	Metadata  string            `json:"metadata"`
+	Deposit   string            `json:"deposit"`
+	Title     string            `json:"title"`
+	Summary   string            `json:"summary"`
+	Expedited bool              `json:"expedited,omitempty"`
+}
+
+// Validate checks the fields monod and the gov module require.
+func (p *ProposalFile) Validate() error {
+	if strings.TrimSpace(p.Title) == "" {
+		return fmt.Errorf("proposal title is required")
+	}
+	if strings.TrimSpace(p.Summary) == "" {
+		return fmt.Errorf("proposal summary is required")
+	}
+	for i, raw := range p.Messages {
+		var header struct {
+			Type string `json:"@type"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil || header.Type == "" {
+			return fmt.Errorf("proposal message %d has no @type", i+1)
+		}
+	}
+	if p.Deposit != "" {
+		if err := ValidateAmount(p.Deposit); err != nil {
+			return fmt.Errorf("deposit: %w", err)
+		}
+	}
+	return nil
+}
+
+// toMsg returns the proposal as a MsgSubmitProposal from proposer. It fails
+// if a proposal message cannot be encoded natively.
+func (p *ProposalFile) toMsg(proposer string) (*MsgSubmitProposal, error) {
+	deposit, err := parseCoin(p.Deposit)
+	if err != nil {
+		return nil, err
+	}
+	msg := &MsgSubmitProposal{
+		InitialDeposit: []Coin{deposit},
+		Proposer:       proposer,
+		Metadata:       p.Metadata,
+		Title:          p.Title,
+		Summary:        p.Summary,
+		Expedited:      p.Expedited,
+	}
+	for _, raw := range p.Messages {
+		inner, err := UnmarshalTxMsgJSON(raw)
+		if err != nil {
+			return nil, err
+		}
+		msg.Messages = append(msg.Messages, inner)
+	}
+	return msg, nil
+}
+
+// LoadProposalFile reads and validates a proposal file.
+func LoadProposalFile(path string) (*ProposalFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var p ProposalFile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse proposal file: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// WriteProposalFile writes a proposal file for monod.
+func WriteProposalFile(path string, p *ProposalFile) error {
+	if p.Messages == nil {
+		p.Messages = []json.RawMessage{}
+	}
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Proposal status names of the gov v1 ProposalStatus enum.
+const (
+	ProposalStatusDepositPeriod = "PROPOSAL_STATUS_DEPOSIT_PERIOD"
+	ProposalStatusVotingPeriod  = "PROPOSAL_STATUS_VOTING_PERIOD"
+	ProposalStatusPassed        = "PROPOSAL_STATUS_PASSED"
+	ProposalStatusRejected      = "PROPOSAL_STATUS_REJECTED"
+	ProposalStatusFailed        = "PROPOSAL_STATUS_FAILED"
+)
+
+// ParseProposalStatus maps a status filter (voting, deposit, passed,
+// rejected, failed or all) to its ProposalStatus name; "all" maps to "".
+func ParseProposalStatus(status string) (string, error) {
+	switch strings.ToLower(status) {
+	case "", "all":
+		return "", nil
+	case "voting", "active":
+		return ProposalStatusVotingPeriod, nil
+	case "deposit":
+		return ProposalStatusDepositPeriod, nil
+	case "passed":
+		return ProposalStatusPassed, nil
+	case "rejected":
+		return ProposalStatusRejected, nil
+	case "failed":
+		return ProposalStatusFailed, nil
+	default:
+		return "", fmt.Errorf("invalid proposal status: %s (valid: voting, deposit, passed, rejected, failed, all)", status)
+	}
+}
+
+// proposalStatusLabel returns the short form of a ProposalStatus name.
+func proposalStatusLabel(status string) string {
+	switch status {
+	case ProposalStatusVotingPeriod:
+		return "voting"
+	case ProposalStatusDepositPeriod:
+		return "deposit"
+	default:
+		return strings.ToLower(strings.TrimPrefix(status, "PROPOSAL_STATUS_"))
+	}
+}
+
+// GovProposal is a governance proposal as shown by `gov list` and `gov show`.
+type GovProposal struct {
+	ID              uint64    `json:"id"`
+	Title           string    `json:"title"`
+	Summary         string    `json:"summary"`
+	Status          string    `json:"status"` // voting, deposit, passed, rejected or failed
+	Messages        []string  `json:"messages"`
+	Proposer        string    `json:"proposer"`
+	Metadata        string    `json:"metadata,omitempty"`
+	Expedited       bool      `json:"expedited"`
+	SubmitTime      time.Time `json:"submit_time"`
+	DepositEndTime  time.Time `json:"deposit_end_time"`
+	VotingStartTime time.Time `json:"voting_start_time"`
+	VotingEndTime   time.Time `json:"voting_end_time"`
+	TotalDeposit    []Coin    `json:"total_deposit"`
+	FailedReason    string    `json:"failed_reason,omitempty"`
+	// Vote is the voter's vote during the voting period: an option such as
+	// "yes", a split like "yes 70% / no 30%", or "not voted". Empty when no
+	// voter was given or the proposal is not in its voting period.
+	Vote string `json:"vote,omitempty"`
+}
+
+// Voting reports whether the proposal is in its voting period.
+func (p *GovProposal) Voting() bool {
+	return p.Status == "voting"
+}
+
+// newGovProposal converts a REST proposal.
+func newGovProposal(p *rpc.Proposal) (*GovProposal, error) {
+	id, err := strconv.ParseUint(p.ID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal id %q", p.ID)
+	}
+	gp := &GovProposal{
+		ID:              id,
+		Title:           p.Title,
+		Summary:         p.Summary,
+		Status:          proposalStatusLabel(p.Status),
+		Messages:        make([]string, 0, len(p.Messages)),
+		Proposer:        p.Proposer,
+		Metadata:        p.Metadata,
+		Expedited:       p.Expedited,
+		SubmitTime:      p.SubmitTime,
+		DepositEndTime:  p.DepositEndTime,
+		VotingStartTime: p.VotingStartTime,
+		VotingEndTime:   p.VotingEndTime,
+		TotalDeposit:    make([]Coin, 0, len(p.TotalDeposit)),
+		FailedReason:    p.FailedReason,
+	}
+	for _, m := range p.Messages {
+		gp.Messages = append(gp.Messages, m.Type)
+	}
+	for _, c := range p.TotalDeposit {
+		gp.TotalDeposit = append(gp.TotalDeposit, Coin{Denom: c.Denom, Amount: c.Amount})
+	}
+	return gp, nil
+}
+
+// lookupVote fills in voter's vote on a proposal in its voting period. Votes
+// are pruned once voting ends, so other proposals are left alone.
+func (p *GovProposal) lookupVote(client *rpc.CosmosClient, voter string) error {
+	if voter == "" || !p.Voting() {
+		return nil
+	}
+	vote, err := client.ProposalVote(p.ID, voter)
+	if err != nil {
+		return err
+	}
+	p.Vote = formatVote(vote)
+	return nil
+}
+
+// formatVote describes a vote; nil means the voter has not voted.
+func formatVote(vote *rpc.Vote) string {
+	if vote == nil || len(vote.Options) == 0 {
+		return "not voted"
+	}
+	if len(vote.Options) == 1 {
+		return voteOptionLabel(vote.Options[0].Option)
+	}
+	parts := make([]string, 0, len(vote.Options))
+	for _, o := range vote.Options {
+		weight, _ := strconv.ParseFloat(o.Weight, 64)
+		parts = append(parts, fmt.Sprintf("%s %.0f%%", voteOptionLabel(o.Option), weight*100))
+	}
+	return strings.Join(parts, " / ")
+}
+
+// voteOptionLabel returns "yes" for VOTE_OPTION_YES and so on.
+func voteOptionLabel(option string) string {
+	return strings.ToLower(strings.TrimPrefix(option, "VOTE_OPTION_"))
+}
+
+// ListProposals fetches up to limit proposals with the given status filter,
+// newest first. If voter is set, the voter's vote is looked up for each
+// proposal in its voting period.
+func ListProposals(client *rpc.CosmosClient, status, voter string, limit int) ([]GovProposal, error) {
+	statusName, err := ParseProposalStatus(status)
+	if err != nil {
+		return nil, err
+	}
+	proposals, err := client.Proposals(statusName, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]GovProposal, 0, len(proposals))
+	for i := range proposals {
+		gp, err := newGovProposal(&proposals[i])
+		if err != nil {
+			return nil, err
+		}
+		if err := gp.lookupVote(client, voter); err != nil {
+			return nil, fmt.Errorf("proposal %d: %w", gp.ID, err)
+		}
+		result = append(result, *gp)
+	}
+	return result, nil
+}
+
+// GetProposal fetches one proposal and, if voter is set, the voter's vote.
+func GetProposal(client *rpc.CosmosClient, id uint64, voter string) (*GovProposal, error) {
+	p, err := client.Proposal(id)
+	if err != nil {
+		return nil, err
+	}
+	gp, err := newGovProposal(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := gp.lookupVote(client, voter); err != nil {
+		return nil, err
+	}
+	return gp, nil
+}
+
+// PendingVoteUrgent is how close to the end of voting a missing vote becomes
+// critical.
+const PendingVoteUrgent = 24 * time.Hour
+
+// GovVoterAddress returns the account address that votes for addr. A
+// validator votes with its operator's account, so a monovaloper1... address
+// is converted; a mono1... address is returned as is.
+func GovVoterAddress(addr string) (string, error) {
+	if strings.HasPrefix(addr, Bech32PrefixValAddr) {
+		return ValoperToAccountAddress(addr)
+	}
+	if err := ValidateAddress(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
+
+// PendingVotes returns the proposals in their voting period that voter has
+// not voted on, ordered by voting end time, soonest first.
+func PendingVotes(client *rpc.CosmosClient, voter string) ([]GovProposal, error) {
+	if voter == "" {
+		return nil, fmt.Errorf("voter address is required")
+	}
+	proposals, err := ListProposals(client, "voting", voter, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]GovProposal, 0, len(proposals))
+	for _, p := range proposals {
+		if p.Vote == "not voted" {
+			pending = append(pending, p)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].VotingEndTime.Before(pending[j].VotingEndTime)
+	})
+	return pending, nil
+}
+
+// pendingVoteDetail is one proposal in a pending votes alert.
+type pendingVoteDetail struct {
+	ID            uint64    `json:"id"`
+	Title         string    `json:"title"`
+	VotingEndTime time.Time `json:"voting_end_time"`
+	TimeLeft      string    `json:"time_left"`
+}
+
+// PendingVotesAlert builds the monitor alert for proposals voter has not
+// voted on. It is critical if any vote is due within PendingVoteUrgent.
+func PendingVotesAlert(voter string, pending []GovProposal, now time.Time) MonitorAlert {
+	severity := "warning"
+	details := make([]pendingVoteDetail, 0, len(pending))
+	for _, p := range pending {
+		left := p.VotingEndTime.Sub(now)
+		if left < PendingVoteUrgent {
+			severity = "critical"
+		}
+		details = append(details, pendingVoteDetail{
+			ID:            p.ID,
+			Title:         p.Title,
+			VotingEndTime: p.VotingEndTime,
+			TimeLeft:      FormatTimeLeft(left),
+		})
+	}
+
+	message := fmt.Sprintf("%s has not voted on %d governance proposal(s)", voter, len(pending))
+	if len(pending) > 0 {
+		message += fmt.Sprintf("; voting on #%d ends in %s", pending[0].ID, details[0].TimeLeft)
+	}
+	return MonitorAlert{
+		Kind:     "gov_pending_votes",
+		Severity: severity,
+		Message:  message,
+		Details:  details,
+	}
+}
+
+// ProposalTally is a proposal's tally with the outcome it would have if
+// voting ended now. Counts are voting power in alyth.
+type ProposalTally struct {
+	ProposalID uint64   `json:"proposal_id"`
+	Status     string   `json:"status"`
+	Final      bool     `json:"final"` // voting has ended and the counts are final
+	Yes        *big.Int `json:"yes"`
+	No         *big.Int `json:"no"`
+	Abstain    *big.Int `json:"abstain"`
+	NoWithVeto *big.Int `json:"no_with_veto"`
+	// Bonded is the total bonded stake; nil if it could not be fetched,
+	// in which case turnout and the projected outcome are unknown.
+	Bonded        *big.Int `json:"bonded,omitempty"`
+	Quorum        float64  `json:"quorum"`
+	Threshold     float64  `json:"threshold"`
+	VetoThreshold float64  `json:"veto_threshold"`
+}
+
+// Total returns the total voting power that voted.
+func (t *ProposalTally) Total() *big.Int {
+	total := new(big.Int).Add(t.Yes, t.No)
+	total.Add(total, t.Abstain)
+	return total.Add(total, t.NoWithVeto)
+}
+
+// ratio returns a/b as a float, or 0 if b is zero.
+func ratio(a, b *big.Int) float64 {
+	if b == nil || b.Sign() == 0 {
+		return 0
+	}
+	f, _ := new(big.Rat).SetFrac(a, b).Float64()
+	return f
+}
+
+// Share returns v as a fraction of all votes.
+func (t *ProposalTally) Share(v *big.Int) float64 {
+	return ratio(v, t.Total())
+}
+
+// Turnout returns the fraction of bonded stake that voted.
+func (t *ProposalTally) Turnout() float64 {
+	return ratio(t.Total(), t.Bonded)
+}
+
+// Outcome applies the gov module's tally rules to the current counts:
+// "passing", "failing", "vetoed", "quorum not reached", or "unknown" when
+// the bonded stake or tally params are not known.
+func (t *ProposalTally) Outcome() string {
+	if t.Bonded == nil || t.Quorum == 0 {
+		return "unknown"
+	}
+	total := t.Total()
+	if t.Turnout() < t.Quorum {
+		return "quorum not reached"
+	}
+	if ratio(t.NoWithVeto, total) > t.VetoThreshold {
+		return "vetoed"
+	}
+	nonAbstain := new(big.Int).Sub(total, t.Abstain)
+	if nonAbstain.Sign() > 0 && ratio(t.Yes, nonAbstain) > t.Threshold {
+		return "passing"
+	}
+	return "failing"
+}
+
+// parseVotingPower parses a tally count; v1 tallies may be decimals.
+func parseVotingPower(s string) *big.Int {
+	if s == "" {
+		return new(big.Int)
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return new(big.Int)
+	}
+	return new(big.Int).Quo(r.Num(), r.Denom())
+}
+
+// parseDec parses a gov param decimal such as "0.334000000000000000".
+func parseDec(s string) float64 {
+	f, _ := strconv.ParseFloat(s, 64)
+	return f
+}
+
+// TallyProposal fetches a proposal's tally: the live tally during voting,
+// the final tally afterwards. The tally params and bonded stake are fetched
+// for turnout and the projected outcome; if they are unavailable only the
+// counts are reported.
+func TallyProposal(client *rpc.CosmosClient, id uint64) (*ProposalTally, error) {
+	p, err := client.Proposal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &p.FinalTallyResult
+	if p.Status == ProposalStatusVotingPeriod {
+		if counts, err = client.ProposalTally(id); err != nil {
+			return nil, err
+		}
+	}
+	final := p.Status != ProposalStatusVotingPeriod && p.Status != ProposalStatusDepositPeriod
+
+	t := &ProposalTally{
+		ProposalID: id,
+		Status:     proposalStatusLabel(p.Status),
+		Final:      final,
+		Yes:        parseVotingPower(counts.YesCount),
+		No:         parseVotingPower(counts.NoCount),
+		Abstain:    parseVotingPower(counts.AbstainCount),
+		NoWithVeto: parseVotingPower(counts.NoWithVetoCount),
+	}
+
+	if params, err := client.TallyParams(); err == nil {
+		t.Quorum = parseDec(params.Quorum)
+		t.Threshold = parseDec(params.Threshold)
+		t.VetoThreshold = parseDec(params.VetoThreshold)
+		if p.Expedited && params.ExpeditedThreshold != "" {
+			t.Threshold = parseDec(params.ExpeditedThreshold)
+		}
+	}
+	if pool, err := client.StakingPool(); err == nil {
+		if bonded, ok := new(big.Int).SetString(pool.BondedTokens, 10); ok {
+			t.Bonded = bonded
+		}
+	}
+	return t, nil
+}
+
+// FormatTimeLeft formats the time until a deadline, e.g. "2d 4h" or "35m".
+func FormatTimeLeft(d time.Duration) string {
+	if d <= 0 {
+		return "ended"
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
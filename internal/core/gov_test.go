@@ -0,0 +1,266 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+const testVoter = "mono1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq"
+
+// mockGovREST serves two proposals in their voting period (7 and 6) and one
+// that passed (5). testVoter voted yes on 7 only.
+func mockGovREST(t *testing.T, tally string) *httptest.Server {
+	t.Helper()
+	proposal := func(id, status string) string {
+		return `{"id":"` + id + `","status":"` + status + `","title":"Proposal ` + id + `","summary":"s",
+			"messages":[{"@type":"/cosmos.gov.v1.MsgExecLegacyContent"}],
+			"voting_end_time":"2030-01-01T00:00:00Z","total_deposit":[{"denom":"alyth","amount":"10"}],
+			"final_tally_result":{"yes_count":"90","no_count":"10","abstain_count":"0","no_with_veto_count":"0"}}`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cosmos/gov/v1/proposals":
+			if r.URL.Query().Get("proposal_status") != ProposalStatusVotingPeriod {
+				t.Errorf("proposal_status = %q", r.URL.Query().Get("proposal_status"))
+			}
+			w.Write([]byte(`{"proposals":[` + proposal("7", ProposalStatusVotingPeriod) + `,` + proposal("6", ProposalStatusVotingPeriod) + `]}`))
+		case "/cosmos/gov/v1/proposals/7":
+			w.Write([]byte(`{"proposal":` + proposal("7", ProposalStatusVotingPeriod) + `}`))
+		case "/cosmos/gov/v1/proposals/5":
+			w.Write([]byte(`{"proposal":` + proposal("5", ProposalStatusPassed) + `}`))
+		case "/cosmos/gov/v1/proposals/7/votes/" + testVoter:
+			w.Write([]byte(`{"vote":{"proposal_id":"7","voter":"` + testVoter + `","options":[{"option":"VOTE_OPTION_YES","weight":"1.000000000000000000"}]}}`))
+		case "/cosmos/gov/v1/proposals/6/votes/" + testVoter:
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":3,"message":"voter: ` + testVoter + ` not found for proposal: 6"}`))
+		case "/cosmos/gov/v1/proposals/7/tally":
+			w.Write([]byte(`{"tally":` + tally + `}`))
+		case "/cosmos/gov/v1/params/tallying":
+			w.Write([]byte(`{"params":{"quorum":"0.334000000000000000","threshold":"0.500000000000000000","veto_threshold":"0.334000000000000000"}}`))
+		case "/cosmos/staking/v1beta1/pool":
+			w.Write([]byte(`{"pool":{"not_bonded_tokens":"0","bonded_tokens":"1000"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestListProposals_VoterStatus(t *testing.T) {
+	srv := mockGovREST(t, "{}")
+
+	proposals, err := ListProposals(rpc.NewCosmosClient(srv.URL), "voting", testVoter, 10)
+	if err != nil {
+		t.Fatalf("ListProposals() error = %v", err)
+	}
+	if len(proposals) != 2 {
+		t.Fatalf("got %d proposals, want 2", len(proposals))
+	}
+	if p := proposals[0]; p.ID != 7 || p.Status != "voting" || p.Vote != "yes" || p.VotingEndTime.Year() != 2030 {
+		t.Errorf("proposal 7 = %+v", p)
+	}
+	if proposals[1].Vote != "not voted" {
+		t.Errorf("proposal 6 vote = %q, want not voted", proposals[1].Vote)
+	}
+
+	if _, err := ListProposals(rpc.NewCosmosClient(srv.URL), "open", "", 10); err == nil {
+		t.Error("expected error for invalid status")
+	}
+}
+
+func TestPendingVotes(t *testing.T) {
+	srv := mockGovREST(t, "{}")
+
+	pending, err := PendingVotes(rpc.NewCosmosClient(srv.URL), testVoter)
+	if err != nil {
+		t.Fatalf("PendingVotes() error = %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != 6 {
+		t.Fatalf("pending = %+v, want proposal 6 only", pending)
+	}
+
+	alert := PendingVotesAlert(testVoter, pending, time.Date(2029, 12, 30, 0, 0, 0, 0, time.UTC))
+	if alert.Severity != "warning" || !strings.Contains(alert.Message, "voting on #6 ends in 2d 0h") {
+		t.Errorf("alert = %+v", alert)
+	}
+	alert = PendingVotesAlert(testVoter, pending, time.Date(2029, 12, 31, 12, 0, 0, 0, time.UTC))
+	if alert.Severity != "critical" {
+		t.Errorf("severity = %q, want critical within a day of the deadline", alert.Severity)
+	}
+}
+
+func TestGovVoterAddress(t *testing.T) {
+	account := testSigner(t).Address()
+	valoper, err := AccountToValoperAddress(account)
+	if err != nil {
+		t.Fatalf("AccountToValoperAddress() error = %v", err)
+	}
+	if got, err := GovVoterAddress(valoper); err != nil || got != account {
+		t.Errorf("GovVoterAddress(%s) = %s, %v; want %s", valoper, got, err, account)
+	}
+	if got, _ := GovVoterAddress(account); got != account {
+		t.Errorf("GovVoterAddress(%s) = %s", account, got)
+	}
+	if _, err := GovVoterAddress("cosmos1abc"); err == nil {
+		t.Error("expected error for foreign address")
+	}
+}
+
+func TestSendAlert(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(nil)
+	keys := &MonitorKeys{NodeID: "node-1", PublicKey: pub, PrivateKey: priv}
+
+	var got AlertPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/alert" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	payload, err := SignAlert(keys, "Testnet", MonitorAlert{Kind: "gov_pending_votes", Severity: "warning", Message: "m"})
+	if err != nil {
+		t.Fatalf("SignAlert() error = %v", err)
+	}
+	if err := SendAlert(context.Background(), srv.URL, payload); err != nil {
+		t.Fatalf("SendAlert() error = %v", err)
+	}
+
+	sig, _ := base64.StdEncoding.DecodeString(got.Signature)
+	alertHash, _ := AlertHash([]byte(`{"kind":"gov_pending_votes","severity":"warning","message":"m"}`))
+	if got.AlertHash != alertHash {
+		t.Errorf("alert_hash = %s, want %s", got.AlertHash, alertHash)
+	}
+	message := fmt.Sprintf("%s|%s|%d|%s|%s", got.NodeID, got.Network, got.TimestampUnix, got.Nonce, alertHash)
+	if !ed25519.Verify(pub, []byte(message), sig) {
+		t.Error("alert signature does not verify")
+	}
+	if got.Alert.Kind != "gov_pending_votes" {
+		t.Errorf("alert = %+v", got.Alert)
+	}
+}
+
+func TestTallyProposal(t *testing.T) {
+	tests := []struct {
+		name    string
+		tally   string
+		outcome string
+	}{
+		{"passing", `{"yes_count":"300","no_count":"100","abstain_count":"100","no_with_veto_count":"0"}`, "passing"},
+		{"failing", `{"yes_count":"100","no_count":"300","abstain_count":"0","no_with_veto_count":"0"}`, "failing"},
+		{"vetoed", `{"yes_count":"200","no_count":"0","abstain_count":"0","no_with_veto_count":"200"}`, "vetoed"},
+		{"no quorum", `{"yes_count":"300","no_count":"0","abstain_count":"0","no_with_veto_count":"0"}`, "quorum not reached"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := mockGovREST(t, tt.tally)
+			tally, err := TallyProposal(rpc.NewCosmosClient(srv.URL), 7)
+			if err != nil {
+				t.Fatalf("TallyProposal() error = %v", err)
+			}
+			if tally.Final || tally.Bonded.Cmp(big.NewInt(1000)) != 0 {
+				t.Errorf("tally = %+v", tally)
+			}
+			if got := tally.Outcome(); got != tt.outcome {
+				t.Errorf("Outcome() = %q, want %q", got, tt.outcome)
+			}
+		})
+	}
+
+	// A finished proposal reports its final tally
+	srv := mockGovREST(t, "{}")
+	tally, err := TallyProposal(rpc.NewCosmosClient(srv.URL), 5)
+	if err != nil {
+		t.Fatalf("TallyProposal() error = %v", err)
+	}
+	if !tally.Final || tally.Yes.Int64() != 90 || tally.Share(tally.Yes) != 0.9 {
+		t.Errorf("final tally = %+v", tally)
+	}
+}
+
+func TestBuildDepositTx(t *testing.T) {
+	opts := TxBuilderOptions{Network: NetworkLocalnet, From: testVoter, Fees: "5000alyth"}
+
+	cmd, err := BuildDepositTx(opts, DepositParams{ProposalID: "4", Amount: "1000alyth"})
+	if err != nil {
+		t.Fatalf("BuildDepositTx() error = %v", err)
+	}
+	if !strings.Contains(cmd.String(), "tx gov deposit 4 1000alyth") {
+		t.Errorf("command = %s", cmd)
+	}
+	msg, ok := cmd.Msgs[0].(*MsgDeposit)
+	if !ok || msg.ProposalID != 4 || msg.Depositor != testVoter || msg.Amount[0].Amount != "1000" {
+		t.Errorf("Msgs = %+v", cmd.Msgs)
+	}
+
+	if _, err := BuildDepositTx(opts, DepositParams{ProposalID: "4x", Amount: "1000alyth"}); err == nil {
+		t.Error("expected error for invalid proposal ID")
+	}
+}
+
+func TestBuildSubmitProposalTx(t *testing.T) {
+	opts := TxBuilderOptions{Network: NetworkLocalnet, From: testVoter, Fees: "5000alyth"}
+	path := filepath.Join(t.TempDir(), "proposal.json")
+	os.WriteFile(path, []byte(`{"messages":[],"metadata":"ipfs://x","title":"Text","summary":"Signal"}`), 0644)
+
+	proposal, err := LoadProposalFile(path)
+	if err != nil {
+		t.Fatalf("LoadProposalFile() error = %v", err)
+	}
+	if _, err := BuildSubmitProposalTx(opts, SubmitProposalParams{ProposalPath: path, Proposal: proposal}); err == nil {
+		t.Error("expected error without a deposit")
+	}
+
+	proposal.Deposit = "100alyth"
+	cmd, err := BuildSubmitProposalTx(opts, SubmitProposalParams{ProposalPath: path, Proposal: proposal})
+	if err != nil {
+		t.Fatalf("BuildSubmitProposalTx() error = %v", err)
+	}
+	if !strings.Contains(cmd.String(), "tx gov submit-proposal "+path) {
+		t.Errorf("command = %s", cmd)
+	}
+	if len(cmd.Msgs) != 1 {
+		t.Fatalf("text proposal should sign natively, Msgs = %v", cmd.Msgs)
+	}
+
+	// The native message survives the JSON round trip used by offline signing
+	raw, err := MarshalTxMsgJSON(cmd.Msgs[0])
+	if err != nil {
+		t.Fatalf("MarshalTxMsgJSON() error = %v", err)
+	}
+	decoded, err := UnmarshalTxMsgJSON(raw)
+	if err != nil || !bytes.Equal(decoded.MarshalProto(), cmd.Msgs[0].MarshalProto()) {
+		t.Errorf("MsgSubmitProposal did not round-trip: %s, %v", raw, err)
+	}
+
+	// Messages without a native encoding leave the tx to monod
+	proposal.Messages = []json.RawMessage{json.RawMessage(`{"@type":"/cosmos.upgrade.v1beta1.MsgSoftwareUpgrade"}`)}
+	cmd, err = BuildSubmitProposalTx(opts, SubmitProposalParams{ProposalPath: path, Proposal: proposal})
+	if err != nil {
+		t.Fatalf("BuildSubmitProposalTx() error = %v", err)
+	}
+	if len(cmd.Msgs) != 0 {
+		t.Errorf("Msgs = %v, want none", cmd.Msgs)
+	}
+
+	proposal.Summary = ""
+	if _, err := BuildSubmitProposalTx(opts, SubmitProposalParams{ProposalPath: path, Proposal: proposal}); err == nil {
+		t.Error("expected error without a summary")
+	}
+}
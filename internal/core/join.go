@@ -25,6 +25,20 @@ const (
 	SyncStrategyStateSync SyncStrategy = "statesync"
 )
 
+// ParseSyncStrategy parses a string into a SyncStrategy.
+func ParseSyncStrategy(s string) (SyncStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "default":
+		return SyncStrategyDefault, nil
+	case "bootstrap":
+		return SyncStrategyBootstrap, nil
+	case "statesync", "state-sync":
+		return SyncStrategyStateSync, nil
+	default:
+		return "", fmt.Errorf("unknown sync strategy: %s (valid: default, bootstrap, statesync)", s)
+	}
+}
+
 // JoinOptions contains options for the join operation.
 type JoinOptions struct {
 	Network       NetworkName
@@ -38,6 +52,16 @@ type JoinOptions struct {
 	ClearAddrbook bool         // Clear addrbook.json on bootstrap mode
 	Moniker       string       // Node moniker (auto-generated if empty)
 	MonodPath     string       // Path to monod binary (auto-detected if empty)
+
+	// FS holds Home (default: the local filesystem). On any other FS the
+	// home must already be initialized, snapshots cannot be restored and
+	// external_address is left to the node's host.
+	FS FS
+
+	// Snapshot bootstrap: restore data/ from a verified snapshot before first start.
+	SnapshotURL      string                                // Snapshot archive URL (from NetworkConfig.SnapshotURL)
+	SnapshotSHA256   string                                // Expected SHA256 of the snapshot archive
+	SnapshotProgress func(phase string, done, total int64) // Optional download/extract progress callback
 }
 
 // JoinResult contains the results of a join operation.
@@ -49,7 +73,9 @@ type JoinResult struct {
 	NodeID          string
 	Success         bool
 	Steps           []JoinStep
-	Initialized     bool // True if node was initialized during this run
+	Initialized     bool             // True if node was initialized during this run
+	StateSync       *StateSyncConfig // Resolved [statesync] values (statesync mode only)
+	Snapshot        *SnapshotResult  // Snapshot restore result (snapshot bootstrap only)
 }
 
 // JoinStep represents a step in the join process.
@@ -62,8 +88,13 @@ type JoinStep struct {
 // IsNodeHomeInitialized checks if the node home directory is initialized.
 // A node is considered initialized if config.toml exists.
 func IsNodeHomeInitialized(home string) bool {
+	return IsNodeHomeInitializedFS(OSFS{}, home)
+}
+
+// IsNodeHomeInitializedFS is IsNodeHomeInitialized on fsys.
+func IsNodeHomeInitializedFS(fsys FS, home string) bool {
 	configPath := filepath.Join(home, "config", "config.toml")
-	_, err := os.Stat(configPath)
+	_, err := fsys.Stat(configPath)
 	return err == nil
 }
 
@@ -82,8 +113,13 @@ func (e *PreflightError) Error() string {
 // Returns true if ANY of the database directories exist (state.db, application.db, etc.)
 // This catches partial InitChain failures that leave corrupt state.
 func HasStaleData(home string) bool {
+	return HasStaleDataFS(OSFS{}, home)
+}
+
+// HasStaleDataFS is HasStaleData on fsys.
+func HasStaleDataFS(fsys FS, home string) bool {
 	dataPath := filepath.Join(home, "data")
-	if _, err := os.Stat(dataPath); os.IsNotExist(err) {
+	if _, err := fsys.Stat(dataPath); os.IsNotExist(err) {
 		return false
 	}
 
@@ -103,7 +139,7 @@ func HasStaleData(home string) bool {
 
 	for _, db := range dbDirs {
 		dbPath := filepath.Join(dataPath, db)
-		if _, err := os.Stat(dbPath); err == nil {
+		if _, err := fsys.Stat(dbPath); err == nil {
 			// Directory exists - data is dirty
 			return true
 		}
@@ -115,8 +151,13 @@ func HasStaleData(home string) bool {
 // GetExistingChainID attempts to read the chain-id from the existing genesis.json.
 // Returns empty string if genesis doesn't exist or can't be parsed.
 func GetExistingChainID(home string) string {
+	return GetExistingChainIDFS(OSFS{}, home)
+}
+
+// GetExistingChainIDFS is GetExistingChainID on fsys.
+func GetExistingChainIDFS(fsys FS, home string) string {
 	genesisPath := filepath.Join(home, "config", "genesis.json")
-	data, err := os.ReadFile(genesisPath)
+	data, err := fsys.ReadFile(genesisPath)
 	if err != nil {
 		return ""
 	}
@@ -136,11 +177,16 @@ func GetExistingChainID(home string) string {
 // SDK formatting differences (e.g., initial_height as string vs integer).
 // Consensus equivalence is determined by AppHash, not file SHA.
 func PreflightCheck(home string, expectedChainID string) *PreflightError {
+	return PreflightCheckFS(OSFS{}, home, expectedChainID)
+}
+
+// PreflightCheckFS is PreflightCheck on fsys.
+func PreflightCheckFS(fsys FS, home string, expectedChainID string) *PreflightError {
 	// Check 1: Detect dirty data directory (leftover from failed InitChain)
 	// This is the PRIMARY check - if ANY *.db directories exist, the node will fail.
 	// This must run BEFORE checking initialization status because a partial init
 	// leaves databases but may not complete config file creation.
-	if HasStaleData(home) {
+	if HasStaleDataFS(fsys, home) {
 		return &PreflightError{
 			Type:    "dirty_data",
 			Message: "Detected leftover blockchain state from a failed initialization",
@@ -154,8 +200,8 @@ func PreflightCheck(home string, expectedChainID string) *PreflightError {
 	}
 
 	// Check 2: If node is already initialized, check for chain-id mismatch
-	if IsNodeHomeInitialized(home) {
-		existingChainID := GetExistingChainID(home)
+	if IsNodeHomeInitializedFS(fsys, home) {
+		existingChainID := GetExistingChainIDFS(fsys, home)
 		if existingChainID != "" && existingChainID != expectedChainID {
 			return &PreflightError{
 				Type:    "chain_id_mismatch",
@@ -258,12 +304,25 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		logger = slog.Default()
 	}
 
+	fsys := opts.FS
+	if fsys == nil {
+		fsys = OSFS{}
+	}
+	_, localHome := fsys.(OSFS)
+
 	// Get network config
 	network, err := GetNetwork(opts.Network)
 	if err != nil {
 		return nil, fmt.Errorf("invalid network: %w", err)
 	}
 
+	if opts.SnapshotURL != "" && opts.SyncStrategy == SyncStrategyStateSync {
+		return nil, fmt.Errorf("snapshot restore and state sync cannot be combined")
+	}
+	if opts.SnapshotURL != "" && !localHome {
+		return nil, fmt.Errorf("snapshot restore requires a local node home")
+	}
+
 	// Use network defaults if not specified
 	genesisURL := opts.GenesisURL
 	if genesisURL == "" {
@@ -310,7 +369,7 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 	logger.Info("running preflight checks")
 	result.Steps = append(result.Steps, JoinStep{Name: "Preflight checks", Status: "pending"})
 
-	if preflightErr := PreflightCheck(opts.Home, chainID); preflightErr != nil {
+	if preflightErr := PreflightCheckFS(fsys, opts.Home, chainID); preflightErr != nil {
 		result.Steps[len(result.Steps)-1].Status = "failed"
 		result.Steps[len(result.Steps)-1].Message = preflightErr.Message
 		return result, fmt.Errorf("preflight check failed: %s\n\n%s", preflightErr.Message, preflightErr.Details)
@@ -319,10 +378,16 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 	result.Steps[len(result.Steps)-1].Message = "no issues detected"
 
 	// Step 4: Initialize node home if not already initialized
-	if !IsNodeHomeInitialized(opts.Home) {
+	if !IsNodeHomeInitializedFS(fsys, opts.Home) {
 		logger.Info("initializing node home", "home", opts.Home)
 		result.Steps = append(result.Steps, JoinStep{Name: "Initialize node", Status: "pending"})
 
+		if !localHome {
+			result.Steps[len(result.Steps)-1].Status = "failed"
+			result.Steps[len(result.Steps)-1].Message = "not initialized"
+			return result, fmt.Errorf("cannot initialize node: %s is not initialized; run 'monod init' on its host first", opts.Home)
+		}
+
 		// Find monod binary
 		monodPath, err := FindMonodBinary(opts.MonodPath)
 		if err != nil {
@@ -373,6 +438,7 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 	var seeds []Peer
 	var persistentPeers []Peer
 	var bootstrapPeers []Peer
+	var trustedRPCs []string
 	var pexEnabled = true // Default: pex is enabled
 
 	if peersURL != "" {
@@ -402,6 +468,7 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 					seeds = reg.Seeds
 					persistentPeers = MergePeers(reg.Peers, reg.PersistentPeers)
 					bootstrapPeers = reg.BootstrapPeers
+					trustedRPCs = reg.TrustedRPCEndpoints
 					genesisSHA = reg.GenesisSHA
 					result.Steps[len(result.Steps)-1].Status = "success"
 					result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("%d seeds, %d peers, %d bootstrap", len(seeds), len(persistentPeers), len(bootstrapPeers))
@@ -436,6 +503,24 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		})
 	}
 
+	// Handle state sync strategy
+	var stateSync *StateSyncConfig
+	if opts.SyncStrategy == SyncStrategyStateSync {
+		logger.Info("state sync mode: resolving trust height from trusted RPC endpoints", "endpoints", len(trustedRPCs))
+		result.Steps = append(result.Steps, JoinStep{Name: "Configure state sync", Status: "pending"})
+
+		stateSync, err = ResolveStateSyncConfig(trustedRPCs, network.ChainID, fetcher)
+		if err != nil {
+			result.Steps[len(result.Steps)-1].Status = "failed"
+			result.Steps[len(result.Steps)-1].Message = err.Error()
+			return result, fmt.Errorf("state sync setup failed: %w", err)
+		}
+		result.StateSync = stateSync
+		result.Steps[len(result.Steps)-1].Status = "success"
+		result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("trust_height=%d, trust_hash=%s, %d rpc servers",
+			stateSync.TrustHeight, stateSync.TrustHash, len(stateSync.RPCServers))
+	}
+
 	// Step 6: Verify SHA256 (use opts.GenesisSHA if provided, otherwise use from peers.json)
 	expectedSHA := opts.GenesisSHA
 	if expectedSHA == "" {
@@ -462,7 +547,7 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 	logger.Info("writing genesis", "home", opts.Home, "dry_run", opts.DryRun)
 	result.Steps = append(result.Steps, JoinStep{Name: "Write genesis", Status: "pending"})
 
-	genesisPath, err := WriteGenesis(opts.Home, genesisData, opts.DryRun)
+	genesisPath, err := WriteGenesisFS(fsys, opts.Home, genesisData, opts.DryRun)
 	if err != nil {
 		result.Steps[len(result.Steps)-1].Status = "failed"
 		result.Steps[len(result.Steps)-1].Message = err.Error()
@@ -476,11 +561,37 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		result.Steps[len(result.Steps)-1].Status = "success"
 	}
 
-	// Step 8: Clear addrbook if in bootstrap mode
+	// Step 8: Restore data snapshot if requested
+	if opts.SnapshotURL != "" {
+		logger.Info("restoring data snapshot", "url", opts.SnapshotURL)
+		result.Steps = append(result.Steps, JoinStep{Name: "Restore snapshot", Status: "pending"})
+
+		snap, err := RestoreSnapshot(SnapshotOptions{
+			Home:       opts.Home,
+			URL:        opts.SnapshotURL,
+			SHA256:     opts.SnapshotSHA256,
+			DryRun:     opts.DryRun,
+			OnProgress: opts.SnapshotProgress,
+		})
+		result.Snapshot = snap
+		if err != nil {
+			result.Steps[len(result.Steps)-1].Status = "failed"
+			result.Steps[len(result.Steps)-1].Message = err.Error()
+			return result, fmt.Errorf("failed to restore snapshot: %w", err)
+		}
+		result.Steps[len(result.Steps)-1].Status = "success"
+		if opts.DryRun {
+			result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("(dry-run) %s", opts.SnapshotURL)
+		} else {
+			result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("%d files, sha256 verified", snap.FilesExtracted)
+		}
+	}
+
+	// Step 9: Clear addrbook if in bootstrap mode
 	if opts.SyncStrategy == SyncStrategyBootstrap || opts.ClearAddrbook {
 		logger.Info("clearing addrbook.json")
 		result.Steps = append(result.Steps, JoinStep{Name: "Clear addrbook", Status: "pending"})
-		if err := ClearAddrbook(opts.Home, opts.DryRun); err != nil {
+		if err := ClearAddrbookFS(fsys, opts.Home, opts.DryRun); err != nil {
 			result.Steps[len(result.Steps)-1].Status = "skipped"
 			result.Steps[len(result.Steps)-1].Message = err.Error()
 		} else {
@@ -491,7 +602,7 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		}
 	}
 
-	// Step 9: Apply config
+	// Step 10: Apply config
 	logger.Info("applying config")
 	result.Steps = append(result.Steps, JoinStep{Name: "Apply config", Status: "pending"})
 
@@ -501,10 +612,11 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 	} else {
 		patch = GenerateBootstrapConfigPatch(persistentPeers)
 	}
+	patch.StateSync = stateSync
 
 	// Apply config patch directly to config.toml
 	configPath := filepath.Join(opts.Home, "config", "config.toml")
-	if err := ApplyConfigPatch(configPath, patch, opts.DryRun); err != nil {
+	if err := ApplyConfigPatchFS(fsys, configPath, patch, opts.DryRun); err != nil {
 		result.Steps[len(result.Steps)-1].Status = "failed"
 		result.Steps[len(result.Steps)-1].Message = err.Error()
 		return result, fmt.Errorf("failed to apply config: %w", err)
@@ -516,6 +628,10 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		pexLine = fmt.Sprintf("pex = %v", *patch.PEX)
 	}
 	result.ConfigPatch = fmt.Sprintf("seeds=%q, persistent_peers=%q, %s", patch.Seeds, patch.PersistentPeers, pexLine)
+	if patch.StateSync != nil {
+		result.ConfigPatch += fmt.Sprintf("\n[statesync] enable=true, rpc_servers=%q, trust_height=%d, trust_hash=%q",
+			strings.Join(patch.StateSync.RPCServers, ","), patch.StateSync.TrustHeight, patch.StateSync.TrustHash)
+	}
 
 	if opts.DryRun {
 		result.Steps[len(result.Steps)-1].Status = "success"
@@ -525,13 +641,13 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		result.Steps[len(result.Steps)-1].Message = "config.toml updated"
 	}
 
-	// Step 10: Set chain-id in client.toml (CRITICAL for monod start)
+	// Step 11: Set chain-id in client.toml (CRITICAL for monod start)
 	// Without this, monod start fails with "invalid chain-id on InitChain; expected: , got: <chain-id>"
 	// because the SDK reads chain-id from client.toml, not genesis.json, for ABCI validation.
 	logger.Info("setting chain-id in client.toml", "chain_id", chainID)
 	result.Steps = append(result.Steps, JoinStep{Name: "Set client chain-id", Status: "pending"})
 
-	if err := SetClientChainID(opts.Home, chainID, opts.DryRun); err != nil {
+	if err := SetClientChainIDFS(fsys, opts.Home, chainID, opts.DryRun); err != nil {
 		result.Steps[len(result.Steps)-1].Status = "failed"
 		result.Steps[len(result.Steps)-1].Message = err.Error()
 		return result, fmt.Errorf("failed to set chain-id in client.toml: %w", err)
@@ -545,14 +661,14 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("chain-id = %q", chainID)
 	}
 
-	// Step 11: Set evm-chain-id in app.toml (CRITICAL for EVM determinism)
+	// Step 12: Set evm-chain-id in app.toml (CRITICAL for EVM determinism)
 	// Without the correct evm-chain-id, nodes compute different state for EVM transactions,
 	// causing AppHash mismatches and consensus failures.
 	if network.EVMChainID != 0 {
 		logger.Info("setting evm-chain-id in app.toml", "evm_chain_id", network.EVMChainID)
 		result.Steps = append(result.Steps, JoinStep{Name: "Set EVM chain-id", Status: "pending"})
 
-		if err := SetEVMChainID(opts.Home, network.EVMChainID, opts.DryRun); err != nil {
+		if err := SetEVMChainIDFS(fsys, opts.Home, network.EVMChainID, opts.DryRun); err != nil {
 			result.Steps[len(result.Steps)-1].Status = "failed"
 			result.Steps[len(result.Steps)-1].Message = err.Error()
 			return result, fmt.Errorf("failed to set evm-chain-id in app.toml: %w", err)
@@ -567,20 +683,23 @@ func Join(opts JoinOptions, fetcher Fetcher) (*JoinResult, error) {
 		}
 	}
 
-	// Step 12: Detect and set external_address (CRITICAL for validators)
+	// Step 13: Detect and set external_address (CRITICAL for validators)
 	// Without external_address, other validators cannot connect to receive block proposals,
 	// causing the node to sign but never propose blocks.
 	logger.Info("detecting public IP for external_address")
 	result.Steps = append(result.Steps, JoinStep{Name: "Set external address", Status: "pending"})
 
-	publicIP := net.DetectPublicIP()
-	if publicIP == "" {
+	if !localHome {
+		// The public IP detected here is not the node's
+		result.Steps[len(result.Steps)-1].Status = "skipped"
+		result.Steps[len(result.Steps)-1].Message = "node home is not local - set external_address on the node's host if running as validator"
+	} else if publicIP := net.DetectPublicIP(); publicIP == "" {
 		result.Steps[len(result.Steps)-1].Status = "skipped"
 		result.Steps[len(result.Steps)-1].Message = "could not detect public IP - set external_address manually if running as validator"
 		logger.Warn("could not detect public IP, external_address not set")
 	} else {
 		externalAddr := net.FormatExternalAddress(publicIP, 26656)
-		if err := SetExternalAddress(opts.Home, externalAddr, opts.DryRun); err != nil {
+		if err := SetExternalAddressFS(fsys, opts.Home, externalAddr, opts.DryRun); err != nil {
 			result.Steps[len(result.Steps)-1].Status = "failed"
 			result.Steps[len(result.Steps)-1].Message = err.Error()
 			// Non-fatal: log warning and continue
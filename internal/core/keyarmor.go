@@ -0,0 +1,77 @@
+package core
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// Armored private keys in the format of `monod keys export`, which
+// `monod keys import` reads back.
+const (
+	armorBlockPrivKey       = "TENDERMINT PRIVATE KEY"
+	armorBcryptCost         = 12
+	aminoNameEthSecp256k1SK = "cosmos/evm/PrivKeyEthSecp256k1"
+)
+
+// bcryptBase64 is bcrypt's unpadded base64 alphabet.
+var bcryptBase64 = base64.NewEncoding("./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789").WithPadding(base64.NoPadding)
+
+// armorPrivKey encrypts an eth_secp256k1 private key with passphrase as the
+// Cosmos SDK does: the secretbox key is the SHA-256 of the bcrypt hash of the
+// passphrase, and the salt goes in the armor header so monod can derive it
+// again.
+func armorPrivKey(privKey []byte, passphrase string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(passphrase), armorBcryptCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive key encryption key: %w", err)
+	}
+	salt, err := bcryptSalt(hash)
+	if err != nil {
+		return "", err
+	}
+	key := sha256.Sum256(hash)
+
+	// Amino encoding of the key: type prefix, then the length-prefixed bytes
+	plain := append(aminoPrefix(aminoNameEthSecp256k1SK), byte(len(privKey)))
+	plain = append(plain, privKey...)
+
+	var nonce [24]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+	sealed := secretbox.Seal(nonce[:], plain, &nonce, &key)
+
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, armorBlockPrivKey, map[string]string{
+		"kdf":  "bcrypt",
+		"salt": fmt.Sprintf("%X", salt),
+		"type": "eth_secp256k1",
+	})
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(sealed); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// bcryptSalt returns the raw 16-byte salt of a "$2a$<cost>$<salt><hash>"
+// bcrypt hash.
+func bcryptSalt(hash []byte) ([]byte, error) {
+	const prefix = len("$2a$12$")
+	if len(hash) < prefix+22 {
+		return nil, fmt.Errorf("unexpected bcrypt hash")
+	}
+	return bcryptBase64.DecodeString(string(hash[prefix : prefix+22]))
+}
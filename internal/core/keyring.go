@@ -0,0 +1,160 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// KeyringOptions selects the monod keyring keys are moved to or from.
+type KeyringOptions struct {
+	MonodPath  string // monod binary (default: FindMonodBinary)
+	Home       string // monod home (monod's default if empty)
+	Backend    string // os, file or test (monod's default if empty)
+	Passphrase string // keyring passphrase, needed by the file backend
+}
+
+// ImportKeyToKeyring adds an eth_secp256k1 private key to the keyring as
+// name and returns the bech32 address monod reports for it. The key is
+// handed to `monod keys import` as an armored, passphrase-encrypted file in
+// a private temp directory, so it never appears in a process argument.
+func ImportKeyToKeyring(ctx context.Context, opts KeyringOptions, name string, privKey []byte) (string, error) {
+	if len(privKey) != 32 {
+		return "", fmt.Errorf("private key must be 32 bytes, got %d", len(privKey))
+	}
+
+	// monod decrypts the file with the key passphrase, read from stdin. The
+	// file backend uses the keyring passphrase; other backends store the key
+	// unencrypted or re-encrypt it, so a throwaway one does.
+	keyPass := opts.Passphrase
+	if opts.Backend != "file" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		keyPass = hex.EncodeToString(b)
+	}
+	armored, err := armorPrivKey(privKey, keyPass)
+	if err != nil {
+		return "", err
+	}
+	dir, err := os.MkdirTemp("", "monoctl-key-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, name+".armor")
+	if err := os.WriteFile(keyFile, []byte(armored), 0600); err != nil {
+		return "", err
+	}
+
+	args := append([]string{"keys", "import", name, keyFile}, opts.args()...)
+	if _, err := runKeyring(ctx, opts, keyPass+"\n"+opts.passphraseInput(), args); err != nil {
+		return "", err
+	}
+	return KeyringAddress(ctx, opts, name)
+}
+
+// ExportKeyFromKeyring returns the private key of name with
+// `monod keys unsafe-export-eth-key`.
+func ExportKeyFromKeyring(ctx context.Context, opts KeyringOptions, name string) ([]byte, error) {
+	// The os backend asks for confirmation, the file backend for the key
+	// password (the keyring passphrase) and then the keyring passphrase
+	input := opts.passphraseInput()
+	if opts.Backend == "os" || opts.Backend == "" {
+		input = "y\n"
+	}
+	args := append([]string{"keys", "unsafe-export-eth-key", name}, opts.args()...)
+	out, err := runKeyring(ctx, opts, input, args)
+	if err != nil {
+		return nil, err
+	}
+	return parseExportedKey(out)
+}
+
+// KeyringAddress returns the bech32 address of name.
+func KeyringAddress(ctx context.Context, opts KeyringOptions, name string) (string, error) {
+	args := append([]string{"keys", "show", name, "--address"}, opts.args()...)
+	out, err := runKeyring(ctx, opts, opts.passphraseInput(), args)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Fields(out)
+	if len(lines) == 0 {
+		return "", fmt.Errorf("monod keys show printed no address")
+	}
+	addr := lines[len(lines)-1]
+	if err := ValidateAddress(addr); err != nil {
+		return "", fmt.Errorf("unexpected output from monod keys show: %w", err)
+	}
+	return addr, nil
+}
+
+// args returns the --home and --keyring-backend flags.
+func (o KeyringOptions) args() []string {
+	var args []string
+	if o.Home != "" {
+		args = append(args, "--home", o.Home)
+	}
+	if o.Backend != "" {
+		args = append(args, "--keyring-backend", o.Backend)
+	}
+	return args
+}
+
+// passphraseInput answers the keyring passphrase prompts of the file
+// backend (twice, as monod asks for confirmation when it creates the
+// keyring).
+func (o KeyringOptions) passphraseInput() string {
+	if o.Backend != "file" {
+		return ""
+	}
+	return strings.Repeat(o.Passphrase+"\n", 2)
+}
+
+// runKeyring runs monod with input on stdin and returns its stdout.
+func runKeyring(ctx context.Context, opts KeyringOptions, input string, args []string) (string, error) {
+	if opts.Backend == "file" && opts.Passphrase == "" {
+		return "", fmt.Errorf("the file keyring backend needs a keyring passphrase")
+	}
+	monodPath, err := FindMonodBinary(opts.MonodPath)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.CommandContext(ctx, monodPath, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("monod %s %s failed: %s", args[0], args[1], msg)
+	}
+	return stdout.String(), nil
+}
+
+// parseExportedKey finds the hex private key in the output of
+// unsafe-export-eth-key, which may follow prompt text.
+func parseExportedKey(out string) ([]byte, error) {
+	fields := strings.Fields(out)
+	for i := len(fields) - 1; i >= 0; i-- {
+		f := strings.TrimPrefix(strings.TrimPrefix(fields[i], "0x"), "0X")
+		if len(f) != 64 {
+			continue
+		}
+		if key, err := hex.DecodeString(f); err == nil {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("no private key in monod output")
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/openpgp/armor"
+)
+
+// fakeMonod is a monod stand-in whose keyring is a directory of files in
+// --home. It records its arguments and stdin in <home>/args and
+// <home>/stdin. Imported armor files are kept as <name>.armor; export
+// prints <name>, which tests write.
+const fakeMonod = `#!/bin/sh
+all="$*"; cmd="$2"; name="$3"; file="$4"
+while [ $# -gt 0 ]; do
+  [ "$1" = "--home" ] && home="$2"
+  shift
+done
+echo "$all" >> "$home/args"
+cat >> "$home/stdin"
+case "$cmd" in
+import)
+  [ -e "$home/$name.armor" ] && { echo "cannot overwrite key: $name" >&2; exit 1; }
+  grep -q "BEGIN TENDERMINT PRIVATE KEY" "$file" || { echo "invalid armor" >&2; exit 1; }
+  cp "$file" "$home/$name.armor"
+  ls -l "$file" | cut -c1-10 > "$home/$name.mode" ;;
+unsafe-export-eth-key)
+  [ -e "$home/$name" ] || { echo "$name.info: key not found" >&2; exit 1; }
+  echo "**WARNING** this is an unsafe way to export your unencrypted private key" >&2
+  tr a-f A-F < "$home/$name" ;;
+show)
+  [ -e "$home/$name.armor" ] || { echo "$name.info: key not found" >&2; exit 1; }
+  echo "$FAKE_MONOD_ADDRESS" ;;
+esac
+`
+
+func fakeKeyring(t *testing.T, backend string) KeyringOptions {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake monod is a shell script")
+	}
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "monod")
+	if err := os.WriteFile(bin, []byte(fakeMonod), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("FAKE_MONOD_ADDRESS", "mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u")
+	return KeyringOptions{MonodPath: bin, Home: dir, Backend: backend}
+}
+
+func TestKeyringImportExport(t *testing.T) {
+	ctx := context.Background()
+	opts := fakeKeyring(t, "test")
+	key, _ := hex.DecodeString("1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727")
+
+	addr, err := ImportKeyToKeyring(ctx, opts, "ops", key)
+	if err != nil {
+		t.Fatalf("ImportKeyToKeyring() error = %v", err)
+	}
+	if addr != "mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u" {
+		t.Errorf("address = %s", addr)
+	}
+	// The key goes to monod in a private, encrypted file, never as an argument
+	args, _ := os.ReadFile(filepath.Join(opts.Home, "args"))
+	if !strings.HasPrefix(string(args), "keys import ops ") || strings.Contains(string(args), hex.EncodeToString(key)) {
+		t.Errorf("monod args = %q", args)
+	}
+	if mode, _ := os.ReadFile(filepath.Join(opts.Home, "ops.mode")); string(mode) != "-rw-------\n" {
+		t.Errorf("key file mode = %q", mode)
+	}
+	armored, _ := os.ReadFile(filepath.Join(opts.Home, "ops.armor"))
+	if bytes.Contains(armored, []byte(hex.EncodeToString(key))) {
+		t.Error("key file holds the plaintext key")
+	}
+
+	// The fake exports <name> as is
+	if err := os.WriteFile(filepath.Join(opts.Home, "ops"), []byte(hex.EncodeToString(key)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ExportKeyFromKeyring(ctx, opts, "ops")
+	if err != nil {
+		t.Fatalf("ExportKeyFromKeyring() error = %v", err)
+	}
+	if !bytes.Equal(got, key) {
+		t.Errorf("exported key = %x, want %x", got, key)
+	}
+
+	// monod's errors are passed on
+	if _, err := ImportKeyToKeyring(ctx, opts, "ops", key); err == nil || !strings.Contains(err.Error(), "cannot overwrite") {
+		t.Errorf("second import error = %v", err)
+	}
+	if _, err := ExportKeyFromKeyring(ctx, opts, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("export of missing key error = %v", err)
+	}
+	if _, err := ImportKeyToKeyring(ctx, opts, "short", key[:31]); err == nil {
+		t.Error("expected an error for a short key")
+	}
+}
+
+func TestKeyringFileBackendPassphrase(t *testing.T) {
+	ctx := context.Background()
+	opts := fakeKeyring(t, "file")
+	key, _ := hex.DecodeString("1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727")
+
+	if _, err := ImportKeyToKeyring(ctx, opts, "ops", key); err == nil || !strings.Contains(err.Error(), "passphrase") {
+		t.Fatalf("import without passphrase error = %v", err)
+	}
+
+	opts.Passphrase = "keyring-pass"
+	if _, err := ImportKeyToKeyring(ctx, opts, "ops", key); err != nil {
+		t.Fatal(err)
+	}
+	stdin, _ := os.ReadFile(filepath.Join(opts.Home, "stdin"))
+	// Key password, keyring passphrase twice, then keys show's passphrase twice
+	if want := strings.Repeat("keyring-pass\n", 5); string(stdin) != want {
+		t.Errorf("stdin = %q, want %q", stdin, want)
+	}
+}
+
+func TestArmorPrivKey(t *testing.T) {
+	key, _ := hex.DecodeString("1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727")
+	armored, err := armorPrivKey(key, "key-pass")
+	if err != nil {
+		t.Fatalf("armorPrivKey() error = %v", err)
+	}
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		t.Fatalf("armor.Decode() error = %v", err)
+	}
+	if block.Type != "TENDERMINT PRIVATE KEY" || block.Header["kdf"] != "bcrypt" || block.Header["type"] != "eth_secp256k1" {
+		t.Errorf("armor type = %s, headers = %v", block.Type, block.Header)
+	}
+	if salt, err := hex.DecodeString(block.Header["salt"]); err != nil || len(salt) != 16 {
+		t.Errorf("salt = %q", block.Header["salt"])
+	}
+	body, err := io.ReadAll(block.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nonce, secretbox overhead, amino prefix, length and key
+	if len(body) != 24+secretbox.Overhead+4+1+32 {
+		t.Errorf("encrypted key is %d bytes", len(body))
+	}
+
+	// The salt is the one bcrypt hashed with
+	salt := make([]byte, 16)
+	hash := []byte("$2a$12$" + bcryptBase64.EncodeToString(salt) + "0123456789012345678901234567890")
+	if got, err := bcryptSalt(hash); err != nil || !bytes.Equal(got, salt) {
+		t.Errorf("bcryptSalt() = %x, %v", got, err)
+	}
+}
+
+func TestParseExportedKey(t *testing.T) {
+	want := "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727"
+	for _, out := range []string{
+		want + "\n",
+		strings.ToUpper(want),
+		"Enter key password:\n0x" + want + "\n",
+	} {
+		key, err := parseExportedKey(out)
+		if err != nil || hex.EncodeToString(key) != want {
+			t.Errorf("parseExportedKey(%q) = %x, %v", out, key, err)
+		}
+	}
+	if _, err := parseExportedKey("Error: key not found"); err == nil {
+		t.Error("expected an error without a key")
+	}
+}
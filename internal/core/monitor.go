@@ -250,6 +250,27 @@ func LoadKeys(keysDir, home string) (*MonitorKeys, error) {
 	}, nil
 }
 
+// LoadMonitorPublicKey reads the public key saved by CreateKeys.
+func LoadMonitorPublicKey(keysDir string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(filepath.Join(keysDir, "monitor.pub"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+	return DecodeMonitorPublicKey(strings.TrimSpace(string(data)))
+}
+
+// DecodeMonitorPublicKey decodes a base64 ed25519 public key.
+func DecodeMonitorPublicKey(b64 string) (ed25519.PublicKey, error) {
+	key, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(key))
+	}
+	return ed25519.PublicKey(key), nil
+}
+
 // GetLocalNodeID retrieves the node ID from the local node.
 // If home is empty, uses MONOD_HOME env var or defaults to ~/.monod.
 func GetLocalNodeID(home string) (string, error) {
@@ -351,13 +372,13 @@ func GetLocalStatus(home string, version string) (*MonitorStatus, error) {
 
 	// Read chain_id from client.toml
 	clientTomlPath := filepath.Join(home, "config", "client.toml")
-	if chainID, err := readTomlValue(clientTomlPath, "chain-id"); err == nil {
+	if chainID, err := GetConfigValue(clientTomlPath, "", "chain-id"); err == nil {
 		status.ChainID = chainID
 	}
 
 	// Read evm-chain-id from app.toml
 	appTomlPath := filepath.Join(home, "config", "app.toml")
-	if evmChainIDStr, err := readTomlValue(appTomlPath, "evm-chain-id"); err == nil {
+	if evmChainIDStr, err := GetConfigValue(appTomlPath, "evm", "evm-chain-id"); err == nil {
 		status.EVMChainID, _ = strconv.ParseUint(evmChainIDStr, 10, 64)
 	}
 
@@ -393,12 +414,12 @@ func GetLocalCapabilities(home string) (*MonitorCapabilities, error) {
 
 	// Read pruning from app.toml
 	appTomlPath := filepath.Join(home, "config", "app.toml")
-	if pruning, err := readTomlValue(appTomlPath, "pruning"); err == nil {
+	if pruning, err := GetConfigValue(appTomlPath, "", "pruning"); err == nil {
 		caps.PruningMode = pruning
 	}
 
 	// Check for state-sync (snapshot-interval > 0)
-	if interval, err := readTomlValue(appTomlPath, "snapshot-interval"); err == nil {
+	if interval, err := GetConfigValue(appTomlPath, "state-sync", "snapshot-interval"); err == nil {
 		if i, _ := strconv.Atoi(interval); i > 0 {
 			caps.SnapshotsEnabled = true
 		}
@@ -441,29 +462,6 @@ func isPortOpen(port int) bool {
 	return true
 }
 
-// readTomlValue reads a simple key=value from a TOML file.
-func readTomlValue(path, key string) (string, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
-	}
-
-	lines := strings.Split(string(data), "\n")
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, key+" =") || strings.HasPrefix(line, key+"=") {
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) == 2 {
-				value := strings.TrimSpace(parts[1])
-				value = strings.Trim(value, "\"")
-				return value, nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("key %s not found", key)
-}
-
 // SignHeartbeat creates a signed heartbeat payload.
 func SignHeartbeat(keys *MonitorKeys, network string, status *MonitorStatus, caps *MonitorCapabilities) (*HeartbeatPayload, error) {
 	timestamp := time.Now().Unix()
@@ -476,7 +474,7 @@ func SignHeartbeat(keys *MonitorKeys, network string, status *MonitorStatus, cap
 	nonce := hex.EncodeToString(nonceBytes)
 
 	// Create message to sign: node_id|network|timestamp_unix|nonce
-	message := fmt.Sprintf("%s|%s|%d|%s", keys.NodeID, network, timestamp, nonce)
+	message := SignedMessage(keys.NodeID, network, timestamp, nonce)
 
 	// Sign with ed25519
 	signature := ed25519.Sign(keys.PrivateKey, []byte(message))
@@ -539,6 +537,90 @@ func SendHeartbeat(ctx context.Context, apiEndpoint string, payload *HeartbeatPa
 	return &result, nil
 }
 
+// MonitorAlert is an operator alert delivered through the node-monitor API.
+type MonitorAlert struct {
+	Kind     string      `json:"kind"`     // e.g. "gov_pending_votes"
+	Severity string      `json:"severity"` // info, warning or critical
+	Message  string      `json:"message"`
+	Details  interface{} `json:"details,omitempty"`
+}
+
+// AlertPayload is a signed alert sent to the node-monitor API.
+type AlertPayload struct {
+	NodeID        string `json:"node_id"`
+	Network       string `json:"network"`
+	TimestampUnix int64  `json:"timestamp_unix"`
+	Nonce         string `json:"nonce"`
+	// AlertHash is the AlertHash of the alert's JSON encoding
+	AlertHash string       `json:"alert_hash"`
+	Signature string       `json:"signature"`
+	Alert     MonitorAlert `json:"alert"`
+}
+
+// SignAlert creates a signed alert payload. The signature covers the
+// heartbeat fields and the hash of the alert, see AlertSignedMessage.
+func SignAlert(keys *MonitorKeys, network string, alert MonitorAlert) (*AlertPayload, error) {
+	timestamp := time.Now().Unix()
+
+	alertJSON, err := json.Marshal(alert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal alert: %w", err)
+	}
+	alertHash, err := AlertHash(alertJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate nonce
+	nonceBytes := make([]byte, 16)
+	if _, err := rand.Read(nonceBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	nonce := hex.EncodeToString(nonceBytes)
+
+	message := AlertSignedMessage(keys.NodeID, network, timestamp, nonce, alertHash)
+	signature := ed25519.Sign(keys.PrivateKey, []byte(message))
+
+	return &AlertPayload{
+		NodeID:        keys.NodeID,
+		Network:       network,
+		TimestampUnix: timestamp,
+		Nonce:         nonce,
+		AlertHash:     alertHash,
+		Signature:     base64.StdEncoding.EncodeToString(signature),
+		Alert:         alert,
+	}, nil
+}
+
+// SendAlert sends a signed alert to a self-hosted node-monitor API
+// ('monoctl monitor server'). The hosted node-monitor does not serve
+// /v1/alert.
+func SendAlert(ctx context.Context, apiEndpoint string, payload *AlertPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", apiEndpoint+"/v1/alert", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("alert failed: %s (HTTP %d)", string(body), resp.StatusCode)
+	}
+	return nil
+}
+
 // StartRegistration begins the registration process.
 func StartRegistration(ctx context.Context, apiEndpoint string, keys *MonitorKeys, network, moniker, role string) (*RegistrationStartResponse, error) {
 	req := RegistrationStartRequest{
@@ -603,7 +685,7 @@ func SetVisibility(ctx context.Context, apiEndpoint string, keys *MonitorKeys, n
 	nonce := hex.EncodeToString(nonceBytes)
 
 	// Create message to sign (same format as heartbeat)
-	message := fmt.Sprintf("%s|%s|%d|%s", keys.NodeID, network, timestamp, nonce)
+	message := SignedMessage(keys.NodeID, network, timestamp, nonce)
 	signature := ed25519.Sign(keys.PrivateKey, []byte(message))
 	signatureB64 := base64.StdEncoding.EncodeToString(signature)
 
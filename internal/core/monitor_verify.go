@@ -0,0 +1,216 @@
+package core
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultMonitorMaxSkew is how far a signed timestamp may be from the
+// verifier's clock.
+const DefaultMonitorMaxSkew = 5 * time.Minute
+
+// Heartbeat verification errors. VerifyHeartbeat wraps one of these so
+// callers can tell the failures apart with errors.Is.
+var (
+	ErrMalformedPayload = errors.New("malformed signed payload")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrTimestampSkew    = errors.New("timestamp outside allowed clock skew")
+	ErrNonceReused      = errors.New("nonce already used")
+)
+
+// SignedMessage returns the bytes signed by heartbeats and visibility
+// changes: node_id|network|timestamp_unix|nonce.
+func SignedMessage(nodeID, network string, timestamp int64, nonce string) string {
+	return fmt.Sprintf("%s|%s|%d|%s", nodeID, network, timestamp, nonce)
+}
+
+// AlertSignedMessage returns the bytes signed by alerts:
+// node_id|network|timestamp_unix|nonce|alert_hash, so the signature also
+// covers the alert itself.
+func AlertSignedMessage(nodeID, network string, timestamp int64, nonce, alertHash string) string {
+	return SignedMessage(nodeID, network, timestamp, nonce) + "|" + alertHash
+}
+
+// AlertHash returns the hex SHA-256 of an alert's JSON encoding, with
+// insignificant whitespace removed.
+func AlertHash(alertJSON []byte) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, alertJSON); err != nil {
+		return "", fmt.Errorf("invalid alert JSON: %w", err)
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NonceStore remembers the nonces of verified payloads so they cannot be
+// replayed. Implementations must be safe for concurrent use.
+type NonceStore interface {
+	// Use records nonce for nodeID until expires and reports whether it
+	// was unused.
+	Use(nodeID, nonce string, expires time.Time) (bool, error)
+}
+
+// MemoryNonceStore is an in-memory NonceStore. Expired nonces are dropped
+// as new ones are recorded.
+type MemoryNonceStore struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryNonceStore creates an empty MemoryNonceStore.
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{seen: map[string]time.Time{}}
+}
+
+// Use implements NonceStore.
+func (s *MemoryNonceStore) Use(nodeID, nonce string, expires time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, exp := range s.seen {
+		if now.After(exp) {
+			delete(s.seen, k)
+		}
+	}
+	key := nodeID + "|" + nonce
+	if _, ok := s.seen[key]; ok {
+		return false, nil
+	}
+	s.seen[key] = expires
+	return true, nil
+}
+
+// VerifyOptions configures signature verification.
+type VerifyOptions struct {
+	// PublicKey is the node's registered monitor key.
+	PublicKey ed25519.PublicKey
+	// MaxSkew bounds the difference between the signed timestamp and Now.
+	// Zero uses DefaultMonitorMaxSkew; negative skips the check, e.g. to
+	// audit an old payload.
+	MaxSkew time.Duration
+	// Nonces rejects replayed payloads. Nil skips the check.
+	Nonces NonceStore
+	// Now is the verifier's clock (default: time.Now()).
+	Now time.Time
+}
+
+// VerifyHeartbeat checks a heartbeat the way the node-monitor API does:
+// the signed fields must be canonically encoded, the signature must verify
+// against opts.PublicKey, the timestamp must be within the allowed skew,
+// and the nonce must not have been used before.
+//
+// The signature covers only the identity fields, not Status or
+// Capabilities, so those are only as trustworthy as the transport (TLS).
+func VerifyHeartbeat(p *HeartbeatPayload, opts VerifyOptions) error {
+	return VerifySignedMessage(p.NodeID, p.Network, p.TimestampUnix, p.Nonce, p.Signature, opts)
+}
+
+// VerifySignedMessage verifies any payload signed over SignedMessage, such
+// as visibility changes. See VerifyHeartbeat.
+func VerifySignedMessage(nodeID, network string, timestamp int64, nonce, signature string, opts VerifyOptions) error {
+	return verifyMessage(nodeID, network, timestamp, nonce, signature, SignedMessage(nodeID, network, timestamp, nonce), opts)
+}
+
+// VerifyAlert verifies an alert like VerifyHeartbeat, and checks that
+// alertJSON, the alert as it was received, matches the signed alert hash.
+// A nil alertJSON checks the encoding of p.Alert instead.
+func VerifyAlert(p *AlertPayload, alertJSON []byte, opts VerifyOptions) error {
+	if alertJSON == nil {
+		var err error
+		if alertJSON, err = json.Marshal(p.Alert); err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedPayload, err)
+		}
+	}
+	hash, err := AlertHash(alertJSON)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedPayload, err)
+	}
+	if hash != p.AlertHash {
+		return fmt.Errorf("%w: alert does not match alert_hash", ErrInvalidSignature)
+	}
+	message := AlertSignedMessage(p.NodeID, p.Network, p.TimestampUnix, p.Nonce, p.AlertHash)
+	return verifyMessage(p.NodeID, p.Network, p.TimestampUnix, p.Nonce, p.Signature, message, opts)
+}
+
+// verifyMessage verifies a signature over message, which is built from the
+// other fields.
+func verifyMessage(nodeID, network string, timestamp int64, nonce, signature, message string, opts VerifyOptions) error {
+	sig, err := checkSignedFields(nodeID, network, timestamp, nonce, signature)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrMalformedPayload, err)
+	}
+	if len(opts.PublicKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("public key must be %d bytes, got %d", ed25519.PublicKeySize, len(opts.PublicKey))
+	}
+
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	maxSkew := opts.MaxSkew
+	if maxSkew == 0 {
+		maxSkew = DefaultMonitorMaxSkew
+	}
+	signedAt := time.Unix(timestamp, 0)
+	if maxSkew > 0 {
+		if skew := now.Sub(signedAt); skew > maxSkew || skew < -maxSkew {
+			return fmt.Errorf("%w: signed at %s, %s from now", ErrTimestampSkew,
+				signedAt.UTC().Format(time.RFC3339), skew.Round(time.Second))
+		}
+	}
+
+	if !ed25519.Verify(opts.PublicKey, []byte(message), sig) {
+		return ErrInvalidSignature
+	}
+
+	// Record the nonce only once the signature is known to be good, so
+	// forged payloads cannot burn a node's nonces
+	if opts.Nonces != nil {
+		expires := signedAt.Add(maxSkew)
+		if maxSkew < 0 {
+			expires = now.Add(DefaultMonitorMaxSkew)
+		}
+		fresh, err := opts.Nonces.Use(nodeID, nonce, expires)
+		if err != nil {
+			return fmt.Errorf("failed to check nonce: %w", err)
+		}
+		if !fresh {
+			return ErrNonceReused
+		}
+	}
+	return nil
+}
+
+// checkSignedFields checks the fields are encoded as SignHeartbeat encodes
+// them, so one signed message has exactly one payload representation, and
+// returns the decoded signature.
+func checkSignedFields(nodeID, network string, timestamp int64, nonce, signature string) ([]byte, error) {
+	if nodeID == "" || network == "" {
+		return nil, fmt.Errorf("node_id and network are required")
+	}
+	// The fields are joined with "|", so they must not contain it
+	if strings.Contains(nodeID, "|") || strings.Contains(network, "|") {
+		return nil, fmt.Errorf("node_id and network must not contain '|'")
+	}
+	if timestamp <= 0 {
+		return nil, fmt.Errorf("timestamp_unix must be positive")
+	}
+	if raw, err := hex.DecodeString(nonce); err != nil || len(raw) != 16 || nonce != strings.ToLower(nonce) {
+		return nil, fmt.Errorf("nonce must be 32 lowercase hex characters")
+	}
+	sig, err := base64.StdEncoding.Strict().DecodeString(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("signature must be a base64 ed25519 signature")
+	}
+	return sig, nil
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestMonitorKeys(t *testing.T) *MonitorKeys {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &MonitorKeys{NodeID: "0123456789abcdef0123456789abcdef01234567", PublicKey: pub, PrivateKey: priv}
+}
+
+func TestVerifyHeartbeat(t *testing.T) {
+	keys := newTestMonitorKeys(t)
+	other := newTestMonitorKeys(t)
+	sign := func() *HeartbeatPayload {
+		p, err := SignHeartbeat(keys, "Sprintnet", &MonitorStatus{Height: 10}, &MonitorCapabilities{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(p *HeartbeatPayload, opts *VerifyOptions)
+		wantErr error
+	}{
+		{"valid", func(p *HeartbeatPayload, opts *VerifyOptions) {}, nil},
+		{"wrong key", func(p *HeartbeatPayload, opts *VerifyOptions) { opts.PublicKey = other.PublicKey }, ErrInvalidSignature},
+		{"tampered network", func(p *HeartbeatPayload, opts *VerifyOptions) { p.Network = "Mainnet" }, ErrInvalidSignature},
+		{"tampered timestamp", func(p *HeartbeatPayload, opts *VerifyOptions) { p.TimestampUnix-- }, ErrInvalidSignature},
+		{"uppercase nonce", func(p *HeartbeatPayload, opts *VerifyOptions) { p.Nonce = "ABCDEF0123456789ABCDEF0123456789" }, ErrMalformedPayload},
+		{"short nonce", func(p *HeartbeatPayload, opts *VerifyOptions) { p.Nonce = "abcd" }, ErrMalformedPayload},
+		{"separator in node id", func(p *HeartbeatPayload, opts *VerifyOptions) { p.NodeID = "a|b" }, ErrMalformedPayload},
+		{"bad signature encoding", func(p *HeartbeatPayload, opts *VerifyOptions) { p.Signature = "not base64!" }, ErrMalformedPayload},
+		{"clock ahead", func(p *HeartbeatPayload, opts *VerifyOptions) { opts.Now = time.Now().Add(10 * time.Minute) }, ErrTimestampSkew},
+		{"clock behind", func(p *HeartbeatPayload, opts *VerifyOptions) { opts.Now = time.Now().Add(-10 * time.Minute) }, ErrTimestampSkew},
+		{"skew check disabled", func(p *HeartbeatPayload, opts *VerifyOptions) {
+			opts.Now = time.Now().Add(24 * time.Hour)
+			opts.MaxSkew = -1
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := sign()
+			opts := VerifyOptions{PublicKey: keys.PublicKey}
+			tt.mutate(p, &opts)
+			err := VerifyHeartbeat(p, opts)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("VerifyHeartbeat() error = %v", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyHeartbeat() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyHeartbeat_Replay(t *testing.T) {
+	keys := newTestMonitorKeys(t)
+	p, _ := SignHeartbeat(keys, "Sprintnet", &MonitorStatus{}, &MonitorCapabilities{})
+	nonces := NewMemoryNonceStore()
+	opts := VerifyOptions{PublicKey: keys.PublicKey, Nonces: nonces}
+
+	// A forged copy must not consume the nonce
+	sig, _ := base64.StdEncoding.DecodeString(p.Signature)
+	sig[0] ^= 0xff
+	forged := *p
+	forged.Signature = base64.StdEncoding.EncodeToString(sig)
+	if err := VerifyHeartbeat(&forged, opts); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("forged payload error = %v", err)
+	}
+
+	if err := VerifyHeartbeat(p, opts); err != nil {
+		t.Fatalf("first use error = %v", err)
+	}
+	if err := VerifyHeartbeat(p, opts); !errors.Is(err, ErrNonceReused) {
+		t.Errorf("replay error = %v, want ErrNonceReused", err)
+	}
+
+	// Alerts also sign the alert hash, and share the nonce store
+	alert, _ := SignAlert(keys, "Sprintnet", MonitorAlert{Kind: "test", Message: "m"})
+	tampered := *alert
+	tampered.Alert.Message = "forged"
+	if err := VerifyAlert(&tampered, nil, opts); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered alert error = %v, want ErrInvalidSignature", err)
+	}
+	tampered.AlertHash, _ = AlertHash([]byte(`{"kind":"test","severity":"","message":"forged"}`))
+	if err := VerifyAlert(&tampered, nil, opts); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("re-hashed alert error = %v, want ErrInvalidSignature", err)
+	}
+	if err := VerifyAlert(alert, []byte(`{ "kind": "test", "severity": "", "message": "m" }`), opts); err != nil {
+		t.Errorf("alert verification error = %v", err)
+	}
+	if err := VerifyAlert(alert, nil, opts); !errors.Is(err, ErrNonceReused) {
+		t.Errorf("alert replay error = %v, want ErrNonceReused", err)
+	}
+}
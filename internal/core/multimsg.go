@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	oshelpers "github.com/monolythium/mono-commander/internal/os"
+	"github.com/monolythium/mono-commander/internal/rpc"
 )
 
 // TxJSON represents a Cosmos SDK transaction JSON structure
@@ -59,6 +60,10 @@ type MultiMsgExecutor struct {
 	KeyringBackend string // keyring backend
 	Node           string // RPC node URL
 	GasAdjustment  float64 // gas adjustment multiplier (default 1.5)
+	GasLimit       uint64  // fixed gas limit for the combined tx; 0 sums and adjusts the per-message gas
+	// Signer, when set, signs and broadcasts over Cosmos REST instead of monod
+	Signer *TxSigner
+	REST   string // Cosmos REST endpoint for native signing
 }
 
 // NewMultiMsgExecutor creates a new multi-message executor
@@ -68,6 +73,13 @@ func NewMultiMsgExecutor(opts TxBuilderOptions, network Network) *MultiMsgExecut
 		chainID = network.ChainID
 	}
 
+	gasAdjustment := opts.GasAdjustment
+	if gasAdjustment <= 0 {
+		gasAdjustment = DefaultGasAdjustment
+	}
+	// An explicit (or simulated) gas limit covers the whole transaction
+	gasLimit, _ := strconv.ParseUint(opts.Gas, 10, 64)
+
 	return &MultiMsgExecutor{
 		Binary:         "monod",
 		Home:           opts.Home,
@@ -75,8 +87,20 @@ func NewMultiMsgExecutor(opts TxBuilderOptions, network Network) *MultiMsgExecut
 		From:           opts.From,
 		KeyringBackend: opts.KeyringBackend,
 		Node:           opts.Node,
-		GasAdjustment:  1.5,
+		GasAdjustment:  gasAdjustment,
+		GasLimit:       gasLimit,
+		Signer:         opts.Signer,
+		REST:           opts.REST,
+	}
+}
+
+// cosmosClient returns a Cosmos REST client for native signing.
+func (e *MultiMsgExecutor) cosmosClient() *rpc.CosmosClient {
+	rest := e.REST
+	if rest == "" {
+		rest = DefaultCosmosREST
 	}
+	return rpc.NewCosmosClient(strings.TrimRight(rest, "/"))
 }
 
 // GenerateUnsignedTx generates an unsigned transaction JSON from a command
@@ -147,12 +171,19 @@ func (e *MultiMsgExecutor) CombineMessages(txJSONs ...[]byte) ([]byte, error) {
 	// Apply gas adjustment
 	adjustedGas := int64(float64(totalGas) * e.GasAdjustment)
 	combinedTx.AuthInfo.Fee.GasLimit = strconv.FormatInt(adjustedGas, 10)
+	if e.GasLimit > 0 {
+		combinedTx.AuthInfo.Fee.GasLimit = strconv.FormatUint(e.GasLimit, 10)
+	}
 
 	return json.MarshalIndent(combinedTx, "", "  ")
 }
 
 // SignTx signs an unsigned transaction
 func (e *MultiMsgExecutor) SignTx(ctx context.Context, unsignedTxJSON []byte) ([]byte, error) {
+	if e.Signer != nil {
+		return e.signTxNative(unsignedTxJSON)
+	}
+
 	// Write unsigned tx to temp file
 	tmpDir := os.TempDir()
 	unsignedPath := filepath.Join(tmpDir, "unsigned_tx.json")
@@ -201,8 +232,35 @@ func (e *MultiMsgExecutor) SignTx(ctx context.Context, unsignedTxJSON []byte) ([
 	return signedTx, nil
 }
 
+// signTxNative signs with e.Signer using the on-chain account number and sequence.
+func (e *MultiMsgExecutor) signTxNative(unsignedTxJSON []byte) ([]byte, error) {
+	var tx TxJSON
+	if err := json.Unmarshal(unsignedTxJSON, &tx); err != nil {
+		return nil, fmt.Errorf("failed to parse unsigned tx: %w", err)
+	}
+
+	account, err := e.cosmosClient().Account(e.Signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	if err := SignTxDirect(&tx, e.Signer, e.ChainID, account.AccountNumber, account.Sequence); err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	return json.MarshalIndent(tx, "", "  ")
+}
+
 // BroadcastTx broadcasts a signed transaction
 func (e *MultiMsgExecutor) BroadcastTx(ctx context.Context, signedTxJSON []byte) (*oshelpers.TxSummary, error) {
+	if e.Signer != nil {
+		var tx TxJSON
+		if err := json.Unmarshal(signedTxJSON, &tx); err != nil {
+			return nil, fmt.Errorf("failed to parse signed tx: %w", err)
+		}
+		return BroadcastSignedTx(e.cosmosClient(), &tx)
+	}
+
 	// Write signed tx to temp file
 	tmpDir := os.TempDir()
 	signedPath := filepath.Join(tmpDir, "broadcast_tx.json")
@@ -0,0 +1,303 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/monolythium/mono-commander/internal/walletgen"
+)
+
+// Legacy amino type names of the keys in a multisig, which prefix their
+// amino encoding.
+const (
+	aminoNameMultisig       = "tendermint/PubKeyMultisigThreshold"
+	aminoNameEthSecp256k1PK = "cosmos/evm/PubKeyEthSecp256k1"
+)
+
+// MultisigMemberKey is a member public key of a multisig account.
+type MultisigMemberKey struct {
+	Type string `json:"@type"`
+	Key  string `json:"key"`
+}
+
+// MultisigPubKey is a LegacyAminoPubKey threshold multisig key in its JSON
+// form, as printed by `monod keys show <multisig> --pubkey`.
+type MultisigPubKey struct {
+	Type       string              `json:"@type"`
+	Threshold  uint32              `json:"threshold"`
+	PublicKeys []MultisigMemberKey `json:"public_keys"`
+}
+
+// Validate checks the threshold and that every member is an eth_secp256k1 key.
+func (m *MultisigPubKey) Validate() error {
+	if m.Type != TypeURLLegacyAminoPubKey {
+		return fmt.Errorf("expected a %s key, got %q", TypeURLLegacyAminoPubKey, m.Type)
+	}
+	if len(m.PublicKeys) == 0 {
+		return fmt.Errorf("multisig key has no members")
+	}
+	if m.Threshold == 0 || int(m.Threshold) > len(m.PublicKeys) {
+		return fmt.Errorf("invalid threshold %d for %d members", m.Threshold, len(m.PublicKeys))
+	}
+	for i, pk := range m.PublicKeys {
+		if pk.Type != EthSecp256k1PubKeyTypeURL {
+			return fmt.Errorf("member %d: unsupported key type %q", i, pk.Type)
+		}
+		key, err := base64.StdEncoding.DecodeString(pk.Key)
+		if err != nil || len(key) != 33 {
+			return fmt.Errorf("member %d: invalid compressed public key", i)
+		}
+	}
+	return nil
+}
+
+// memberIndex returns the position of a compressed public key among the
+// members, or -1.
+func (m *MultisigPubKey) memberIndex(pubKey []byte) int {
+	encoded := base64.StdEncoding.EncodeToString(pubKey)
+	for i, pk := range m.PublicKeys {
+		if pk.Key == encoded {
+			return i
+		}
+	}
+	return -1
+}
+
+// Address returns the mono1... address of the multisig account: the first
+// 20 bytes of the SHA-256 of its legacy amino encoding, as the Cosmos SDK
+// derives it for a LegacyAminoPubKey.
+func (m *MultisigPubKey) Address() (string, error) {
+	if err := m.Validate(); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(m.aminoBytes())
+	return walletgen.Bech32Encode(Bech32PrefixAccAddr, sum[:20])
+}
+
+// aminoBytes returns the legacy amino binary encoding of the key: the type
+// prefix, the threshold (field 1) and each member (field 2) with its own
+// type prefix. m must be valid.
+func (m *MultisigPubKey) aminoBytes() []byte {
+	out := aminoPrefix(aminoNameMultisig)
+	out = append(out, 1<<3|protoWireVarint)
+	out = binary.AppendUvarint(out, uint64(m.Threshold))
+	for _, pk := range m.PublicKeys {
+		key, _ := base64.StdEncoding.DecodeString(pk.Key)
+		member := aminoPrefix(aminoNameEthSecp256k1PK)
+		member = binary.AppendUvarint(member, uint64(len(key)))
+		member = append(member, key...)
+
+		out = append(out, 2<<3|protoWireBytes)
+		out = binary.AppendUvarint(out, uint64(len(member)))
+		out = append(out, member...)
+	}
+	return out
+}
+
+// aminoPrefix returns the 4-byte amino prefix of a registered type name:
+// the SHA-256 of the name, with leading zero bytes and the 3 disambiguation
+// bytes dropped, then leading zero bytes dropped again.
+func aminoPrefix(name string) []byte {
+	sum := sha256.Sum256([]byte(name))
+	b := sum[:]
+	for b[0] == 0 {
+		b = b[1:]
+	}
+	b = b[3:]
+	for b[0] == 0 {
+		b = b[1:]
+	}
+	return append([]byte(nil), b[:4]...)
+}
+
+// CheckAddress returns an error unless addr is the multisig's address.
+func (m *MultisigPubKey) CheckAddress(addr string) error {
+	want, err := m.Address()
+	if err != nil {
+		return err
+	}
+	if addr != want {
+		return fmt.Errorf("%s is not the address of this multisig key (%s); check --from and --multisig-pubkey", addr, want)
+	}
+	return nil
+}
+
+// pubKeyJSON returns the key in the form used by signer infos.
+func (m *MultisigPubKey) pubKeyJSON() *pubKeyJSON {
+	pk := &pubKeyJSON{Type: m.Type, Threshold: m.Threshold}
+	for _, member := range m.PublicKeys {
+		pk.PublicKeys = append(pk.PublicKeys, pubKeyJSON{Type: member.Type, Key: member.Key})
+	}
+	return pk
+}
+
+// LoadMultisigPubKey reads and validates a multisig public key file.
+func LoadMultisigPubKey(path string) (*MultisigPubKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pk MultisigPubKey
+	if err := json.Unmarshal(data, &pk); err != nil {
+		return nil, fmt.Errorf("failed to parse multisig public key: %w", err)
+	}
+	if err := pk.Validate(); err != nil {
+		return nil, err
+	}
+	return &pk, nil
+}
+
+// SignMultisigPartial produces one member's partial signature for an exported
+// multisig transaction. Members sign the legacy amino JSON sign doc, since the
+// final SIGN_MODE_DIRECT auth info is not known until signatures are combined.
+// No network access is needed.
+func SignMultisigPartial(file *UnsignedTxFile, signer *TxSigner) (*TxJSON, error) {
+	if file.Multisig == nil {
+		return nil, fmt.Errorf("unsigned tx file is not a multisig transaction (export it with --multisig-pubkey)")
+	}
+	if file.Multisig.memberIndex(signer.PubKey()) < 0 {
+		return nil, fmt.Errorf("key %s is not a member of the multisig", signer.Address())
+	}
+	if file.ChainID == "" {
+		return nil, fmt.Errorf("unsigned tx file has no chain_id")
+	}
+
+	signDoc, err := encodeAminoSignDoc(&file.Tx, file.ChainID, file.AccountNumber, file.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(signDoc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign: %w", err)
+	}
+
+	rawInfo, err := json.Marshal(signerInfoJSON{
+		PublicKey: signer.pubKeyJSON(),
+		ModeInfo:  singleModeInfo(SignModeLegacyAminoJSON),
+		Sequence:  strconv.FormatUint(file.Sequence, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tx := file.Tx
+	tx.AuthInfo.SignerInfos = []json.RawMessage{rawInfo}
+	tx.Signatures = []string{base64.StdEncoding.EncodeToString(sig)}
+	return &tx, nil
+}
+
+// CombineMultisig verifies the members' partial signatures and combines them
+// into the multisig's signature on the exported transaction. At least
+// threshold distinct members must have signed.
+func CombineMultisig(file *UnsignedTxFile, partials []*TxJSON) (*TxJSON, error) {
+	ms := file.Multisig
+	if ms == nil {
+		return nil, fmt.Errorf("unsigned tx file is not a multisig transaction (export it with --multisig-pubkey)")
+	}
+
+	signDoc, err := encodeAminoSignDoc(&file.Tx, file.ChainID, file.AccountNumber, file.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	hash := crypto.Keccak256(signDoc)
+
+	sigs := make([][]byte, len(ms.PublicKeys))
+	count := 0
+	for i, partial := range partials {
+		if len(partial.Signatures) != 1 || len(partial.AuthInfo.SignerInfos) != 1 {
+			return nil, fmt.Errorf("signature %d: expected exactly one signature", i+1)
+		}
+		sig, err := base64.StdEncoding.DecodeString(partial.Signatures[0])
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i+1, err)
+		}
+
+		pub, err := crypto.SigToPub(hash, sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i+1, err)
+		}
+		pubKey := crypto.CompressPubkey(pub)
+
+		// The signature must recover to the member key it claims
+		var si signerInfoJSON
+		if err := json.Unmarshal(partial.AuthInfo.SignerInfos[0], &si); err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i+1, err)
+		}
+		if si.PublicKey == nil || si.PublicKey.Key != base64.StdEncoding.EncodeToString(pubKey) {
+			return nil, fmt.Errorf("signature %d does not match this transaction or its key", i+1)
+		}
+
+		idx := ms.memberIndex(pubKey)
+		if idx < 0 {
+			return nil, fmt.Errorf("signature %d is from a key that is not a member of the multisig", i+1)
+		}
+		if sigs[idx] != nil {
+			return nil, fmt.Errorf("signature %d duplicates member %d", i+1, idx+1)
+		}
+		sigs[idx] = sig
+		count++
+	}
+	if count < int(ms.Threshold) {
+		return nil, fmt.Errorf("have %d of %d required signatures", count, ms.Threshold)
+	}
+
+	// CompactBitArray of signers, most significant bit first
+	elems := make([]byte, (len(sigs)+7)/8)
+	var mode modeInfoJSON
+	mode.Multi = &struct {
+		Bitarray  compactBitArrayJSON `json:"bitarray"`
+		ModeInfos []modeInfoJSON      `json:"mode_infos"`
+	}{}
+	var multiSig protoWriter
+	for i, sig := range sigs {
+		if sig == nil {
+			continue
+		}
+		elems[i/8] |= 1 << (7 - uint(i%8))
+		mode.Multi.ModeInfos = append(mode.Multi.ModeInfos, singleModeInfo(SignModeLegacyAminoJSON))
+		multiSig.bytes(1, sig)
+	}
+	mode.Multi.Bitarray = compactBitArrayJSON{
+		ExtraBitsStored: uint32(len(sigs) % 8),
+		Elems:           base64.StdEncoding.EncodeToString(elems),
+	}
+
+	rawInfo, err := json.Marshal(signerInfoJSON{
+		PublicKey: ms.pubKeyJSON(),
+		ModeInfo:  mode,
+		Sequence:  strconv.FormatUint(file.Sequence, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tx := file.Tx
+	tx.AuthInfo.SignerInfos = []json.RawMessage{rawInfo}
+	tx.Signatures = []string{base64.StdEncoding.EncodeToString(multiSig.buf)}
+	return &tx, nil
+}
+
+// LoadPartialSignature reads a member's partial signature file.
+func LoadPartialSignature(path string) (*TxJSON, error) {
+	tx, err := LoadSignedTx(path)
+	if err != nil {
+		return nil, err
+	}
+	var si signerInfoJSON
+	if len(tx.AuthInfo.SignerInfos) == 1 {
+		if err := json.Unmarshal(tx.AuthInfo.SignerInfos[0], &si); err != nil {
+			return nil, fmt.Errorf("invalid signer info in %s: %w", path, err)
+		}
+	}
+	if si.ModeInfo.Single == nil || si.ModeInfo.Single.Mode != SignModeLegacyAminoJSON {
+		return nil, fmt.Errorf("%s is not a multisig partial signature (sign it with tx sign --multisig)", path)
+	}
+	return tx, nil
+}
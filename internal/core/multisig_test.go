@@ -0,0 +1,221 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// testMultisig returns three member signers and their 2-of-3 multisig key.
+func testMultisig(t *testing.T) ([]*TxSigner, *MultisigPubKey) {
+	t.Helper()
+	keys := []string{
+		"4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318",
+		"8da4ef21b864d2cc526dbdb2a120bd2874c36c9d0a1fb7f8c63d7f7a8b41de8f",
+		"0dbbe8e4ae425a6d2687f1a7e3ba17bc98c673636790f1b8ad91193c05875ef1",
+	}
+	ms := &MultisigPubKey{Type: TypeURLLegacyAminoPubKey, Threshold: 2}
+	var signers []*TxSigner
+	for _, k := range keys {
+		key, _ := hex.DecodeString(k)
+		signer, err := NewTxSigner(key)
+		if err != nil {
+			t.Fatalf("NewTxSigner() error = %v", err)
+		}
+		signers = append(signers, signer)
+		ms.PublicKeys = append(ms.PublicKeys, MultisigMemberKey{
+			Type: EthSecp256k1PubKeyTypeURL,
+			Key:  base64.StdEncoding.EncodeToString(signer.PubKey()),
+		})
+	}
+	return signers, ms
+}
+
+func testMultisigFile(t *testing.T, ms *MultisigPubKey) *UnsignedTxFile {
+	t.Helper()
+	treasury, err := ms.Address()
+	if err != nil {
+		t.Fatalf("Address() error = %v", err)
+	}
+	opts := ValidatorActionOptions{Network: NetworkLocalnet, From: treasury, Fees: "10000alyth", Gas: "auto", Multisig: ms}
+
+	cmd, err := BuildBankSendTx(opts.toTxBuilderOptions(), BankSendParams{ToAddress: treasury, Amount: "5alyth"})
+	if err != nil {
+		t.Fatalf("BuildBankSendTx() error = %v", err)
+	}
+	file, err := ExportUnsignedTx(context.Background(), opts, cmd, &rpc.Account{AccountNumber: 9, Sequence: 1})
+	if err != nil {
+		t.Fatalf("ExportUnsignedTx() error = %v", err)
+	}
+	return file
+}
+
+func TestMultisigAddress(t *testing.T) {
+	// Well-known prefixes of the Tendermint key types
+	for name, want := range map[string]string{
+		"tendermint/PubKeySecp256k1":         "eb5ae987",
+		"tendermint/PubKeyMultisigThreshold": "22c1f7e2",
+	} {
+		if got := hex.EncodeToString(aminoPrefix(name)); got != want {
+			t.Errorf("aminoPrefix(%s) = %s, want %s", name, got, want)
+		}
+	}
+
+	_, ms := testMultisig(t)
+	enc := hex.EncodeToString(ms.aminoBytes())
+	if !strings.HasPrefix(enc, "22c1f7e2"+"0802"+"1226"+hex.EncodeToString(aminoPrefix(aminoNameEthSecp256k1PK))+"21") {
+		t.Errorf("amino encoding = %s", enc)
+	}
+
+	addr, err := ms.Address()
+	if err != nil || ValidateAddress(addr) != nil {
+		t.Fatalf("Address() = %s, %v", addr, err)
+	}
+	if err := ms.CheckAddress(addr); err != nil {
+		t.Errorf("CheckAddress(own address) error = %v", err)
+	}
+	other := *ms
+	other.Threshold = 3
+	if err := other.CheckAddress(addr); err == nil {
+		t.Error("a different threshold must give a different address")
+	}
+
+	// Exports for the wrong account are rejected
+	opts := ValidatorActionOptions{Network: NetworkLocalnet, From: testSigner(t).Address(), Fees: "1alyth", Gas: "200000", Multisig: ms}
+	cmd, _ := BuildBankSendTx(opts.toTxBuilderOptions(), BankSendParams{ToAddress: addr, Amount: "5alyth"})
+	if _, err := ExportUnsignedTx(context.Background(), opts, cmd, &rpc.Account{AccountNumber: 9, Sequence: 1}); err == nil ||
+		!strings.Contains(err.Error(), "not the address of this multisig") {
+		t.Errorf("ExportUnsignedTx(wrong --from) error = %v", err)
+	}
+}
+
+func TestEncodeAminoSignDoc(t *testing.T) {
+	msg := &MsgSend{FromAddress: "mono1a", ToAddress: "mono1b", Amount: []Coin{{Denom: BaseDenom, Amount: "5"}}}
+	tx, _ := NewUnsignedTx([]TxMsg{msg}, "", TxFee{Amount: []Coin{}, GasLimit: "200000"})
+
+	doc, err := encodeAminoSignDoc(tx, "mono-local-1", 9, 1)
+	if err != nil {
+		t.Fatalf("encodeAminoSignDoc() error = %v", err)
+	}
+	want := `{"account_number":"9","chain_id":"mono-local-1","fee":{"amount":[],"gas":"200000"},"memo":"",` +
+		`"msgs":[{"type":"cosmos-sdk/MsgSend","value":{"amount":[{"amount":"5","denom":"alyth"}],"from_address":"mono1a","to_address":"mono1b"}}],"sequence":"1"}`
+	if string(doc) != want {
+		t.Errorf("sign doc =\n%s\nwant\n%s", doc, want)
+	}
+
+	vote := (&MsgVote{ProposalID: 3, Voter: "mono1a", Option: "VOTE_OPTION_NO_WITH_VETO"}).AminoJSON()
+	data, _ := json.Marshal(vote)
+	if string(data) != `{"type":"cosmos-sdk/v1/MsgVote","value":{"option":4,"proposal_id":"3","voter":"mono1a"}}` {
+		t.Errorf("vote amino JSON = %s", data)
+	}
+}
+
+func TestMultisigRoundTrip(t *testing.T) {
+	signers, ms := testMultisig(t)
+	file := testMultisigFile(t, ms)
+
+	// The file must survive being written and carried to each member
+	path := filepath.Join(t.TempDir(), "unsigned.json")
+	WriteTxFile(path, file)
+	loaded, err := LoadUnsignedTxFile(path)
+	if err != nil || loaded.Multisig == nil {
+		t.Fatalf("LoadUnsignedTxFile() = %+v, %v", loaded, err)
+	}
+
+	var partials []*TxJSON
+	for _, signer := range []*TxSigner{signers[2], signers[0]} {
+		partial, err := SignMultisigPartial(loaded, signer)
+		if err != nil {
+			t.Fatalf("SignMultisigPartial() error = %v", err)
+		}
+		sigPath := filepath.Join(t.TempDir(), "sig.json")
+		WriteTxFile(sigPath, partial)
+		if partial, err = LoadPartialSignature(sigPath); err != nil {
+			t.Fatalf("LoadPartialSignature() error = %v", err)
+		}
+		partials = append(partials, partial)
+	}
+
+	signed, err := CombineMultisig(loaded, partials)
+	if err != nil {
+		t.Fatalf("CombineMultisig() error = %v", err)
+	}
+
+	var si signerInfoJSON
+	json.Unmarshal(signed.AuthInfo.SignerInfos[0], &si)
+	if si.PublicKey.Type != TypeURLLegacyAminoPubKey || si.ModeInfo.Multi == nil {
+		t.Fatalf("signer info = %s", signed.AuthInfo.SignerInfos[0])
+	}
+	// Members 0 and 2 of 3: bits 101 followed by five padding bits
+	if bits := si.ModeInfo.Multi.Bitarray; bits.ExtraBitsStored != 3 || bits.Elems != base64.StdEncoding.EncodeToString([]byte{0xa0}) {
+		t.Errorf("bitarray = %+v", bits)
+	}
+	if len(si.ModeInfo.Multi.ModeInfos) != 2 {
+		t.Errorf("mode infos = %d, want 2", len(si.ModeInfo.Multi.ModeInfos))
+	}
+
+	if _, err := EncodeTxRaw(signed); err != nil {
+		t.Errorf("EncodeTxRaw() error = %v", err)
+	}
+}
+
+func TestCombineMultisig_Errors(t *testing.T) {
+	signers, ms := testMultisig(t)
+	file := testMultisigFile(t, ms)
+
+	one, _ := SignMultisigPartial(file, signers[1])
+	if _, err := CombineMultisig(file, []*TxJSON{one}); err == nil || !strings.Contains(err.Error(), "1 of 2") {
+		t.Errorf("expected threshold error, got %v", err)
+	}
+	if _, err := CombineMultisig(file, []*TxJSON{one, one}); err == nil {
+		t.Error("expected duplicate signature error")
+	}
+
+	// A signature over a different transaction must be rejected
+	other := *file
+	other.Sequence = 2
+	stale, _ := SignMultisigPartial(&other, signers[0])
+	if _, err := CombineMultisig(file, []*TxJSON{one, stale}); err == nil {
+		t.Error("expected error for signature over a different sequence")
+	}
+
+	// A partial signature with a malformed signer info reports why
+	bad := *one
+	bad.AuthInfo.SignerInfos = []json.RawMessage{json.RawMessage(`{"mode_info":[]}`)}
+	badPath := filepath.Join(t.TempDir(), "sig.json")
+	WriteTxFile(badPath, &bad)
+	if _, err := LoadPartialSignature(badPath); err == nil || !strings.Contains(err.Error(), "invalid signer info") {
+		t.Errorf("LoadPartialSignature(malformed) error = %v", err)
+	}
+
+	// Drop the first member; its key can no longer sign
+	ms.PublicKeys = ms.PublicKeys[1:]
+	if _, err := SignMultisigPartial(file, signers[0]); err == nil {
+		t.Error("expected error for non-member key")
+	}
+}
+
+func TestMultisigAction_NotExecutable(t *testing.T) {
+	_, ms := testMultisig(t)
+	opts := ValidatorActionOptions{
+		Network:  NetworkLocalnet,
+		From:     "mono1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+		Execute:  true,
+		Multisig: ms,
+	}
+
+	result, err := DelegateAction(context.Background(), opts, DelegateParams{ValidatorAddr: testValoper, Amount: "1alyth"})
+	if !errors.Is(err, errMultisigExecute) {
+		t.Fatalf("expected errMultisigExecute, got %v", err)
+	}
+	if !strings.Contains(result.Command.String(), "--generate-only") {
+		t.Errorf("multisig command should be generate-only: %s", result.Command)
+	}
+}
@@ -27,6 +27,8 @@ type NetworkConfig struct {
 	BootstrapPeers []string          `json:"bootstrap_peers"`
 	RPCEndpoints   map[string]string `json:"rpc_endpoints"`
 	PortScheme     map[string]int    `json:"port_scheme"`
+	SnapshotURL    string            `json:"snapshot_url,omitempty"`
+	SnapshotSHA256 string            `json:"snapshot_sha256,omitempty"`
 	NetworkStatus  string            `json:"network_status"`
 	ConfigVersion  string            `json:"config_version"`
 	UpdatedAt      string            `json:"updated_at"`
@@ -0,0 +1,174 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// UnsignedTxFile is an unsigned transaction together with the signer data a
+// cold machine needs to sign it without network access.
+type UnsignedTxFile struct {
+	ChainID       string `json:"chain_id"`
+	Signer        string `json:"signer"`
+	AccountNumber uint64 `json:"account_number,string"`
+	Sequence      uint64 `json:"sequence,string"`
+	Tx            TxJSON `json:"tx"`
+	// Multisig is set when Signer is a multisig account.
+	Multisig *MultisigPubKey `json:"multisig,omitempty"`
+}
+
+// ExportUnsignedTx builds the unsigned transaction for cmd and stamps it with
+// the signer's account number and sequence. If account is nil they are
+// fetched over Cosmos REST.
+//
+// Natively encodable messages are built directly; anything else (such as
+// create-validator) is generated with monod --generate-only and combined.
+func ExportUnsignedTx(ctx context.Context, opts ValidatorActionOptions, cmd *TxCommand, account *rpc.Account) (*UnsignedTxFile, error) {
+	if err := ValidateAddress(opts.From); err != nil {
+		return nil, fmt.Errorf("offline signing requires --from to be the signer's address: %w", err)
+	}
+	// Every member would sign a tx for the wrong account
+	if opts.Multisig != nil {
+		if err := opts.Multisig.CheckAddress(opts.From); err != nil {
+			return nil, err
+		}
+	}
+
+	network, err := GetNetwork(opts.Network)
+	if err != nil {
+		return nil, err
+	}
+	chainID := opts.ChainID
+	if chainID == "" {
+		chainID = network.ChainID
+	}
+
+	var tx *TxJSON
+	if len(cmd.Msgs) > 0 {
+		fee, err := NativeFee(opts.Fees, opts.GasPrices, opts.Gas, len(cmd.Msgs))
+		if err != nil {
+			return nil, err
+		}
+		if tx, err = NewUnsignedTx(cmd.Msgs, "", fee); err != nil {
+			return nil, err
+		}
+	} else {
+		if tx, err = generateUnsignedWithMonod(ctx, opts, network, cmd); err != nil {
+			return nil, err
+		}
+	}
+
+	if account == nil {
+		if account, err = opts.cosmosClient().Account(opts.From); err != nil {
+			return nil, fmt.Errorf("failed to fetch account (pass --account-number and --sequence to skip): %w", err)
+		}
+	}
+
+	return &UnsignedTxFile{
+		ChainID:       chainID,
+		Signer:        opts.From,
+		AccountNumber: account.AccountNumber,
+		Sequence:      account.Sequence,
+		Tx:            *tx,
+		Multisig:      opts.Multisig,
+	}, nil
+}
+
+// generateUnsignedWithMonod runs monod --generate-only for each message of cmd
+// and combines the results into a single transaction.
+func generateUnsignedWithMonod(ctx context.Context, opts ValidatorActionOptions, network Network, cmd *TxCommand) (*TxJSON, error) {
+	executor := NewMultiMsgExecutor(opts.toTxBuilderOptions(), network)
+
+	commands := []*TxCommand{cmd}
+	if cmd.RequiresMultiMsg && len(cmd.MultiMsgCommands) > 0 {
+		commands = cmd.MultiMsgCommands
+	}
+
+	unsignedTxs := make([][]byte, 0, len(commands))
+	for i, sub := range commands {
+		unsignedTx, err := executor.GenerateUnsignedTx(ctx, sub.Args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate unsigned tx for command %d (%s): %w", i, sub.Action, err)
+		}
+		unsignedTxs = append(unsignedTxs, unsignedTx)
+	}
+
+	combined, err := executor.CombineMessages(unsignedTxs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine transactions: %w", err)
+	}
+
+	var tx TxJSON
+	if err := json.Unmarshal(combined, &tx); err != nil {
+		return nil, fmt.Errorf("failed to parse unsigned tx: %w", err)
+	}
+	return &tx, nil
+}
+
+// SignUnsignedTxFile signs an exported transaction with the account number
+// and sequence it carries. No network access is needed.
+func SignUnsignedTxFile(file *UnsignedTxFile, signer *TxSigner) (*TxJSON, error) {
+	if file.Multisig != nil {
+		return nil, fmt.Errorf("transaction is for a multisig account; sign it with --multisig and combine the signatures with 'tx multisign'")
+	}
+	if file.Signer != "" && file.Signer != signer.Address() {
+		return nil, fmt.Errorf("transaction expects signer %s but the key is %s", file.Signer, signer.Address())
+	}
+	if file.ChainID == "" {
+		return nil, fmt.Errorf("unsigned tx file has no chain_id")
+	}
+
+	tx := file.Tx
+	if err := SignTxDirect(&tx, signer, file.ChainID, file.AccountNumber, file.Sequence); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// LoadUnsignedTxFile reads an exported unsigned transaction.
+func LoadUnsignedTxFile(path string) (*UnsignedTxFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file UnsignedTxFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse unsigned tx file: %w", err)
+	}
+	if len(file.Tx.Body.Messages) == 0 {
+		return nil, fmt.Errorf("%s is not an exported unsigned tx (no messages)", path)
+	}
+	return &file, nil
+}
+
+// LoadSignedTx reads a signed transaction JSON file.
+func LoadSignedTx(path string) (*TxJSON, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tx TxJSON
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, fmt.Errorf("failed to parse signed tx: %w", err)
+	}
+	if len(tx.Signatures) == 0 {
+		return nil, fmt.Errorf("%s is not signed", path)
+	}
+	return &tx, nil
+}
+
+// WriteTxFile writes a transaction document as indented JSON, readable only
+// by the owner.
+func WriteTxFile(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0600)
+}
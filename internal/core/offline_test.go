@@ -0,0 +1,96 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+func TestOfflineSigningRoundTrip(t *testing.T) {
+	signer := testSigner(t)
+	opts := ValidatorActionOptions{
+		Network: NetworkLocalnet,
+		From:    signer.Address(),
+		Fees:    "10000alyth",
+		Gas:     "auto",
+	}
+
+	cmd, err := BuildDelegateTx(opts.toTxBuilderOptions(), DelegateParams{ValidatorAddr: testValoper, Amount: "1000alyth"})
+	if err != nil {
+		t.Fatalf("BuildDelegateTx() error = %v", err)
+	}
+
+	// Export without network access
+	file, err := ExportUnsignedTx(context.Background(), opts, cmd, &rpc.Account{AccountNumber: 7, Sequence: 3})
+	if err != nil {
+		t.Fatalf("ExportUnsignedTx() error = %v", err)
+	}
+	if file.ChainID != "mono-local-1" || file.AccountNumber != 7 || file.Sequence != 3 {
+		t.Errorf("file = %+v", file)
+	}
+
+	path := filepath.Join(t.TempDir(), "unsigned.json")
+	if err := WriteTxFile(path, file); err != nil {
+		t.Fatalf("WriteTxFile() error = %v", err)
+	}
+	loaded, err := LoadUnsignedTxFile(path)
+	if err != nil {
+		t.Fatalf("LoadUnsignedTxFile() error = %v", err)
+	}
+
+	signed, err := SignUnsignedTxFile(loaded, signer)
+	if err != nil {
+		t.Fatalf("SignUnsignedTxFile() error = %v", err)
+	}
+
+	// Broadcast from a different process reads the signed file back
+	signedPath := filepath.Join(t.TempDir(), "signed.json")
+	WriteTxFile(signedPath, signed)
+	tx, err := LoadSignedTx(signedPath)
+	if err != nil {
+		t.Fatalf("LoadSignedTx() error = %v", err)
+	}
+
+	var broadcast []byte
+	srv := mockCosmosREST(t, 0, &broadcast)
+	summary, err := BroadcastSignedTx(rpc.NewCosmosClient(srv.URL), tx)
+	if err != nil {
+		t.Fatalf("BroadcastSignedTx() error = %v", err)
+	}
+	if !summary.Success {
+		t.Errorf("summary = %+v", summary)
+	}
+
+	txRaw, _ := EncodeTxRaw(signed)
+	if !bytes.Equal(broadcast, txRaw) {
+		t.Error("broadcast bytes differ from the signed transaction")
+	}
+}
+
+func TestExportUnsignedTx_RequiresAddress(t *testing.T) {
+	opts := ValidatorActionOptions{Network: NetworkLocalnet, From: "coldkey"}
+	cmd, _ := BuildVoteTx(opts.toTxBuilderOptions(), VoteParams{ProposalID: "1", Option: VoteYes})
+
+	_, err := ExportUnsignedTx(context.Background(), opts, cmd, &rpc.Account{})
+	if err == nil || !strings.Contains(err.Error(), "address") {
+		t.Errorf("expected address error, got %v", err)
+	}
+}
+
+func TestSignUnsignedTxFile_WrongSigner(t *testing.T) {
+	signer := testSigner(t)
+	tx, _ := NewUnsignedTx([]TxMsg{&MsgWithdrawValidatorCommission{ValidatorAddress: testValoper}}, "", TxFee{GasLimit: "200000"})
+	file := &UnsignedTxFile{
+		ChainID: "mono-local-1",
+		Signer:  "mono1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+		Tx:      *tx,
+	}
+
+	if _, err := SignUnsignedTxFile(file, signer); err == nil {
+		t.Error("expected signer mismatch error, got nil")
+	}
+}
@@ -241,6 +241,22 @@ func (r *PeersRegistry) GetValidatorP2PPort(name string) int {
 	return 26656
 }
 
+// GetPorts returns the port pair the PortScheme assigns to a node of
+// role, or nil if it assigns none. Non-seed roles use the named validator
+// entry or its "default" fallback.
+func (r *PeersRegistry) GetPorts(role NodeRole, name string) *PortPair {
+	if r.PortScheme == nil {
+		return nil
+	}
+	if role == RoleSeedNode {
+		return r.PortScheme.Seeds
+	}
+	if pp, ok := r.PortScheme.Validators[name]; ok {
+		return pp
+	}
+	return r.PortScheme.Validators["default"]
+}
+
 // ValidatePeersRegistry validates that the peers registry matches the expected genesis.
 func ValidatePeersRegistry(reg *PeersRegistry, expectedChainID, expectedGenesisSHA string) error {
 	if reg.ChainID != expectedChainID {
@@ -0,0 +1,497 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Profile is a user-defined set of config values for tuning a node, e.g.
+// "rpc-heavy" or "low-memory". Profiles are JSON or YAML (.yaml, .yml)
+// files in the profiles directory, with the same keys:
+//
+//	{
+//	  "description": "Public RPC node",
+//	  "settings": [
+//	    {"file": "config.toml", "section": "rpc", "key": "max_open_connections", "value": 2000},
+//	    {"file": "app.toml", "section": "api", "key": "enable", "value": true,
+//	     "when": {"roles": ["archive_node"]}}
+//	  ]
+//	}
+type Profile struct {
+	Name        string           `json:"name,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Settings    []ProfileSetting `json:"settings"`
+}
+
+// ProfileSetting sets one key in one of the node's TOML files.
+type ProfileSetting struct {
+	File     string            `json:"file"`              // config.toml, app.toml or client.toml
+	Section  string            `json:"section,omitempty"` // "" is the root table
+	Key      string            `json:"key"`
+	Value    any               `json:"value"`
+	When     *ProfileCondition `json:"when,omitempty"`
+	Severity DriftSeverity     `json:"severity,omitempty"` // Drift severity (default WARNING)
+}
+
+// ProfileCondition restricts a setting to some nodes. Empty lists match
+// every node.
+type ProfileCondition struct {
+	Roles    []NodeRole `json:"roles,omitempty"`
+	Networks []string   `json:"networks,omitempty"`
+}
+
+// ProfileContext describes the node a profile is applied to.
+type ProfileContext struct {
+	Role    NodeRole // Detected from the config when empty
+	Network string   // Required if a setting has a network condition
+}
+
+// AppliedProfile records the profile applied to a node home, with only
+// the settings whose conditions matched. Drift detection and repair
+// check the node against it.
+type AppliedProfile struct {
+	Name      string           `json:"name"`
+	Network   string           `json:"network,omitempty"`
+	Role      NodeRole         `json:"role"`
+	AppliedAt time.Time        `json:"applied_at"`
+	Settings  []ProfileSetting `json:"settings"`
+}
+
+// ProfileChange is a setting whose value differs from the node's.
+type ProfileChange struct {
+	File    string `json:"file"`
+	Section string `json:"section,omitempty"`
+	Key     string `json:"key"`
+	Old     string `json:"old,omitempty"` // TOML text; empty if the key is missing
+	New     string `json:"new"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+// profileFiles are the files a profile may change.
+var profileFiles = []string{"config.toml", "app.toml", "client.toml"}
+
+// managedKeys are set from the canonical network config by join and
+// config repair; a profile may not override them.
+var managedKeys = map[string]bool{
+	"client.toml::chain-id":            true,
+	"app.toml:evm:evm-chain-id":        true,
+	"config.toml:p2p:seeds":            true,
+	"config.toml:p2p:persistent_peers": true,
+}
+
+// profileExts are the profile file extensions, in lookup order.
+var profileExts = []string{".json", ".yaml", ".yml"}
+
+// AppliedProfileFile is the file in the node's config directory that
+// records the applied profile.
+const AppliedProfileFile = "monoctl_profile.json"
+
+// DefaultProfilesDir returns ~/.mono-commander/profiles.
+func DefaultProfilesDir() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".mono-commander", "profiles"), nil
+}
+
+// LoadProfile reads profile name from dir: name.json, name.yaml or
+// name.yml, whichever exists first.
+func LoadProfile(dir, name string) (*Profile, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid profile name %q", name)
+	}
+	var path string
+	var data []byte
+	for _, ext := range profileExts {
+		var err error
+		path = filepath.Join(dir, name+ext)
+		data, err = os.ReadFile(path)
+		if err == nil {
+			break
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read profile: %w", err)
+		}
+		data = nil
+	}
+	if data == nil {
+		return nil, fmt.Errorf("profile %q not found in %s", name, dir)
+	}
+	if IsYAMLFile(path) {
+		jsonData, err := YAMLToJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		data = jsonData
+	}
+	var p Profile
+	if err := decodeProfileJSON(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if p.Name == "" {
+		p.Name = name
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &p, nil
+}
+
+// ListProfiles loads every profile in dir, sorted by name. A missing
+// directory has no profiles.
+func ListProfiles(dir string) ([]*Profile, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+	var profiles []*Profile
+	seen := map[string]bool{}
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		name := strings.TrimSuffix(e.Name(), ext)
+		if e.IsDir() || !slices.Contains(profileExts, ext) || seen[name] {
+			continue
+		}
+		seen[name] = true
+		p, err := LoadProfile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles, nil
+}
+
+// Validate checks every setting.
+func (p *Profile) Validate() error {
+	if len(p.Settings) == 0 {
+		return fmt.Errorf("profile %s has no settings", p.Name)
+	}
+	for i, s := range p.Settings {
+		where := fmt.Sprintf("setting %d (%s)", i+1, s.name())
+		if !slices.Contains(profileFiles, s.File) {
+			return fmt.Errorf("%s: file must be one of %s", where, strings.Join(profileFiles, ", "))
+		}
+		if s.Key == "" {
+			return fmt.Errorf("%s: key is required", where)
+		}
+		if managedKeys[s.File+":"+s.Section+":"+s.Key] {
+			return fmt.Errorf("%s: %s is managed by join and config repair", where, s.Key)
+		}
+		if s.Value == nil {
+			return fmt.Errorf("%s: value is required", where)
+		}
+		if _, err := formatTOMLValue(s.Value); err != nil {
+			return fmt.Errorf("%s: %w", where, err)
+		}
+		switch s.Severity {
+		case "", SeverityCritical, SeverityWarning, SeverityInfo:
+		default:
+			return fmt.Errorf("%s: invalid severity %q", where, s.Severity)
+		}
+		if s.When != nil {
+			for _, r := range s.When.Roles {
+				if _, err := ParseNodeRole(string(r)); err != nil {
+					return fmt.Errorf("%s: %w", where, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// name returns the setting's file and dotted key, e.g. app.toml api.enable.
+func (s ProfileSetting) name() string {
+	if s.Section == "" {
+		return s.File + " " + s.Key
+	}
+	return s.File + " " + s.Section + "." + s.Key
+}
+
+// matches reports whether the setting applies to ctx.
+func (s ProfileSetting) matches(ctx ProfileContext) (bool, error) {
+	if s.When == nil {
+		return true, nil
+	}
+	if len(s.When.Roles) > 0 {
+		match := false
+		for _, r := range s.When.Roles {
+			role, _ := ParseNodeRole(string(r))
+			match = match || role == ctx.Role
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	if len(s.When.Networks) > 0 {
+		if ctx.Network == "" {
+			return false, fmt.Errorf("%s depends on the network; pass --network", s.name())
+		}
+		match := false
+		for _, n := range s.When.Networks {
+			match = match || strings.EqualFold(n, ctx.Network)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ApplyProfile applies p to the node at home and records it as the
+// node's active profile. It returns the settings that changed; with
+// dryRun nothing is written.
+func ApplyProfile(home string, p *Profile, ctx ProfileContext, dryRun bool) ([]ProfileChange, error) {
+	return ApplyProfileFS(OSFS{}, home, p, ctx, dryRun)
+}
+
+// ApplyProfileFS is ApplyProfile on fsys.
+func ApplyProfileFS(fsys FS, home string, p *Profile, ctx ProfileContext, dryRun bool) ([]ProfileChange, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if ctx.Role == "" {
+		role, err := DetectCurrentRoleFS(fsys, home)
+		if err != nil {
+			return nil, err
+		}
+		ctx.Role = role
+	}
+
+	applied := &AppliedProfile{Name: p.Name, Network: ctx.Network, Role: ctx.Role, AppliedAt: time.Now().UTC()}
+	for _, s := range p.Settings {
+		ok, err := s.matches(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			applied.Settings = append(applied.Settings, s)
+		}
+	}
+
+	changes, err := applySettings(fsys, home, applied.Settings, dryRun)
+	if err != nil || dryRun {
+		return changes, err
+	}
+
+	data, err := json.MarshalIndent(applied, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := fsys.WriteFile(filepath.Join(home, "config", AppliedProfileFile), append(data, '\n'), 0644); err != nil {
+		return nil, fmt.Errorf("failed to record applied profile: %w", err)
+	}
+	return changes, nil
+}
+
+// applySettings sets each setting that differs from the node's value.
+func applySettings(fsys FS, home string, settings []ProfileSetting, dryRun bool) ([]ProfileChange, error) {
+	var changes []ProfileChange
+	for _, file := range profileFiles {
+		var values []configValue
+		for _, s := range settings {
+			if s.File == file {
+				values = append(values, configValue{s.Section, s.Key, s.Value})
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		path := filepath.Join(home, "config", file)
+		data, err := fsys.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		doc, err := ParseTOMLDocument(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		fileChanges, err := diffConfigValues(doc, file, values)
+		if err != nil {
+			return nil, err
+		}
+		if len(fileChanges) == 0 {
+			continue
+		}
+		changes = append(changes, fileChanges...)
+		if dryRun {
+			continue
+		}
+		for _, v := range values {
+			if err := doc.Set(v.table, v.key, v.value); err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+		}
+		if err := fsys.WriteFile(path, doc.Bytes(), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+	}
+	return changes, nil
+}
+
+// diffConfigValues returns the values that differ from doc's.
+func diffConfigValues(doc *TOMLDocument, file string, values []configValue) ([]ProfileChange, error) {
+	var changes []ProfileChange
+	for _, v := range values {
+		text, err := formatTOMLValue(v.value)
+		if err != nil {
+			return nil, err
+		}
+		want, err := decodeTOMLValue(text)
+		if err != nil {
+			return nil, err
+		}
+		current, ok := doc.Get(v.table, v.key)
+		if ok && reflect.DeepEqual(current, want) {
+			continue
+		}
+		change := ProfileChange{File: file, Section: v.table, Key: v.key, New: text, Missing: !ok}
+		if ok {
+			change.Old = formatCurrentValue(current)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// formatCurrentValue formats a decoded value as TOML, falling back to %v
+// for values Set cannot write (inline tables, dates).
+func formatCurrentValue(v any) string {
+	if text, err := formatTOMLValue(v); err == nil {
+		return text
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// ActiveProfile returns the profile applied to the node at home, or nil.
+func ActiveProfile(home string) (*AppliedProfile, error) {
+	return ActiveProfileFS(OSFS{}, home)
+}
+
+// ActiveProfileFS is ActiveProfile on fsys.
+func ActiveProfileFS(fsys FS, home string) (*AppliedProfile, error) {
+	path := filepath.Join(home, "config", AppliedProfileFile)
+	data, err := fsys.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", AppliedProfileFile, err)
+	}
+	var applied AppliedProfile
+	if err := decodeProfileJSON(data, &applied); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &applied, nil
+}
+
+// ClearActiveProfile stops tracking the node's profile. Its settings stay
+// in the config files.
+func ClearActiveProfile(home string) error {
+	return ClearActiveProfileFS(OSFS{}, home)
+}
+
+// ClearActiveProfileFS is ClearActiveProfile on fsys.
+func ClearActiveProfileFS(fsys FS, home string) error {
+	err := fsys.Remove(filepath.Join(home, "config", AppliedProfileFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// DetectProfileDriftFS reports settings of the active profile that the
+// node's config no longer has.
+func DetectProfileDriftFS(fsys FS, home string) ([]DriftResult, error) {
+	applied, err := ActiveProfileFS(fsys, home)
+	if err != nil || applied == nil {
+		return nil, err
+	}
+	changes, err := applySettings(fsys, home, applied.Settings, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifts []DriftResult
+	for _, c := range changes {
+		severity := SeverityWarning
+		var fix *DriftFix
+		for _, s := range applied.Settings {
+			if s.File == c.File && s.Section == c.Section && s.Key == c.Key {
+				fix = &DriftFix{File: s.File, Section: s.Section, Key: s.Key, Value: s.Value}
+				if s.Severity != "" {
+					severity = s.Severity
+				}
+			}
+		}
+		actual := c.Old
+		if c.Missing {
+			actual = "(missing)"
+		}
+		drifts = append(drifts, DriftResult{
+			Field:    fmt.Sprintf("%s (profile %s)", c.dottedKey(), applied.Name),
+			Expected: c.New,
+			Actual:   actual,
+			File:     c.File,
+			Severity: severity,
+			Fix:      fix,
+		})
+	}
+	return drifts, nil
+}
+
+func (c ProfileChange) dottedKey() string {
+	return dottedKey(c.Section, c.Key)
+}
+
+// decodeProfileJSON decodes JSON keeping integers as int64, so values are
+// written back as TOML integers rather than floats.
+func decodeProfileJSON(data []byte, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	var settings []ProfileSetting
+	switch v := v.(type) {
+	case *Profile:
+		settings = v.Settings
+	case *AppliedProfile:
+		settings = v.Settings
+	}
+	for i := range settings {
+		settings[i].Value = normalizeJSONNumber(settings[i].Value)
+	}
+	return nil
+}
+
+func normalizeJSONNumber(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i := range v {
+			v[i] = normalizeJSONNumber(v[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,250 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProfileJSON = `{
+  "description": "RPC-heavy node",
+  "settings": [
+    {"file": "config.toml", "section": "rpc", "key": "max_open_connections", "value": 2000},
+    {"file": "config.toml", "section": "rpc", "key": "cors_allowed_origins", "value": []},
+    {"file": "app.toml", "section": "json-rpc", "key": "gas-cap", "value": 50000000, "severity": "CRITICAL"},
+    {"file": "app.toml", "section": "api", "key": "enable", "value": true, "when": {"roles": ["archive"]}},
+    {"file": "app.toml", "key": "iavl-cache-size", "value": 781250, "when": {"networks": ["Testnet"]}}
+  ]
+}`
+
+// profileHome creates a node home in a MemFS.
+func profileHome(t *testing.T) (*MemFS, string) {
+	t.Helper()
+	home := "/srv/monod"
+	m := NewMemFS()
+	m.MkdirAll(filepath.Join(home, "config"), 0755)
+	files := map[string]string{
+		"config.toml": "[p2p]\nseed_mode = false\n\n[rpc]\n# Maximum number of simultaneous connections\nmax_open_connections = 900\n",
+		"app.toml":    "pruning = \"default\"\n\n[api]\nenable = false\n\n[json-rpc]\ngas-cap = 25000000\n",
+	}
+	for name, content := range files {
+		m.WriteFile(filepath.Join(home, "config", name), []byte(content), 0644)
+	}
+	return m, home
+}
+
+func writeTestProfile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name+".json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestProfile(t, dir, "rpc-heavy", testProfileJSON)
+
+	p, err := LoadProfile(dir, "rpc-heavy")
+	if err != nil {
+		t.Fatalf("LoadProfile() error = %v", err)
+	}
+	if p.Name != "rpc-heavy" || len(p.Settings) != 5 {
+		t.Errorf("profile = %+v", p)
+	}
+	if v, ok := p.Settings[0].Value.(int64); !ok || v != 2000 {
+		t.Errorf("integer value = %#v, want int64", p.Settings[0].Value)
+	}
+
+	if _, err := LoadProfile(dir, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("LoadProfile(missing) error = %v", err)
+	}
+	if _, err := LoadProfile(dir, "../rpc-heavy"); err == nil {
+		t.Error("expected an error for a path in the profile name")
+	}
+
+	os.WriteFile(filepath.Join(dir, "low-memory.yaml"), []byte(`description: Small VPS
+settings:
+  - file: app.toml
+    key: pruning
+    value: everything
+  - file: config.toml
+    section: mempool
+    key: size
+    value: 1000
+    when:
+      roles: [full_node]
+`), 0644)
+	p, err = LoadProfile(dir, "low-memory")
+	if err != nil {
+		t.Fatalf("LoadProfile(yaml) error = %v", err)
+	}
+	if p.Description != "Small VPS" || len(p.Settings) != 2 || p.Settings[1].When == nil {
+		t.Errorf("yaml profile = %+v", p)
+	}
+	if v, ok := p.Settings[1].Value.(int64); !ok || v != 1000 {
+		t.Errorf("yaml integer value = %#v, want int64", p.Settings[1].Value)
+	}
+	os.WriteFile(filepath.Join(dir, "typo.yml"), []byte("settings:\n  - file: app.toml\n    kye: pruning\n"), 0644)
+	if _, err := LoadProfile(dir, "typo"); err == nil || !strings.Contains(err.Error(), "unknown field") {
+		t.Errorf("LoadProfile(typo) error = %v, want unknown field", err)
+	}
+	os.Remove(filepath.Join(dir, "typo.yml"))
+
+	profiles, err := ListProfiles(dir)
+	if err != nil || len(profiles) != 2 || profiles[0].Name != "low-memory" || profiles[1].Name != "rpc-heavy" {
+		t.Errorf("ListProfiles() = %v, %v", profiles, err)
+	}
+	if profiles, err := ListProfiles(filepath.Join(dir, "none")); err != nil || profiles != nil {
+		t.Errorf("ListProfiles(missing dir) = %v, %v", profiles, err)
+	}
+}
+
+func TestProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		setting ProfileSetting
+		wantErr string
+	}{
+		{"unknown file", ProfileSetting{File: "genesis.json", Key: "a", Value: 1}, "file must be"},
+		{"missing key", ProfileSetting{File: "app.toml", Value: 1}, "key is required"},
+		{"missing value", ProfileSetting{File: "app.toml", Key: "a"}, "value is required"},
+		{"managed key", ProfileSetting{File: "config.toml", Section: "p2p", Key: "seeds", Value: ""}, "managed"},
+		{"managed root key", ProfileSetting{File: "client.toml", Key: "chain-id", Value: "x"}, "managed"},
+		{"object value", ProfileSetting{File: "app.toml", Key: "a", Value: map[string]any{"b": 1}}, "unsupported"},
+		{"bad severity", ProfileSetting{File: "app.toml", Key: "a", Value: 1, Severity: "FATAL"}, "severity"},
+		{"bad role", ProfileSetting{File: "app.toml", Key: "a", Value: 1, When: &ProfileCondition{Roles: []NodeRole{"miner"}}}, "invalid node role"},
+	}
+	for _, tt := range tests {
+		p := &Profile{Name: "p", Settings: []ProfileSetting{tt.setting}}
+		if err := p.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: Validate() error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+	if err := (&Profile{Name: "empty"}).Validate(); err == nil {
+		t.Error("expected an error for a profile without settings")
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	dir := t.TempDir()
+	writeTestProfile(t, dir, "rpc-heavy", testProfileJSON)
+	p, _ := LoadProfile(dir, "rpc-heavy")
+	m, home := profileHome(t)
+	configPath := filepath.Join(home, "config", "config.toml")
+	before, _ := m.ReadFile(configPath)
+
+	// A network condition needs the network
+	if _, err := ApplyProfileFS(m, home, p, ProfileContext{}, true); err == nil || !strings.Contains(err.Error(), "--network") {
+		t.Fatalf("ApplyProfileFS() without network error = %v", err)
+	}
+
+	// Dry run reports changes without writing
+	ctx := ProfileContext{Network: "Sprintnet"}
+	changes, err := ApplyProfileFS(m, home, p, ctx, true)
+	if err != nil {
+		t.Fatalf("ApplyProfileFS(dry run) error = %v", err)
+	}
+	want := []ProfileChange{
+		{File: "config.toml", Section: "rpc", Key: "max_open_connections", Old: "900", New: "2000"},
+		{File: "config.toml", Section: "rpc", Key: "cors_allowed_origins", New: "[]", Missing: true},
+		{File: "app.toml", Section: "json-rpc", Key: "gas-cap", Old: "25000000", New: "50000000"},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+	if after, _ := m.ReadFile(configPath); string(after) != string(before) {
+		t.Error("dry run modified config.toml")
+	}
+	if active, _ := ActiveProfileFS(m, home); active != nil {
+		t.Error("dry run recorded the profile")
+	}
+
+	// Apply writes the values, keeps comments and records the profile
+	if _, err := ApplyProfileFS(m, home, p, ctx, false); err != nil {
+		t.Fatalf("ApplyProfileFS() error = %v", err)
+	}
+	config, _ := m.ReadFile(configPath)
+	if !strings.Contains(string(config), "# Maximum number of simultaneous connections\nmax_open_connections = 2000\ncors_allowed_origins = []\n") {
+		t.Errorf("config.toml = %s", config)
+	}
+	if api, _ := GetConfigValueFS(m, filepath.Join(home, "config", "app.toml"), "api", "enable"); api != "false" {
+		t.Errorf("api.enable = %s; the role condition should not match a full node", api)
+	}
+	active, err := ActiveProfileFS(m, home)
+	if err != nil || active == nil || active.Name != "rpc-heavy" || active.Role != RoleFullNode || len(active.Settings) != 3 {
+		t.Fatalf("ActiveProfileFS() = %+v, %v", active, err)
+	}
+
+	// Applying again changes nothing
+	if changes, err := ApplyProfileFS(m, home, p, ctx, false); err != nil || len(changes) != 0 {
+		t.Errorf("second apply = %+v, %v", changes, err)
+	}
+
+	// The role condition matches an archive node
+	changes, err = ApplyProfileFS(m, home, p, ProfileContext{Role: RoleArchiveNode, Network: "testnet"}, true)
+	if err != nil || len(changes) != 2 || changes[0].Key != "enable" || changes[1].Key != "iavl-cache-size" {
+		t.Errorf("archive node changes = %+v, %v", changes, err)
+	}
+
+	if err := ClearActiveProfileFS(m, home); err != nil {
+		t.Fatal(err)
+	}
+	if active, _ := ActiveProfileFS(m, home); active != nil {
+		t.Error("profile still active after clear")
+	}
+}
+
+func TestProfileDriftAndRepair(t *testing.T) {
+	dir := t.TempDir()
+	writeTestProfile(t, dir, "rpc-heavy", testProfileJSON)
+	p, _ := LoadProfile(dir, "rpc-heavy")
+	m, home := profileHome(t)
+	m.WriteFile(filepath.Join(home, "config", "client.toml"), []byte("chain-id = \"mono-sprint-1\"\n"), 0644)
+	m.WriteFile(filepath.Join(home, "config", "app.toml"), []byte("pruning = \"default\"\n\n[evm]\nevm-chain-id = 262146\n\n[json-rpc]\ngas-cap = 25000000\n"), 0644)
+	expected := &DriftConfig{CosmosChainID: "mono-sprint-1", EVMChainID: 262146}
+
+	if _, err := ApplyProfileFS(m, home, p, ProfileContext{Network: "Sprintnet"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if drifts, err := DetectDriftFS(m, home, expected); err != nil || len(drifts) != 0 {
+		t.Fatalf("drift after apply = %+v, %v", drifts, err)
+	}
+
+	// A script changes a profile value behind monoctl's back
+	appPath := filepath.Join(home, "config", "app.toml")
+	app, _ := m.ReadFile(appPath)
+	m.WriteFile(appPath, []byte(strings.Replace(string(app), "gas-cap = 50000000", "gas-cap = 1", 1)), 0644)
+
+	drifts, err := DetectDriftFS(m, home, expected)
+	if err != nil || len(drifts) != 1 {
+		t.Fatalf("drifts = %+v, %v", drifts, err)
+	}
+	want := DriftResult{Field: "json-rpc.gas-cap (profile rpc-heavy)", Expected: "50000000", Actual: "1", File: "app.toml", Severity: SeverityCritical}
+	got := drifts[0]
+	if fix := got.Fix; fix == nil || fix.Section != "json-rpc" || fix.Key != "gas-cap" || fix.Value != int64(50000000) {
+		t.Errorf("drift fix = %+v", fix)
+	}
+	got.Fix = nil
+	if got != want {
+		t.Errorf("drift = %+v, want %+v", got, want)
+	}
+
+	// Repair restores the profile value instead of fighting it
+	results, err := RepairFS(m, home, expected, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := results[len(results)-1]
+	if last.Field != "json-rpc.gas-cap (profile rpc-heavy)" || last.OldValue != "1" || !last.Success {
+		t.Errorf("repair result = %+v", last)
+	}
+	if drifts, _ := DetectDriftFS(m, home, expected); len(drifts) != 0 {
+		t.Errorf("drift after repair = %+v", drifts)
+	}
+}
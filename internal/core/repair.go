@@ -14,18 +14,25 @@ type RepairResult struct {
 	File     string
 	Success  bool
 	Error    string
+	Skipped  bool   // report-only drift, left for the operator
+	Note     string // why a drift was skipped
 }
 
 // Repair fixes configuration drift by applying canonical config
 func Repair(home string, config *DriftConfig, dryRun bool) ([]RepairResult, error) {
+	return RepairFS(OSFS{}, home, config, dryRun)
+}
+
+// RepairFS is Repair on fsys.
+func RepairFS(fsys FS, home string, config *DriftConfig, dryRun bool) ([]RepairResult, error) {
 	var results []RepairResult
 	configDir := filepath.Join(home, "config")
 
 	// Repair client.toml chain-id
 	clientPath := filepath.Join(configDir, "client.toml")
-	oldChainID, _ := GetConfigValue(clientPath, "", "chain-id")
+	oldChainID, _ := GetConfigValueFS(fsys, clientPath, "", "chain-id")
 	oldChainID = strings.Trim(oldChainID, "\"")
-	if err := SetClientChainID(home, config.CosmosChainID, dryRun); err != nil {
+	if err := SetClientChainIDFS(fsys, home, config.CosmosChainID, dryRun); err != nil {
 		results = append(results, RepairResult{
 			Field:    "chain-id",
 			OldValue: oldChainID,
@@ -46,8 +53,8 @@ func Repair(home string, config *DriftConfig, dryRun bool) ([]RepairResult, erro
 
 	// Repair app.toml evm-chain-id
 	appPath := filepath.Join(configDir, "app.toml")
-	oldEVMChainID, _ := GetConfigValue(appPath, "evm", "evm-chain-id")
-	if err := SetEVMChainID(home, config.EVMChainID, dryRun); err != nil {
+	oldEVMChainID, _ := GetConfigValueFS(fsys, appPath, "evm", "evm-chain-id")
+	if err := SetEVMChainIDFS(fsys, home, config.EVMChainID, dryRun); err != nil {
 		results = append(results, RepairResult{
 			Field:    "evm-chain-id",
 			OldValue: oldEVMChainID,
@@ -86,7 +93,7 @@ func Repair(home string, config *DriftConfig, dryRun bool) ([]RepairResult, erro
 	}
 
 	patch := GenerateConfigPatch(seeds, bootstrapPeers)
-	if err := ApplyConfigPatch(configPath, patch, dryRun); err != nil {
+	if err := ApplyConfigPatchFS(fsys, configPath, patch, dryRun); err != nil {
 		results = append(results, RepairResult{
 			Field:   "p2p",
 			File:    "config.toml",
@@ -101,6 +108,17 @@ func Repair(home string, config *DriftConfig, dryRun bool) ([]RepairResult, erro
 		})
 	}
 
+	// Apply the fixes of the remaining checks; this also re-applies the
+	// active profile so repair keeps the node's tuning. Only checks config
+	// asks for have fixes; advisory and security findings are skipped
+	// unless config.ApplySecurityFixes is set
+	drifts, err := detectExtendedDriftFS(fsys, home, config)
+	if err != nil {
+		results = append(results, RepairResult{Field: "config checks", Success: false, Error: err.Error()})
+		return results, nil
+	}
+	results = append(results, repairDriftsFS(fsys, home, drifts, dryRun)...)
+
 	return results, nil
 }
 
@@ -117,7 +135,10 @@ func FormatRepairReport(results []RepairResult, dryRun bool) string {
 	allSuccess := true
 	for _, r := range results {
 		status := "✓"
-		if !r.Success {
+		switch {
+		case r.Skipped:
+			status = "-"
+		case !r.Success:
 			status = "✗"
 			allSuccess = false
 		}
@@ -132,6 +153,9 @@ func FormatRepairReport(results []RepairResult, dryRun bool) string {
 		if r.Error != "" {
 			sb.WriteString(fmt.Sprintf("    Error: %s\n", r.Error))
 		}
+		if r.Note != "" {
+			sb.WriteString(fmt.Sprintf("    %s\n", r.Note))
+		}
 	}
 
 	if allSuccess && !dryRun {
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// ResetResult describes what ResetNodeHome did.
+type ResetResult struct {
+	KeysPreserved []string // Key files restored after the reset
+	Removed       []string // Config files that were removed
+}
+
+// resetConfigFiles are removed from config/ on every reset.
+var resetConfigFiles = []string{
+	"config.toml",
+	"app.toml",
+	"client.toml",
+	"genesis.json",
+	"addrbook.json",
+	"config_patch.toml",
+}
+
+// resetKeyFiles are removed from config/ unless keys are preserved.
+var resetKeyFiles = []string{"node_key.json", "priv_validator_key.json"}
+
+// IsNodeHome reports whether home looks like a monod home, i.e. has a
+// config/ or data/ directory.
+func IsNodeHome(fsys FS, home string) bool {
+	for _, dir := range []string{"config", "data"} {
+		if info, err := fsys.Stat(filepath.Join(home, dir)); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// ResetNodeHome deletes the node's blockchain data and configuration so
+// it can join again, leaving a fresh priv_validator_state.json. With
+// preserveKeys, node_key.json and priv_validator_key.json are kept.
+//
+// The node must be stopped first.
+func ResetNodeHome(fsys FS, home string, preserveKeys bool) (*ResetResult, error) {
+	if !IsNodeHome(fsys, home) {
+		return nil, fmt.Errorf("%s does not look like a monod home directory (no config/ or data/)", home)
+	}
+	configDir := filepath.Join(home, "config")
+	dataDir := filepath.Join(home, "data")
+	result := &ResetResult{}
+
+	// Back up keys
+	backups := map[string][]byte{}
+	if preserveKeys {
+		for _, name := range resetKeyFiles {
+			if data, err := fsys.ReadFile(filepath.Join(configDir, name)); err == nil {
+				backups[name] = data
+			}
+		}
+	}
+
+	// Delete the ENTIRE data directory (not just contents) - this ensures
+	// all *.db directories are removed
+	if err := fsys.RemoveAll(dataDir); err != nil {
+		return nil, fmt.Errorf("failed to remove data dir: %w", err)
+	}
+
+	files := resetConfigFiles
+	if !preserveKeys {
+		files = append(append([]string{}, files...), resetKeyFiles...)
+	}
+	for _, name := range files {
+		if err := fsys.Remove(filepath.Join(configDir, name)); err == nil {
+			result.Removed = append(result.Removed, name)
+		}
+	}
+
+	// Recreate directories and restore keys
+	if err := fsys.MkdirAll(configDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create config dir: %w", err)
+	}
+	if err := fsys.MkdirAll(dataDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create data dir: %w", err)
+	}
+	privValState := `{"height": "0", "round": 0, "step": 0}`
+	if err := fsys.WriteFile(filepath.Join(dataDir, "priv_validator_state.json"), []byte(privValState), 0600); err != nil {
+		return nil, fmt.Errorf("failed to create priv_validator_state.json: %w", err)
+	}
+	for _, name := range resetKeyFiles {
+		data, ok := backups[name]
+		if !ok {
+			continue
+		}
+		if err := fsys.WriteFile(filepath.Join(configDir, name), data, 0600); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", name, err)
+		}
+		result.KeysPreserved = append(result.KeysPreserved, name)
+	}
+
+	// Verify the reset: no databases or addrbook may remain
+	if HasStaleDataFS(fsys, home) {
+		return nil, fmt.Errorf("reset verification failed: %s still contains databases", dataDir)
+	}
+	if _, err := fsys.Stat(filepath.Join(configDir, "addrbook.json")); err == nil {
+		return nil, fmt.Errorf("reset verification failed: addrbook.json still exists")
+	}
+
+	return result, nil
+}
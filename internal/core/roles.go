@@ -2,7 +2,6 @@ package core
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 )
@@ -126,6 +125,11 @@ type RoleIssue struct {
 
 // ValidateRoleConfig validates that a node's configuration matches its declared role.
 func ValidateRoleConfig(home string, declaredRole NodeRole) (*RoleValidationResult, error) {
+	return ValidateRoleConfigFS(OSFS{}, home, declaredRole)
+}
+
+// ValidateRoleConfigFS is ValidateRoleConfig on fsys.
+func ValidateRoleConfigFS(fsys FS, home string, declaredRole NodeRole) (*RoleValidationResult, error) {
 	result := &RoleValidationResult{
 		Valid:       true,
 		Role:        declaredRole,
@@ -137,14 +141,14 @@ func ValidateRoleConfig(home string, declaredRole NodeRole) (*RoleValidationResu
 
 	// Read config.toml
 	configPath := filepath.Join(home, "config", "config.toml")
-	configData, err := os.ReadFile(configPath)
+	configData, err := fsys.ReadFile(configPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config.toml: %w", err)
 	}
 
 	// Read app.toml
 	appPath := filepath.Join(home, "config", "app.toml")
-	appData, err := os.ReadFile(appPath)
+	appData, err := fsys.ReadFile(appPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read app.toml: %w", err)
 	}
@@ -219,16 +223,21 @@ func ValidateRoleConfig(home string, declaredRole NodeRole) (*RoleValidationResu
 
 // DetectCurrentRole attempts to detect the current role based on configuration.
 func DetectCurrentRole(home string) (NodeRole, error) {
+	return DetectCurrentRoleFS(OSFS{}, home)
+}
+
+// DetectCurrentRoleFS is DetectCurrentRole on fsys.
+func DetectCurrentRoleFS(fsys FS, home string) (NodeRole, error) {
 	// Read config.toml
 	configPath := filepath.Join(home, "config", "config.toml")
-	configData, err := os.ReadFile(configPath)
+	configData, err := fsys.ReadFile(configPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read config.toml: %w", err)
 	}
 
 	// Read app.toml
 	appPath := filepath.Join(home, "config", "app.toml")
-	appData, err := os.ReadFile(appPath)
+	appData, err := fsys.ReadFile(appPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read app.toml: %w", err)
 	}
@@ -249,25 +258,32 @@ func DetectCurrentRole(home string) (NodeRole, error) {
 
 // ApplyRoleConfig applies the configuration for a given role to config.toml and app.toml.
 func ApplyRoleConfig(home string, role NodeRole, dryRun bool) error {
+	return ApplyRoleConfigFS(OSFS{}, home, role, dryRun)
+}
+
+// ApplyRoleConfigFS is ApplyRoleConfig on fsys.
+func ApplyRoleConfigFS(fsys FS, home string, role NodeRole, dryRun bool) error {
 	expected := GetRoleConfig(role)
 
+	if dryRun {
+		return nil
+	}
+
 	// Apply seed_mode to config.toml
 	configPath := filepath.Join(home, "config", "config.toml")
-	if err := applyConfigValue(configPath, "p2p", "seed_mode", fmt.Sprintf("%t", expected.SeedMode), dryRun); err != nil {
+	if err := setConfigValues(fsys, configPath, []configValue{{"p2p", "seed_mode", expected.SeedMode}}); err != nil {
 		return fmt.Errorf("failed to set seed_mode: %w", err)
 	}
 
 	// Apply pruning settings to app.toml
 	appPath := filepath.Join(home, "config", "app.toml")
-	if err := applyConfigValue(appPath, "", "pruning", fmt.Sprintf("\"%s\"", expected.Pruning), dryRun); err != nil {
+	if err := setConfigValues(fsys, appPath, []configValue{
+		{"", "pruning", expected.Pruning},
+		{"", "pruning-keep-recent", expected.PruningKeepRecent},
+		{"", "pruning-interval", expected.PruningInterval},
+	}); err != nil {
 		return fmt.Errorf("failed to set pruning: %w", err)
 	}
-	if err := applyConfigValue(appPath, "", "pruning-keep-recent", fmt.Sprintf("\"%s\"", expected.PruningKeepRecent), dryRun); err != nil {
-		return fmt.Errorf("failed to set pruning-keep-recent: %w", err)
-	}
-	if err := applyConfigValue(appPath, "", "pruning-interval", fmt.Sprintf("\"%s\"", expected.PruningInterval), dryRun); err != nil {
-		return fmt.Errorf("failed to set pruning-interval: %w", err)
-	}
 
 	return nil
 }
@@ -278,94 +294,26 @@ func getConfigBool(data, section, key string) bool {
 	return value == "true"
 }
 
-// getConfigString reads a string value from a TOML config string.
+// getConfigString reads a value from a TOML config string, or "" if the
+// key is missing or the file cannot be parsed.
 func getConfigString(data, section, key string) string {
-	lines := strings.Split(data, "\n")
-	inSection := section == ""
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Track section
-		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
-			currentSection := strings.Trim(trimmed, "[]")
-			inSection = (section == "" || currentSection == section)
-			continue
-		}
-
-		if !inSection {
-			continue
-		}
-
-		// Check for key
-		if strings.HasPrefix(trimmed, key) {
-			rest := strings.TrimPrefix(trimmed, key)
-			rest = strings.TrimLeft(rest, " \t")
-			if strings.HasPrefix(rest, "=") {
-				value := strings.TrimPrefix(rest, "=")
-				value = strings.TrimSpace(value)
-				// Remove quotes if present
-				value = strings.Trim(value, "\"")
-				return value
-			}
-		}
-	}
-
-	return ""
-}
-
-// applyConfigValue modifies a value in a TOML config file.
-func applyConfigValue(path, section, key, value string, dryRun bool) error {
-	if dryRun {
-		return nil
-	}
-
-	data, err := os.ReadFile(path)
+	doc, err := ParseTOMLDocument([]byte(data))
 	if err != nil {
-		return err
+		return ""
 	}
-
-	lines := strings.Split(string(data), "\n")
-	var result []string
-	inSection := section == ""
-	keyFound := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Track section
-		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
-			currentSection := strings.Trim(trimmed, "[]")
-			inSection = (section == "" || currentSection == section)
-		}
-
-		// Check for key to replace
-		if inSection && strings.HasPrefix(trimmed, key) {
-			rest := strings.TrimPrefix(trimmed, key)
-			rest = strings.TrimLeft(rest, " \t")
-			if strings.HasPrefix(rest, "=") {
-				// Preserve leading whitespace
-				leadingWS := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
-				result = append(result, fmt.Sprintf("%s%s = %s", leadingWS, key, value))
-				keyFound = true
-				continue
-			}
-		}
-
-		result = append(result, line)
-	}
-
-	if !keyFound {
-		return fmt.Errorf("key '%s' not found in section [%s]", key, section)
-	}
-
-	return os.WriteFile(path, []byte(strings.Join(result, "\n")), 0644)
+	value, _ := doc.GetString(section, key)
+	return value
 }
 
 // IsSeedModeAllowed checks if seed_mode=true is allowed given the current pruning setting.
 func IsSeedModeAllowed(home string) (bool, string) {
+	return IsSeedModeAllowedFS(OSFS{}, home)
+}
+
+// IsSeedModeAllowedFS is IsSeedModeAllowed on fsys.
+func IsSeedModeAllowedFS(fsys FS, home string) (bool, string) {
 	appPath := filepath.Join(home, "config", "app.toml")
-	appData, err := os.ReadFile(appPath)
+	appData, err := fsys.ReadFile(appPath)
 	if err != nil {
 		return false, "Cannot read app.toml"
 	}
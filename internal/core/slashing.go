@@ -0,0 +1,159 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+	"github.com/monolythium/mono-commander/internal/walletgen"
+)
+
+// ErrCannotUnjail is returned when the pre-checks show an unjail tx would
+// be rejected.
+var ErrCannotUnjail = errors.New("validator cannot be unjailed")
+
+// UnjailStatus is what the chain knows about a validator's jailing.
+type UnjailStatus struct {
+	ValoperAddr       string    `json:"valoper_address"`
+	ConsAddr          string    `json:"cons_address"`
+	Moniker           string    `json:"moniker"`
+	Jailed            bool      `json:"jailed"`
+	JailedUntil       time.Time `json:"jailed_until"`
+	Tombstoned        bool      `json:"tombstoned"`
+	MissedBlocks      int64     `json:"missed_blocks"`
+	SelfDelegation    *big.Int  `json:"self_delegation"`
+	MinSelfDelegation *big.Int  `json:"min_self_delegation"`
+}
+
+// ConsAddressFromPubKey derives the monovalcons1... address of a consensus
+// public key as returned by the REST API.
+func ConsAddressFromPubKey(typeURL, keyB64 string) (string, error) {
+	if typeURL != "/cosmos.crypto.ed25519.PubKey" {
+		return "", fmt.Errorf("unsupported consensus key type %q", typeURL)
+	}
+	key, err := base64.StdEncoding.DecodeString(keyB64)
+	if err != nil || len(key) != 32 {
+		return "", fmt.Errorf("invalid ed25519 consensus key")
+	}
+	hash := sha256.Sum256(key)
+	return walletgen.Bech32Encode(Bech32PrefixConsAddr, hash[:20])
+}
+
+// CheckUnjail fetches the validator, its slashing signing info and its
+// operator's self-delegation.
+func CheckUnjail(client *rpc.CosmosClient, valoper string) (*UnjailStatus, error) {
+	v, err := client.Validator(valoper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validator: %w", err)
+	}
+	consAddr, err := ConsAddressFromPubKey(v.ConsensusPubkey.Type, v.ConsensusPubkey.Key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := client.SigningInfo(consAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query signing info: %w", err)
+	}
+
+	status := &UnjailStatus{
+		ValoperAddr:       valoper,
+		ConsAddr:          consAddr,
+		Moniker:           v.Description.Moniker,
+		Jailed:            v.Jailed,
+		JailedUntil:       info.JailedUntil,
+		Tombstoned:        info.Tombstoned,
+		SelfDelegation:    new(big.Int),
+		MinSelfDelegation: parseVotingPower(v.MinSelfDelegation),
+	}
+	status.MissedBlocks, _ = strconv.ParseInt(info.MissedBlocksCounter, 10, 64)
+
+	operator, err := ValoperToAccountAddress(valoper)
+	if err != nil {
+		return nil, err
+	}
+	del, err := client.Delegation(valoper, operator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query self-delegation: %w", err)
+	}
+	if del != nil {
+		status.SelfDelegation = parseVotingPower(del.Balance.Amount)
+	}
+	return status, nil
+}
+
+// CanUnjail returns nil if an unjail tx in a block at now would succeed,
+// or an error wrapping ErrCannotUnjail that gives the reason.
+func (s *UnjailStatus) CanUnjail(now time.Time) error {
+	switch {
+	case !s.Jailed:
+		return fmt.Errorf("%w: %s is not jailed", ErrCannotUnjail, s.ValoperAddr)
+	case s.Tombstoned:
+		return fmt.Errorf("%w: %s is tombstoned for double signing and can never be unjailed", ErrCannotUnjail, s.ValoperAddr)
+	case now.Before(s.JailedUntil):
+		return fmt.Errorf("%w: jailed until %s (%s from now)", ErrCannotUnjail,
+			s.JailedUntil.Local().Format(time.RFC1123), FormatTimeLeft(s.JailedUntil.Sub(now)))
+	case s.SelfDelegation.Sign() == 0:
+		return fmt.Errorf("%w: the operator has no self-delegation; delegate to the validator first", ErrCannotUnjail)
+	case s.SelfDelegation.Cmp(s.MinSelfDelegation) < 0:
+		return fmt.Errorf("%w: self-delegation %s is below the minimum of %s; delegate more first", ErrCannotUnjail,
+			FormatLYTH(s.SelfDelegation), FormatLYTH(s.MinSelfDelegation))
+	}
+	return nil
+}
+
+// ValidatorHealth is a validator's bonding and liveness state.
+type ValidatorHealth struct {
+	ValoperAddr string `json:"valoper_address"`
+	Moniker     string `json:"moniker"`
+	Status      string `json:"status"` // bonded, unbonding or unbonded
+	Jailed      bool   `json:"jailed"`
+	Tokens      string `json:"tokens"`
+	// The fields below come from the slashing signing info, which is
+	// missing if HasSigningInfo is false
+	HasSigningInfo bool      `json:"has_signing_info"`
+	Tombstoned     bool      `json:"tombstoned"`
+	JailedUntil    time.Time `json:"jailed_until"`
+	MissedBlocks   int64     `json:"missed_blocks"`
+}
+
+// GetValidatorHealth fetches a validator and its signing info. Only a failed
+// validator query is an error; without signing info the liveness fields are
+// left empty.
+func GetValidatorHealth(client *rpc.CosmosClient, valoper string) (*ValidatorHealth, error) {
+	v, err := client.Validator(valoper)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query validator: %w", err)
+	}
+	h := &ValidatorHealth{
+		ValoperAddr: valoper,
+		Moniker:     v.Description.Moniker,
+		Status:      bondStatusLabel(v.Status),
+		Jailed:      v.Jailed,
+		Tokens:      v.Tokens,
+	}
+
+	consAddr, err := ConsAddressFromPubKey(v.ConsensusPubkey.Type, v.ConsensusPubkey.Key)
+	if err != nil {
+		return h, nil
+	}
+	info, err := client.SigningInfo(consAddr)
+	if err != nil {
+		return h, nil
+	}
+	h.HasSigningInfo = true
+	h.Tombstoned = info.Tombstoned
+	h.JailedUntil = info.JailedUntil
+	h.MissedBlocks, _ = strconv.ParseInt(info.MissedBlocksCounter, 10, 64)
+	return h, nil
+}
+
+// bondStatusLabel returns "bonded" for BOND_STATUS_BONDED and so on.
+func bondStatusLabel(status string) string {
+	return strings.ToLower(strings.TrimPrefix(status, "BOND_STATUS_"))
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// mockSlashingREST serves the validator of signer's operator address with
+// the given jailed flag and signing info, and a self-delegation of 200.
+func mockSlashingREST(t *testing.T, jailed bool, signingInfo string) *httptest.Server {
+	t.Helper()
+	jailedStr := "false"
+	if jailed {
+		jailedStr = "true"
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/delegations/"):
+			w.Write([]byte(`{"delegation_response":{"delegation":{"shares":"200.000000000000000000"},"balance":{"denom":"alyth","amount":"200"}}}`))
+		case strings.HasPrefix(r.URL.Path, "/cosmos/staking/v1beta1/validators/"):
+			w.Write([]byte(`{"validator":{"operator_address":"x","jailed":` + jailedStr + `,"status":"BOND_STATUS_UNBONDING",
+				"consensus_pubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="},
+				"description":{"moniker":"val"},"min_self_delegation":"100"}}`))
+		case strings.HasPrefix(r.URL.Path, "/cosmos/slashing/v1beta1/signing_infos/monovalcons1"):
+			w.Write([]byte(`{"val_signing_info":` + signingInfo + `}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestUnjailAction_PreChecks(t *testing.T) {
+	tests := []struct {
+		name        string
+		jailed      bool
+		signingInfo string
+		reason      string // empty if the unjail may proceed
+	}{
+		{"served", true, `{"jailed_until":"2020-01-01T00:00:00Z","tombstoned":false,"missed_blocks_counter":"12"}`, ""},
+		{"not jailed", false, `{"jailed_until":"1970-01-01T00:00:00Z","tombstoned":false}`, "is not jailed"},
+		{"tombstoned", true, `{"jailed_until":"9999-12-31T23:59:59Z","tombstoned":true}`, "tombstoned"},
+		{"still jailed", true, `{"jailed_until":"2099-01-01T00:00:00Z","tombstoned":false}`, "jailed until"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := mockSlashingREST(t, tt.jailed, tt.signingInfo)
+			signer := testSigner(t)
+			opts := ValidatorActionOptions{Network: NetworkLocalnet, From: signer.Address(), DryRun: true, REST: srv.URL}
+
+			result, err := UnjailAction(context.Background(), opts, UnjailParams{})
+			if tt.reason == "" {
+				if err != nil {
+					t.Fatalf("UnjailAction() error = %v", err)
+				}
+				msg, ok := result.Command.Msgs[0].(*MsgUnjail)
+				if !ok || !strings.HasPrefix(msg.ValidatorAddr, "monovaloper1") {
+					t.Errorf("Msgs = %+v", result.Command.Msgs)
+				}
+				return
+			}
+			if !errors.Is(err, ErrCannotUnjail) || !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("error = %v, want %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestUnjailAction_UnreachableREST(t *testing.T) {
+	opts := ValidatorActionOptions{
+		Network: NetworkLocalnet,
+		From:    "mykey",
+		DryRun:  true,
+		REST:    "http://127.0.0.1:1",
+	}
+	params := UnjailParams{ValidatorAddr: testValoper}
+
+	// A dry-run only warns
+	result, err := UnjailAction(context.Background(), opts, params)
+	if err != nil {
+		t.Fatalf("UnjailAction() error = %v", err)
+	}
+	if !strings.Contains(result.Command.String(), "tx slashing unjail") || len(result.Warnings) != 2 {
+		t.Errorf("result = %+v", result)
+	}
+
+	// Executing without the checks is refused
+	opts.DryRun, opts.Execute = false, true
+	if _, err := UnjailAction(context.Background(), opts, params); err == nil {
+		t.Error("expected error when signing info cannot be checked")
+	}
+}
+
+func TestResolveUnjailValidator(t *testing.T) {
+	account := testSigner(t).Address()
+	valoper, _ := AccountToValoperAddress(account)
+
+	if got, err := ResolveUnjailValidator(account, UnjailParams{}); err != nil || got != valoper {
+		t.Errorf("ResolveUnjailValidator() = %s, %v; want %s", got, err, valoper)
+	}
+	if _, err := ResolveUnjailValidator(account, UnjailParams{ValidatorAddr: testValoper}); err == nil {
+		t.Error("expected error when --from is not the operator")
+	}
+	if _, err := ResolveUnjailValidator("mykey", UnjailParams{}); err == nil {
+		t.Error("expected error for a key name without a validator address")
+	}
+}
@@ -0,0 +1,278 @@
+package core
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/update"
+)
+
+// privValidatorStateFile is the double-sign protection file in the data directory.
+// It belongs to the local validator and must never be replaced by a snapshot.
+const privValidatorStateFile = "priv_validator_state.json"
+
+// SnapshotOptions contains options for restoring a data snapshot.
+type SnapshotOptions struct {
+	Home       string
+	URL        string
+	SHA256     string
+	Insecure   bool // Skip checksum verification (not recommended)
+	DryRun     bool
+	HTTPClient *http.Client
+	// OnProgress is called with the phase ("download" or "extract"), bytes
+	// processed so far, and the total size (0 if unknown).
+	OnProgress func(phase string, done, total int64)
+}
+
+// SnapshotResult contains the results of a snapshot restore.
+type SnapshotResult struct {
+	URL             string `json:"url"`
+	SHA256          string `json:"sha256,omitempty"`
+	Verified        bool   `json:"verified"`
+	DownloadedBytes int64  `json:"downloaded_bytes"`
+	FilesExtracted  int    `json:"files_extracted"`
+	StatePreserved  bool   `json:"priv_validator_state_preserved"`
+	DataDir         string `json:"data_dir"`
+}
+
+// ResolveSnapshotSource returns the snapshot URL and SHA256 published in the
+// network registry.
+func ResolveSnapshotSource(config *NetworkConfig) (string, string, error) {
+	if config == nil || config.SnapshotURL == "" {
+		return "", "", fmt.Errorf("no snapshot_url published for this network")
+	}
+	return config.SnapshotURL, config.SnapshotSHA256, nil
+}
+
+// progressInterval is the minimum time between progress reports. Reads are
+// a few KiB, far too frequent to redraw a progress line on each.
+const progressInterval = 250 * time.Millisecond
+
+// progressReader reports the number of bytes read through OnProgress: on
+// the first read, at most every progressInterval after that, and once when
+// the phase completes.
+type progressReader struct {
+	r          io.Reader
+	phase      string
+	done       int64
+	total      int64
+	onProgress func(phase string, done, total int64)
+	last       time.Time
+	finished   bool
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.done += int64(n)
+	if p.onProgress == nil || p.finished {
+		return n, err
+	}
+	final := err == io.EOF || (p.total > 0 && p.done >= p.total)
+	if final || (n > 0 && time.Since(p.last) >= progressInterval) {
+		p.finished = final
+		p.last = time.Now()
+		p.onProgress(p.phase, p.done, p.total)
+	}
+	return n, err
+}
+
+// RestoreSnapshot downloads a compressed data/ snapshot, verifies its SHA256,
+// and extracts it into the node home. The existing priv_validator_state.json is
+// never overwritten.
+//
+// The data directory must not contain blockchain databases; run
+// `monoctl node reset --preserve-keys` first if it does.
+func RestoreSnapshot(opts SnapshotOptions) (*SnapshotResult, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("snapshot URL is required")
+	}
+	if opts.SHA256 == "" && !opts.Insecure {
+		return nil, fmt.Errorf("snapshot SHA256 is required (use --insecure to skip verification)")
+	}
+
+	dataDir := filepath.Join(opts.Home, "data")
+	result := &SnapshotResult{
+		URL:     opts.URL,
+		SHA256:  opts.SHA256,
+		DataDir: dataDir,
+	}
+
+	if HasStaleData(opts.Home) {
+		return result, fmt.Errorf("data directory %s already contains blockchain data - run 'monoctl node reset --home %s --preserve-keys' first", dataDir, opts.Home)
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		return result, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	// Download into the node home so the archive lives on the same filesystem
+	tmpFile, err := os.CreateTemp(opts.Home, ".snapshot-*")
+	if err != nil {
+		return result, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+	defer os.Remove(tmpPath)
+
+	n, err := downloadSnapshot(opts, tmpFile)
+	tmpFile.Close()
+	if err != nil {
+		return result, err
+	}
+	result.DownloadedBytes = n
+
+	if opts.SHA256 != "" {
+		if err := update.VerifyChecksum(tmpPath, opts.SHA256); err != nil {
+			return result, fmt.Errorf("snapshot verification failed: %w", err)
+		}
+		result.Verified = true
+	}
+
+	statePath := filepath.Join(dataDir, privValidatorStateFile)
+	if _, err := os.Stat(statePath); err == nil {
+		result.StatePreserved = true
+	}
+
+	files, err := extractSnapshot(tmpPath, n, dataDir, opts.OnProgress)
+	result.FilesExtracted = files
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// downloadSnapshot streams the snapshot URL into w.
+func downloadSnapshot(opts SnapshotOptions, w io.Writer) (int64, error) {
+	client := opts.HTTPClient
+	if client == nil {
+		// No overall timeout: snapshots can be many gigabytes
+		client = &http.Client{}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, opts.URL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", "mono-commander")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("snapshot download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("snapshot download failed: HTTP %d", resp.StatusCode)
+	}
+
+	total := resp.ContentLength
+	if total < 0 {
+		total = 0
+	}
+	body := &progressReader{r: resp.Body, phase: "download", total: total, onProgress: opts.OnProgress}
+
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, fmt.Errorf("snapshot download failed: %w", err)
+	}
+	return n, nil
+}
+
+// extractSnapshot extracts a .tar or .tar.gz archive into dataDir.
+// Entries may be rooted at "data/" or directly at the data directory contents.
+func extractSnapshot(archivePath string, size int64, dataDir string, onProgress func(string, int64, int64)) (int, error) {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	br := bufio.NewReader(&progressReader{r: f, phase: "extract", total: size, onProgress: onProgress})
+
+	var r io.Reader = br
+	magic, _ := br.Peek(2)
+	if bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return 0, fmt.Errorf("failed to open gzip stream: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	files := 0
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return files, fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+
+		rel, ok := snapshotEntryPath(hdr.Name)
+		if !ok {
+			continue
+		}
+		if rel == privValidatorStateFile {
+			// Never take the double-sign state from someone else's node
+			continue
+		}
+
+		target := filepath.Join(dataDir, rel)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return files, fmt.Errorf("failed to create %s: %w", target, err)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return files, fmt.Errorf("failed to create %s: %w", filepath.Dir(target), err)
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm()|0600)
+			if err != nil {
+				return files, fmt.Errorf("failed to create %s: %w", target, err)
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return files, fmt.Errorf("failed to write %s: %w", target, err)
+			}
+			if err := out.Close(); err != nil {
+				return files, fmt.Errorf("failed to write %s: %w", target, err)
+			}
+			files++
+		default:
+			// Symlinks and special files are not expected in a data snapshot
+			continue
+		}
+	}
+
+	return files, nil
+}
+
+// snapshotEntryPath converts an archive entry name to a path relative to the
+// data directory. It strips an optional leading "data/"; cleaning the name as
+// a rooted path prevents entries like "../../etc" from escaping the data directory.
+func snapshotEntryPath(name string) (string, bool) {
+	name = strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(name)), "/")
+	name = strings.TrimPrefix(name, "data/")
+	if name == "" || name == "data" {
+		return "", false
+	}
+	return filepath.FromSlash(name), true
+}
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildSnapshotArchive creates a .tar.gz archive with the given files.
+func buildSnapshotArchive(t *testing.T, files map[string]string) ([]byte, string) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		hdr := &tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("failed to write header: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("failed to write content: %v", err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+
+	sum := sha256.Sum256(buf.Bytes())
+	return buf.Bytes(), hex.EncodeToString(sum[:])
+}
+
+func serveSnapshot(t *testing.T, data []byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	archive, sha := buildSnapshotArchive(t, map[string]string{
+		"data/application.db/000001.log": "app",
+		"data/blockstore.db/CURRENT":     "block",
+		"data/priv_validator_state.json": `{"height":"999","round":0,"step":3}`,
+	})
+	srv := serveSnapshot(t, archive)
+
+	home := t.TempDir()
+	dataDir := filepath.Join(home, "data")
+	os.MkdirAll(dataDir, 0700)
+	localState := `{"height":"0","round":0,"step":0}`
+	os.WriteFile(filepath.Join(dataDir, "priv_validator_state.json"), []byte(localState), 0600)
+
+	var phases []string
+	result, err := RestoreSnapshot(SnapshotOptions{
+		Home:   home,
+		URL:    srv.URL + "/data.tar.gz",
+		SHA256: sha,
+		OnProgress: func(phase string, done, total int64) {
+			if len(phases) == 0 || phases[len(phases)-1] != phase {
+				phases = append(phases, phase)
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("RestoreSnapshot() error = %v", err)
+	}
+
+	if !result.Verified {
+		t.Error("Verified = false, want true")
+	}
+	if result.FilesExtracted != 2 {
+		t.Errorf("FilesExtracted = %d, want 2", result.FilesExtracted)
+	}
+	if !result.StatePreserved {
+		t.Error("StatePreserved = false, want true")
+	}
+
+	got, _ := os.ReadFile(filepath.Join(dataDir, "application.db", "000001.log"))
+	if string(got) != "app" {
+		t.Errorf("application.db content = %q, want app", got)
+	}
+
+	state, _ := os.ReadFile(filepath.Join(dataDir, "priv_validator_state.json"))
+	if string(state) != localState {
+		t.Errorf("priv_validator_state.json was overwritten: %s", state)
+	}
+
+	if strings.Join(phases, ",") != "download,extract" {
+		t.Errorf("progress phases = %v, want [download extract]", phases)
+	}
+
+	// Temp archive must be cleaned up
+	entries, _ := os.ReadDir(home)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".snapshot-") {
+			t.Errorf("temp archive %s left behind", e.Name())
+		}
+	}
+}
+
+func TestRestoreSnapshot_ChecksumMismatch(t *testing.T) {
+	archive, _ := buildSnapshotArchive(t, map[string]string{"data/state.db/CURRENT": "x"})
+	srv := serveSnapshot(t, archive)
+
+	home := t.TempDir()
+	_, err := RestoreSnapshot(SnapshotOptions{
+		Home:   home,
+		URL:    srv.URL,
+		SHA256: strings.Repeat("0", 64),
+	})
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected checksum mismatch error, got %v", err)
+	}
+
+	if HasStaleData(home) {
+		t.Error("snapshot was extracted despite checksum mismatch")
+	}
+}
+
+func TestRestoreSnapshot_RequiresChecksum(t *testing.T) {
+	_, err := RestoreSnapshot(SnapshotOptions{Home: t.TempDir(), URL: "https://example.com/data.tar.gz"})
+	if err == nil {
+		t.Error("expected error without SHA256, got nil")
+	}
+}
+
+func TestRestoreSnapshot_RefusesDirtyData(t *testing.T) {
+	home := t.TempDir()
+	os.MkdirAll(filepath.Join(home, "data", "state.db"), 0755)
+
+	_, err := RestoreSnapshot(SnapshotOptions{Home: home, URL: "https://example.com/data.tar.gz", SHA256: strings.Repeat("0", 64)})
+	if err == nil || !strings.Contains(err.Error(), "node reset") {
+		t.Errorf("expected dirty data error, got %v", err)
+	}
+}
+
+func TestSnapshotEntryPath(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{"data/state.db/CURRENT", filepath.Join("state.db", "CURRENT"), true},
+		{"./data/state.db/CURRENT", filepath.Join("state.db", "CURRENT"), true},
+		{"state.db/CURRENT", filepath.Join("state.db", "CURRENT"), true},
+		{"data/", "", false},
+		{"../../etc/passwd", filepath.Join("etc", "passwd"), true},
+		{"/abs/file", filepath.Join("abs", "file"), true},
+	}
+
+	for _, tt := range tests {
+		got, ok := snapshotEntryPath(tt.name)
+		if ok != tt.wantOK || got != tt.want {
+			t.Errorf("snapshotEntryPath(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestProgressReader_Throttled(t *testing.T) {
+	for _, total := range []int64{1 << 20, 0} {
+		var reports []int64
+		p := &progressReader{
+			r:     bytes.NewReader(make([]byte, 1<<20)),
+			phase: "download",
+			total: total,
+			onProgress: func(phase string, done, total int64) {
+				reports = append(reports, done)
+			},
+		}
+		buf := make([]byte, 4096)
+		for {
+			if _, err := p.Read(buf); err != nil {
+				break
+			}
+		}
+
+		// The first read, then nothing within the interval, then completion
+		if len(reports) != 2 || reports[0] != 4096 || reports[1] != 1<<20 {
+			t.Errorf("total %d: reports = %v, want [4096 %d]", total, reports, 1<<20)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StateSyncTrustOffset is how many blocks below the latest height the trust
+// height is placed. Snapshots are taken periodically, so the trust height must
+// be old enough that a snapshot exists above it, but recent enough to be
+// within the trust period.
+const StateSyncTrustOffset = 2000
+
+// MinStateSyncRPCServers is the minimum number of agreeing RPC servers.
+// CometBFT requires at least two rpc_servers for light client verification.
+const MinStateSyncRPCServers = 2
+
+// StateSyncConfig holds the values written to the [statesync] section of config.toml.
+type StateSyncConfig struct {
+	Enable      bool     `json:"enable"`
+	RPCServers  []string `json:"rpc_servers"`
+	TrustHeight int64    `json:"trust_height"`
+	TrustHash   string   `json:"trust_hash"`
+}
+
+// cometBlockResponse represents the /block RPC response.
+type cometBlockResponse struct {
+	Result struct {
+		BlockID struct {
+			Hash string `json:"hash"`
+		} `json:"block_id"`
+		Block struct {
+			Header struct {
+				ChainID string `json:"chain_id"`
+				Height  string `json:"height"`
+			} `json:"header"`
+		} `json:"block"`
+	} `json:"result"`
+}
+
+// stateSyncBlock is a block header as reported by a single RPC endpoint.
+type stateSyncBlock struct {
+	ChainID string
+	Height  int64
+	Hash    string
+}
+
+// fetchStateSyncBlock fetches a block header from a CometBFT RPC endpoint.
+// A height of 0 fetches the latest block.
+func fetchStateSyncBlock(fetcher Fetcher, endpoint string, height int64) (*stateSyncBlock, error) {
+	url := endpoint + "/block"
+	if height > 0 {
+		url = fmt.Sprintf("%s/block?height=%d", endpoint, height)
+	}
+
+	data, err := fetcher.Fetch(url)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty response from %s", url)
+	}
+
+	var resp cometBlockResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse block response from %s: %w", url, err)
+	}
+
+	blockHeight, err := strconv.ParseInt(resp.Result.Block.Header.Height, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block height from %s: %q", url, resp.Result.Block.Header.Height)
+	}
+	if resp.Result.BlockID.Hash == "" {
+		return nil, fmt.Errorf("missing block hash from %s", url)
+	}
+
+	return &stateSyncBlock{
+		ChainID: resp.Result.Block.Header.ChainID,
+		Height:  blockHeight,
+		Hash:    strings.ToUpper(resp.Result.BlockID.Hash),
+	}, nil
+}
+
+// normalizeRPCEndpoint trims whitespace and trailing slashes from an RPC URL.
+func normalizeRPCEndpoint(endpoint string) string {
+	return strings.TrimRight(strings.TrimSpace(endpoint), "/")
+}
+
+// ResolveStateSyncConfig picks a trust height and hash from the trusted RPC
+// endpoints and cross-checks them.
+//
+// The trust height is StateSyncTrustOffset blocks below the lowest latest height
+// reported by the reachable endpoints. The block hash at that height must be
+// identical on at least MinStateSyncRPCServers endpoints; any endpoint reporting
+// a different hash is treated as fatal, since it indicates a fork or a
+// misbehaving RPC server.
+func ResolveStateSyncConfig(endpoints []string, chainID string, fetcher Fetcher) (*StateSyncConfig, error) {
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, ep := range endpoints {
+		ep = normalizeRPCEndpoint(ep)
+		if ep == "" || seen[ep] {
+			continue
+		}
+		seen[ep] = true
+		candidates = append(candidates, ep)
+	}
+
+	if len(candidates) < MinStateSyncRPCServers {
+		return nil, fmt.Errorf("state sync requires at least %d trusted RPC endpoints (found %d)", MinStateSyncRPCServers, len(candidates))
+	}
+
+	// Find the latest height every reachable endpoint can serve
+	var reachable []string
+	var minLatest int64
+	for _, ep := range candidates {
+		block, err := fetchStateSyncBlock(fetcher, ep, 0)
+		if err != nil {
+			continue
+		}
+		if chainID != "" && block.ChainID != "" && block.ChainID != chainID {
+			return nil, fmt.Errorf("RPC endpoint %s is on chain %s, expected %s", ep, block.ChainID, chainID)
+		}
+		if minLatest == 0 || block.Height < minLatest {
+			minLatest = block.Height
+		}
+		reachable = append(reachable, ep)
+	}
+
+	if len(reachable) < MinStateSyncRPCServers {
+		return nil, fmt.Errorf("state sync requires at least %d reachable RPC endpoints (reached %d of %d)", MinStateSyncRPCServers, len(reachable), len(candidates))
+	}
+
+	trustHeight := minLatest - StateSyncTrustOffset
+	if trustHeight <= 0 {
+		return nil, fmt.Errorf("chain height %d is too low for state sync (need more than %d blocks)", minLatest, StateSyncTrustOffset)
+	}
+
+	// Cross-check the block hash at the trust height
+	var trustHash string
+	var agreeing []string
+	for _, ep := range reachable {
+		block, err := fetchStateSyncBlock(fetcher, ep, trustHeight)
+		if err != nil {
+			continue
+		}
+		if block.Height != trustHeight {
+			return nil, fmt.Errorf("RPC endpoint %s returned height %d, expected %d", ep, block.Height, trustHeight)
+		}
+		if trustHash == "" {
+			trustHash = block.Hash
+		} else if block.Hash != trustHash {
+			return nil, fmt.Errorf("FATAL: trust hash mismatch at height %d: %s reports %s, %s reports %s - do NOT proceed",
+				trustHeight, agreeing[0], trustHash, ep, block.Hash)
+		}
+		agreeing = append(agreeing, ep)
+	}
+
+	if len(agreeing) < MinStateSyncRPCServers {
+		return nil, fmt.Errorf("could not verify trust hash at height %d on at least %d endpoints (verified on %d)", trustHeight, MinStateSyncRPCServers, len(agreeing))
+	}
+
+	return &StateSyncConfig{
+		Enable:      true,
+		RPCServers:  agreeing,
+		TrustHeight: trustHeight,
+		TrustHash:   trustHash,
+	}, nil
+}
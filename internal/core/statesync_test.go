@@ -0,0 +1,203 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func blockJSON(chainID string, height int64, hash string) []byte {
+	return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":-1,"result":{"block_id":{"hash":"%s"},"block":{"header":{"chain_id":"%s","height":"%d"}}}}`,
+		hash, chainID, height))
+}
+
+func TestResolveStateSyncConfig(t *testing.T) {
+	fetcher := NewMockFetcher()
+	fetcher.AddResponse("https://rpc1.example.com/block", blockJSON("mono-sprint-1", 10500, "AAAA"))
+	fetcher.AddResponse("https://rpc2.example.com/block", blockJSON("mono-sprint-1", 10400, "BBBB"))
+	fetcher.AddResponse("https://rpc1.example.com/block?height=8400", blockJSON("mono-sprint-1", 8400, "abcdef"))
+	fetcher.AddResponse("https://rpc2.example.com/block?height=8400", blockJSON("mono-sprint-1", 8400, "ABCDEF"))
+
+	cfg, err := ResolveStateSyncConfig([]string{"https://rpc1.example.com/", "https://rpc2.example.com"}, "mono-sprint-1", fetcher)
+	if err != nil {
+		t.Fatalf("ResolveStateSyncConfig() error = %v", err)
+	}
+
+	if cfg.TrustHeight != 8400 {
+		t.Errorf("TrustHeight = %d, want 8400", cfg.TrustHeight)
+	}
+	if cfg.TrustHash != "ABCDEF" {
+		t.Errorf("TrustHash = %s, want ABCDEF", cfg.TrustHash)
+	}
+	if len(cfg.RPCServers) != 2 {
+		t.Errorf("RPCServers = %v, want 2 entries", cfg.RPCServers)
+	}
+	if !cfg.Enable {
+		t.Error("Enable = false, want true")
+	}
+}
+
+func TestResolveStateSyncConfig_HashMismatch(t *testing.T) {
+	fetcher := NewMockFetcher()
+	fetcher.AddResponse("https://rpc1.example.com/block", blockJSON("mono-sprint-1", 5000, "AAAA"))
+	fetcher.AddResponse("https://rpc2.example.com/block", blockJSON("mono-sprint-1", 5000, "AAAA"))
+	fetcher.AddResponse("https://rpc1.example.com/block?height=3000", blockJSON("mono-sprint-1", 3000, "1111"))
+	fetcher.AddResponse("https://rpc2.example.com/block?height=3000", blockJSON("mono-sprint-1", 3000, "2222"))
+
+	_, err := ResolveStateSyncConfig([]string{"https://rpc1.example.com", "https://rpc2.example.com"}, "mono-sprint-1", fetcher)
+	if err == nil || !strings.Contains(err.Error(), "trust hash mismatch") {
+		t.Errorf("expected trust hash mismatch error, got %v", err)
+	}
+}
+
+func TestResolveStateSyncConfig_NotEnoughEndpoints(t *testing.T) {
+	fetcher := NewMockFetcher()
+	fetcher.AddResponse("https://rpc1.example.com/block", blockJSON("mono-sprint-1", 5000, "AAAA"))
+	fetcher.AddError("https://rpc2.example.com/block", fmt.Errorf("connection refused"))
+
+	tests := []struct {
+		name      string
+		endpoints []string
+	}{
+		{"none", nil},
+		{"single", []string{"https://rpc1.example.com"}},
+		{"duplicate", []string{"https://rpc1.example.com", "https://rpc1.example.com/"}},
+		{"unreachable", []string{"https://rpc1.example.com", "https://rpc2.example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ResolveStateSyncConfig(tt.endpoints, "mono-sprint-1", fetcher); err == nil {
+				t.Error("expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestResolveStateSyncConfig_WrongChain(t *testing.T) {
+	fetcher := NewMockFetcher()
+	fetcher.AddResponse("https://rpc1.example.com/block", blockJSON("mono-test-1", 5000, "AAAA"))
+	fetcher.AddResponse("https://rpc2.example.com/block", blockJSON("mono-test-1", 5000, "AAAA"))
+
+	_, err := ResolveStateSyncConfig([]string{"https://rpc1.example.com", "https://rpc2.example.com"}, "mono-sprint-1", fetcher)
+	if err == nil || !strings.Contains(err.Error(), "mono-test-1") {
+		t.Errorf("expected chain mismatch error, got %v", err)
+	}
+}
+
+func TestResolveStateSyncConfig_ChainTooShort(t *testing.T) {
+	fetcher := NewMockFetcher()
+	fetcher.AddResponse("https://rpc1.example.com/block", blockJSON("mono-sprint-1", 1500, "AAAA"))
+	fetcher.AddResponse("https://rpc2.example.com/block", blockJSON("mono-sprint-1", 1500, "AAAA"))
+
+	_, err := ResolveStateSyncConfig([]string{"https://rpc1.example.com", "https://rpc2.example.com"}, "mono-sprint-1", fetcher)
+	if err == nil || !strings.Contains(err.Error(), "too low") {
+		t.Errorf("expected chain too short error, got %v", err)
+	}
+}
+
+func TestApplyConfigPatch_StateSync(t *testing.T) {
+	configContent := `[p2p]
+seeds = ""
+persistent_peers = ""
+
+[statesync]
+enable = false
+rpc_servers = ""
+trust_height = 0
+trust_hash = ""
+trust_period = "168h0m0s"
+`
+
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	patch := &ConfigPatch{
+		StateSync: &StateSyncConfig{
+			Enable:      true,
+			RPCServers:  []string{"https://rpc1.example.com", "https://rpc2.example.com"},
+			TrustHeight: 8400,
+			TrustHash:   "ABCDEF",
+		},
+	}
+
+	if err := ApplyConfigPatch(configPath, patch, false); err != nil {
+		t.Fatalf("ApplyConfigPatch failed: %v", err)
+	}
+
+	enable, _ := GetConfigValue(configPath, "statesync", "enable")
+	if enable != "true" {
+		t.Errorf("enable = %s, want true", enable)
+	}
+	servers, _ := GetConfigValue(configPath, "statesync", "rpc_servers")
+	if servers != "https://rpc1.example.com,https://rpc2.example.com" {
+		t.Errorf("rpc_servers = %s", servers)
+	}
+	height, _ := GetConfigValue(configPath, "statesync", "trust_height")
+	if height != "8400" {
+		t.Errorf("trust_height = %s, want 8400", height)
+	}
+	hash, _ := GetConfigValue(configPath, "statesync", "trust_hash")
+	if hash != "ABCDEF" {
+		t.Errorf("trust_hash = %s, want ABCDEF", hash)
+	}
+	period, _ := GetConfigValue(configPath, "statesync", "trust_period")
+	if period != "168h0m0s" {
+		t.Errorf("trust_period changed to %s", period)
+	}
+}
+
+func TestApplyConfigPatch_StateSyncMissingSection(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("[p2p]\nseeds = \"\"\npersistent_peers = \"\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write test config: %v", err)
+	}
+
+	patch := &ConfigPatch{StateSync: &StateSyncConfig{Enable: true, RPCServers: []string{"a", "b"}, TrustHeight: 10, TrustHash: "AB"}}
+	if err := ApplyConfigPatch(configPath, patch, false); err != nil {
+		t.Fatalf("ApplyConfigPatch failed: %v", err)
+	}
+
+	// The missing [statesync] section is added
+	if err := ValidateConfigTOML(configPath); err != nil {
+		t.Fatalf("patched config is invalid: %v", err)
+	}
+	if enable, _ := GetConfigValue(configPath, "statesync", "enable"); enable != "true" {
+		t.Errorf("enable = %s, want true", enable)
+	}
+	if servers, _ := GetConfigValue(configPath, "statesync", "rpc_servers"); servers != "a,b" {
+		t.Errorf("rpc_servers = %s, want a,b", servers)
+	}
+}
+
+func TestParseSyncStrategy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    SyncStrategy
+		wantErr bool
+	}{
+		{"", SyncStrategyDefault, false},
+		{"default", SyncStrategyDefault, false},
+		{"Bootstrap", SyncStrategyBootstrap, false},
+		{"statesync", SyncStrategyStateSync, false},
+		{"state-sync", SyncStrategyStateSync, false},
+		{"snapshot", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseSyncStrategy(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSyncStrategy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseSyncStrategy(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,587 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	toml "github.com/pelletier/go-toml/v2"
+)
+
+// TOMLDocument is a TOML file that can be read and edited without losing
+// comments, ordering or formatting. Only the values that are set change;
+// everything else is written back byte for byte.
+//
+// Keys are addressed by table and key, e.g. ("p2p", "seeds"); the table
+// "" is the root table and nested tables use dots ("json-rpc.ws"). Keys
+// written as dotted keys or inside inline tables are found too. Keys in
+// arrays of tables ([[table]]) cannot be addressed.
+//
+// Duplicate keys, which the TOML spec forbids but hand-edited files
+// contain, are tolerated: Get returns the last definition, as line-based
+// readers do, and Set replaces the first and removes the others.
+type TOMLDocument struct {
+	stmts   []*tomlStmt
+	newline string
+}
+
+type tomlStmtKind int
+
+const (
+	tomlTrivia     tomlStmtKind = iota // blank line or comment
+	tomlTable                          // [table]
+	tomlArrayTable                     // [[table]]
+	tomlKeyValue                       // key = value
+)
+
+// tomlStmt is one statement of a document. raw is its source text up to
+// and including the final newline; a key/value may span several lines
+// (multi-line arrays and strings).
+type tomlStmt struct {
+	kind tomlStmtKind
+	raw  string
+
+	// table is the table a key/value belongs to, or a header's own table.
+	table []string
+	// key is a key/value's key relative to table.
+	key []string
+	// inArray marks key/values below an array-of-tables header.
+	inArray bool
+	// value is the decoded value and valueStart:valueEnd its text in raw.
+	value                any
+	valueStart, valueEnd int
+}
+
+func (s *tomlStmt) path() []string {
+	return append(append([]string{}, s.table...), s.key...)
+}
+
+// ParseTOMLDocument parses data into a TOMLDocument.
+func ParseTOMLDocument(data []byte) (*TOMLDocument, error) {
+	src := string(data)
+	doc := &TOMLDocument{newline: "\n"}
+	if strings.Contains(src, "\r\n") {
+		doc.newline = "\r\n"
+	}
+
+	var table []string
+	inArray := false
+	for pos, line := 0, 1; pos < len(src); {
+		stmt, end, err := parseTOMLStmt(src, pos)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TOML at line %d: %w", line, err)
+		}
+		stmt.raw = src[pos:end]
+		switch stmt.kind {
+		case tomlTable, tomlArrayTable:
+			table, inArray = stmt.table, stmt.kind == tomlArrayTable
+		case tomlKeyValue:
+			stmt.table, stmt.inArray = table, inArray
+			if stmt.value, err = decodeTOMLValue(stmt.raw[stmt.valueStart:stmt.valueEnd]); err != nil {
+				return nil, fmt.Errorf("invalid TOML at line %d: %w", line, err)
+			}
+		}
+		doc.stmts = append(doc.stmts, stmt)
+		line += strings.Count(stmt.raw, "\n")
+		pos = end
+	}
+	return doc, nil
+}
+
+// Bytes returns the document's TOML text.
+func (d *TOMLDocument) Bytes() []byte {
+	var b strings.Builder
+	for _, s := range d.stmts {
+		b.WriteString(s.raw)
+	}
+	return []byte(b.String())
+}
+
+// Get returns the decoded value of key in table. Strings decode to string,
+// integers to int64, floats to float64, arrays to []any and inline tables
+// to map[string]any.
+func (d *TOMLDocument) Get(table, key string) (any, bool) {
+	want := tomlPath(table, key)
+	var value any
+	found := false
+	for _, s := range d.stmts {
+		if s.kind != tomlKeyValue || s.inArray {
+			continue
+		}
+		path := s.path()
+		switch {
+		case pathEqual(path, want):
+			value, found = s.value, true
+		case pathHasPrefix(want, path):
+			// The key is inside an inline table
+			v := s.value
+			for _, k := range want[len(path):] {
+				m, ok := v.(map[string]any)
+				if !ok {
+					v = nil
+					break
+				}
+				v = m[k]
+			}
+			if v != nil {
+				value, found = v, true
+			}
+		}
+	}
+	return value, found
+}
+
+// GetString returns key in table formatted as text: strings unquoted,
+// other values as fmt's %v prints them.
+func (d *TOMLDocument) GetString(table, key string) (string, bool) {
+	v, ok := d.Get(table, key)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%v", v), true
+}
+
+// Set sets key in table to value, which must be a string, bool, integer,
+// float or a slice of those. A missing key is added to its table, and a
+// missing table is appended to the document.
+func (d *TOMLDocument) Set(table, key string, value any) error {
+	text, err := formatTOMLValue(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	decoded, err := decodeTOMLValue(text)
+	if err != nil {
+		return fmt.Errorf("%s: %w", key, err)
+	}
+	want := tomlPath(table, key)
+
+	var kept []*tomlStmt
+	replaced := false
+	for _, s := range d.stmts {
+		if s.kind == tomlArrayTable && pathEqual(s.table, want[:len(want)-1]) {
+			return fmt.Errorf("cannot set %s: [[%s]] is an array of tables", key, table)
+		}
+		if s.kind != tomlKeyValue || s.inArray {
+			kept = append(kept, s)
+			continue
+		}
+		path := s.path()
+		if pathHasPrefix(want, path) && !pathEqual(want, path) {
+			return fmt.Errorf("cannot set %s: %s is an inline value", strings.Join(want, "."), strings.Join(path, "."))
+		}
+		if !pathEqual(path, want) {
+			kept = append(kept, s)
+			continue
+		}
+		if replaced {
+			continue // drop duplicates
+		}
+		s.raw = s.raw[:s.valueStart] + text + s.raw[s.valueEnd:]
+		s.valueEnd = s.valueStart + len(text)
+		s.value = decoded
+		replaced = true
+		kept = append(kept, s)
+	}
+	d.stmts = kept
+	if !replaced {
+		d.insert(want[:len(want)-1], want[len(want)-1], text, decoded)
+	}
+	return nil
+}
+
+// insert adds a key/value that does not exist yet.
+func (d *TOMLDocument) insert(table []string, key, text string, decoded any) {
+	// The table is defined by a [table] header (the root table by the
+	// start of the document): add the key after the table's last key.
+	at, indent, headerFound := -1, "", len(table) == 0
+	current := []string{}
+	for i, s := range d.stmts {
+		switch s.kind {
+		case tomlTable, tomlArrayTable:
+			current = nil
+			if s.kind == tomlTable && pathEqual(s.table, table) {
+				current = table
+				at, indent, headerFound = i, "", true
+			}
+		case tomlKeyValue:
+			if current != nil && pathEqual(s.table, table) && !s.inArray {
+				at, indent = i, leadingSpace(s.raw)
+			}
+		}
+	}
+	if headerFound {
+		if at == -1 && len(table) == 0 {
+			// No root keys: add the key before the first table
+			d.insertAt(0, &tomlStmt{kind: tomlTrivia, raw: d.newline})
+		}
+		d.insertAt(at+1, d.keyValue(table, []string{key}, indent, text, decoded))
+		return
+	}
+
+	// The table is only defined by dotted keys in a parent table
+	// (statesync.enable = true): add another dotted key next to them.
+	for i := len(d.stmts) - 1; i >= 0; i-- {
+		s := d.stmts[i]
+		if s.kind == tomlKeyValue && !s.inArray && len(s.key) > 1 && pathHasPrefix(s.path(), table) && len(s.table) < len(table) {
+			rel := append(append([]string{}, table[len(s.table):]...), key)
+			d.insertAt(i+1, d.keyValue(s.table, rel, leadingSpace(s.raw), text, decoded))
+			return
+		}
+	}
+
+	// Otherwise append the table
+	if n := len(d.stmts); n > 0 && !strings.HasSuffix(d.stmts[n-1].raw, "\n") {
+		d.stmts[n-1].raw += d.newline
+	}
+	header := &tomlStmt{kind: tomlTable, table: table, raw: d.newline + "[" + formatTOMLKey(table) + "]" + d.newline}
+	d.stmts = append(d.stmts, header, d.keyValue(table, []string{key}, "", text, decoded))
+}
+
+func (d *TOMLDocument) keyValue(table, key []string, indent, text string, decoded any) *tomlStmt {
+	prefix := indent + formatTOMLKey(key) + " = "
+	return &tomlStmt{
+		kind:       tomlKeyValue,
+		raw:        prefix + text + d.newline,
+		table:      table,
+		key:        key,
+		value:      decoded,
+		valueStart: len(prefix),
+		valueEnd:   len(prefix) + len(text),
+	}
+}
+
+func (d *TOMLDocument) insertAt(i int, s *tomlStmt) {
+	if i > 0 && !strings.HasSuffix(d.stmts[i-1].raw, "\n") {
+		d.stmts[i-1].raw += d.newline
+	}
+	d.stmts = append(d.stmts[:i], append([]*tomlStmt{s}, d.stmts[i:]...)...)
+}
+
+// parseTOMLStmt parses the statement starting at the beginning of a line
+// and returns it with the offset just past it.
+func parseTOMLStmt(src string, pos int) (*tomlStmt, int, error) {
+	i := skipSpace(src, pos)
+	switch {
+	case i >= len(src) || src[i] == '\n' || src[i] == '\r' || src[i] == '#':
+		return &tomlStmt{kind: tomlTrivia}, lineEnd(src, i), nil
+
+	case src[i] == '[':
+		stmt := &tomlStmt{kind: tomlTable}
+		i++
+		if i < len(src) && src[i] == '[' {
+			stmt.kind = tomlArrayTable
+			i++
+		}
+		key, next, err := parseTOMLKey(src, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		closing := "]"
+		if stmt.kind == tomlArrayTable {
+			closing = "]]"
+		}
+		next = skipSpace(src, next)
+		if !strings.HasPrefix(src[next:], closing) {
+			return nil, 0, fmt.Errorf("expected %q after table name", closing)
+		}
+		stmt.table = key
+		end, err := endOfStmt(src, next+len(closing))
+		return stmt, end, err
+
+	default:
+		key, next, err := parseTOMLKey(src, i)
+		if err != nil {
+			return nil, 0, err
+		}
+		next = skipSpace(src, next)
+		if next >= len(src) || src[next] != '=' {
+			return nil, 0, fmt.Errorf("expected '=' after key %q", strings.Join(key, "."))
+		}
+		start := skipSpace(src, next+1)
+		valueEnd, err := scanTOMLValue(src, start)
+		if err != nil {
+			return nil, 0, err
+		}
+		for valueEnd > start && (src[valueEnd-1] == ' ' || src[valueEnd-1] == '\t' || src[valueEnd-1] == '\r') {
+			valueEnd--
+		}
+		if valueEnd == start {
+			return nil, 0, fmt.Errorf("missing value for key %q", strings.Join(key, "."))
+		}
+		end, err := endOfStmt(src, valueEnd)
+		return &tomlStmt{kind: tomlKeyValue, key: key, valueStart: start - pos, valueEnd: valueEnd - pos}, end, err
+	}
+}
+
+// parseTOMLKey parses a bare, quoted or dotted key.
+func parseTOMLKey(src string, i int) ([]string, int, error) {
+	var parts []string
+	for {
+		i = skipSpace(src, i)
+		if i >= len(src) {
+			return nil, 0, fmt.Errorf("unexpected end of file in key")
+		}
+		switch src[i] {
+		case '"', '\'':
+			end, err := scanTOMLString(src, i)
+			if err != nil {
+				return nil, 0, err
+			}
+			part, err := decodeTOMLValue(src[i:end])
+			if err != nil {
+				return nil, 0, err
+			}
+			parts = append(parts, part.(string))
+			i = end
+		default:
+			start := i
+			for i < len(src) && isBareKeyChar(src[i]) {
+				i++
+			}
+			if i == start {
+				return nil, 0, fmt.Errorf("unexpected character %q in key", src[i])
+			}
+			parts = append(parts, src[start:i])
+		}
+		i = skipSpace(src, i)
+		if i >= len(src) || src[i] != '.' {
+			return parts, i, nil
+		}
+		i++
+	}
+}
+
+// scanTOMLValue returns the offset where the value starting at i ends: the
+// end of its line, or of the line that closes its array, inline table or
+// multi-line string.
+func scanTOMLValue(src string, i int) (int, error) {
+	depth := 0
+	for i < len(src) {
+		switch c := src[i]; c {
+		case '"', '\'':
+			end, err := scanTOMLString(src, i)
+			if err != nil {
+				return 0, err
+			}
+			i = end
+			continue
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+			if depth < 0 {
+				return 0, fmt.Errorf("unexpected %q", c)
+			}
+		case '#':
+			if depth == 0 {
+				return i, nil
+			}
+			i = lineEnd(src, i) - 1 // comments are allowed inside arrays
+		case '\n':
+			if depth == 0 {
+				return i, nil
+			}
+		}
+		i++
+	}
+	if depth != 0 {
+		return 0, fmt.Errorf("unterminated array or inline table")
+	}
+	return i, nil
+}
+
+// scanTOMLString returns the offset just past the string starting at i.
+func scanTOMLString(src string, i int) (int, error) {
+	quote := src[i]
+	if strings.HasPrefix(src[i:], strings.Repeat(string(quote), 3)) {
+		delim := strings.Repeat(string(quote), 3)
+		for j := i + 3; j < len(src); j++ {
+			if quote == '"' && src[j] == '\\' {
+				j++
+				continue
+			}
+			if strings.HasPrefix(src[j:], delim) {
+				end := j + 3
+				// Up to two quotes may directly precede the delimiter
+				for k := 0; k < 2 && end < len(src) && src[end] == quote; k++ {
+					end++
+				}
+				return end, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated multi-line string")
+	}
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			if quote == '"' {
+				j++
+			}
+		case '\n':
+			return 0, fmt.Errorf("unterminated string")
+		case quote:
+			return j + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// endOfStmt checks that only whitespace and a comment follow offset i on
+// its line and returns the offset of the next line.
+func endOfStmt(src string, i int) (int, error) {
+	i = skipSpace(src, i)
+	if i < len(src) && src[i] != '\n' && src[i] != '\r' && src[i] != '#' {
+		return 0, fmt.Errorf("unexpected %q after value", src[i])
+	}
+	return lineEnd(src, i), nil
+}
+
+// lineEnd returns the offset just past the newline ending i's line.
+func lineEnd(src string, i int) int {
+	if j := strings.IndexByte(src[i:], '\n'); j >= 0 {
+		return i + j + 1
+	}
+	return len(src)
+}
+
+func skipSpace(src string, i int) int {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t') {
+		i++
+	}
+	return i
+}
+
+func leadingSpace(s string) string {
+	return s[:skipSpace(s, 0)]
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// decodeTOMLValue decodes the text of a single value.
+func decodeTOMLValue(text string) (any, error) {
+	var m map[string]any
+	if err := toml.Unmarshal([]byte("v = "+text), &m); err != nil {
+		return nil, err
+	}
+	return m["v"], nil
+}
+
+// formatTOMLValue encodes a Go value as TOML.
+func formatTOMLValue(value any) (string, error) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return formatTOMLString(rv.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return "", fmt.Errorf("%d does not fit a TOML integer", rv.Uint())
+		}
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", fmt.Errorf("unsupported float %v", f)
+		}
+		s := strconv.FormatFloat(f, 'f', -1, 64)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s, nil
+	case reflect.Slice, reflect.Array:
+		items := make([]string, rv.Len())
+		for i := range items {
+			item, err := formatTOMLValue(rv.Index(i).Interface())
+			if err != nil {
+				return "", err
+			}
+			items[i] = item
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	}
+	return "", fmt.Errorf("unsupported value type %T", value)
+}
+
+// formatTOMLString encodes s as a TOML basic string.
+func formatTOMLString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// formatTOMLKey encodes a dotted key, quoting parts that are not bare keys.
+func formatTOMLKey(parts []string) string {
+	out := make([]string, len(parts))
+	for i, p := range parts {
+		out[i] = p
+		if p == "" || strings.IndexFunc(p, func(r rune) bool { return r > 0x7f || !isBareKeyChar(byte(r)) }) >= 0 {
+			out[i] = formatTOMLString(p)
+		}
+	}
+	return strings.Join(out, ".")
+}
+
+// tomlPath splits a table name and key into a key path.
+func tomlPath(table, key string) []string {
+	if table == "" {
+		return []string{key}
+	}
+	return append(strings.Split(table, "."), key)
+}
+
+func pathEqual(a, b []string) bool {
+	return len(a) == len(b) && pathHasPrefix(a, b)
+}
+
+func pathHasPrefix(path, prefix []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// updateTOMLFile applies edit to the TOML file at path and writes it back.
+func updateTOMLFile(fsys FS, path string, edit func(*TOMLDocument) error) error {
+	data, err := fsys.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	doc, err := ParseTOMLDocument(data)
+	if err != nil {
+		return err
+	}
+	if err := edit(doc); err != nil {
+		return err
+	}
+	return fsys.WriteFile(path, doc.Bytes(), 0644)
+}
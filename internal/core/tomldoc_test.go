@@ -0,0 +1,153 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParseTOML(t *testing.T, src string) *TOMLDocument {
+	t.Helper()
+	doc, err := ParseTOMLDocument([]byte(src))
+	if err != nil {
+		t.Fatalf("ParseTOMLDocument() error = %v", err)
+	}
+	return doc
+}
+
+func TestTOMLDocument_RoundTrip(t *testing.T) {
+	src := `# Top comment
+chain-id = "mono-1" # trailing
+
+[p2p]
+  # indented comment
+  seeds = ""
+  persistent_peers = [
+    "a@host:26656", # first
+    "b@host:26656",
+  ]
+  limits = { inbound = 40, outbound = 10 }
+  description = """
+multi [ line
+# not a comment
+"""
+  'quoted.key' = 'literal # not a comment'
+
+[[mempool.rules]]
+name = "x"
+`
+	doc := mustParseTOML(t, src)
+	if got := string(doc.Bytes()); got != src {
+		t.Errorf("Bytes() changed the document:\n%s", got)
+	}
+
+	tests := []struct {
+		table, key string
+		want       any
+	}{
+		{"", "chain-id", "mono-1"},
+		{"p2p", "seeds", ""},
+		{"p2p", "persistent_peers", []any{"a@host:26656", "b@host:26656"}},
+		{"p2p", "limits", map[string]any{"inbound": int64(40), "outbound": int64(10)}},
+		{"p2p.limits", "outbound", int64(10)},
+		{"p2p", "description", "multi [ line\n# not a comment\n"},
+		{"p2p", "quoted.key", "literal # not a comment"},
+	}
+	for _, tt := range tests {
+		got, ok := doc.Get(tt.table, tt.key)
+		if !ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Get(%q, %q) = %#v, %v; want %#v", tt.table, tt.key, got, ok, tt.want)
+		}
+	}
+	if _, ok := doc.Get("mempool.rules", "name"); ok {
+		t.Error("keys in arrays of tables should not be addressable")
+	}
+}
+
+func TestTOMLDocument_SetReplacesMultiLineValue(t *testing.T) {
+	doc := mustParseTOML(t, "[p2p]\npersistent_peers = [\n  \"a\",\n  \"b\",\n] # peers\npex = true\n")
+	if err := doc.Set("p2p", "persistent_peers", []string{"c"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "[p2p]\npersistent_peers = [\"c\"] # peers\npex = true\n"
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestTOMLDocument_SetAddsMissingKeys(t *testing.T) {
+	doc := mustParseTOML(t, "# config\n\n[p2p]\n  seeds = \"\"\n\n[rpc]\nladdr = \"tcp://127.0.0.1:26657\"")
+	doc.Set("p2p", "pex", false)
+	doc.Set("rpc", "cors_allowed_origins", []string{})
+	doc.Set("statesync", "trust_hash", "AB\"C")
+	doc.Set("", "chain-id", "mono-1")
+
+	want := "chain-id = \"mono-1\"\n\n# config\n\n[p2p]\n  seeds = \"\"\n  pex = false\n\n[rpc]\nladdr = \"tcp://127.0.0.1:26657\"\ncors_allowed_origins = []\n\n[statesync]\ntrust_hash = \"AB\\\"C\"\n"
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Bytes() =\n%s\nwant\n%s", got, want)
+	}
+	if v, _ := doc.GetString("statesync", "trust_hash"); v != `AB"C` {
+		t.Errorf("trust_hash = %q", v)
+	}
+}
+
+func TestTOMLDocument_DottedKeys(t *testing.T) {
+	doc := mustParseTOML(t, "statesync.enable = false\n\n[p2p]\nseeds = \"\"\n")
+	if v, _ := doc.GetString("statesync", "enable"); v != "false" {
+		t.Errorf("statesync.enable = %q", v)
+	}
+	doc.Set("statesync", "enable", true)
+	doc.Set("statesync", "trust_height", 100)
+	want := "statesync.enable = true\nstatesync.trust_height = 100\n\n[p2p]\nseeds = \"\"\n"
+	if got := string(doc.Bytes()); got != want {
+		t.Errorf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestTOMLDocument_DuplicateKeys(t *testing.T) {
+	doc := mustParseTOML(t, "[p2p]\nseeds = \"old\"\npex = true\nseeds = \"newer\"\n")
+	if v, _ := doc.GetString("p2p", "seeds"); v != "newer" {
+		t.Errorf("Get() of a duplicate key = %q, want the last definition", v)
+	}
+	doc.Set("p2p", "seeds", "x")
+	if got, want := string(doc.Bytes()), "[p2p]\nseeds = \"x\"\npex = true\n"; got != want {
+		t.Errorf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestTOMLDocument_InlineTableKeys(t *testing.T) {
+	doc := mustParseTOML(t, "[p2p]\nlimits = { inbound = 40 }\n")
+	if err := doc.Set("p2p.limits", "inbound", 50); err == nil || !strings.Contains(err.Error(), "inline") {
+		t.Errorf("Set() inside an inline table error = %v", err)
+	}
+	if err := doc.Set("p2p", "limits", 1); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(doc.Bytes()); got != "[p2p]\nlimits = 1\n" {
+		t.Errorf("Bytes() = %q", got)
+	}
+}
+
+func TestTOMLDocument_PreservesCRLF(t *testing.T) {
+	doc := mustParseTOML(t, "[p2p]\r\nseeds = \"\" # s\r\n")
+	doc.Set("p2p", "seeds", "a")
+	doc.Set("p2p", "pex", true)
+	if got, want := string(doc.Bytes()), "[p2p]\r\nseeds = \"a\" # s\r\npex = true\r\n"; got != want {
+		t.Errorf("Bytes() = %q, want %q", got, want)
+	}
+}
+
+func TestParseTOMLDocument_Invalid(t *testing.T) {
+	for _, src := range []string{
+		"[p2p\nseeds = \"\"\n",
+		"seeds = \n",
+		"seeds = \"unterminated\n",
+		"peers = [\n\"a\",\n",
+		"seeds = \"\" extra\n",
+		"= 1\n",
+	} {
+		if _, err := ParseTOMLDocument([]byte(src)); err == nil {
+			t.Errorf("ParseTOMLDocument(%q) succeeded, want an error", src)
+		}
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"math/big"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/monolythium/mono-commander/internal/walletgen"
 )
 
 // Denom constants
@@ -64,7 +67,10 @@ const (
 	TxActionWithdrawRewards TxAction = "withdraw-rewards"
 	TxActionWithdrawComm    TxAction = "withdraw-commission"
 	TxActionVote            TxAction = "vote"
+	TxActionDeposit         TxAction = "deposit"
+	TxActionSubmitProposal  TxAction = "submit-proposal"
 	TxActionSend            TxAction = "send"
+	TxActionUnjail          TxAction = "unjail"
 )
 
 // VoteOption represents a governance vote option
@@ -94,6 +100,9 @@ type TxCommand struct {
 	// MultiMsgCommands contains individual commands for multi-msg tx
 	// When RequiresMultiMsg is true, these are the separate messages
 	MultiMsgCommands []*TxCommand
+	// Msgs contains the messages for native signing. Only set when From is
+	// an address, since a keyring key name cannot be resolved without monod.
+	Msgs []TxMsg
 }
 
 // String returns the full command as a string
@@ -108,15 +117,19 @@ func (c *TxCommand) String() string {
 type TxBuilderOptions struct {
 	Network        NetworkName
 	Home           string
-	From           string // key name or address
-	Fees           string // amount in alyth (e.g., "10000alyth")
-	GasPrices      string // price in alyth (e.g., "0.025alyth")
-	Gas            string // gas limit or "auto"
-	Node           string // RPC node URL
-	ChainID        string // chain-id override (uses network default if empty)
-	KeyringBackend string // keyring backend (os, file, test, memory)
-	Broadcast      bool   // whether to broadcast (default: false for dry-run)
-	DryRun         bool   // dry-run mode (default: true)
+	From           string          // key name or address
+	Fees           string          // amount in alyth (e.g., "10000alyth")
+	GasPrices      string          // price in alyth (e.g., "0.025alyth")
+	Gas            string          // gas limit or "auto"
+	Node           string          // RPC node URL
+	ChainID        string          // chain-id override (uses network default if empty)
+	KeyringBackend string          // keyring backend (os, file, test, memory)
+	Broadcast      bool            // whether to broadcast (default: false for dry-run)
+	DryRun         bool            // dry-run mode (default: true)
+	Signer         *TxSigner       // sign natively instead of with monod (optional)
+	REST           string          // Cosmos REST endpoint for native signing
+	Multisig       *MultisigPubKey // From is this multisig account (optional)
+	GasAdjustment  float64         // multiplier for --gas auto (monod default if zero)
 }
 
 // ValidateAddress validates a Monolythium account address (mono1...)
@@ -141,6 +154,32 @@ func ValidateValoperAddress(addr string) error {
 	return nil
 }
 
+// AccountToValoperAddress converts a mono1... account address to the
+// monovaloper1... operator address of the same key.
+func AccountToValoperAddress(addr string) (string, error) {
+	if err := ValidateAddress(addr); err != nil {
+		return "", err
+	}
+	_, data, err := walletgen.Bech32Decode(addr)
+	if err != nil {
+		return "", err
+	}
+	return walletgen.Bech32Encode(Bech32PrefixValAddr, data)
+}
+
+// ValoperToAccountAddress converts a monovaloper1... operator address to the
+// mono1... account address of the same key.
+func ValoperToAccountAddress(addr string) (string, error) {
+	if err := ValidateValoperAddress(addr); err != nil {
+		return "", err
+	}
+	_, data, err := walletgen.Bech32Decode(addr)
+	if err != nil {
+		return "", err
+	}
+	return walletgen.Bech32Encode(Bech32PrefixAccAddr, data)
+}
+
 // ValidateAmount validates an amount string in alyth format
 func ValidateAmount(amount string) error {
 	if amount == "" {
@@ -204,14 +243,19 @@ func ParseAmount(amount string) (*big.Int, error) {
 	return value, nil
 }
 
-// FormatLYTH formats an alyth amount as LYTH for display
+// FormatLYTH formats an alyth amount as LYTH for display. Fractional
+// amounts, such as fees, keep their significant decimals.
 func FormatLYTH(alyth *big.Int) string {
 	if alyth == nil {
 		return "0 LYTH"
 	}
 	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(Decimals), nil)
-	lyth := new(big.Int).Div(alyth, divisor)
-	return fmt.Sprintf("%s LYTH", lyth.String())
+	lyth, frac := new(big.Int).QuoRem(alyth, divisor, new(big.Int))
+	if frac.Sign() == 0 {
+		return fmt.Sprintf("%s LYTH", lyth.String())
+	}
+	decimals := strings.TrimRight(fmt.Sprintf("%0*s", Decimals, frac.String()), "0")
+	return fmt.Sprintf("%s.%s LYTH", lyth.String(), decimals)
 }
 
 // LYTHToAlyth converts LYTH to alyth
@@ -289,6 +333,9 @@ func buildCommonArgs(opts TxBuilderOptions, network Network) []string {
 	if opts.Gas != "" {
 		args = append(args, "--gas", opts.Gas)
 	}
+	if opts.Gas == "auto" && opts.GasAdjustment > 0 {
+		args = append(args, "--gas-adjustment", strconv.FormatFloat(opts.GasAdjustment, 'f', -1, 64))
+	}
 
 	// Node RPC
 	if opts.Node != "" {
@@ -300,8 +347,9 @@ func buildCommonArgs(opts TxBuilderOptions, network Network) []string {
 		args = append(args, "--keyring-backend", opts.KeyringBackend)
 	}
 
-	// Broadcast mode
-	if opts.Broadcast {
+	// Broadcast mode. A multisig cannot sign in one step, so its
+	// transactions are always generated for offline signing.
+	if opts.Broadcast && opts.Multisig == nil {
 		args = append(args, "--broadcast-mode", "sync")
 		args = append(args, "-y") // skip confirmation
 	} else {
@@ -441,9 +489,70 @@ func BuildCreateValidatorTx(opts TxBuilderOptions, params CreateValidatorParams)
 	// We provide individual commands that can be combined via unsigned tx JSON
 	cmd.Args = createValArgs // Primary command for display
 
+	// Native signing and export need the consensus key up front; monod
+	// reads it itself, so a missing key only matters without monod
+	if ValidateAddress(opts.From) == nil {
+		msgs, err := createValidatorMsgs(opts, params)
+		if err != nil && (opts.Signer != nil || opts.Multisig != nil) {
+			return nil, err
+		}
+		cmd.Msgs = msgs
+	}
 	return cmd, nil
 }
 
+// createValidatorMsgs returns the MsgCreateValidator and the burn that
+// must accompany it, for native signing.
+func createValidatorMsgs(opts TxBuilderOptions, params CreateValidatorParams) ([]TxMsg, error) {
+	pubKey, err := LoadConsensusPubKey(params.PubKeyPath, opts.Home)
+	if err != nil {
+		return nil, err
+	}
+	valoper, err := AccountToValoperAddress(opts.From)
+	if err != nil {
+		return nil, err
+	}
+	var rates CommissionRates
+	for _, r := range []struct {
+		dst  *string
+		flag string
+		v    string
+	}{
+		{&rates.Rate, "commission-rate", params.CommissionRate},
+		{&rates.MaxRate, "commission-max-rate", params.CommissionMaxRate},
+		{&rates.MaxChangeRate, "commission-max-change-rate", params.CommissionMaxChange},
+	} {
+		if *r.dst, err = legacyDec(r.v); err != nil {
+			return nil, fmt.Errorf("%s: %w", r.flag, err)
+		}
+	}
+	value, err := parseCoin(params.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return []TxMsg{
+		&MsgCreateValidator{
+			Description: ValidatorDescription{
+				Moniker:         params.Moniker,
+				Identity:        params.Identity,
+				Website:         params.Website,
+				SecurityContact: params.SecurityContact,
+				Details:         params.Details,
+			},
+			Commission:        rates,
+			MinSelfDelegation: strings.TrimSuffix(params.MinSelfDelegation, BaseDenom),
+			ValidatorAddress:  valoper,
+			Pubkey:            *pubKey,
+			Value:             value,
+		},
+		&MsgBurn{
+			FromAddress: opts.From,
+			Amount:      []Coin{{Denom: BaseDenom, Amount: ValidatorBurnAlyth.String()}},
+		},
+	}, nil
+}
+
 // mustParseAmount is a helper that panics on invalid amount (for known-good values)
 func mustParseAmount(amount string) *big.Int {
 	v, err := ParseAmount(amount)
@@ -476,12 +585,21 @@ func BuildDelegateTx(opts TxBuilderOptions, params DelegateParams) (*TxCommand,
 	args := []string{"tx", "staking", "delegate", params.ValidatorAddr, params.Amount}
 	args = append(args, buildCommonArgs(opts, network)...)
 
-	return &TxCommand{
+	cmd := &TxCommand{
 		Action:      TxActionDelegate,
 		Binary:      "monod",
 		Args:        args,
 		Description: fmt.Sprintf("Delegate %s to %s", FormatLYTH(mustParseAmount(params.Amount)), params.ValidatorAddr),
-	}, nil
+	}
+	if ValidateAddress(opts.From) == nil {
+		amount, _ := parseCoin(params.Amount)
+		cmd.Msgs = []TxMsg{&MsgDelegate{
+			DelegatorAddress: opts.From,
+			ValidatorAddress: params.ValidatorAddr,
+			Amount:           amount,
+		}}
+	}
+	return cmd, nil
 }
 
 // UnbondParams contains parameters for unbonding
@@ -507,7 +625,7 @@ func BuildUnbondTx(opts TxBuilderOptions, params UnbondParams) (*TxCommand, erro
 	args := []string{"tx", "staking", "unbond", params.ValidatorAddr, params.Amount}
 	args = append(args, buildCommonArgs(opts, network)...)
 
-	return &TxCommand{
+	cmd := &TxCommand{
 		Action:      TxActionUnbond,
 		Binary:      "monod",
 		Args:        args,
@@ -515,7 +633,16 @@ func BuildUnbondTx(opts TxBuilderOptions, params UnbondParams) (*TxCommand, erro
 		WarningMessages: []string{
 			"Unbonded tokens will be available after the 3-day unbonding period.",
 		},
-	}, nil
+	}
+	if ValidateAddress(opts.From) == nil {
+		amount, _ := parseCoin(params.Amount)
+		cmd.Msgs = []TxMsg{&MsgUndelegate{
+			DelegatorAddress: opts.From,
+			ValidatorAddress: params.ValidatorAddr,
+			Amount:           amount,
+		}}
+	}
+	return cmd, nil
 }
 
 // RedelegateParams contains parameters for redelegation
@@ -545,7 +672,7 @@ func BuildRedelegateTx(opts TxBuilderOptions, params RedelegateParams) (*TxComma
 	args := []string{"tx", "staking", "redelegate", params.SrcValidatorAddr, params.DstValidatorAddr, params.Amount}
 	args = append(args, buildCommonArgs(opts, network)...)
 
-	return &TxCommand{
+	cmd := &TxCommand{
 		Action:      TxActionRedelegate,
 		Binary:      "monod",
 		Args:        args,
@@ -553,7 +680,17 @@ func BuildRedelegateTx(opts TxBuilderOptions, params RedelegateParams) (*TxComma
 		WarningMessages: []string{
 			"Redelegation is instant but you cannot redelegate the same tokens again for 3 days.",
 		},
-	}, nil
+	}
+	if ValidateAddress(opts.From) == nil {
+		amount, _ := parseCoin(params.Amount)
+		cmd.Msgs = []TxMsg{&MsgBeginRedelegate{
+			DelegatorAddress:    opts.From,
+			ValidatorSrcAddress: params.SrcValidatorAddr,
+			ValidatorDstAddress: params.DstValidatorAddr,
+			Amount:              amount,
+		}}
+	}
+	return cmd, nil
 }
 
 // WithdrawRewardsParams contains parameters for withdrawing rewards
@@ -603,12 +740,31 @@ func BuildWithdrawRewardsTx(opts TxBuilderOptions, params WithdrawRewardsParams)
 
 	args = append(args, buildCommonArgs(opts, network)...)
 
-	return &TxCommand{
+	cmd := &TxCommand{
 		Action:      TxActionWithdrawRewards,
 		Binary:      "monod",
 		Args:        args,
 		Description: description,
-	}, nil
+	}
+
+	// Withdrawing from all validators needs the delegation list, which is
+	// only known on-chain; the native executor resolves it at broadcast time.
+	if ValidateAddress(opts.From) == nil {
+		validatorAddr := params.ValidatorAddr
+		if params.Commission && validatorAddr == "" {
+			validatorAddr, _ = AccountToValoperAddress(opts.From)
+		}
+		if validatorAddr != "" {
+			cmd.Msgs = []TxMsg{&MsgWithdrawDelegatorReward{
+				DelegatorAddress: opts.From,
+				ValidatorAddress: validatorAddr,
+			}}
+			if params.Commission {
+				cmd.Msgs = append(cmd.Msgs, &MsgWithdrawValidatorCommission{ValidatorAddress: validatorAddr})
+			}
+		}
+	}
+	return cmd, nil
 }
 
 // VoteParams contains parameters for governance voting
@@ -617,19 +773,26 @@ type VoteParams struct {
 	Option     VoteOption // vote option
 }
 
-// BuildVoteTx builds a governance vote transaction command
-func BuildVoteTx(opts TxBuilderOptions, params VoteParams) (*TxCommand, error) {
-	if params.ProposalID == "" {
-		return nil, fmt.Errorf("proposal ID is required")
+// ParseProposalID validates a governance proposal ID
+func ParseProposalID(id string) (uint64, error) {
+	if id == "" {
+		return 0, fmt.Errorf("proposal ID is required")
 	}
-
-	// Validate proposal ID is numeric
-	var propID int
-	if _, err := fmt.Sscanf(params.ProposalID, "%d", &propID); err != nil {
-		return nil, fmt.Errorf("invalid proposal ID: %s (must be numeric)", params.ProposalID)
+	propID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid proposal ID: %s (must be numeric)", id)
+	}
+	if propID == 0 {
+		return 0, fmt.Errorf("proposal ID must be positive")
 	}
-	if propID <= 0 {
-		return nil, fmt.Errorf("proposal ID must be positive")
+	return propID, nil
+}
+
+// BuildVoteTx builds a governance vote transaction command
+func BuildVoteTx(opts TxBuilderOptions, params VoteParams) (*TxCommand, error) {
+	propID, err := ParseProposalID(params.ProposalID)
+	if err != nil {
+		return nil, err
 	}
 
 	network, err := GetNetwork(opts.Network)
@@ -640,12 +803,118 @@ func BuildVoteTx(opts TxBuilderOptions, params VoteParams) (*TxCommand, error) {
 	args := []string{"tx", "gov", "vote", params.ProposalID, string(params.Option)}
 	args = append(args, buildCommonArgs(opts, network)...)
 
-	return &TxCommand{
+	cmd := &TxCommand{
 		Action:      TxActionVote,
 		Binary:      "monod",
 		Args:        args,
 		Description: fmt.Sprintf("Vote %s on proposal #%s", params.Option, params.ProposalID),
-	}, nil
+	}
+	if ValidateAddress(opts.From) == nil {
+		option := voteOptionName(params.Option)
+		if option == "" {
+			return nil, fmt.Errorf("invalid vote option: %s (valid: yes, no, abstain, no_with_veto)", params.Option)
+		}
+		cmd.Msgs = []TxMsg{&MsgVote{
+			ProposalID: propID,
+			Voter:      opts.From,
+			Option:     option,
+		}}
+	}
+	return cmd, nil
+}
+
+// DepositParams contains parameters for a governance deposit
+type DepositParams struct {
+	ProposalID string // proposal ID (numeric)
+	Amount     string // in alyth
+}
+
+// BuildDepositTx builds a governance deposit transaction command
+func BuildDepositTx(opts TxBuilderOptions, params DepositParams) (*TxCommand, error) {
+	propID, err := ParseProposalID(params.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateAmount(params.Amount); err != nil {
+		return nil, err
+	}
+
+	network, err := GetNetwork(opts.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"tx", "gov", "deposit", params.ProposalID, params.Amount}
+	args = append(args, buildCommonArgs(opts, network)...)
+
+	cmd := &TxCommand{
+		Action:      TxActionDeposit,
+		Binary:      "monod",
+		Args:        args,
+		Description: fmt.Sprintf("Deposit %s on proposal #%s", FormatLYTH(mustParseAmount(params.Amount)), params.ProposalID),
+		WarningMessages: []string{
+			"Deposits are burned if the proposal fails to reach the minimum deposit or is vetoed.",
+		},
+	}
+	if ValidateAddress(opts.From) == nil {
+		amount, _ := parseCoin(params.Amount)
+		cmd.Msgs = []TxMsg{&MsgDeposit{
+			ProposalID: propID,
+			Depositor:  opts.From,
+			Amount:     []Coin{amount},
+		}}
+	}
+	return cmd, nil
+}
+
+// SubmitProposalParams contains parameters for submitting a proposal
+type SubmitProposalParams struct {
+	ProposalPath string        // proposal file passed to monod
+	Proposal     *ProposalFile // contents of ProposalPath
+}
+
+// BuildSubmitProposalTx builds a governance submit-proposal transaction command
+func BuildSubmitProposalTx(opts TxBuilderOptions, params SubmitProposalParams) (*TxCommand, error) {
+	if params.Proposal == nil || params.ProposalPath == "" {
+		return nil, fmt.Errorf("proposal file is required")
+	}
+	p := params.Proposal
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if p.Deposit == "" {
+		return nil, fmt.Errorf("a deposit is required (set \"deposit\" in the proposal file or pass --deposit)")
+	}
+
+	network, err := GetNetwork(opts.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"tx", "gov", "submit-proposal", params.ProposalPath}
+	args = append(args, buildCommonArgs(opts, network)...)
+
+	cmd := &TxCommand{
+		Action:      TxActionSubmitProposal,
+		Binary:      "monod",
+		Args:        args,
+		Description: fmt.Sprintf("Submit proposal %q with a deposit of %s", p.Title, FormatLYTH(mustParseAmount(p.Deposit))),
+		WarningMessages: []string{
+			"The deposit is burned if the proposal does not reach the minimum deposit, fails quorum or is vetoed.",
+		},
+	}
+	if p.Expedited {
+		cmd.WarningMessages = append(cmd.WarningMessages,
+			"Expedited proposals have a shorter voting period, a higher pass threshold and a higher minimum deposit.")
+	}
+
+	// Proposal messages can only be signed natively if they are supported
+	if ValidateAddress(opts.From) == nil {
+		if msg, err := p.toMsg(opts.From); err == nil {
+			cmd.Msgs = []TxMsg{msg}
+		}
+	}
+	return cmd, nil
 }
 
 // BankSendParams contains parameters for bank send
@@ -671,10 +940,81 @@ func BuildBankSendTx(opts TxBuilderOptions, params BankSendParams) (*TxCommand,
 	args := []string{"tx", "bank", "send", opts.From, params.ToAddress, params.Amount}
 	args = append(args, buildCommonArgs(opts, network)...)
 
-	return &TxCommand{
+	cmd := &TxCommand{
 		Action:      TxActionSend,
 		Binary:      "monod",
 		Args:        args,
 		Description: fmt.Sprintf("Send %s to %s", FormatLYTH(mustParseAmount(params.Amount)), params.ToAddress),
-	}, nil
+	}
+	if ValidateAddress(opts.From) == nil {
+		amount, _ := parseCoin(params.Amount)
+		cmd.Msgs = []TxMsg{&MsgSend{
+			FromAddress: opts.From,
+			ToAddress:   params.ToAddress,
+			Amount:      []Coin{amount},
+		}}
+	}
+	return cmd, nil
+}
+
+// UnjailParams contains parameters for unjailing a validator
+type UnjailParams struct {
+	ValidatorAddr string // monovaloper1... (default: the operator address of From)
+}
+
+// ResolveUnjailValidator returns the operator address to unjail. An unjail
+// must be signed by the operator, so when From is an address it has to be
+// the operator's account.
+func ResolveUnjailValidator(from string, params UnjailParams) (string, error) {
+	if params.ValidatorAddr != "" {
+		if err := ValidateValoperAddress(params.ValidatorAddr); err != nil {
+			return "", err
+		}
+	}
+	if ValidateAddress(from) != nil {
+		if params.ValidatorAddr == "" {
+			return "", fmt.Errorf("validator operator address is required when --from is a key name")
+		}
+		return params.ValidatorAddr, nil
+	}
+
+	valoper, err := AccountToValoperAddress(from)
+	if err != nil {
+		return "", err
+	}
+	if params.ValidatorAddr != "" && params.ValidatorAddr != valoper {
+		return "", fmt.Errorf("unjail must be signed by the validator operator: %s is the operator of %s, not %s",
+			from, valoper, params.ValidatorAddr)
+	}
+	return valoper, nil
+}
+
+// BuildUnjailTx builds a slashing unjail transaction command
+func BuildUnjailTx(opts TxBuilderOptions, params UnjailParams) (*TxCommand, error) {
+	valoper, err := ResolveUnjailValidator(opts.From, params)
+	if err != nil {
+		return nil, err
+	}
+
+	network, err := GetNetwork(opts.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"tx", "slashing", "unjail"}
+	args = append(args, buildCommonArgs(opts, network)...)
+
+	cmd := &TxCommand{
+		Action:      TxActionUnjail,
+		Binary:      "monod",
+		Args:        args,
+		Description: fmt.Sprintf("Unjail validator %s", valoper),
+		WarningMessages: []string{
+			"Make sure the node is synced and signing blocks before unjailing; a validator that keeps missing blocks is jailed again",
+		},
+	}
+	if ValidateAddress(opts.From) == nil {
+		cmd.Msgs = []TxMsg{&MsgUnjail{ValidatorAddr: valoper}}
+	}
+	return cmd, nil
 }
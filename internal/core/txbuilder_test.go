@@ -156,6 +156,8 @@ func TestFormatLYTH(t *testing.T) {
 		{"zero", big.NewInt(0), "0 LYTH"},
 		{"1 LYTH", new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil), "1 LYTH"},
 		{"100 LYTH", new(big.Int).Mul(big.NewInt(100), new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)), "100 LYTH"},
+		{"fee", big.NewInt(5000000000000000), "0.005 LYTH"},
+		{"1.5 LYTH", big.NewInt(1500000000000000000), "1.5 LYTH"},
 	}
 
 	for _, tt := range tests {
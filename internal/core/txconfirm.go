@@ -0,0 +1,149 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// DefaultConfirmTimeout is how long to wait for a broadcast transaction to be
+// included in a block.
+const DefaultConfirmTimeout = 60 * time.Second
+
+// DefaultConfirmInterval is the delay between lookups while waiting for a tx.
+const DefaultConfirmInterval = 2 * time.Second
+
+// confirmPollInterval is the interval used by actions; tests shorten it.
+var confirmPollInterval = DefaultConfirmInterval
+
+// ErrTxNotConfirmed is returned when a transaction is not found in a block
+// before the timeout. It may still be included later.
+var ErrTxNotConfirmed = errors.New("transaction not included in a block")
+
+// TxQuerier looks up a transaction by hash, returning rpc.ErrTxNotFound while
+// it is not in a block. rpc.CosmosClient and rpc.CometClient implement it.
+type TxQuerier interface {
+	GetTx(hash string) (*rpc.TxResponse, error)
+}
+
+// TxConfirmation is the outcome of a transaction included in a block.
+type TxConfirmation struct {
+	TxHash    string
+	Height    int64
+	Code      uint32 // ABCI code; 0 means the transaction succeeded
+	Codespace string
+	RawLog    string
+	GasWanted int64
+	GasUsed   int64
+	Events    []rpc.TxEvent
+}
+
+// WaitForTx polls q every interval until the transaction is included in a
+// block or timeout passes. Lookup errors other than rpc.ErrTxNotFound are
+// retried, since the node may be briefly unavailable; the last one is
+// reported on timeout.
+func WaitForTx(ctx context.Context, q TxQuerier, hash string, timeout, interval time.Duration) (*TxConfirmation, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		resp, err := q.GetTx(hash)
+		if err == nil {
+			height, _ := strconv.ParseInt(resp.Height, 10, 64)
+			gasWanted, _ := strconv.ParseInt(resp.GasWanted, 10, 64)
+			gasUsed, _ := strconv.ParseInt(resp.GasUsed, 10, 64)
+			return &TxConfirmation{
+				TxHash:    hash,
+				Height:    height,
+				Code:      resp.Code,
+				Codespace: resp.Codespace,
+				RawLog:    resp.RawLog,
+				GasWanted: gasWanted,
+				GasUsed:   gasUsed,
+				Events:    resp.Events,
+			}, nil
+		}
+		if !errors.Is(err, rpc.ErrTxNotFound) {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return nil, fmt.Errorf("%w after %s (last error: %v)", ErrTxNotConfirmed, timeout, lastErr)
+			}
+			return nil, fmt.Errorf("%w after %s", ErrTxNotConfirmed, timeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// txQuerier returns the client used to confirm transactions: Cosmos REST when
+// signing natively or when an endpoint is configured, otherwise the CometBFT
+// RPC node that monod broadcast to.
+func (o ValidatorActionOptions) txQuerier() TxQuerier {
+	if o.Signer != nil || o.REST != "" {
+		return o.cosmosClient()
+	}
+	node := o.Node
+	if node == "" {
+		node = "http://localhost:26657"
+	}
+	// monod accepts tcp:// node addresses; the RPC also serves HTTP there
+	if strings.HasPrefix(node, "tcp://") {
+		node = "http://" + strings.TrimPrefix(node, "tcp://")
+	}
+	return rpc.NewCometClient(strings.TrimRight(node, "/"))
+}
+
+// confirmStep waits for an executed transaction to be included in a block and
+// records the outcome on the result. A transaction that fails on-chain fails
+// the action. A timeout is only a warning: the transaction may still be
+// included, and retrying it blindly could submit it twice.
+func confirmStep(ctx context.Context, opts ValidatorActionOptions, result *ValidatorActionResult) (*ValidatorActionResult, error) {
+	if opts.ConfirmTimeout <= 0 || !result.Success || result.TxHash == "" {
+		return result, nil
+	}
+
+	result.Steps = append(result.Steps, ActionStep{Name: "Confirm transaction", Status: "pending"})
+
+	conf, err := WaitForTx(ctx, opts.txQuerier(), result.TxHash, opts.ConfirmTimeout, confirmPollInterval)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"could not confirm %s: %v; check the hash before retrying", result.TxHash, err))
+		return result, nil
+	}
+
+	result.Confirmed = true
+	result.Height = conf.Height
+	result.GasWanted = conf.GasWanted
+	result.GasUsed = conf.GasUsed
+	result.Code = conf.Code
+	result.Codespace = conf.Codespace
+	result.Events = conf.Events
+
+	if conf.Code != 0 {
+		err := &TxError{TxHash: conf.TxHash, Codespace: conf.Codespace, Code: conf.Code, RawLog: conf.RawLog}
+		result.Success = false
+		result.Error = err
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("failed at height %d: %s", conf.Height, err)
+		return result, err
+	}
+
+	result.Steps[len(result.Steps)-1].Status = "success"
+	result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("included at height %d, gas used %d of %d",
+		conf.Height, conf.GasUsed, conf.GasWanted)
+	return result, nil
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// mockConfirmREST serves the account and broadcast endpoints and answers tx
+// lookups for ABCD with txResponse, or 404 while txResponse is empty.
+func mockConfirmREST(t *testing.T, txResponse string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/cosmos/auth/v1beta1/accounts/"):
+			w.Write([]byte(`{"account":{"address":"x","account_number":"7","sequence":"3"}}`))
+		case r.URL.Path == "/cosmos/tx/v1beta1/txs" && r.Method == http.MethodPost:
+			w.Write([]byte(`{"tx_response":{"height":"0","txhash":"ABCD","code":0}}`))
+		case r.URL.Path == "/cosmos/tx/v1beta1/txs/ABCD" && txResponse != "":
+			w.Write([]byte(`{"tx_response":` + txResponse + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":5,"message":"tx not found: ABCD"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func confirmOpts(t *testing.T, rest string) ValidatorActionOptions {
+	t.Helper()
+	confirmPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { confirmPollInterval = DefaultConfirmInterval })
+
+	signer := testSigner(t)
+	return ValidatorActionOptions{
+		Network:        NetworkLocalnet,
+		From:           signer.Address(),
+		Fees:           "5000alyth",
+		Execute:        true,
+		Signer:         signer,
+		REST:           rest,
+		ConfirmTimeout: 200 * time.Millisecond,
+	}
+}
+
+func TestWaitForTx_CometRPC(t *testing.T) {
+	lookups := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tx" || r.URL.Query().Get("hash") != "0xABCD" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		lookups++
+		if lookups < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"error":{"code":-32603,"message":"Internal error","data":"tx (ABCD) not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"hash":"abcd","height":"42","tx_result":{
+			"code":0,"gas_wanted":"200000","gas_used":"81234",
+			"events":[{"type":"transfer","attributes":[{"key":"amount","value":"5alyth","index":true}]}]}}}`))
+	}))
+	defer srv.Close()
+
+	conf, err := WaitForTx(context.Background(), rpc.NewCometClient(srv.URL), "ABCD", time.Second, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForTx() error = %v", err)
+	}
+	if conf.Height != 42 || conf.GasUsed != 81234 || conf.GasWanted != 200000 || conf.Code != 0 {
+		t.Errorf("confirmation = %+v", conf)
+	}
+	if len(conf.Events) != 1 || conf.Events[0].Type != "transfer" || conf.Events[0].Attributes[0].Value != "5alyth" {
+		t.Errorf("events = %+v", conf.Events)
+	}
+	if lookups != 3 {
+		t.Errorf("lookups = %d, want 3", lookups)
+	}
+}
+
+func TestDelegateAction_Confirmed(t *testing.T) {
+	srv := mockConfirmREST(t, `{"height":"17","txhash":"ABCD","code":0,"gas_wanted":"200000","gas_used":"95000",
+		"events":[{"type":"delegate","attributes":[{"key":"validator","value":"`+testValoper+`"}]}]}`)
+
+	result, err := DelegateAction(context.Background(), confirmOpts(t, srv.URL), DelegateParams{ValidatorAddr: testValoper, Amount: "1alyth"})
+	if err != nil {
+		t.Fatalf("DelegateAction() error = %v", err)
+	}
+	if !result.Success || !result.Confirmed || result.Height != 17 || result.GasUsed != 95000 {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Events) != 1 || result.Events[0].Type != "delegate" {
+		t.Errorf("events = %+v", result.Events)
+	}
+}
+
+func TestDelegateAction_FailsOnChain(t *testing.T) {
+	srv := mockConfirmREST(t, `{"height":"17","txhash":"ABCD","codespace":"staking","code":7,"raw_log":"out of gas","gas_wanted":"1000","gas_used":"1001"}`)
+
+	result, err := DelegateAction(context.Background(), confirmOpts(t, srv.URL), DelegateParams{ValidatorAddr: testValoper, Amount: "1alyth"})
+	var txErr *TxError
+	if !errors.As(err, &txErr) || txErr.Code != 7 {
+		t.Fatalf("expected on-chain TxError, got %v", err)
+	}
+	if result.Success || !result.Confirmed || result.Code != 7 || result.Codespace != "staking" || result.Height != 17 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestDelegateAction_ConfirmTimeoutIsWarning(t *testing.T) {
+	srv := mockConfirmREST(t, "")
+
+	result, err := DelegateAction(context.Background(), confirmOpts(t, srv.URL), DelegateParams{ValidatorAddr: testValoper, Amount: "1alyth"})
+	if err != nil {
+		t.Fatalf("DelegateAction() error = %v", err)
+	}
+	if !result.Success || result.Confirmed {
+		t.Errorf("result = %+v", result)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], ErrTxNotConfirmed.Error()) {
+		t.Errorf("Warnings = %v", result.Warnings)
+	}
+}
@@ -0,0 +1,1011 @@
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Protobuf wire types used by Cosmos SDK transactions.
+const (
+	protoWireVarint = 0
+	protoWireBytes  = 2
+)
+
+// Protobuf type URLs for messages that can be signed natively.
+const (
+	TypeURLMsgSend                        = "/cosmos.bank.v1beta1.MsgSend"
+	TypeURLMsgDelegate                    = "/cosmos.staking.v1beta1.MsgDelegate"
+	TypeURLMsgUndelegate                  = "/cosmos.staking.v1beta1.MsgUndelegate"
+	TypeURLMsgBeginRedelegate             = "/cosmos.staking.v1beta1.MsgBeginRedelegate"
+	TypeURLMsgWithdrawDelegatorReward     = "/cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward"
+	TypeURLMsgWithdrawValidatorCommission = "/cosmos.distribution.v1beta1.MsgWithdrawValidatorCommission"
+	TypeURLMsgVote                        = "/cosmos.gov.v1.MsgVote"
+	TypeURLMsgDeposit                     = "/cosmos.gov.v1.MsgDeposit"
+	TypeURLMsgSubmitProposal              = "/cosmos.gov.v1.MsgSubmitProposal"
+	TypeURLMsgUnjail                      = "/cosmos.slashing.v1beta1.MsgUnjail"
+	TypeURLMsgCreateValidator             = "/cosmos.staking.v1beta1.MsgCreateValidator"
+	TypeURLMsgBurn                        = "/cosmos.bank.v1beta1.MsgBurn"
+	TypeURLLegacyAminoPubKey              = "/cosmos.crypto.multisig.LegacyAminoPubKey"
+	TypeURLEd25519PubKey                  = "/cosmos.crypto.ed25519.PubKey"
+)
+
+// protoWriter is a minimal protobuf encoder. Fields must be written in
+// ascending field-number order to produce the canonical encoding the
+// Cosmos SDK expects for signing.
+type protoWriter struct {
+	buf []byte
+}
+
+func (w *protoWriter) key(field, wireType int) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(field)<<3|uint64(wireType))
+}
+
+// varint writes a varint field, omitting the proto3 default of zero.
+func (w *protoWriter) varint(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	w.key(field, protoWireVarint)
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+// bytes writes a length-delimited field, omitting empty values.
+func (w *protoWriter) bytes(field int, v []byte) {
+	if len(v) == 0 {
+		return
+	}
+	w.key(field, protoWireBytes)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// str writes a string field, omitting the empty string.
+func (w *protoWriter) str(field int, v string) {
+	w.bytes(field, []byte(v))
+}
+
+// message writes an embedded message. Unlike scalars it is written even when
+// empty, since presence of a message field is significant.
+func (w *protoWriter) message(field int, v []byte) {
+	w.key(field, protoWireBytes)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(v)))
+	w.buf = append(w.buf, v...)
+}
+
+// anyMsg writes a google.protobuf.Any embedded message.
+func (w *protoWriter) anyMsg(field int, typeURL string, value []byte) {
+	var a protoWriter
+	a.str(1, typeURL)
+	a.bytes(2, value)
+	w.message(field, a.buf)
+}
+
+// coin writes a cosmos.base.v1beta1.Coin embedded message.
+func (w *protoWriter) coin(field int, c Coin) {
+	var cw protoWriter
+	cw.str(1, c.Denom)
+	cw.str(2, c.Amount)
+	w.message(field, cw.buf)
+}
+
+// TxMsg is a transaction message that can be encoded without monod.
+type TxMsg interface {
+	// TypeURL returns the protobuf Any type URL of the message.
+	TypeURL() string
+	// MarshalProto returns the protobuf encoding of the message.
+	MarshalProto() []byte
+	// AminoJSON returns the message as it appears in a legacy amino JSON
+	// sign doc, used when signing for a multisig account.
+	AminoJSON() AminoMsg
+}
+
+// AminoMsg is a message in legacy amino JSON form. Value is a map so that
+// encoding/json emits its keys sorted, as the amino sign doc requires.
+type AminoMsg struct {
+	Type  string                 `json:"type"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// aminoCoin returns a coin in amino JSON form.
+func aminoCoin(c Coin) map[string]interface{} {
+	return map[string]interface{}{"amount": c.Amount, "denom": c.Denom}
+}
+
+// aminoCoins returns coins in amino JSON form. An empty list encodes as [].
+func aminoCoins(coins []Coin) []interface{} {
+	out := make([]interface{}, 0, len(coins))
+	for _, c := range coins {
+		out = append(out, aminoCoin(c))
+	}
+	return out
+}
+
+// MsgSend is cosmos.bank.v1beta1.MsgSend.
+type MsgSend struct {
+	FromAddress string `json:"from_address"`
+	ToAddress   string `json:"to_address"`
+	Amount      []Coin `json:"amount"`
+}
+
+func (m *MsgSend) TypeURL() string { return TypeURLMsgSend }
+
+func (m *MsgSend) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.FromAddress)
+	w.str(2, m.ToAddress)
+	for _, c := range m.Amount {
+		w.coin(3, c)
+	}
+	return w.buf
+}
+
+func (m *MsgSend) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgSend", Value: map[string]interface{}{
+		"from_address": m.FromAddress,
+		"to_address":   m.ToAddress,
+		"amount":       aminoCoins(m.Amount),
+	}}
+}
+
+// MsgDelegate is cosmos.staking.v1beta1.MsgDelegate.
+type MsgDelegate struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+	Amount           Coin   `json:"amount"`
+}
+
+func (m *MsgDelegate) TypeURL() string { return TypeURLMsgDelegate }
+
+func (m *MsgDelegate) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.DelegatorAddress)
+	w.str(2, m.ValidatorAddress)
+	w.coin(3, m.Amount)
+	return w.buf
+}
+
+func (m *MsgDelegate) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgDelegate", Value: map[string]interface{}{
+		"delegator_address": m.DelegatorAddress,
+		"validator_address": m.ValidatorAddress,
+		"amount":            aminoCoin(m.Amount),
+	}}
+}
+
+// MsgUndelegate is cosmos.staking.v1beta1.MsgUndelegate.
+type MsgUndelegate struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+	Amount           Coin   `json:"amount"`
+}
+
+func (m *MsgUndelegate) TypeURL() string { return TypeURLMsgUndelegate }
+
+func (m *MsgUndelegate) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.DelegatorAddress)
+	w.str(2, m.ValidatorAddress)
+	w.coin(3, m.Amount)
+	return w.buf
+}
+
+func (m *MsgUndelegate) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgUndelegate", Value: map[string]interface{}{
+		"delegator_address": m.DelegatorAddress,
+		"validator_address": m.ValidatorAddress,
+		"amount":            aminoCoin(m.Amount),
+	}}
+}
+
+// MsgBeginRedelegate is cosmos.staking.v1beta1.MsgBeginRedelegate.
+type MsgBeginRedelegate struct {
+	DelegatorAddress    string `json:"delegator_address"`
+	ValidatorSrcAddress string `json:"validator_src_address"`
+	ValidatorDstAddress string `json:"validator_dst_address"`
+	Amount              Coin   `json:"amount"`
+}
+
+func (m *MsgBeginRedelegate) TypeURL() string { return TypeURLMsgBeginRedelegate }
+
+func (m *MsgBeginRedelegate) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.DelegatorAddress)
+	w.str(2, m.ValidatorSrcAddress)
+	w.str(3, m.ValidatorDstAddress)
+	w.coin(4, m.Amount)
+	return w.buf
+}
+
+func (m *MsgBeginRedelegate) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgBeginRedelegate", Value: map[string]interface{}{
+		"delegator_address":     m.DelegatorAddress,
+		"validator_src_address": m.ValidatorSrcAddress,
+		"validator_dst_address": m.ValidatorDstAddress,
+		"amount":                aminoCoin(m.Amount),
+	}}
+}
+
+// MsgWithdrawDelegatorReward is cosmos.distribution.v1beta1.MsgWithdrawDelegatorReward.
+type MsgWithdrawDelegatorReward struct {
+	DelegatorAddress string `json:"delegator_address"`
+	ValidatorAddress string `json:"validator_address"`
+}
+
+func (m *MsgWithdrawDelegatorReward) TypeURL() string { return TypeURLMsgWithdrawDelegatorReward }
+
+func (m *MsgWithdrawDelegatorReward) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.DelegatorAddress)
+	w.str(2, m.ValidatorAddress)
+	return w.buf
+}
+
+func (m *MsgWithdrawDelegatorReward) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgWithdrawDelegationReward", Value: map[string]interface{}{
+		"delegator_address": m.DelegatorAddress,
+		"validator_address": m.ValidatorAddress,
+	}}
+}
+
+// MsgWithdrawValidatorCommission is cosmos.distribution.v1beta1.MsgWithdrawValidatorCommission.
+type MsgWithdrawValidatorCommission struct {
+	ValidatorAddress string `json:"validator_address"`
+}
+
+func (m *MsgWithdrawValidatorCommission) TypeURL() string {
+	return TypeURLMsgWithdrawValidatorCommission
+}
+
+func (m *MsgWithdrawValidatorCommission) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.ValidatorAddress)
+	return w.buf
+}
+
+func (m *MsgWithdrawValidatorCommission) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgWithdrawValCommission", Value: map[string]interface{}{
+		"validator_address": m.ValidatorAddress,
+	}}
+}
+
+// MsgUnjail is cosmos.slashing.v1beta1.MsgUnjail.
+type MsgUnjail struct {
+	ValidatorAddr string `json:"validator_addr"`
+}
+
+func (m *MsgUnjail) TypeURL() string { return TypeURLMsgUnjail }
+
+func (m *MsgUnjail) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.ValidatorAddr)
+	return w.buf
+}
+
+// AminoJSON uses the field's amino name, "address".
+func (m *MsgUnjail) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgUnjail", Value: map[string]interface{}{
+		"address": m.ValidatorAddr,
+	}}
+}
+
+// ValidatorDescription is cosmos.staking.v1beta1.Description.
+type ValidatorDescription struct {
+	Moniker         string `json:"moniker"`
+	Identity        string `json:"identity"`
+	Website         string `json:"website"`
+	SecurityContact string `json:"security_contact"`
+	Details         string `json:"details"`
+}
+
+// CommissionRates is cosmos.staking.v1beta1.CommissionRates. The rates are
+// decimals with 18 places (e.g. "0.100000000000000000"), as in the JSON
+// encoding; see legacyDec.
+type CommissionRates struct {
+	Rate          string `json:"rate"`
+	MaxRate       string `json:"max_rate"`
+	MaxChangeRate string `json:"max_change_rate"`
+}
+
+// MsgCreateValidator is cosmos.staking.v1beta1.MsgCreateValidator. Pubkey
+// is the validator's ed25519 consensus key; DelegatorAddress is deprecated
+// and left empty.
+type MsgCreateValidator struct {
+	Description       ValidatorDescription `json:"description"`
+	Commission        CommissionRates      `json:"commission"`
+	MinSelfDelegation string               `json:"min_self_delegation"`
+	DelegatorAddress  string               `json:"delegator_address"`
+	ValidatorAddress  string               `json:"validator_address"`
+	Pubkey            pubKeyJSON           `json:"pubkey"`
+	Value             Coin                 `json:"value"`
+}
+
+func (m *MsgCreateValidator) TypeURL() string { return TypeURLMsgCreateValidator }
+
+// MarshalProto encodes the rates as LegacyDec does: the decimal scaled by
+// 10^18, as an integer string.
+func (m *MsgCreateValidator) MarshalProto() []byte {
+	var d protoWriter
+	d.str(1, m.Description.Moniker)
+	d.str(2, m.Description.Identity)
+	d.str(3, m.Description.Website)
+	d.str(4, m.Description.SecurityContact)
+	d.str(5, m.Description.Details)
+
+	var c protoWriter
+	c.str(1, legacyDecProto(m.Commission.Rate))
+	c.str(2, legacyDecProto(m.Commission.MaxRate))
+	c.str(3, legacyDecProto(m.Commission.MaxChangeRate))
+
+	key, _ := base64.StdEncoding.DecodeString(m.Pubkey.Key)
+	var pk protoWriter
+	pk.bytes(1, key)
+
+	var w protoWriter
+	w.message(1, d.buf)
+	w.message(2, c.buf)
+	w.str(3, m.MinSelfDelegation)
+	w.str(4, m.DelegatorAddress)
+	w.str(5, m.ValidatorAddress)
+	w.anyMsg(6, m.Pubkey.Type, pk.buf)
+	w.coin(7, m.Value)
+	return w.buf
+}
+
+// AminoJSON uses the amino form of the consensus key and omits empty
+// description fields and the deprecated delegator address.
+func (m *MsgCreateValidator) AminoJSON() AminoMsg {
+	description := map[string]interface{}{}
+	for name, v := range map[string]string{
+		"moniker":          m.Description.Moniker,
+		"identity":         m.Description.Identity,
+		"website":          m.Description.Website,
+		"security_contact": m.Description.SecurityContact,
+		"details":          m.Description.Details,
+	} {
+		if v != "" {
+			description[name] = v
+		}
+	}
+	value := map[string]interface{}{
+		"description": description,
+		"commission": map[string]interface{}{
+			"rate":            m.Commission.Rate,
+			"max_rate":        m.Commission.MaxRate,
+			"max_change_rate": m.Commission.MaxChangeRate,
+		},
+		"min_self_delegation": m.MinSelfDelegation,
+		"validator_address":   m.ValidatorAddress,
+		"pubkey":              map[string]interface{}{"type": "tendermint/PubKeyEd25519", "value": m.Pubkey.Key},
+		"value":               aminoCoin(m.Value),
+	}
+	if m.DelegatorAddress != "" {
+		value["delegator_address"] = m.DelegatorAddress
+	}
+	return AminoMsg{Type: "cosmos-sdk/MsgCreateValidator", Value: value}
+}
+
+// MsgBurn is monod's cosmos.bank.v1beta1.MsgBurn (`monod tx bank burn`),
+// which destroys coins of FromAddress.
+type MsgBurn struct {
+	FromAddress string `json:"from_address"`
+	Amount      []Coin `json:"amount"`
+}
+
+func (m *MsgBurn) TypeURL() string { return TypeURLMsgBurn }
+
+func (m *MsgBurn) MarshalProto() []byte {
+	var w protoWriter
+	w.str(1, m.FromAddress)
+	for _, c := range m.Amount {
+		w.coin(2, c)
+	}
+	return w.buf
+}
+
+func (m *MsgBurn) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/MsgBurn", Value: map[string]interface{}{
+		"from_address": m.FromAddress,
+		"amount":       aminoCoins(m.Amount),
+	}}
+}
+
+// legacyDecPlaces is the precision of the SDK's LegacyDec.
+const legacyDecPlaces = 18
+
+// legacyDec converts a plain decimal such as "0.1" to the JSON form of a
+// LegacyDec, with exactly 18 places.
+func legacyDec(s string) (string, error) {
+	whole, frac, point := strings.Cut(s, ".")
+	if whole == "" || (point && frac == "") || len(frac) > legacyDecPlaces || strings.Trim(whole+frac, "0123456789") != "" {
+		return "", fmt.Errorf("invalid decimal %q: want digits with at most %d decimal places", s, legacyDecPlaces)
+	}
+	whole = strings.TrimLeft(whole, "0")
+	if whole == "" {
+		whole = "0"
+	}
+	return whole + "." + frac + strings.Repeat("0", legacyDecPlaces-len(frac)), nil
+}
+
+// legacyDecProto returns the protobuf form of a LegacyDec in JSON form: its
+// digits without the point or leading zeros.
+func legacyDecProto(s string) string {
+	digits := strings.TrimLeft(strings.Replace(s, ".", "", 1), "0")
+	if digits == "" {
+		return "0"
+	}
+	return digits
+}
+
+// voteOptionEnum maps gov v1 VoteOption enum names to their numeric values.
+var voteOptionEnum = map[string]uint64{
+	"VOTE_OPTION_YES":          1,
+	"VOTE_OPTION_ABSTAIN":      2,
+	"VOTE_OPTION_NO":           3,
+	"VOTE_OPTION_NO_WITH_VETO": 4,
+}
+
+// MsgVote is cosmos.gov.v1.MsgVote. Option holds the enum name
+// (e.g. "VOTE_OPTION_YES"), as in the JSON encoding.
+type MsgVote struct {
+	ProposalID uint64 `json:"proposal_id,string"`
+	Voter      string `json:"voter"`
+	Option     string `json:"option"`
+	Metadata   string `json:"metadata"`
+}
+
+// UnmarshalJSON rejects unknown vote options, which would otherwise be
+// signed as VOTE_OPTION_UNSPECIFIED.
+func (m *MsgVote) UnmarshalJSON(data []byte) error {
+	type plain MsgVote
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if _, ok := voteOptionEnum[v.Option]; !ok {
+		return fmt.Errorf("unknown vote option %q", v.Option)
+	}
+	*m = MsgVote(v)
+	return nil
+}
+
+func (m *MsgVote) TypeURL() string { return TypeURLMsgVote }
+
+// MarshalProto encodes a vote built by BuildVoteTx or decoded from JSON;
+// both reject unknown options.
+func (m *MsgVote) MarshalProto() []byte {
+	var w protoWriter
+	w.varint(1, m.ProposalID)
+	w.str(2, m.Voter)
+	w.varint(3, voteOptionEnum[m.Option])
+	w.str(4, m.Metadata)
+	return w.buf
+}
+
+// AminoJSON encodes the option as its enum number; empty metadata is omitted.
+func (m *MsgVote) AminoJSON() AminoMsg {
+	value := map[string]interface{}{
+		"proposal_id": strconv.FormatUint(m.ProposalID, 10),
+		"voter":       m.Voter,
+		"option":      voteOptionEnum[m.Option],
+	}
+	if m.Metadata != "" {
+		value["metadata"] = m.Metadata
+	}
+	return AminoMsg{Type: "cosmos-sdk/v1/MsgVote", Value: value}
+}
+
+// MsgDeposit is cosmos.gov.v1.MsgDeposit.
+type MsgDeposit struct {
+	ProposalID uint64 `json:"proposal_id,string"`
+	Depositor  string `json:"depositor"`
+	Amount     []Coin `json:"amount"`
+}
+
+func (m *MsgDeposit) TypeURL() string { return TypeURLMsgDeposit }
+
+func (m *MsgDeposit) MarshalProto() []byte {
+	var w protoWriter
+	w.varint(1, m.ProposalID)
+	w.str(2, m.Depositor)
+	for _, c := range m.Amount {
+		w.coin(3, c)
+	}
+	return w.buf
+}
+
+func (m *MsgDeposit) AminoJSON() AminoMsg {
+	return AminoMsg{Type: "cosmos-sdk/v1/MsgDeposit", Value: map[string]interface{}{
+		"proposal_id": strconv.FormatUint(m.ProposalID, 10),
+		"depositor":   m.Depositor,
+		"amount":      aminoCoins(m.Amount),
+	}}
+}
+
+// MsgSubmitProposal is cosmos.gov.v1.MsgSubmitProposal. The proposal's own
+// messages must themselves be natively encodable.
+type MsgSubmitProposal struct {
+	Messages       []TxMsg
+	InitialDeposit []Coin
+	Proposer       string
+	Metadata       string
+	Title          string
+	Summary        string
+	Expedited      bool
+}
+
+// msgSubmitProposalJSON is the JSON form of MsgSubmitProposal.
+type msgSubmitProposalJSON struct {
+	Messages       []json.RawMessage `json:"messages"`
+	InitialDeposit []Coin            `json:"initial_deposit"`
+	Proposer       string            `json:"proposer"`
+	Metadata       string            `json:"metadata"`
+	Title          string            `json:"title"`
+	Summary        string            `json:"summary"`
+	Expedited      bool              `json:"expedited"`
+}
+
+func (m *MsgSubmitProposal) TypeURL() string { return TypeURLMsgSubmitProposal }
+
+func (m *MsgSubmitProposal) MarshalProto() []byte {
+	var w protoWriter
+	for _, msg := range m.Messages {
+		w.anyMsg(1, msg.TypeURL(), msg.MarshalProto())
+	}
+	for _, c := range m.InitialDeposit {
+		w.coin(2, c)
+	}
+	w.str(3, m.Proposer)
+	w.str(4, m.Metadata)
+	w.str(5, m.Title)
+	w.str(6, m.Summary)
+	if m.Expedited {
+		w.varint(7, 1)
+	}
+	return w.buf
+}
+
+// AminoJSON omits empty metadata, messages and a false expedited flag.
+func (m *MsgSubmitProposal) AminoJSON() AminoMsg {
+	value := map[string]interface{}{
+		"initial_deposit": aminoCoins(m.InitialDeposit),
+		"proposer":        m.Proposer,
+		"title":           m.Title,
+		"summary":         m.Summary,
+	}
+	if len(m.Messages) > 0 {
+		msgs := make([]interface{}, 0, len(m.Messages))
+		for _, msg := range m.Messages {
+			msgs = append(msgs, msg.AminoJSON())
+		}
+		value["messages"] = msgs
+	}
+	if m.Metadata != "" {
+		value["metadata"] = m.Metadata
+	}
+	if m.Expedited {
+		value["expedited"] = true
+	}
+	return AminoMsg{Type: "cosmos-sdk/v1/MsgSubmitProposal", Value: value}
+}
+
+func (m *MsgSubmitProposal) MarshalJSON() ([]byte, error) {
+	out := msgSubmitProposalJSON{
+		Messages:       make([]json.RawMessage, 0, len(m.Messages)),
+		InitialDeposit: m.InitialDeposit,
+		Proposer:       m.Proposer,
+		Metadata:       m.Metadata,
+		Title:          m.Title,
+		Summary:        m.Summary,
+		Expedited:      m.Expedited,
+	}
+	if out.InitialDeposit == nil {
+		out.InitialDeposit = []Coin{}
+	}
+	for _, msg := range m.Messages {
+		raw, err := MarshalTxMsgJSON(msg)
+		if err != nil {
+			return nil, err
+		}
+		out.Messages = append(out.Messages, raw)
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON fails if a proposal message is not natively encodable.
+func (m *MsgSubmitProposal) UnmarshalJSON(data []byte) error {
+	var in msgSubmitProposalJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	msgs := make([]TxMsg, 0, len(in.Messages))
+	for _, raw := range in.Messages {
+		msg, err := UnmarshalTxMsgJSON(raw)
+		if err != nil {
+			return fmt.Errorf("proposal message: %w", err)
+		}
+		msgs = append(msgs, msg)
+	}
+	*m = MsgSubmitProposal{
+		Messages:       msgs,
+		InitialDeposit: in.InitialDeposit,
+		Proposer:       in.Proposer,
+		Metadata:       in.Metadata,
+		Title:          in.Title,
+		Summary:        in.Summary,
+		Expedited:      in.Expedited,
+	}
+	return nil
+}
+
+// voteOptionName returns the gov v1 enum name for a VoteOption.
+func voteOptionName(option VoteOption) string {
+	switch option {
+	case VoteYes:
+		return "VOTE_OPTION_YES"
+	case VoteNo:
+		return "VOTE_OPTION_NO"
+	case VoteAbstain:
+		return "VOTE_OPTION_ABSTAIN"
+	case VoteNoWithVeto:
+		return "VOTE_OPTION_NO_WITH_VETO"
+	default:
+		return ""
+	}
+}
+
+// newTxMsg returns an empty message for a supported type URL.
+func newTxMsg(typeURL string) (TxMsg, error) {
+	switch typeURL {
+	case TypeURLMsgSend:
+		return &MsgSend{}, nil
+	case TypeURLMsgDelegate:
+		return &MsgDelegate{}, nil
+	case TypeURLMsgUndelegate:
+		return &MsgUndelegate{}, nil
+	case TypeURLMsgBeginRedelegate:
+		return &MsgBeginRedelegate{}, nil
+	case TypeURLMsgWithdrawDelegatorReward:
+		return &MsgWithdrawDelegatorReward{}, nil
+	case TypeURLMsgWithdrawValidatorCommission:
+		return &MsgWithdrawValidatorCommission{}, nil
+	case TypeURLMsgVote:
+		return &MsgVote{}, nil
+	case TypeURLMsgDeposit:
+		return &MsgDeposit{}, nil
+	case TypeURLMsgSubmitProposal:
+		return &MsgSubmitProposal{}, nil
+	case TypeURLMsgUnjail:
+		return &MsgUnjail{}, nil
+	case TypeURLMsgCreateValidator:
+		return &MsgCreateValidator{}, nil
+	case TypeURLMsgBurn:
+		return &MsgBurn{}, nil
+	default:
+		return nil, fmt.Errorf("message type %s is not supported by native signing", typeURL)
+	}
+}
+
+// MarshalTxMsgJSON encodes a message in the Cosmos SDK JSON form with its "@type".
+func MarshalTxMsgJSON(msg TxMsg) (json.RawMessage, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	typeField, _ := json.Marshal(msg.TypeURL())
+
+	out := []byte(`{"@type":`)
+	out = append(out, typeField...)
+	if len(body) > 2 {
+		out = append(out, ',')
+	}
+	return append(out, body[1:]...), nil
+}
+
+// UnmarshalTxMsgJSON decodes a message from its Cosmos SDK JSON form.
+func UnmarshalTxMsgJSON(data json.RawMessage) (TxMsg, error) {
+	var header struct {
+		Type string `json:"@type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid message JSON: %w", err)
+	}
+
+	msg, err := newTxMsg(header.Type)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, msg); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", header.Type, err)
+	}
+	return msg, nil
+}
+
+// parseCoin converts an amount like "1000alyth" into a Coin.
+func parseCoin(amount string) (Coin, error) {
+	if err := ValidateAmount(amount); err != nil {
+		return Coin{}, err
+	}
+	return Coin{Denom: BaseDenom, Amount: strings.TrimSuffix(amount, BaseDenom)}, nil
+}
+
+// Sign modes supported for native signing.
+const (
+	SignModeDirect          = "SIGN_MODE_DIRECT"
+	SignModeLegacyAminoJSON = "SIGN_MODE_LEGACY_AMINO_JSON"
+)
+
+// signModeValues maps sign mode names to their cosmos.tx.signing.v1beta1.SignMode values.
+var signModeValues = map[string]uint64{
+	SignModeDirect:          1,
+	SignModeLegacyAminoJSON: 127,
+}
+
+// pubKeyJSON is the JSON form of a public key Any. Key is set for single
+// keys; Threshold and PublicKeys for a LegacyAminoPubKey multisig.
+type pubKeyJSON struct {
+	Type       string       `json:"@type"`
+	Key        string       `json:"key,omitempty"`
+	Threshold  uint32       `json:"threshold,omitempty"`
+	PublicKeys []pubKeyJSON `json:"public_keys,omitempty"`
+}
+
+// compactBitArrayJSON is the JSON form of cosmos.crypto.multisig.v1beta1.CompactBitArray.
+type compactBitArrayJSON struct {
+	ExtraBitsStored uint32 `json:"extra_bits_stored"`
+	Elems           string `json:"elems"`
+}
+
+// modeInfoJSON is the JSON form of cosmos.tx.v1beta1.ModeInfo.
+type modeInfoJSON struct {
+	Single *struct {
+		Mode string `json:"mode"`
+	} `json:"single,omitempty"`
+	Multi *struct {
+		Bitarray  compactBitArrayJSON `json:"bitarray"`
+		ModeInfos []modeInfoJSON      `json:"mode_infos"`
+	} `json:"multi,omitempty"`
+}
+
+// signerInfoJSON is the JSON form of cosmos.tx.v1beta1.SignerInfo.
+type signerInfoJSON struct {
+	PublicKey *pubKeyJSON  `json:"public_key"`
+	ModeInfo  modeInfoJSON `json:"mode_info"`
+	Sequence  string       `json:"sequence"`
+}
+
+// singleModeInfo returns a ModeInfo for a single signer.
+func singleModeInfo(mode string) modeInfoJSON {
+	var mi modeInfoJSON
+	mi.Single = &struct {
+		Mode string `json:"mode"`
+	}{Mode: mode}
+	return mi
+}
+
+// encodePubKey encodes a public key as the value of its Any.
+func encodePubKey(pk *pubKeyJSON) ([]byte, error) {
+	if pk.Type == TypeURLLegacyAminoPubKey {
+		var w protoWriter
+		w.varint(1, uint64(pk.Threshold))
+		for i := range pk.PublicKeys {
+			value, err := encodePubKey(&pk.PublicKeys[i])
+			if err != nil {
+				return nil, err
+			}
+			w.anyMsg(2, pk.PublicKeys[i].Type, value)
+		}
+		return w.buf, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(pk.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	var w protoWriter
+	w.bytes(1, key)
+	return w.buf, nil
+}
+
+// encodeModeInfo encodes a cosmos.tx.v1beta1.ModeInfo.
+func encodeModeInfo(mi modeInfoJSON) ([]byte, error) {
+	var w protoWriter
+	switch {
+	case mi.Single != nil:
+		mode, ok := signModeValues[mi.Single.Mode]
+		if !ok {
+			return nil, fmt.Errorf("sign mode %q is not supported by native signing", mi.Single.Mode)
+		}
+		var single protoWriter
+		single.varint(1, mode)
+		w.message(1, single.buf)
+	case mi.Multi != nil:
+		elems, err := base64.StdEncoding.DecodeString(mi.Multi.Bitarray.Elems)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bitarray: %w", err)
+		}
+		var bits, multi protoWriter
+		bits.varint(1, uint64(mi.Multi.Bitarray.ExtraBitsStored))
+		bits.bytes(2, elems)
+		multi.message(1, bits.buf)
+		for _, sub := range mi.Multi.ModeInfos {
+			subBytes, err := encodeModeInfo(sub)
+			if err != nil {
+				return nil, err
+			}
+			multi.message(2, subBytes)
+		}
+		w.message(2, multi.buf)
+	default:
+		return nil, fmt.Errorf("mode_info has neither single nor multi")
+	}
+	return w.buf, nil
+}
+
+// encodeTxBody encodes the body of a TxJSON as cosmos.tx.v1beta1.TxBody.
+func encodeTxBody(body TxBody) ([]byte, error) {
+	if len(body.ExtensionOptions) > 0 || len(body.NonCriticalExtensionOptions) > 0 {
+		return nil, fmt.Errorf("extension options are not supported by native signing")
+	}
+
+	var w protoWriter
+	for i, raw := range body.Messages {
+		msg, err := UnmarshalTxMsgJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		w.anyMsg(1, msg.TypeURL(), msg.MarshalProto())
+	}
+	w.str(2, body.Memo)
+
+	if body.TimeoutHeight != "" {
+		timeout, err := strconv.ParseUint(body.TimeoutHeight, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout_height %q", body.TimeoutHeight)
+		}
+		w.varint(3, timeout)
+	}
+	return w.buf, nil
+}
+
+// encodeAuthInfo encodes the auth info of a TxJSON as cosmos.tx.v1beta1.AuthInfo.
+func encodeAuthInfo(info AuthInfo) ([]byte, error) {
+	var w protoWriter
+	for i, raw := range info.SignerInfos {
+		var si signerInfoJSON
+		if err := json.Unmarshal(raw, &si); err != nil {
+			return nil, fmt.Errorf("signer info %d: %w", i, err)
+		}
+		// The public key may be omitted once the account has one on chain
+		var pubKey []byte
+		if si.PublicKey != nil {
+			var err error
+			if pubKey, err = encodePubKey(si.PublicKey); err != nil {
+				return nil, fmt.Errorf("signer info %d: %w", i, err)
+			}
+		}
+		mode, err := encodeModeInfo(si.ModeInfo)
+		if err != nil {
+			return nil, fmt.Errorf("signer info %d: %w", i, err)
+		}
+		sequence, err := strconv.ParseUint(si.Sequence, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("signer info %d: invalid sequence %q", i, si.Sequence)
+		}
+
+		var sw protoWriter
+		if si.PublicKey != nil {
+			sw.anyMsg(1, si.PublicKey.Type, pubKey)
+		}
+		sw.message(2, mode)
+		sw.varint(3, sequence)
+		w.message(1, sw.buf)
+	}
+
+	gasLimit, err := strconv.ParseUint(info.Fee.GasLimit, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas_limit %q", info.Fee.GasLimit)
+	}
+
+	var fee protoWriter
+	for _, c := range info.Fee.Amount {
+		fee.coin(1, c)
+	}
+	fee.varint(2, gasLimit)
+	fee.str(3, info.Fee.Payer)
+	fee.str(4, info.Fee.Granter)
+	w.message(2, fee.buf)
+
+	return w.buf, nil
+}
+
+// encodeSignDoc encodes a cosmos.tx.v1beta1.SignDoc.
+func encodeSignDoc(bodyBytes, authInfoBytes []byte, chainID string, accountNumber uint64) []byte {
+	var w protoWriter
+	w.bytes(1, bodyBytes)
+	w.bytes(2, authInfoBytes)
+	w.str(3, chainID)
+	w.varint(4, accountNumber)
+	return w.buf
+}
+
+// encodeAminoSignDoc returns the legacy amino JSON StdSignDoc for tx: compact
+// JSON with sorted keys, as signed in SIGN_MODE_LEGACY_AMINO_JSON.
+func encodeAminoSignDoc(tx *TxJSON, chainID string, accountNumber, sequence uint64) ([]byte, error) {
+	msgs := make([]AminoMsg, 0, len(tx.Body.Messages))
+	for i, raw := range tx.Body.Messages {
+		msg, err := UnmarshalTxMsgJSON(raw)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		msgs = append(msgs, msg.AminoJSON())
+	}
+
+	fee := map[string]interface{}{
+		"amount": aminoCoins(tx.AuthInfo.Fee.Amount),
+		"gas":    tx.AuthInfo.Fee.GasLimit,
+	}
+	if tx.AuthInfo.Fee.Payer != "" {
+		fee["payer"] = tx.AuthInfo.Fee.Payer
+	}
+	if tx.AuthInfo.Fee.Granter != "" {
+		fee["granter"] = tx.AuthInfo.Fee.Granter
+	}
+
+	doc := map[string]interface{}{
+		"account_number": strconv.FormatUint(accountNumber, 10),
+		"chain_id":       chainID,
+		"fee":            fee,
+		"memo":           tx.Body.Memo,
+		"msgs":           msgs,
+		"sequence":       strconv.FormatUint(sequence, 10),
+	}
+	if tx.Body.TimeoutHeight != "" && tx.Body.TimeoutHeight != "0" {
+		doc["timeout_height"] = tx.Body.TimeoutHeight
+	}
+
+	// Amino JSON does not HTML-escape, unlike encoding/json's default
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// EncodeTxRaw encodes a signed TxJSON as cosmos.tx.v1beta1.TxRaw bytes,
+// ready for broadcasting.
+func EncodeTxRaw(tx *TxJSON) ([]byte, error) {
+	if len(tx.Signatures) == 0 {
+		return nil, fmt.Errorf("transaction is not signed")
+	}
+	if len(tx.Signatures) != len(tx.AuthInfo.SignerInfos) {
+		return nil, fmt.Errorf("transaction has %d signatures but %d signer infos", len(tx.Signatures), len(tx.AuthInfo.SignerInfos))
+	}
+
+	bodyBytes, err := encodeTxBody(tx.Body)
+	if err != nil {
+		return nil, err
+	}
+	authInfoBytes, err := encodeAuthInfo(tx.AuthInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	var w protoWriter
+	w.bytes(1, bodyBytes)
+	w.bytes(2, authInfoBytes)
+	for i, sig := range tx.Signatures {
+		raw, err := base64.StdEncoding.DecodeString(sig)
+		if err != nil {
+			return nil, fmt.Errorf("signature %d: %w", i, err)
+		}
+		w.message(3, raw)
+	}
+	return w.buf, nil
+}
@@ -0,0 +1,247 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	oshelpers "github.com/monolythium/mono-commander/internal/os"
+	"github.com/monolythium/mono-commander/internal/rpc"
+	"github.com/monolythium/mono-commander/internal/walletgen"
+)
+
+// EthSecp256k1PubKeyTypeURL is the Any type URL of Monolythium account keys.
+const EthSecp256k1PubKeyTypeURL = "/cosmos.evm.crypto.v1.ethsecp256k1.PubKey"
+
+// DefaultGasPerMsg is the gas limit reserved per message when signing natively
+// with --gas auto.
+const DefaultGasPerMsg = 200000
+
+// DefaultCosmosREST is the Cosmos REST endpoint used when none is configured.
+const DefaultCosmosREST = "http://localhost:1317"
+
+// TxSigner signs transactions with an eth_secp256k1 account key.
+type TxSigner struct {
+	key     *ecdsa.PrivateKey
+	address string
+}
+
+// NewTxSigner creates a signer from raw private key bytes.
+func NewTxSigner(privKey []byte) (*TxSigner, error) {
+	kp, err := walletgen.FromPrivateKeyBytes(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	address, err := kp.Bech32Address()
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive address: %w", err)
+	}
+	return &TxSigner{key: kp.PrivateKey(), address: address}, nil
+}
+
+// LoadKeystoreSigner decrypts a walletgen keystore file and returns a signer for it.
+func LoadKeystoreSigner(path, password string) (*TxSigner, error) {
+	ks, err := walletgen.LoadKeystore(path)
+	if err != nil {
+		return nil, err
+	}
+	privKey, err := walletgen.DecryptKeystore(ks, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewTxSigner(privKey)
+}
+
+// Address returns the signer's mono1... address.
+func (s *TxSigner) Address() string {
+	return s.address
+}
+
+// PubKey returns the compressed secp256k1 public key.
+func (s *TxSigner) PubKey() []byte {
+	return crypto.CompressPubkey(&s.key.PublicKey)
+}
+
+// pubKeyJSON returns the public key in its JSON Any form.
+func (s *TxSigner) pubKeyJSON() *pubKeyJSON {
+	return &pubKeyJSON{
+		Type: EthSecp256k1PubKeyTypeURL,
+		Key:  base64.StdEncoding.EncodeToString(s.PubKey()),
+	}
+}
+
+// Sign signs the keccak256 hash of signBytes, as eth_secp256k1 keys do.
+func (s *TxSigner) Sign(signBytes []byte) ([]byte, error) {
+	return crypto.Sign(crypto.Keccak256(signBytes), s.key)
+}
+
+// TxError is a transaction rejected by the chain.
+type TxError struct {
+	TxHash    string
+	Codespace string
+	Code      uint32
+	RawLog    string
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("tx failed with code %d (%s): %s", e.Code, e.Codespace, e.RawLog)
+}
+
+// NewUnsignedTx builds an unsigned transaction from natively encodable messages.
+func NewUnsignedTx(msgs []TxMsg, memo string, fee TxFee) (*TxJSON, error) {
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("transaction has no messages")
+	}
+
+	tx := &TxJSON{
+		Body: TxBody{
+			Messages:                    make([]json.RawMessage, 0, len(msgs)),
+			Memo:                        memo,
+			TimeoutHeight:               "0",
+			ExtensionOptions:            []json.RawMessage{},
+			NonCriticalExtensionOptions: []json.RawMessage{},
+		},
+		AuthInfo: AuthInfo{
+			SignerInfos: []json.RawMessage{},
+			Fee:         fee,
+		},
+		Signatures: []string{},
+	}
+	for _, msg := range msgs {
+		raw, err := MarshalTxMsgJSON(msg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", msg.TypeURL(), err)
+		}
+		tx.Body.Messages = append(tx.Body.Messages, raw)
+	}
+	return tx, nil
+}
+
+// SignTxDirect signs tx in place with SIGN_MODE_DIRECT, replacing any
+// existing signer infos and signatures.
+func SignTxDirect(tx *TxJSON, signer *TxSigner, chainID string, accountNumber, sequence uint64) error {
+	si := signerInfoJSON{
+		PublicKey: signer.pubKeyJSON(),
+		ModeInfo:  singleModeInfo(SignModeDirect),
+		Sequence:  strconv.FormatUint(sequence, 10),
+	}
+
+	rawInfo, err := json.Marshal(si)
+	if err != nil {
+		return err
+	}
+	tx.AuthInfo.SignerInfos = []json.RawMessage{rawInfo}
+
+	bodyBytes, err := encodeTxBody(tx.Body)
+	if err != nil {
+		return err
+	}
+	authInfoBytes, err := encodeAuthInfo(tx.AuthInfo)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.Sign(encodeSignDoc(bodyBytes, authInfoBytes, chainID, accountNumber))
+	if err != nil {
+		return fmt.Errorf("failed to sign: %w", err)
+	}
+	tx.Signatures = []string{base64.StdEncoding.EncodeToString(sig)}
+	return nil
+}
+
+// BroadcastSignedTx encodes a signed transaction and broadcasts it over Cosmos
+// REST. A transaction rejected by CheckTx is returned as a *TxError.
+func BroadcastSignedTx(client *rpc.CosmosClient, tx *TxJSON) (*oshelpers.TxSummary, error) {
+	txBytes, err := EncodeTxRaw(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.BroadcastTx(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	height, _ := strconv.ParseInt(resp.Height, 10, 64)
+	summary := &oshelpers.TxSummary{
+		TxHash:  resp.TxHash,
+		Height:  height,
+		Code:    int(resp.Code),
+		Success: resp.Code == 0,
+		RawLog:  resp.RawLog,
+	}
+	if resp.Code != 0 {
+		return summary, &TxError{
+			TxHash:    resp.TxHash,
+			Codespace: resp.Codespace,
+			Code:      resp.Code,
+			RawLog:    resp.RawLog,
+		}
+	}
+	return summary, nil
+}
+
+// NativeFee computes the fee for a natively signed transaction.
+// gas is a gas limit or "auto"; with "auto" DefaultGasPerMsg is reserved per
+// message. fees takes precedence over gasPrices.
+func NativeFee(fees, gasPrices, gas string, msgCount int) (TxFee, error) {
+	fee := TxFee{Amount: []Coin{}}
+
+	gasLimit := uint64(DefaultGasPerMsg * msgCount)
+	if gas != "" && gas != "auto" {
+		limit, err := strconv.ParseUint(gas, 10, 64)
+		if err != nil {
+			return fee, fmt.Errorf("invalid gas limit: %s", gas)
+		}
+		gasLimit = limit
+	}
+	fee.GasLimit = strconv.FormatUint(gasLimit, 10)
+
+	switch {
+	case fees != "":
+		coin, err := parseCoin(fees)
+		if err != nil {
+			return fee, fmt.Errorf("fees: %w", err)
+		}
+		fee.Amount = []Coin{coin}
+	case gasPrices != "":
+		price, ok := new(big.Rat).SetString(strings.TrimSuffix(gasPrices, BaseDenom))
+		if !ok || !strings.HasSuffix(gasPrices, BaseDenom) || price.Sign() < 0 {
+			return fee, fmt.Errorf("invalid gas prices: %s (expected e.g. 0.025%s)", gasPrices, BaseDenom)
+		}
+		total := new(big.Rat).Mul(price, new(big.Rat).SetInt64(int64(gasLimit)))
+		// Round up so the fee never falls below the node's minimum gas price
+		amount := new(big.Int).Quo(total.Num(), total.Denom())
+		if new(big.Int).Rem(total.Num(), total.Denom()).Sign() != 0 {
+			amount.Add(amount, big.NewInt(1))
+		}
+		fee.Amount = []Coin{{Denom: BaseDenom, Amount: amount.String()}}
+	}
+
+	return fee, nil
+}
+
+// SignAndBroadcast builds, signs and broadcasts msgs without monod. The
+// account number and sequence are fetched from the Cosmos REST endpoint.
+func SignAndBroadcast(client *rpc.CosmosClient, signer *TxSigner, chainID string, msgs []TxMsg, fee TxFee) (*oshelpers.TxSummary, error) {
+	tx, err := NewUnsignedTx(msgs, "", fee)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := client.Account(signer.Address())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+
+	if err := SignTxDirect(tx, signer, chainID, account.AccountNumber, account.Sequence); err != nil {
+		return nil, err
+	}
+
+	return BroadcastSignedTx(client, tx)
+}
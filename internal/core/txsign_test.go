@@ -0,0 +1,401 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+const testValoper = "monovaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq5nfrmp"
+
+func testSigner(t *testing.T) *TxSigner {
+	t.Helper()
+	key, _ := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	signer, err := NewTxSigner(key)
+	if err != nil {
+		t.Fatalf("NewTxSigner() error = %v", err)
+	}
+	return signer
+}
+
+// mockCosmosREST serves the account and broadcast endpoints and records the
+// last broadcast tx_bytes.
+func mockCosmosREST(t *testing.T, code uint32, broadcast *[]byte) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/cosmos/auth/v1beta1/accounts/"):
+			w.Write([]byte(`{"account":{"@type":"/cosmos.auth.v1beta1.BaseAccount","address":"x","account_number":"7","sequence":"3"}}`))
+		case r.URL.Path == "/cosmos/tx/v1beta1/txs" && r.Method == http.MethodPost:
+			var req struct {
+				TxBytes string `json:"tx_bytes"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			*broadcast, _ = base64.StdEncoding.DecodeString(req.TxBytes)
+			if code != 0 {
+				w.Write([]byte(`{"tx_response":{"txhash":"ABCD","codespace":"sdk","code":5,"raw_log":"insufficient funds"}}`))
+				return
+			}
+			w.Write([]byte(`{"tx_response":{"height":"0","txhash":"ABCD","code":0}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMsgDelegate_MarshalProto(t *testing.T) {
+	msg := &MsgDelegate{
+		DelegatorAddress: "a",
+		ValidatorAddress: "b",
+		Amount:           Coin{Denom: "alyth", Amount: "1"},
+	}
+
+	want := []byte{0x0a, 0x01, 'a', 0x12, 0x01, 'b', 0x1a, 0x0a, 0x0a, 0x05, 'a', 'l', 'y', 't', 'h', 0x12, 0x01, '1'}
+	if got := msg.MarshalProto(); !bytes.Equal(got, want) {
+		t.Errorf("MarshalProto() = %x, want %x", got, want)
+	}
+}
+
+func TestMsgCreateValidator_MarshalProto(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	msg := &MsgCreateValidator{
+		Description:       ValidatorDescription{Moniker: "m"},
+		Commission:        CommissionRates{Rate: "0.100000000000000000", MaxRate: "0.200000000000000000", MaxChangeRate: "0.010000000000000000"},
+		MinSelfDelegation: "1",
+		ValidatorAddress:  "v",
+		Pubkey:            pubKeyJSON{Type: TypeURLEd25519PubKey, Key: key},
+		Value:             Coin{Denom: "alyth", Amount: "2"},
+	}
+
+	want := "0a03" + "0a016d" + // description
+		"123b" + "0a12" + hex.EncodeToString([]byte("100000000000000000")) + // commission, scaled by 10^18
+		"1212" + hex.EncodeToString([]byte("200000000000000000")) +
+		"1a11" + hex.EncodeToString([]byte("10000000000000000")) +
+		"1a0131" + // min_self_delegation
+		"2a0176" + // validator_address
+		"3243" + "0a1d" + hex.EncodeToString([]byte(TypeURLEd25519PubKey)) + "1222" + "0a20" + strings.Repeat("01", 32) + // pubkey
+		"3a0a" + "0a05" + hex.EncodeToString([]byte("alyth")) + "120132" // value
+	if got := hex.EncodeToString(msg.MarshalProto()); got != want {
+		t.Errorf("MarshalProto() = %s, want %s", got, want)
+	}
+
+	amino, _ := json.Marshal(msg.AminoJSON())
+	wantAmino := `{"type":"cosmos-sdk/MsgCreateValidator","value":{"commission":{"max_change_rate":"0.010000000000000000","max_rate":"0.200000000000000000","rate":"0.100000000000000000"},` +
+		`"description":{"moniker":"m"},"min_self_delegation":"1","pubkey":{"type":"tendermint/PubKeyEd25519","value":"` + key + `"},` +
+		`"validator_address":"v","value":{"amount":"2","denom":"alyth"}}}`
+	if string(amino) != wantAmino {
+		t.Errorf("AminoJSON() = %s, want %s", amino, wantAmino)
+	}
+}
+
+func TestMsgBurn_MarshalProto(t *testing.T) {
+	msg := &MsgBurn{FromAddress: "a", Amount: []Coin{{Denom: "alyth", Amount: "1"}}}
+
+	want := []byte{0x0a, 0x01, 'a', 0x12, 0x0a, 0x0a, 0x05, 'a', 'l', 'y', 't', 'h', 0x12, 0x01, '1'}
+	if got := msg.MarshalProto(); !bytes.Equal(got, want) {
+		t.Errorf("MarshalProto() = %x, want %x", got, want)
+	}
+}
+
+func TestLegacyDec(t *testing.T) {
+	for in, want := range map[string]string{
+		"0.1":   "0.100000000000000000",
+		"1":     "1.000000000000000000",
+		"00.05": "0.050000000000000000",
+	} {
+		if got, err := legacyDec(in); err != nil || got != want {
+			t.Errorf("legacyDec(%s) = %s, %v, want %s", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", ".5", "1.", "1e-1", "-0.1", "0.1234567890123456789"} {
+		if _, err := legacyDec(in); err == nil {
+			t.Errorf("legacyDec(%q) should fail", in)
+		}
+	}
+	if got := legacyDecProto("0.010000000000000000"); got != "10000000000000000" {
+		t.Errorf("legacyDecProto() = %s", got)
+	}
+	if got := legacyDecProto("0.000000000000000000"); got != "0" {
+		t.Errorf("legacyDecProto(zero) = %s", got)
+	}
+}
+
+func TestTxMsgJSON_RoundTrip(t *testing.T) {
+	msgs := []TxMsg{
+		&MsgSend{FromAddress: "mono1a", ToAddress: "mono1b", Amount: []Coin{{Denom: BaseDenom, Amount: "5"}}},
+		&MsgVote{ProposalID: 12, Voter: "mono1a", Option: "VOTE_OPTION_NO"},
+		&MsgWithdrawValidatorCommission{ValidatorAddress: testValoper},
+		&MsgBurn{FromAddress: "mono1a", Amount: []Coin{{Denom: BaseDenom, Amount: "5"}}},
+		&MsgCreateValidator{
+			Description:       ValidatorDescription{Moniker: "m", Website: "https://m"},
+			Commission:        CommissionRates{Rate: "0.100000000000000000", MaxRate: "0.200000000000000000", MaxChangeRate: "0.010000000000000000"},
+			MinSelfDelegation: "1",
+			ValidatorAddress:  testValoper,
+			Pubkey:            pubKeyJSON{Type: TypeURLEd25519PubKey, Key: base64.StdEncoding.EncodeToString(make([]byte, 32))},
+			Value:             Coin{Denom: BaseDenom, Amount: "2"},
+		},
+	}
+
+	for _, msg := range msgs {
+		raw, err := MarshalTxMsgJSON(msg)
+		if err != nil {
+			t.Fatalf("MarshalTxMsgJSON() error = %v", err)
+		}
+		if !strings.Contains(string(raw), `"@type":"`+msg.TypeURL()+`"`) {
+			t.Errorf("JSON missing @type: %s", raw)
+		}
+
+		decoded, err := UnmarshalTxMsgJSON(raw)
+		if err != nil {
+			t.Fatalf("UnmarshalTxMsgJSON() error = %v", err)
+		}
+		if !bytes.Equal(decoded.MarshalProto(), msg.MarshalProto()) {
+			t.Errorf("%s did not round-trip: %s", msg.TypeURL(), raw)
+		}
+	}
+
+	if _, err := UnmarshalTxMsgJSON(json.RawMessage(`{"@type":"/cosmos.staking.v1beta1.MsgEditValidator"}`)); err == nil {
+		t.Error("expected error for unsupported message type")
+	}
+	vote := json.RawMessage(`{"@type":"/cosmos.gov.v1.MsgVote","proposal_id":"12","voter":"mono1a","option":"VOTE_OPTION_MAYBE"}`)
+	if _, err := UnmarshalTxMsgJSON(vote); err == nil || !strings.Contains(err.Error(), "unknown vote option") {
+		t.Errorf("UnmarshalTxMsgJSON(unknown option) error = %v", err)
+	}
+	if _, err := encodeTxBody(TxBody{Messages: []json.RawMessage{vote}}); err == nil {
+		t.Error("encodeTxBody() should reject an unknown vote option")
+	}
+}
+
+func TestSignTxDirect(t *testing.T) {
+	signer := testSigner(t)
+	msg := &MsgDelegate{DelegatorAddress: signer.Address(), ValidatorAddress: testValoper, Amount: Coin{Denom: BaseDenom, Amount: "100"}}
+
+	tx, err := NewUnsignedTx([]TxMsg{msg}, "memo", TxFee{Amount: []Coin{{Denom: BaseDenom, Amount: "10"}}, GasLimit: "200000"})
+	if err != nil {
+		t.Fatalf("NewUnsignedTx() error = %v", err)
+	}
+	if err := SignTxDirect(tx, signer, "mono-local-1", 7, 3); err != nil {
+		t.Fatalf("SignTxDirect() error = %v", err)
+	}
+
+	if len(tx.Signatures) != 1 || len(tx.AuthInfo.SignerInfos) != 1 {
+		t.Fatalf("signatures = %d, signer infos = %d, want 1 each", len(tx.Signatures), len(tx.AuthInfo.SignerInfos))
+	}
+
+	// The signature must recover to the signer's key over the SignDoc
+	bodyBytes, _ := encodeTxBody(tx.Body)
+	authInfoBytes, _ := encodeAuthInfo(tx.AuthInfo)
+	hash := crypto.Keccak256(encodeSignDoc(bodyBytes, authInfoBytes, "mono-local-1", 7))
+	sig, _ := base64.StdEncoding.DecodeString(tx.Signatures[0])
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		t.Fatalf("SigToPub() error = %v", err)
+	}
+	if !bytes.Equal(crypto.CompressPubkey(pub), signer.PubKey()) {
+		t.Error("signature does not recover to signer public key")
+	}
+
+	// Signed JSON must survive a round trip and still encode to TxRaw
+	data, _ := json.Marshal(tx)
+	var decoded TxJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode signed tx: %v", err)
+	}
+	txRaw, err := EncodeTxRaw(&decoded)
+	if err != nil {
+		t.Fatalf("EncodeTxRaw() error = %v", err)
+	}
+	if !bytes.Contains(txRaw, bodyBytes) || !bytes.Contains(txRaw, sig) {
+		t.Error("TxRaw does not contain body bytes and signature")
+	}
+}
+
+func TestEncodeTxRaw_Unsigned(t *testing.T) {
+	tx, _ := NewUnsignedTx([]TxMsg{&MsgWithdrawValidatorCommission{ValidatorAddress: testValoper}}, "", TxFee{GasLimit: "1"})
+	if _, err := EncodeTxRaw(tx); err == nil {
+		t.Error("expected error for unsigned tx")
+	}
+}
+
+func TestNativeFee(t *testing.T) {
+	tests := []struct {
+		name      string
+		fees      string
+		gasPrices string
+		gas       string
+		msgs      int
+		wantGas   string
+		wantFee   string
+		wantErr   bool
+	}{
+		{"explicit fees", "5000alyth", "", "150000", 1, "150000", "5000", false},
+		{"auto gas", "", "", "auto", 2, "400000", "", false},
+		{"gas prices round up", "", "0.025alyth", "100001", 1, "100001", "2501", false},
+		{"bad gas", "", "", "lots", 1, "", "", true},
+		{"bad gas prices", "", "0.025ulyth", "1", 1, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee, err := NativeFee(tt.fees, tt.gasPrices, tt.gas, tt.msgs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NativeFee() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if fee.GasLimit != tt.wantGas {
+				t.Errorf("GasLimit = %s, want %s", fee.GasLimit, tt.wantGas)
+			}
+			got := ""
+			if len(fee.Amount) > 0 {
+				got = fee.Amount[0].Amount
+			}
+			if got != tt.wantFee {
+				t.Errorf("fee amount = %q, want %q", got, tt.wantFee)
+			}
+		})
+	}
+}
+
+func TestSignAndBroadcast_Rejected(t *testing.T) {
+	var broadcast []byte
+	srv := mockCosmosREST(t, 5, &broadcast)
+	signer := testSigner(t)
+
+	msgs := []TxMsg{&MsgSend{FromAddress: signer.Address(), ToAddress: signer.Address(), Amount: []Coin{{Denom: BaseDenom, Amount: "1"}}}}
+	summary, err := SignAndBroadcast(rpc.NewCosmosClient(srv.URL), signer, "mono-local-1", msgs, TxFee{GasLimit: "200000"})
+
+	var txErr *TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("expected *TxError, got %v", err)
+	}
+	if txErr.Code != 5 || txErr.Codespace != "sdk" || txErr.RawLog != "insufficient funds" {
+		t.Errorf("TxError = %+v", txErr)
+	}
+	if summary == nil || summary.TxHash != "ABCD" || summary.Success {
+		t.Errorf("summary = %+v", summary)
+	}
+	if len(broadcast) == 0 {
+		t.Error("no tx bytes were broadcast")
+	}
+}
+
+func TestDelegateAction_NativeSigner(t *testing.T) {
+	var broadcast []byte
+	srv := mockCosmosREST(t, 0, &broadcast)
+	signer := testSigner(t)
+
+	opts := ValidatorActionOptions{
+		Network: NetworkLocalnet,
+		From:    signer.Address(),
+		Gas:     "auto",
+		Execute: true,
+		Signer:  signer,
+		REST:    srv.URL,
+	}
+	params := DelegateParams{ValidatorAddr: testValoper, Amount: "1000alyth"}
+
+	result, err := DelegateAction(context.Background(), opts, params)
+	if err != nil {
+		t.Fatalf("DelegateAction() error = %v", err)
+	}
+	if !result.Executed || !result.Success || result.TxHash != "ABCD" {
+		t.Errorf("result = %+v", result)
+	}
+
+	want := (&MsgDelegate{DelegatorAddress: signer.Address(), ValidatorAddress: testValoper, Amount: Coin{Denom: BaseDenom, Amount: "1000"}}).MarshalProto()
+	if !bytes.Contains(broadcast, want) {
+		t.Error("broadcast tx does not contain the delegate message")
+	}
+}
+
+func TestCreateValidatorAction_NativeSigner(t *testing.T) {
+	var broadcast []byte
+	srv := mockCosmosREST(t, 0, &broadcast)
+	signer := testSigner(t)
+
+	// Without --pubkey the consensus key comes from the node home
+	home := t.TempDir()
+	os.MkdirAll(filepath.Join(home, "config"), 0755)
+	consKey := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{7}, 32))
+	os.WriteFile(filepath.Join(home, "config", "priv_validator_key.json"),
+		[]byte(`{"pub_key":{"type":"tendermint/PubKeyEd25519","value":"`+consKey+`"}}`), 0600)
+
+	opts := ValidatorActionOptions{
+		Network: NetworkLocalnet,
+		Home:    home,
+		From:    signer.Address(),
+		Gas:     "400000",
+		Fees:    "1000alyth",
+		Execute: true,
+		Signer:  signer,
+		REST:    srv.URL,
+	}
+	params := CreateValidatorParams{
+		Moniker:             "val",
+		CommissionRate:      "0.10",
+		CommissionMaxRate:   "0.20",
+		CommissionMaxChange: "0.01",
+		MinSelfDelegation:   LYTHToAlyth(100000),
+		Amount:              LYTHToAlyth(100000),
+	}
+
+	result, err := CreateValidatorAction(context.Background(), opts, params)
+	if err != nil {
+		t.Fatalf("CreateValidatorAction() error = %v", err)
+	}
+	if !result.Executed || !result.Success || result.TxHash != "ABCD" {
+		t.Errorf("result = %+v", result)
+	}
+	for _, msg := range result.Command.Msgs {
+		if !bytes.Contains(broadcast, msg.MarshalProto()) {
+			t.Errorf("broadcast tx does not contain %s", msg.TypeURL())
+		}
+	}
+	if len(result.Command.Msgs) != 2 || result.Command.Msgs[1].TypeURL() != TypeURLMsgBurn {
+		t.Errorf("messages = %+v, want create-validator and burn", result.Command.Msgs)
+	}
+
+	// A native signer needs the consensus key
+	opts.Home = t.TempDir()
+	if _, err := CreateValidatorAction(context.Background(), opts, params); err == nil || !strings.Contains(err.Error(), "consensus key") {
+		t.Errorf("CreateValidatorAction() without a consensus key error = %v", err)
+	}
+}
+
+func TestBuildWithdrawRewardsTx_NativeCommission(t *testing.T) {
+	signer := testSigner(t)
+	cmd, err := BuildWithdrawRewardsTx(TxBuilderOptions{Network: NetworkLocalnet, From: signer.Address()}, WithdrawRewardsParams{Commission: true})
+	if err != nil {
+		t.Fatalf("BuildWithdrawRewardsTx() error = %v", err)
+	}
+
+	if len(cmd.Msgs) != 2 {
+		t.Fatalf("Msgs = %d, want 2", len(cmd.Msgs))
+	}
+	commission, ok := cmd.Msgs[1].(*MsgWithdrawValidatorCommission)
+	if !ok || !strings.HasPrefix(commission.ValidatorAddress, Bech32PrefixValAddr+"1") {
+		t.Errorf("commission msg = %+v", cmd.Msgs[1])
+	}
+
+	// Key names cannot be resolved natively
+	cmd, _ = BuildWithdrawRewardsTx(TxBuilderOptions{Network: NetworkLocalnet, From: "mykey"}, WithdrawRewardsParams{Commission: true})
+	if len(cmd.Msgs) != 0 {
+		t.Errorf("Msgs = %d for key name, want 0", len(cmd.Msgs))
+	}
+}
@@ -3,10 +3,14 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	oshelpers "github.com/monolythium/mono-commander/internal/os"
+	"github.com/monolythium/mono-commander/internal/rpc"
 )
 
 // ValidatorActionOptions contains common options for validator actions
@@ -23,6 +27,19 @@ type ValidatorActionOptions struct {
 	DryRun         bool   // default true: only show command
 	Execute        bool   // if true and DryRun false, execute the command
 	Logger         *slog.Logger
+	// Signer signs and broadcasts natively over Cosmos REST instead of
+	// running monod. From must be the signer's address.
+	Signer *TxSigner
+	REST   string // Cosmos REST endpoint (default: localhost:1317)
+	// Multisig marks From as a multisig account. Its transactions cannot be
+	// executed directly; they are exported, signed by members and combined.
+	Multisig *MultisigPubKey
+	// Simulate estimates gas over Cosmos REST before executing or previewing.
+	Simulate      bool
+	GasAdjustment float64 // multiplier for simulated gas (default: 1.5)
+	// ConfirmTimeout is how long to wait for an executed tx to be included
+	// in a block. 0 returns as soon as the tx is broadcast.
+	ConfirmTimeout time.Duration
 }
 
 // ValidatorActionResult contains the result of a validator action
@@ -33,6 +50,13 @@ type ValidatorActionResult struct {
 	Success     bool
 	TxHash      string
 	Height      int64
+	Code        uint32 // ABCI code of a rejected or failed tx
+	Codespace   string // codespace of a rejected or failed tx
+	Confirmed   bool   // the tx was found in a block
+	GasWanted   int64  // gas limit of the confirmed tx
+	GasUsed     int64  // gas used by the confirmed tx
+	Events      []rpc.TxEvent
+	GasEstimate *GasEstimate
 	Error       error
 	Steps       []ActionStep
 	Warnings    []string
@@ -64,9 +88,26 @@ func (o ValidatorActionOptions) toTxBuilderOptions() TxBuilderOptions {
 		KeyringBackend: o.KeyringBackend,
 		Broadcast:      shouldBroadcast,
 		DryRun:         effectiveDryRun,
+		Signer:         o.Signer,
+		REST:           o.REST,
+		Multisig:       o.Multisig,
+		GasAdjustment:  o.GasAdjustment,
 	}
 }
 
+// errMultisigExecute is returned when executing a multisig transaction directly.
+var errMultisigExecute = errors.New("a multisig transaction needs signatures from several keys; " +
+	"use 'tx export-unsigned', 'tx sign --multisig' per member, 'tx multisign' and 'tx broadcast'")
+
+// cosmosClient returns a Cosmos REST client for native signing.
+func (o ValidatorActionOptions) cosmosClient() *rpc.CosmosClient {
+	rest := o.REST
+	if rest == "" {
+		rest = DefaultCosmosREST
+	}
+	return rpc.NewCosmosClient(strings.TrimRight(rest, "/"))
+}
+
 // CreateValidatorAction executes or previews a create-validator transaction
 func CreateValidatorAction(ctx context.Context, opts ValidatorActionOptions, params CreateValidatorParams) (*ValidatorActionResult, error) {
 	result := &ValidatorActionResult{
@@ -106,6 +147,12 @@ func CreateValidatorAction(ctx context.Context, opts ValidatorActionOptions, par
 	result.Warnings = cmd.WarningMessages
 	result.Steps[len(result.Steps)-1].Status = "success"
 
+	// Multi-message creation is easily under-gassed; simulate the whole tx
+	if opts.Simulate {
+		opts = simulateGasStep(ctx, opts, result)
+		txOpts.Gas = opts.Gas
+	}
+
 	// Step 3: Check if should execute
 	if opts.DryRun || !opts.Execute {
 		result.Steps = append(result.Steps, ActionStep{
@@ -121,6 +168,20 @@ func CreateValidatorAction(ctx context.Context, opts ValidatorActionOptions, par
 	// Step 4: Execute transaction
 	result.Steps = append(result.Steps, ActionStep{Name: "Execute transaction", Status: "pending"})
 
+	if opts.Multisig != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = errMultisigExecute.Error()
+		result.Error = errMultisigExecute
+		return result, errMultisigExecute
+	}
+
+	if opts.Signer != nil {
+		if _, err := executeNative(opts, result); err != nil {
+			return result, err
+		}
+		return confirmStep(ctx, opts, result)
+	}
+
 	// For multi-msg transactions, use MultiMsgExecutor
 	if cmd.RequiresMultiMsg && len(cmd.MultiMsgCommands) > 0 {
 		// Create multi-message executor
@@ -143,7 +204,7 @@ func CreateValidatorAction(ctx context.Context, opts ValidatorActionOptions, par
 		result.Steps[len(result.Steps)-1].Status = "success"
 		result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("txhash: %s", summary.TxHash)
 
-		return result, nil
+		return confirmStep(ctx, opts, result)
 	}
 
 	// Single message transaction - use regular execution
@@ -166,7 +227,7 @@ func CreateValidatorAction(ctx context.Context, opts ValidatorActionOptions, par
 	result.Steps[len(result.Steps)-1].Status = "success"
 	result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("txhash: %s", summary.TxHash)
 
-	return result, nil
+	return confirmStep(ctx, opts, result)
 }
 
 // DelegateAction executes or previews a delegate transaction
@@ -311,6 +372,23 @@ func WithdrawRewardsAction(ctx context.Context, opts ValidatorActionOptions, par
 		result.Error = err
 		return result, err
 	}
+
+	// Native withdraw-all needs one message per delegation
+	if opts.Signer != nil && opts.Execute && len(cmd.Msgs) == 0 {
+		validators, err := opts.cosmosClient().DelegatorValidators(opts.Signer.Address())
+		if err != nil {
+			result.Steps[len(result.Steps)-1].Status = "failed"
+			result.Steps[len(result.Steps)-1].Message = err.Error()
+			result.Error = err
+			return result, err
+		}
+		for _, val := range validators {
+			cmd.Msgs = append(cmd.Msgs, &MsgWithdrawDelegatorReward{
+				DelegatorAddress: opts.Signer.Address(),
+				ValidatorAddress: val,
+			})
+		}
+	}
 	result.Command = cmd
 	result.Description = cmd.Description
 	result.Steps[len(result.Steps)-1].Status = "success"
@@ -353,8 +431,147 @@ func VoteAction(ctx context.Context, opts ValidatorActionOptions, params VotePar
 	return executeOrSkip(ctx, opts, result)
 }
 
+// UnjailAction executes or previews an unjail transaction. The validator's
+// signing info is checked first, and the action refuses if the unjail would
+// be rejected. When executing, the check must succeed; in a dry-run an
+// unreachable REST endpoint is only a warning.
+func UnjailAction(ctx context.Context, opts ValidatorActionOptions, params UnjailParams) (*ValidatorActionResult, error) {
+	result := &ValidatorActionResult{
+		Action: TxActionUnjail,
+		Steps:  make([]ActionStep, 0),
+	}
+
+	// Step 1: Validate network
+	result.Steps = append(result.Steps, ActionStep{Name: "Validate network", Status: "pending"})
+	_, err := GetNetwork(opts.Network)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Error = err
+		return result, err
+	}
+	result.Steps[len(result.Steps)-1].Status = "success"
+
+	// Step 2: Build transaction command
+	result.Steps = append(result.Steps, ActionStep{Name: "Build transaction", Status: "pending"})
+	txOpts := opts.toTxBuilderOptions()
+	cmd, err := BuildUnjailTx(txOpts, params)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Error = err
+		return result, err
+	}
+	result.Command = cmd
+	result.Description = cmd.Description
+	result.Warnings = cmd.WarningMessages
+	result.Steps[len(result.Steps)-1].Status = "success"
+
+	// Step 3: Check signing info
+	result.Steps = append(result.Steps, ActionStep{Name: "Check signing info", Status: "pending"})
+	valoper, _ := ResolveUnjailValidator(opts.From, params)
+	status, err := CheckUnjail(opts.cosmosClient(), valoper)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		if opts.Execute && !opts.DryRun {
+			result.Error = fmt.Errorf("could not verify the validator can be unjailed: %w", err)
+			return result, result.Error
+		}
+		result.Warnings = append(result.Warnings, fmt.Sprintf("could not check signing info: %v", err))
+	} else {
+		if err := status.CanUnjail(time.Now()); err != nil {
+			result.Steps[len(result.Steps)-1].Status = "failed"
+			result.Steps[len(result.Steps)-1].Message = err.Error()
+			result.Error = err
+			return result, err
+		}
+		result.Steps[len(result.Steps)-1].Status = "success"
+		result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("jail time served, %d missed blocks, self-delegation %s",
+			status.MissedBlocks, FormatLYTH(status.SelfDelegation))
+	}
+
+	return executeOrSkip(ctx, opts, result)
+}
+
+// DepositAction executes or previews a governance deposit transaction
+func DepositAction(ctx context.Context, opts ValidatorActionOptions, params DepositParams) (*ValidatorActionResult, error) {
+	result := &ValidatorActionResult{
+		Action: TxActionDeposit,
+		Steps:  make([]ActionStep, 0),
+	}
+
+	// Step 1: Validate network
+	result.Steps = append(result.Steps, ActionStep{Name: "Validate network", Status: "pending"})
+	_, err := GetNetwork(opts.Network)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Error = err
+		return result, err
+	}
+	result.Steps[len(result.Steps)-1].Status = "success"
+
+	// Step 2: Build transaction command
+	result.Steps = append(result.Steps, ActionStep{Name: "Build transaction", Status: "pending"})
+	txOpts := opts.toTxBuilderOptions()
+	cmd, err := BuildDepositTx(txOpts, params)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Error = err
+		return result, err
+	}
+	result.Command = cmd
+	result.Description = cmd.Description
+	result.Warnings = cmd.WarningMessages
+	result.Steps[len(result.Steps)-1].Status = "success"
+
+	return executeOrSkip(ctx, opts, result)
+}
+
+// SubmitProposalAction executes or previews a governance submit-proposal transaction
+func SubmitProposalAction(ctx context.Context, opts ValidatorActionOptions, params SubmitProposalParams) (*ValidatorActionResult, error) {
+	result := &ValidatorActionResult{
+		Action: TxActionSubmitProposal,
+		Steps:  make([]ActionStep, 0),
+	}
+
+	// Step 1: Validate network
+	result.Steps = append(result.Steps, ActionStep{Name: "Validate network", Status: "pending"})
+	_, err := GetNetwork(opts.Network)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Error = err
+		return result, err
+	}
+	result.Steps[len(result.Steps)-1].Status = "success"
+
+	// Step 2: Build transaction command
+	result.Steps = append(result.Steps, ActionStep{Name: "Build transaction", Status: "pending"})
+	txOpts := opts.toTxBuilderOptions()
+	cmd, err := BuildSubmitProposalTx(txOpts, params)
+	if err != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Error = err
+		return result, err
+	}
+	result.Command = cmd
+	result.Description = cmd.Description
+	result.Warnings = cmd.WarningMessages
+	result.Steps[len(result.Steps)-1].Status = "success"
+
+	return executeOrSkip(ctx, opts, result)
+}
+
 // executeOrSkip is a helper to execute tx or skip in dry-run mode
 func executeOrSkip(ctx context.Context, opts ValidatorActionOptions, result *ValidatorActionResult) (*ValidatorActionResult, error) {
+	if opts.Simulate {
+		opts = simulateGasStep(ctx, opts, result)
+	}
+
 	if opts.DryRun || !opts.Execute {
 		result.Steps = append(result.Steps, ActionStep{
 			Name:    "Execute transaction",
@@ -368,6 +585,21 @@ func executeOrSkip(ctx context.Context, opts ValidatorActionOptions, result *Val
 
 	// Execute transaction
 	result.Steps = append(result.Steps, ActionStep{Name: "Execute transaction", Status: "pending"})
+
+	if opts.Multisig != nil {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = errMultisigExecute.Error()
+		result.Error = errMultisigExecute
+		return result, errMultisigExecute
+	}
+
+	if opts.Signer != nil {
+		if _, err := executeNative(opts, result); err != nil {
+			return result, err
+		}
+		return confirmStep(ctx, opts, result)
+	}
+
 	runner := oshelpers.NewRunner(false)
 
 	execResult := runner.RunTx(ctx, result.Command.Binary, result.Command.Args)
@@ -390,6 +622,58 @@ func executeOrSkip(ctx context.Context, opts ValidatorActionOptions, result *Val
 		result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("txhash: %s", summary.TxHash)
 	}
 
+	return confirmStep(ctx, opts, result)
+}
+
+// executeNative signs and broadcasts the command's messages without monod.
+func executeNative(opts ValidatorActionOptions, result *ValidatorActionResult) (*ValidatorActionResult, error) {
+	fail := func(err error) (*ValidatorActionResult, error) {
+		result.Steps[len(result.Steps)-1].Status = "failed"
+		result.Steps[len(result.Steps)-1].Message = err.Error()
+		result.Error = err
+		return result, err
+	}
+
+	msgs := result.Command.Msgs
+	if len(msgs) == 0 {
+		return fail(fmt.Errorf("nothing to sign natively for %s (is --from the signer's address?)", result.Action))
+	}
+	if opts.From != opts.Signer.Address() {
+		return fail(fmt.Errorf("--from %s does not match the signing key address %s", opts.From, opts.Signer.Address()))
+	}
+
+	network, err := GetNetwork(opts.Network)
+	if err != nil {
+		return fail(err)
+	}
+	chainID := opts.ChainID
+	if chainID == "" {
+		chainID = network.ChainID
+	}
+
+	fee, err := NativeFee(opts.Fees, opts.GasPrices, opts.Gas, len(msgs))
+	if err != nil {
+		return fail(err)
+	}
+
+	summary, err := SignAndBroadcast(opts.cosmosClient(), opts.Signer, chainID, msgs, fee)
+	result.Executed = true
+	if summary != nil {
+		result.TxHash = summary.TxHash
+		result.Height = summary.Height
+	}
+	if err != nil {
+		var txErr *TxError
+		if errors.As(err, &txErr) {
+			result.Code = txErr.Code
+			result.Codespace = txErr.Codespace
+		}
+		return fail(err)
+	}
+
+	result.Success = summary.Success
+	result.Steps[len(result.Steps)-1].Status = "success"
+	result.Steps[len(result.Steps)-1].Message = fmt.Sprintf("txhash: %s", summary.TxHash)
 	return result, nil
 }
 
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IsYAMLFile reports whether path ends in .yaml or .yml.
+func IsYAMLFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// YAMLToJSON converts a YAML document to JSON.
+func YAMLToJSON(data []byte) ([]byte, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	jsonData, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported YAML value: %w", err)
+	}
+	return jsonData, nil
+}
+
+// UnmarshalYAML decodes a YAML document into v through JSON, so the json
+// tags of v's type apply to YAML files too.
+func UnmarshalYAML(data []byte, v any) error {
+	jsonData, err := YAMLToJSON(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// UnmarshalConfigFile decodes a .yaml/.yml or JSON file's data into v.
+func UnmarshalConfigFile(path string, data []byte, v any) error {
+	if IsYAMLFile(path) {
+		return UnmarshalYAML(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
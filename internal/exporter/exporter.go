@@ -0,0 +1,222 @@
+// Package exporter serves node and validator health as Prometheus metrics.
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+	"github.com/monolythium/mono-commander/internal/mesh"
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// Config selects what the exporter checks.
+type Config struct {
+	Network   core.NetworkName
+	Endpoints core.Endpoints
+
+	// Validator is the operator address (monovaloper1...) whose bonded,
+	// jailed and missed-blocks state is exported. Optional.
+	Validator string
+
+	// Home is the node home directory checked for configuration drift
+	// against Drift. A nil Drift disables the drift metrics.
+	Home  string
+	Drift *core.DriftConfig
+
+	// MeshHome is the home directory holding the Mesh/Rosetta sidecar
+	// config (usually the user's home). Empty disables the mesh metrics.
+	MeshHome string
+}
+
+// Exporter collects metrics on every scrape.
+type Exporter struct {
+	cfg Config
+	// mu serializes scrapes so slow checks do not pile up
+	mu sync.Mutex
+}
+
+// New creates an exporter.
+func New(cfg Config) *Exporter {
+	return &Exporter{cfg: cfg}
+}
+
+// Handler returns an HTTP handler serving /metrics.
+func (e *Exporter) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		e.Collect(r.Context(), w)
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintln(w, `<html><body><h1>monoctl exporter</h1><a href="/metrics">Metrics</a></body></html>`)
+	})
+	return mux
+}
+
+// Collect runs all checks and writes the results to w in the Prometheus
+// text exposition format. Failed checks are reported as metrics, never as
+// errors, so one broken endpoint does not hide the others.
+func (e *Exporter) Collect(ctx context.Context, w io.Writer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	start := time.Now()
+	m := &metricWriter{w: w, network: string(e.cfg.Network)}
+
+	e.collectNode(m)
+	e.collectRPC(m)
+	if e.cfg.Validator != "" {
+		e.collectValidator(m)
+	}
+	if e.cfg.MeshHome != "" {
+		e.collectMesh(ctx, m)
+	}
+	if e.cfg.Drift != nil {
+		e.collectDrift(m)
+	}
+
+	m.gauge("mono_exporter_scrape_duration_seconds", "Time taken to collect all metrics.",
+		time.Since(start).Seconds())
+}
+
+func (e *Exporter) collectNode(m *metricWriter) {
+	status, err := core.GetNodeStatus(core.StatusOptions{Network: e.cfg.Network, Endpoints: e.cfg.Endpoints})
+	m.gauge("mono_node_up", "Whether the node's Comet RPC status could be fetched.", boolValue(err == nil))
+	if err != nil {
+		return
+	}
+	m.gauge("mono_node_info", "Node information; the value is always 1.", 1,
+		"chain_id", status.ChainID, "moniker", status.Moniker, "version", status.NodeVersion)
+	m.gauge("mono_node_height", "Latest block height.", float64(status.LatestHeight))
+	m.gauge("mono_node_catching_up", "Whether the node is catching up (1) or in sync (0).", boolValue(status.CatchingUp))
+	m.gauge("mono_node_peers", "Number of connected peers.", float64(status.PeersCount))
+}
+
+func (e *Exporter) collectRPC(m *metricWriter) {
+	results := core.CheckRPC(e.cfg.Network, e.cfg.Endpoints)
+	for _, r := range results.Results {
+		m.gauge("mono_rpc_up", "Whether an RPC endpoint passed its health check.", boolValue(r.Status == "PASS"),
+			"check", checkName(r.Type), "endpoint", r.Endpoint)
+	}
+}
+
+func (e *Exporter) collectValidator(m *metricWriter) {
+	client := rpc.NewCosmosClient(strings.TrimRight(e.cfg.Endpoints.CosmosREST, "/"))
+	h, err := core.GetValidatorHealth(client, e.cfg.Validator)
+	m.gauge("mono_validator_up", "Whether the validator could be fetched over Cosmos REST.", boolValue(err == nil),
+		"validator", e.cfg.Validator)
+	if err != nil {
+		return
+	}
+	m.gauge("mono_validator_bonded", "Whether the validator is in the active set.",
+		boolValue(h.Status == "bonded"), "validator", e.cfg.Validator)
+	m.gauge("mono_validator_jailed", "Whether the validator is jailed.", boolValue(h.Jailed),
+		"validator", e.cfg.Validator)
+	if tokens, err := strconv.ParseFloat(h.Tokens, 64); err == nil {
+		m.gauge("mono_validator_tokens", "Tokens bonded to the validator, in alyth.", tokens,
+			"validator", e.cfg.Validator)
+	}
+
+	if !h.HasSigningInfo {
+		return
+	}
+	m.gauge("mono_validator_missed_blocks", "Blocks missed in the current signing window.", float64(h.MissedBlocks),
+		"validator", e.cfg.Validator)
+	m.gauge("mono_validator_tombstoned", "Whether the validator is tombstoned.", boolValue(h.Tombstoned),
+		"validator", e.cfg.Validator)
+	var jailedUntil float64
+	if h.JailedUntil.After(time.Unix(0, 0)) {
+		jailedUntil = float64(h.JailedUntil.Unix())
+	}
+	m.gauge("mono_validator_jailed_until_timestamp_seconds", "End of the validator's jail period (0 if never jailed).",
+		jailedUntil, "validator", e.cfg.Validator)
+}
+
+func (e *Exporter) collectMesh(ctx context.Context, m *metricWriter) {
+	result := mesh.FullCheck(ctx, string(e.cfg.Network), e.cfg.MeshHome, e.cfg.Network)
+	m.gauge("mono_mesh_installed", "Whether the Mesh/Rosetta binary is installed.", boolValue(result.BinaryExists))
+	m.gauge("mono_mesh_configured", "Whether the Mesh/Rosetta config file exists.", boolValue(result.ConfigExists))
+	healthy := result.ServiceHealth != nil && result.ServiceHealth.Healthy
+	m.gauge("mono_mesh_up", "Whether the Mesh/Rosetta API passed its health check.", boolValue(healthy))
+	if healthy {
+		m.gauge("mono_mesh_response_time_seconds", "Response time of the Mesh/Rosetta health check.",
+			float64(result.ServiceHealth.ResponseTime)/1000)
+	}
+}
+
+func (e *Exporter) collectDrift(m *metricWriter) {
+	drifts, err := core.DetectDrift(e.cfg.Home, e.cfg.Drift)
+	m.gauge("mono_config_drift_check_up", "Whether configuration drift detection ran.", boolValue(err == nil))
+	if err != nil {
+		return
+	}
+	counts := map[core.DriftSeverity]int{}
+	for _, d := range drifts {
+		counts[d.Severity]++
+	}
+	for _, severity := range []core.DriftSeverity{core.SeverityCritical, core.SeverityWarning, core.SeverityInfo} {
+		m.gauge("mono_config_drift", "Number of configuration values that differ from the canonical config.",
+			float64(counts[severity]), "severity", strings.ToLower(string(severity)))
+	}
+}
+
+// checkName turns a CheckRPC type such as "EVM JSON-RPC" into a label value
+// such as "evm_json_rpc".
+func checkName(t string) string {
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(t))
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// metricWriter writes gauges in the Prometheus text format. The samples of
+// one metric must be written consecutively; HELP and TYPE are written before
+// the first. Every sample carries the network label.
+type metricWriter struct {
+	w       io.Writer
+	network string
+	last    string
+}
+
+// gauge writes one sample. labels are name, value pairs.
+func (m *metricWriter) gauge(name, help string, value float64, labels ...string) {
+	if name != m.last {
+		fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+		m.last = name
+	}
+
+	pairs := map[string]string{"network": m.network}
+	for i := 0; i+1 < len(labels); i += 2 {
+		pairs[labels[i]] = labels[i+1]
+	}
+	keys := make([]string, 0, len(pairs))
+	for k := range pairs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf(`%s="%s"`, k, labelEscaper.Replace(pairs[k])))
+	}
+	fmt.Fprintf(m.w, "%s{%s} %s\n", name, strings.Join(parts, ","), strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+// labelEscaper escapes label values as the text format requires.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
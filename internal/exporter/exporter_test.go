@@ -0,0 +1,115 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/monolythium/mono-commander/internal/core"
+)
+
+const testValoper = "monovaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq5nfrmp"
+
+// mockNode serves Comet RPC and Cosmos REST from one server.
+func mockNode(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/status":
+			w.Write([]byte(`{"result":{"node_info":{"network":"mono-local-1","moniker":"node \"a\"","version":"0.38.0"},
+				"sync_info":{"latest_block_height":"1234","catching_up":false}}}`))
+		case r.URL.Path == "/net_info":
+			w.Write([]byte(`{"result":{"n_peers":"5"}}`))
+		case r.URL.Path == "/cosmos/base/tendermint/v1beta1/node_info":
+			w.Write([]byte(`{"default_node_info":{"network":"mono-local-1"},"application_version":{"app_name":"monod"}}`))
+		case r.URL.Path == "/cosmos/staking/v1beta1/validators/"+testValoper:
+			w.Write([]byte(`{"validator":{"jailed":true,"status":"BOND_STATUS_UNBONDING","tokens":"1000",
+				"consensus_pubkey":{"@type":"/cosmos.crypto.ed25519.PubKey","key":"AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}}}`))
+		case strings.HasPrefix(r.URL.Path, "/cosmos/slashing/v1beta1/signing_infos/"):
+			w.Write([]byte(`{"val_signing_info":{"jailed_until":"2030-01-01T00:00:00Z","tombstoned":false,"missed_blocks_counter":"42"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCollect(t *testing.T) {
+	srv := mockNode(t)
+
+	home := t.TempDir()
+	os.MkdirAll(filepath.Join(home, "config"), 0755)
+	os.WriteFile(filepath.Join(home, "config", "app.toml"), []byte("[evm]\nevm-chain-id = 1\n"), 0644)
+
+	e := New(Config{
+		Network:   core.NetworkLocalnet,
+		Endpoints: core.Endpoints{CometRPC: srv.URL, CosmosREST: srv.URL, EVMRPC: "http://127.0.0.1:1"},
+		Validator: testValoper,
+		Home:      home,
+		Drift:     &core.DriftConfig{CosmosChainID: "mono-local-1", EVMChainID: 262145},
+	})
+
+	var buf bytes.Buffer
+	e.Collect(context.Background(), &buf)
+	out := buf.String()
+
+	for _, want := range []string{
+		`mono_node_up{network="Localnet"} 1`,
+		`mono_node_height{network="Localnet"} 1234`,
+		`mono_node_peers{network="Localnet"} 5`,
+		`mono_node_info{chain_id="mono-local-1",moniker="node \"a\"",network="Localnet",version="0.38.0"} 1`,
+		`mono_rpc_up{check="comet_rpc",endpoint="` + srv.URL + `",network="Localnet"} 1`,
+		`mono_rpc_up{check="evm_json_rpc",endpoint="http://127.0.0.1:1",network="Localnet"} 0`,
+		`mono_validator_bonded{network="Localnet",validator="` + testValoper + `"} 0`,
+		`mono_validator_jailed{network="Localnet",validator="` + testValoper + `"} 1`,
+		`mono_validator_missed_blocks{network="Localnet",validator="` + testValoper + `"} 42`,
+		`mono_validator_jailed_until_timestamp_seconds{network="Localnet",validator="` + testValoper + `"} 1.893456e+09`,
+		`mono_config_drift{network="Localnet",severity="critical"} 1`,
+		`mono_config_drift{network="Localnet",severity="warning"} 0`,
+	} {
+		if !strings.Contains(out, want+"\n") {
+			t.Errorf("missing %s", want)
+		}
+	}
+
+	// HELP and TYPE appear once per metric
+	if n := strings.Count(out, "# TYPE mono_rpc_up gauge"); n != 1 {
+		t.Errorf("TYPE mono_rpc_up written %d times", n)
+	}
+	if strings.Contains(out, "mono_mesh_") {
+		t.Error("mesh metrics should be disabled without MeshHome")
+	}
+	if t.Failed() {
+		t.Logf("output:\n%s", out)
+	}
+}
+
+func TestHandler_NodeDown(t *testing.T) {
+	e := New(Config{
+		Network:   core.NetworkLocalnet,
+		Endpoints: core.Endpoints{CometRPC: "http://127.0.0.1:1", CosmosREST: "http://127.0.0.1:1", EVMRPC: "http://127.0.0.1:1"},
+	})
+	srv := httptest.NewServer(e.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics error = %v", err)
+	}
+	defer resp.Body.Close()
+	var buf bytes.Buffer
+	buf.ReadFrom(resp.Body)
+
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("status %d, content type %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(buf.String(), `mono_node_up{network="Localnet"} 0`) || strings.Contains(buf.String(), "mono_node_height") {
+		t.Errorf("unexpected output:\n%s", buf.String())
+	}
+}
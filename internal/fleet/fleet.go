@@ -0,0 +1,292 @@
+package fleet
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/monolythium/mono-commander/internal/core"
+	"github.com/monolythium/mono-commander/internal/logs"
+	"github.com/monolythium/mono-commander/internal/remote"
+	"github.com/monolythium/mono-commander/internal/rpc"
+)
+
+// DefaultConcurrency is how many nodes are worked on at once.
+const DefaultConcurrency = 8
+
+// forEach calls fn for every node, at most concurrency at a time, and
+// returns when all calls are done.
+func forEach(nodes []Node, concurrency int, fn func(i int, n Node)) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, n := range nodes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, n Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i, n)
+		}(i, n)
+	}
+	wg.Wait()
+}
+
+// StatusResult is one node's status.
+type StatusResult struct {
+	Node    string           `json:"node"`
+	Network string           `json:"network"`
+	Role    string           `json:"role,omitempty"`
+	Mode    string           `json:"mode"`
+	Status  *core.NodeStatus `json:"status,omitempty"`
+	// Service is the systemd unit or compose state; only checked for
+	// nodes on this machine
+	Service string `json:"service,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Status fetches every node's status over Comet RPC.
+func Status(nodes []Node, concurrency int) []StatusResult {
+	results := make([]StatusResult, len(nodes))
+	forEach(nodes, concurrency, func(i int, n Node) {
+		r := StatusResult{Node: n.Name, Network: n.Network, Role: n.Role, Mode: n.Mode}
+		status, err := core.GetNodeStatus(core.StatusOptions{
+			Network:   core.NetworkName(n.Network),
+			Endpoints: n.Endpoints(),
+		})
+		if err != nil {
+			r.Error = err.Error()
+		} else {
+			r.Status = status
+		}
+		if n.IsLocal() {
+			r.Service = serviceStatus(n)
+		}
+		results[i] = r
+	})
+	return results
+}
+
+// serviceStatus returns the state of the node's systemd unit or docker
+// compose service.
+func serviceStatus(n Node) string {
+	if n.Mode != ModeDocker {
+		return logs.GetSystemdServiceStatus(n.Network)
+	}
+	cmd := exec.Command("docker", "compose", "ps", "--status", "running", "--quiet")
+	cmd.Dir = n.Home
+	out, err := cmd.Output()
+	if err != nil {
+		return "unknown"
+	}
+	if strings.TrimSpace(string(out)) == "" {
+		return "stopped"
+	}
+	return "running"
+}
+
+// HealthResult is one node's RPC and validator health.
+type HealthResult struct {
+	Node           string                `json:"node"`
+	Network        string                `json:"network"`
+	RPC            *core.RPCCheckResults `json:"rpc"`
+	Validator      *core.ValidatorHealth `json:"validator,omitempty"`
+	ValidatorError string                `json:"validator_error,omitempty"`
+	// Healthy is true if every RPC check passed and the validator, if
+	// any, is bonded and not jailed
+	Healthy bool `json:"healthy"`
+}
+
+// Health runs the RPC checks on every node, plus the validator checks on
+// nodes with a validator address.
+func Health(nodes []Node, concurrency int) []HealthResult {
+	results := make([]HealthResult, len(nodes))
+	forEach(nodes, concurrency, func(i int, n Node) {
+		endpoints := n.Endpoints()
+		r := HealthResult{Node: n.Name, Network: n.Network}
+		r.RPC = core.CheckRPC(core.NetworkName(n.Network), endpoints)
+		r.Healthy = r.RPC.AllPass
+
+		if n.Validator != "" {
+			client := rpc.NewCosmosClient(strings.TrimRight(endpoints.CosmosREST, "/"))
+			h, err := core.GetValidatorHealth(client, n.Validator)
+			if err != nil {
+				r.ValidatorError = err.Error()
+				r.Healthy = false
+			} else {
+				r.Validator = h
+				if h.Jailed || h.Status != "bonded" {
+					r.Healthy = false
+				}
+			}
+		}
+		results[i] = r
+	})
+	return results
+}
+
+// DoctorResult is one node's configuration drift.
+type DoctorResult struct {
+	Node        string             `json:"node"`
+	Network     string             `json:"network"`
+	Home        string             `json:"home"`
+	Drifts      []core.DriftResult `json:"drifts"`
+	HasCritical bool               `json:"has_critical"`
+	Error       string             `json:"error,omitempty"`
+}
+
+// ExpectedConfig returns the configuration a network's nodes should have.
+type ExpectedConfig func(network core.NetworkName) (*core.DriftConfig, error)
+
+// Dialer opens an SSH connection to a node's host.
+type Dialer func(target remote.Target) (*remote.Client, error)
+
+// homeFS returns the filesystem holding the node home: this machine's, or
+// the SSH host's. done closes the connection, if any.
+func homeFS(n Node, dial Dialer) (fsys core.FS, done func(), err error) {
+	if n.SSH == "" {
+		if !n.IsLocal() {
+			return nil, nil, fmt.Errorf("remote node (host %s) has no ssh target to reach its home", n.Host)
+		}
+		return core.OSFS{}, func() {}, nil
+	}
+	target, err := remote.ParseTarget(n.SSH)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := dial(target)
+	if err != nil {
+		return nil, nil, err
+	}
+	return remote.NewFS(client), func() { client.Close() }, nil
+}
+
+// Doctor detects configuration drift in every node home, over SSH for
+// nodes with an ssh target. expected is called once per network.
+func Doctor(nodes []Node, concurrency int, expected ExpectedConfig, dial Dialer) []DoctorResult {
+	configs, errs := perNetwork(nodes, func(network core.NetworkName) (interface{}, error) {
+		return expected(network)
+	})
+
+	results := make([]DoctorResult, len(nodes))
+	forEach(nodes, concurrency, func(i int, n Node) {
+		r := DoctorResult{Node: n.Name, Network: n.Network, Home: n.Home, Drifts: []core.DriftResult{}}
+		if err := errs[n.Network]; err != nil {
+			r.Error = err.Error()
+			results[i] = r
+			return
+		}
+		fsys, done, err := homeFS(n, dial)
+		if err != nil {
+			r.Error = err.Error()
+			results[i] = r
+			return
+		}
+		defer done()
+		drifts, err := core.DetectDriftFS(fsys, n.Home, configs[n.Network].(*core.DriftConfig))
+		if err != nil {
+			r.Error = err.Error()
+		} else {
+			r.Drifts = drifts
+			r.HasCritical = core.HasCriticalDrift(drifts)
+		}
+		results[i] = r
+	})
+	return results
+}
+
+// PeersResult is the outcome of updating one node's peers.
+type PeersResult struct {
+	Node       string `json:"node"`
+	Network    string `json:"network"`
+	PeersCount int    `json:"peers_count"`
+	PatchPath  string `json:"patch_path,omitempty"`
+	Patch      string `json:"patch,omitempty"` // only set on a dry run
+	Error      string `json:"error,omitempty"`
+}
+
+// UpdatePeers fetches each network's peers registry once and writes the
+// config patch to every node home of that network, over SSH for nodes with
+// an ssh target, as 'peers update' does for one node.
+func UpdatePeers(nodes []Node, concurrency int, fetcher core.Fetcher, dryRun bool, dial Dialer) []PeersResult {
+	registries, errs := perNetwork(nodes, func(network core.NetworkName) (interface{}, error) {
+		return fetchPeers(network, fetcher)
+	})
+
+	results := make([]PeersResult, len(nodes))
+	forEach(nodes, concurrency, func(i int, n Node) {
+		r := PeersResult{Node: n.Name, Network: n.Network}
+		if err := errs[n.Network]; err != nil {
+			r.Error = err.Error()
+			results[i] = r
+			return
+		}
+		fsys, done, err := homeFS(n, dial)
+		if err != nil {
+			r.Error = err.Error()
+			results[i] = r
+			return
+		}
+		defer done()
+		reg := registries[n.Network].(*core.PeersRegistry)
+		peers := core.MergePeers(reg.Peers, reg.PersistentPeers)
+		path, content, err := core.WriteConfigPatchFS(fsys, n.Home, core.GenerateConfigPatch(reg.Seeds, peers), dryRun)
+		if err != nil {
+			r.Error = err.Error()
+		} else {
+			r.PeersCount = len(peers)
+			r.PatchPath = path
+			if dryRun {
+				r.Patch = content
+			}
+		}
+		results[i] = r
+	})
+	return results
+}
+
+func fetchPeers(network core.NetworkName, fetcher core.Fetcher) (*core.PeersRegistry, error) {
+	netCfg, err := core.GetNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+	if netCfg.PeersURL == "" {
+		return nil, fmt.Errorf("no peers registry for %s", network)
+	}
+	data, err := fetcher.Fetch(netCfg.PeersURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch peers: %w", err)
+	}
+	reg, err := core.ParsePeersRegistry(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.ValidatePeersRegistry(reg, netCfg.ChainID, ""); err != nil {
+		return nil, fmt.Errorf("invalid peers registry: %w", err)
+	}
+	return reg, nil
+}
+
+// perNetwork calls fn once for every network in nodes.
+func perNetwork(nodes []Node, fn func(core.NetworkName) (interface{}, error)) (map[string]interface{}, map[string]error) {
+	values := map[string]interface{}{}
+	errs := map[string]error{}
+	for _, n := range nodes {
+		if _, done := values[n.Network]; done {
+			continue
+		}
+		if _, done := errs[n.Network]; done {
+			continue
+		}
+		v, err := fn(core.NetworkName(n.Network))
+		if err != nil {
+			errs[n.Network] = err
+		} else {
+			values[n.Network] = v
+		}
+	}
+	return values, errs
+}
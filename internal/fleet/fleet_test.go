@@ -0,0 +1,302 @@
+package fleet
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/monolythium/mono-commander/internal/core"
+	"github.com/monolythium/mono-commander/internal/remote"
+	"github.com/monolythium/mono-commander/internal/remote/sshtest"
+)
+
+const testValoper = "monovaloper1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq5nfrmp"
+
+// mockNode serves Comet RPC and Cosmos REST for a node at height.
+func mockNode(t *testing.T, height int, jailed bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/status":
+			fmt.Fprintf(w, `{"result":{"node_info":{"network":"mono-sprint-1","moniker":"m"},
+				"sync_info":{"latest_block_height":"%d","catching_up":false}}}`, height)
+		case r.URL.Path == "/net_info":
+			w.Write([]byte(`{"result":{"n_peers":"4"}}`))
+		case r.URL.Path == "/cosmos/base/tendermint/v1beta1/node_info":
+			w.Write([]byte(`{"default_node_info":{"network":"mono-sprint-1"}}`))
+		case r.URL.Path == "/cosmos/staking/v1beta1/validators/"+testValoper:
+			fmt.Fprintf(w, `{"validator":{"jailed":%t,"status":"BOND_STATUS_BONDED","tokens":"1"}}`, jailed)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// sshHost starts an SSH server whose home holds a node home in .monod, and
+// returns it with a dialer for it.
+func sshHost(t *testing.T) (*sshtest.Server, Dialer) {
+	t.Helper()
+	s := sshtest.NewServer(t)
+	os.MkdirAll(filepath.Join(s.Home, ".monod", "config"), 0755)
+	return s, func(target remote.Target) (*remote.Client, error) {
+		if target != s.Target {
+			return nil, fmt.Errorf("unexpected target %s", target)
+		}
+		return remote.Dial(s.Config())
+	}
+}
+
+// noDial fails tests that should not connect anywhere.
+func noDial(target remote.Target) (*remote.Client, error) {
+	return nil, fmt.Errorf("unexpected dial to %s", target)
+}
+
+func TestInventory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fleet.json")
+	os.WriteFile(path, []byte(`{"nodes": [
+		{"name": "sprint-val", "network": "sprintnet", "role": "validator", "host": "10.0.0.1", "tags": ["eu"]},
+		{"name": "sprint-rpc", "network": "Sprintnet", "role": "fullnode", "mode": "docker", "home": "/srv/rpc"},
+		{"name": "test-seed", "network": "testnet", "role": "seed", "comet_rpc": "http://10.0.0.3:36657"},
+		{"name": "test-val", "network": "testnet", "ssh": "ops@10.0.0.4:2222", "home": "~/nodes/val"}
+	]}`), 0644)
+
+	inv, err := LoadInventory(path)
+	if err != nil {
+		t.Fatalf("LoadInventory() error = %v", err)
+	}
+	if inv.Nodes[0].Network != "Sprintnet" || inv.Nodes[0].Mode != ModeSystemd || !strings.HasSuffix(inv.Nodes[0].Home, ".monod") {
+		t.Errorf("defaults not applied: %+v", inv.Nodes[0])
+	}
+	if e := inv.Nodes[0].Endpoints(); e.CometRPC != "http://10.0.0.1:26657" || e.EVMRPC != "http://10.0.0.1:8545" {
+		t.Errorf("endpoints = %+v", e)
+	}
+	if e := inv.Nodes[2].Endpoints(); e.CometRPC != "http://10.0.0.3:36657" || e.CosmosREST != "http://localhost:1317" {
+		t.Errorf("endpoints = %+v", e)
+	}
+	if inv.Nodes[0].IsLocal() || !inv.Nodes[1].IsLocal() {
+		t.Error("IsLocal should depend on host")
+	}
+	// SSH nodes default to the SSH host, with homes relative to the SSH user's
+	if n := inv.Nodes[3]; n.Host != "10.0.0.4" || n.Home != "nodes/val" || n.IsLocal() {
+		t.Errorf("ssh node = %+v", n)
+	}
+
+	tests := []struct {
+		filter Filter
+		want   string
+	}{
+		{Filter{}, "sprint-val sprint-rpc test-seed test-val"},
+		{Filter{Network: "sprintnet"}, "sprint-val sprint-rpc"},
+		{Filter{Role: "seed"}, "test-seed"},
+		{Filter{Tag: "eu"}, "sprint-val"},
+		{Filter{Names: []string{"test-seed", "sprint-val"}}, "sprint-val test-seed"},
+	}
+	for _, tt := range tests {
+		nodes, err := inv.Select(tt.filter)
+		if err != nil {
+			t.Fatalf("Select(%+v) error = %v", tt.filter, err)
+		}
+		var names []string
+		for _, n := range nodes {
+			names = append(names, n.Name)
+		}
+		if got := strings.Join(names, " "); got != tt.want {
+			t.Errorf("Select(%+v) = %s, want %s", tt.filter, got, tt.want)
+		}
+	}
+	if _, err := inv.Select(Filter{Names: []string{"nope"}}); err == nil {
+		t.Error("expected an error for an unknown node name")
+	}
+	if _, err := inv.Select(Filter{Network: "mainnet"}); err == nil {
+		t.Error("expected an error when nothing matches")
+	}
+
+	bad := Inventory{Nodes: []Node{{Name: "x", Network: "testnet", SSH: "ops@"}}}
+	if err := bad.Validate(); err == nil || !strings.Contains(err.Error(), "ssh") {
+		t.Errorf("Validate() with a bad ssh target error = %v", err)
+	}
+}
+
+func TestInventoryYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fleet.yaml")
+	os.WriteFile(path, []byte(`nodes:
+  - name: sprint-val
+    network: sprintnet
+    role: validator
+    host: 10.0.0.1
+    tags: [eu]
+  - name: test-seed
+    network: testnet
+    role: seed
+    comet_rpc: http://10.0.0.3:36657
+`), 0644)
+
+	inv, err := LoadInventory(path)
+	if err != nil {
+		t.Fatalf("LoadInventory() error = %v", err)
+	}
+	if len(inv.Nodes) != 2 || inv.Nodes[0].Host != "10.0.0.1" || inv.Nodes[0].Tags[0] != "eu" {
+		t.Errorf("nodes = %+v", inv.Nodes)
+	}
+	if e := inv.Nodes[1].Endpoints(); e.CometRPC != "http://10.0.0.3:36657" {
+		t.Errorf("endpoints = %+v", e)
+	}
+
+	os.WriteFile(path, []byte("nodes: [\n"), 0644)
+	if _, err := LoadInventory(path); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestInventoryValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   []Node
+		wantErr string
+	}{
+		{"empty", nil, "no nodes"},
+		{"missing name", []Node{{Network: "sprintnet"}}, "name is required"},
+		{"duplicate name", []Node{{Name: "a", Network: "sprintnet"}, {Name: "a", Network: "testnet"}}, "duplicate name"},
+		{"bad network", []Node{{Name: "a", Network: "devnet"}}, "node a"},
+		{"bad mode", []Node{{Name: "a", Network: "sprintnet", Mode: "k8s"}}, "mode must be"},
+		{"bad validator", []Node{{Name: "a", Network: "sprintnet", Validator: "mono1abc"}}, "validator"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := &Inventory{Nodes: tt.nodes}
+			if err := inv.Validate(); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatusAndHealth(t *testing.T) {
+	up := mockNode(t, 1234, false)
+	jailed := mockNode(t, 1200, true)
+	nodes := []Node{
+		{Name: "up", Network: "Sprintnet", Host: "10.0.0.1", CometRPC: up.URL, CosmosREST: up.URL, EVMRPC: up.URL},
+		{Name: "down", Network: "Sprintnet", Host: "10.0.0.2", CometRPC: "http://127.0.0.1:1"},
+		{Name: "jailed", Network: "Sprintnet", Host: "10.0.0.3", CometRPC: jailed.URL, CosmosREST: jailed.URL,
+			EVMRPC: "http://127.0.0.1:1", Validator: testValoper},
+	}
+
+	status := Status(nodes, 2)
+	if status[0].Status == nil || status[0].Status.LatestHeight != 1234 || status[0].Status.PeersCount != 4 {
+		t.Errorf("status[0] = %+v", status[0])
+	}
+	if status[1].Error == "" || status[1].Status != nil {
+		t.Errorf("status[1] = %+v, want an error", status[1])
+	}
+	if status[0].Service != "" {
+		t.Error("service status should only be checked for local nodes")
+	}
+
+	health := Health(nodes, 2)
+	if health[1].Healthy || health[1].RPC.AllPass {
+		t.Errorf("health[1] = %+v, want unhealthy", health[1])
+	}
+	if health[2].Healthy || health[2].Validator == nil || !health[2].Validator.Jailed {
+		t.Errorf("health[2] = %+v, want jailed validator", health[2])
+	}
+}
+
+func TestDoctor(t *testing.T) {
+	good, bad := t.TempDir(), t.TempDir()
+	for home, evmChainID := range map[string]string{good: "262146", bad: "1"} {
+		os.MkdirAll(filepath.Join(home, "config"), 0755)
+		os.WriteFile(filepath.Join(home, "config", "app.toml"), []byte("[evm]\nevm-chain-id = "+evmChainID+"\n"), 0644)
+	}
+	nodes := []Node{
+		{Name: "good", Network: "Sprintnet", Home: good},
+		{Name: "bad", Network: "Sprintnet", Home: bad},
+		{Name: "other", Network: "Testnet", Home: good},
+		{Name: "remote", Network: "Sprintnet", Home: good, Host: "10.0.0.1"},
+	}
+	s, dial := sshHost(t)
+	os.WriteFile(filepath.Join(s.Home, ".monod", "config", "app.toml"), []byte("[evm]\nevm-chain-id = 1\n"), 0644)
+	nodes = append(nodes, Node{Name: "ssh", Network: "Sprintnet", Home: ".monod", SSH: s.Target.String()})
+
+	calls := map[core.NetworkName]int{}
+	results := Doctor(nodes, 0, func(network core.NetworkName) (*core.DriftConfig, error) {
+		calls[network]++
+		if network == core.NetworkTestnet {
+			return nil, fmt.Errorf("canonical config unavailable")
+		}
+		return &core.DriftConfig{CosmosChainID: "mono-sprint-1", EVMChainID: 262146}, nil
+	}, dial)
+
+	if calls[core.NetworkSprintnet] != 1 || calls[core.NetworkTestnet] != 1 {
+		t.Errorf("expected config fetched %v times, want once per network", calls)
+	}
+	if results[0].HasCritical || len(results[0].Drifts) != 0 {
+		t.Errorf("good = %+v", results[0])
+	}
+	if !results[1].HasCritical {
+		t.Errorf("bad = %+v, want critical drift", results[1])
+	}
+	if !strings.Contains(results[2].Error, "canonical config unavailable") {
+		t.Errorf("other = %+v, want the config error", results[2])
+	}
+	if !strings.Contains(results[3].Error, "no ssh target") || len(results[3].Drifts) != 0 {
+		t.Errorf("remote = %+v, want a missing ssh target error", results[3])
+	}
+	if results[4].Error != "" || !results[4].HasCritical {
+		t.Errorf("ssh = %+v, want critical drift read over SSH", results[4])
+	}
+}
+
+func TestUpdatePeers(t *testing.T) {
+	sprintnet, _ := core.GetNetwork(core.NetworkSprintnet)
+	fetcher := core.NewMockFetcher()
+	fetcher.AddResponse(sprintnet.PeersURL, []byte(`{
+		"chain_id": "mono-sprint-1",
+		"peers": [{"node_id": "abcdef1234567890abcdef1234567890abcdef12", "address": "192.168.1.1", "port": 26656}]
+	}`))
+
+	a, b := t.TempDir(), t.TempDir()
+	nodes := []Node{{Name: "a", Network: "Sprintnet", Home: a}, {Name: "b", Network: "Sprintnet", Home: b}}
+
+	results := UpdatePeers(nodes, 0, fetcher, true, noDial)
+	for _, r := range results {
+		if r.Error != "" || r.PeersCount != 1 || !strings.Contains(r.Patch, "abcdef1234567890abcdef1234567890abcdef12@192.168.1.1:26656") {
+			t.Errorf("dry run result = %+v", r)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(a, "config", "config_patch.toml")); err == nil {
+		t.Error("dry run should not write the patch")
+	}
+
+	unreachable := UpdatePeers([]Node{{Name: "r", Network: "Sprintnet", Home: a, Host: "10.0.0.1"}}, 0, fetcher, false, noDial)
+	if !strings.Contains(unreachable[0].Error, "no ssh target") {
+		t.Errorf("remote result = %+v, want a missing ssh target error", unreachable[0])
+	}
+	if _, err := os.Stat(filepath.Join(a, "config", "config_patch.toml")); err == nil {
+		t.Error("remote node home should not be written locally")
+	}
+
+	s, dial := sshHost(t)
+	overSSH := UpdatePeers([]Node{{Name: "s", Network: "Sprintnet", Home: ".monod", SSH: s.Target.String()}}, 0, fetcher, false, dial)
+	if overSSH[0].Error != "" {
+		t.Fatalf("UpdatePeers(ssh) error = %s", overSSH[0].Error)
+	}
+	if data, err := os.ReadFile(filepath.Join(s.Home, ".monod", "config", "config_patch.toml")); err != nil || !strings.Contains(string(data), "192.168.1.1:26656") {
+		t.Errorf("patch on the SSH host = %q, %v", data, err)
+	}
+
+	results = UpdatePeers(nodes, 0, fetcher, false, noDial)
+	for i, home := range []string{a, b} {
+		if results[i].Error != "" {
+			t.Fatalf("UpdatePeers(%s) error = %s", results[i].Node, results[i].Error)
+		}
+		if _, err := os.Stat(filepath.Join(home, "config", "config_patch.toml")); err != nil {
+			t.Errorf("patch not written for %s: %v", results[i].Node, err)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Package fleet runs node checks and maintenance across the nodes listed in
+// an inventory file.
+package fleet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/monolythium/mono-commander/internal/core"
+	"github.com/monolythium/mono-commander/internal/remote"
+)
+
+// Deployment modes.
+const (
+	ModeSystemd = "systemd"
+	ModeDocker  = "docker"
+)
+
+// Inventory is the fleet inventory file (YAML or JSON).
+type Inventory struct {
+	Nodes []Node `json:"nodes"`
+}
+
+// Node is one inventory entry.
+type Node struct {
+	// Name identifies the node in output and filters; it must be unique
+	Name    string `json:"name"`
+	Network string `json:"network"`
+	// Home is the node home directory (default: ~/.monod). On SSH nodes
+	// it is on the remote host, and relative to the SSH user's home.
+	Home string `json:"home,omitempty"`
+	Role string `json:"role,omitempty"`
+	// Mode is how the node is deployed: systemd (default) or docker
+	Mode string `json:"mode,omitempty"`
+	// Host is the RPC host used to build the default endpoints
+	// (default: the SSH host, or localhost)
+	Host string `json:"host,omitempty"`
+	// SSH is the user@host[:port] that doctor and peers update reach the
+	// node home over; nodes without it must be on this machine
+	SSH        string `json:"ssh,omitempty"`
+	CometRPC   string `json:"comet_rpc,omitempty"`
+	CosmosREST string `json:"cosmos_rest,omitempty"`
+	EVMRPC     string `json:"evm_rpc,omitempty"`
+	// Validator is the operator address (monovaloper1...) checked by
+	// fleet health
+	Validator string   `json:"validator,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+}
+
+// DefaultInventoryPath returns the first of fleet.yaml, fleet.yml and
+// fleet.json that exists in ~/.mono-commander, or fleet.json if none does.
+func DefaultInventoryPath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(homeDir, ".mono-commander")
+	for _, name := range []string{"fleet.yaml", "fleet.yml"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			return filepath.Join(dir, name), nil
+		}
+	}
+	return filepath.Join(dir, "fleet.json"), nil
+}
+
+// LoadInventory reads and validates an inventory file. Files ending in
+// .yaml or .yml are YAML, anything else JSON; both use the same keys.
+func LoadInventory(path string) (*Inventory, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read inventory: %w", err)
+	}
+	var inv Inventory
+	if err := core.UnmarshalConfigFile(path, data, &inv); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if err := inv.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &inv, nil
+}
+
+// Validate checks every node and fills in defaults.
+func (inv *Inventory) Validate() error {
+	if len(inv.Nodes) == 0 {
+		return fmt.Errorf("no nodes in inventory")
+	}
+	homeDir, _ := os.UserHomeDir()
+
+	names := map[string]bool{}
+	for i := range inv.Nodes {
+		n := &inv.Nodes[i]
+		if n.Name == "" {
+			return fmt.Errorf("node %d: name is required", i+1)
+		}
+		if names[n.Name] {
+			return fmt.Errorf("node %d: duplicate name %q", i+1, n.Name)
+		}
+		names[n.Name] = true
+
+		network, err := core.ParseNetworkName(n.Network)
+		if err != nil {
+			return fmt.Errorf("node %s: %w", n.Name, err)
+		}
+		n.Network = string(network)
+
+		switch n.Mode {
+		case "":
+			n.Mode = ModeSystemd
+		case ModeSystemd, ModeDocker:
+		default:
+			return fmt.Errorf("node %s: mode must be %s or %s", n.Name, ModeSystemd, ModeDocker)
+		}
+
+		if n.SSH != "" {
+			target, err := remote.ParseTarget(n.SSH)
+			if err != nil {
+				return fmt.Errorf("node %s: ssh: %w", n.Name, err)
+			}
+			if n.Host == "" {
+				n.Host = target.Host
+			}
+			// The remote FS resolves relative paths from the SSH user's home
+			if n.Home == "" {
+				n.Home = ".monod"
+			} else if n.Home == "~" || strings.HasPrefix(n.Home, "~/") {
+				n.Home = strings.TrimPrefix(strings.TrimPrefix(n.Home, "~"), "/")
+			}
+		} else if n.Home == "" {
+			n.Home = filepath.Join(homeDir, ".monod")
+		} else if strings.HasPrefix(n.Home, "~") {
+			n.Home = filepath.Join(homeDir, strings.TrimPrefix(n.Home, "~"))
+		}
+
+		if n.Validator != "" {
+			if err := core.ValidateValoperAddress(n.Validator); err != nil {
+				return fmt.Errorf("node %s: validator: %w", n.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Filter selects nodes. Empty criteria match every node.
+type Filter struct {
+	Names   []string
+	Network string
+	Role    string
+	Tag     string
+}
+
+// Select returns the nodes matching f, in inventory order.
+func (inv *Inventory) Select(f Filter) ([]Node, error) {
+	wanted := map[string]bool{}
+	for _, name := range f.Names {
+		wanted[name] = true
+	}
+	missing := map[string]bool{}
+	for name := range wanted {
+		missing[name] = true
+	}
+
+	var nodes []Node
+	for _, n := range inv.Nodes {
+		if len(wanted) > 0 && !wanted[n.Name] {
+			continue
+		}
+		if f.Network != "" && !strings.EqualFold(n.Network, f.Network) {
+			continue
+		}
+		if f.Role != "" && !strings.EqualFold(n.Role, f.Role) {
+			continue
+		}
+		if f.Tag != "" && !hasTag(n.Tags, f.Tag) {
+			continue
+		}
+		delete(missing, n.Name)
+		nodes = append(nodes, n)
+	}
+
+	for name := range missing {
+		return nil, fmt.Errorf("node %q is not in the inventory", name)
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes match the filter")
+	}
+	return nodes, nil
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoints returns the node's RPC endpoints: the explicit ones, or the
+// default ports on Host.
+func (n Node) Endpoints() core.Endpoints {
+	host := n.Host
+	if host == "" {
+		host = "localhost"
+	}
+	e := core.Endpoints{
+		CometRPC:   fmt.Sprintf("http://%s:26657", host),
+		CosmosREST: fmt.Sprintf("http://%s:1317", host),
+		EVMRPC:     fmt.Sprintf("http://%s:8545", host),
+	}
+	if n.CometRPC != "" {
+		e.CometRPC = n.CometRPC
+	}
+	if n.CosmosREST != "" {
+		e.CosmosREST = n.CosmosREST
+	}
+	if n.EVMRPC != "" {
+		e.EVMRPC = n.EVMRPC
+	}
+	return e
+}
+
+// IsLocal reports whether the node runs on this machine, so its service
+// can be inspected.
+func (n Node) IsLocal() bool {
+	if n.SSH != "" {
+		return false
+	}
+	switch n.Host {
+	case "", "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
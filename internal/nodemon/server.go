@@ -0,0 +1,351 @@
+// Package nodemon is a self-hosted node-monitor API. It implements the
+// endpoints monoctl's monitor commands use (/v1/register/start,
+// /v1/heartbeat, /v1/visibility and /v1/alert) so private fleets can run
+// without the hosted service.
+package nodemon
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+)
+
+// Health values reported in heartbeat responses.
+const (
+	HealthHealthy = "healthy"
+	HealthLagging = "lagging"
+	HealthSyncing = "syncing"
+)
+
+// Server defaults.
+const (
+	DefaultStaleAfter   = 5 * time.Minute
+	DefaultLagThreshold = 10
+	linkTokenTTL        = 15 * time.Minute
+)
+
+// Server serves the node-monitor API.
+type Server struct {
+	store Store
+	// mu serializes updates so concurrent requests for one node do not
+	// overwrite each other's changes
+	mu sync.Mutex
+
+	// MaxClockSkew is how far a signed timestamp may be from the server's
+	// clock.
+	MaxClockSkew time.Duration
+	// StaleAfter excludes nodes whose last heartbeat is older than this
+	// from the canonical height.
+	StaleAfter time.Duration
+	// LagThreshold is the number of blocks behind the canonical height
+	// at which a node is reported as lagging.
+	LagThreshold int64
+	// Nonces rejects replayed requests.
+	Nonces core.NonceStore
+	// Log receives one line per request handled; nil discards it.
+	Log io.Writer
+
+	now func() time.Time
+}
+
+// NewServer creates a server backed by store.
+func NewServer(store Store) *Server {
+	return &Server{
+		store:        store,
+		MaxClockSkew: core.DefaultMonitorMaxSkew,
+		Nonces:       core.NewMemoryNonceStore(),
+		StaleAfter:   DefaultStaleAfter,
+		LagThreshold: DefaultLagThreshold,
+		now:          time.Now,
+	}
+}
+
+// Handler returns the API's HTTP handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/register/start", s.handleRegister)
+	mux.HandleFunc("POST /v1/heartbeat", s.handleHeartbeat)
+	mux.HandleFunc("POST /v1/visibility", s.handleVisibility)
+	mux.HandleFunc("POST /v1/alert", s.handleAlert)
+	mux.HandleFunc("GET /v1/nodes", s.handleNodes)
+	return mux
+}
+
+func (s *Server) handleRegister(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req core.RegistrationStartRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.NodeID == "" || req.Network == "" {
+		http.Error(w, "node_id and network are required", http.StatusBadRequest)
+		return
+	}
+	if key, err := base64.StdEncoding.DecodeString(req.PublicKey); err != nil || len(key) != ed25519.PublicKeySize {
+		http.Error(w, "public_key must be a base64 ed25519 public key", http.StatusBadRequest)
+		return
+	}
+
+	rec, err := s.store.Get(req.Network, req.NodeID)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	if rec == nil {
+		rec = &NodeRecord{NodeID: req.NodeID, Network: req.Network, Visibility: "private", RegisteredAt: s.now()}
+	} else if rec.PublicKey != req.PublicKey {
+		// Registration is not signed, so never let it replace a key
+		http.Error(w, "node is already registered with a different public key", http.StatusConflict)
+		return
+	}
+	rec.PublicKey = req.PublicKey
+	rec.Moniker = req.Moniker
+	rec.Role = req.Role
+	if err := s.store.Put(rec); err != nil {
+		s.fail(w, err)
+		return
+	}
+
+	// There is no account to link locally, so the node is registered
+	// immediately; the token is returned for compatibility
+	token := make([]byte, 16)
+	rand.Read(token)
+	s.logf("register %s/%s moniker=%q role=%s", req.Network, req.NodeID, req.Moniker, req.Role)
+	writeJSON(w, core.RegistrationStartResponse{
+		LinkToken: hex.EncodeToString(token),
+		ExpiresAt: s.now().Add(linkTokenTTL).UTC(),
+	})
+}
+
+func (s *Server) handleHeartbeat(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var hb core.HeartbeatPayload
+	if !decode(w, r, &hb) {
+		return
+	}
+	rec, ok := s.authenticate(w, hb.NodeID, hb.Network, func(opts core.VerifyOptions) error {
+		return core.VerifyHeartbeat(&hb, opts)
+	})
+	if !ok {
+		return
+	}
+
+	now := s.now()
+	canonical := hb.Status.Height
+	nodes, err := s.store.List(hb.Network)
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	for _, n := range nodes {
+		if n.NodeID != hb.NodeID && n.Status != nil && now.Sub(n.LastHeartbeat) <= s.StaleAfter &&
+			n.Status.Height > canonical {
+			canonical = n.Status.Height
+		}
+	}
+	lag := canonical - hb.Status.Height
+
+	health := HealthHealthy
+	switch {
+	case hb.Status.CatchingUp:
+		health = HealthSyncing
+	case lag > s.LagThreshold:
+		health = HealthLagging
+	}
+
+	rec.LastHeartbeat = now
+	rec.Status = &hb.Status
+	rec.Capabilities = &hb.Capabilities
+	rec.Health = health
+	rec.LagBlocks = lag
+	if err := s.store.Put(rec); err != nil {
+		s.fail(w, err)
+		return
+	}
+
+	s.logf("heartbeat %s/%s height=%d lag=%d health=%s", hb.Network, hb.NodeID, hb.Status.Height, lag, health)
+	writeJSON(w, core.HeartbeatResponse{
+		Success:         true,
+		Health:          health,
+		LagBlocks:       lag,
+		CanonicalHeight: canonical,
+	})
+}
+
+// visibilityRequest is the body core.SetVisibility sends.
+type visibilityRequest struct {
+	NodeID        string `json:"node_id"`
+	Network       string `json:"network"`
+	Visibility    string `json:"visibility"`
+	TimestampUnix int64  `json:"timestamp_unix"`
+	Nonce         string `json:"nonce"`
+	Signature     string `json:"signature"`
+}
+
+func (s *Server) handleVisibility(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var req visibilityRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	if req.Visibility != "public" && req.Visibility != "private" {
+		http.Error(w, "visibility must be public or private", http.StatusBadRequest)
+		return
+	}
+	rec, ok := s.authenticate(w, req.NodeID, req.Network, func(opts core.VerifyOptions) error {
+		return core.VerifySignedMessage(req.NodeID, req.Network, req.TimestampUnix, req.Nonce, req.Signature, opts)
+	})
+	if !ok {
+		return
+	}
+
+	rec.Visibility = req.Visibility
+	if err := s.store.Put(rec); err != nil {
+		s.fail(w, err)
+		return
+	}
+	s.logf("visibility %s/%s %s", req.Network, req.NodeID, req.Visibility)
+	writeJSON(w, core.VisibilityResponse{Success: true, NodeID: rec.NodeID, Visibility: rec.Visibility})
+}
+
+// alertRequest keeps the alert as received, so its hash can be checked
+// against the signed alert_hash.
+type alertRequest struct {
+	core.AlertPayload
+	Alert json.RawMessage `json:"alert"`
+}
+
+func (s *Server) handleAlert(w http.ResponseWriter, r *http.Request) {
+	var req alertRequest
+	if !decode(w, r, &req) {
+		return
+	}
+	payload := req.AlertPayload
+	if err := json.Unmarshal(req.Alert, &payload.Alert); err != nil {
+		http.Error(w, "invalid alert: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, ok := s.authenticate(w, payload.NodeID, payload.Network, func(opts core.VerifyOptions) error {
+		return core.VerifyAlert(&payload, req.Alert, opts)
+	}); !ok {
+		return
+	}
+	s.logf("alert %s/%s %s %s: %s", payload.Network, payload.NodeID,
+		payload.Alert.Severity, payload.Alert.Kind, payload.Alert.Message)
+	writeJSON(w, map[string]bool{"success": true})
+}
+
+// PublicNode is a node listed by GET /v1/nodes. Only nodes that opted in to
+// public visibility are listed.
+type PublicNode struct {
+	NodeID        string    `json:"node_id"`
+	Network       string    `json:"network"`
+	Moniker       string    `json:"moniker"`
+	Role          string    `json:"role"`
+	Health        string    `json:"health,omitempty"`
+	Height        int64     `json:"height"`
+	LagBlocks     int64     `json:"lag_blocks"`
+	VersionMonod  string    `json:"version_monod,omitempty"`
+	LastHeartbeat time.Time `json:"last_heartbeat,omitempty"`
+}
+
+func (s *Server) handleNodes(w http.ResponseWriter, r *http.Request) {
+	nodes, err := s.store.List(r.URL.Query().Get("network"))
+	if err != nil {
+		s.fail(w, err)
+		return
+	}
+	list := []PublicNode{}
+	for _, n := range nodes {
+		if n.Visibility != "public" {
+			continue
+		}
+		p := PublicNode{
+			NodeID:        n.NodeID,
+			Network:       n.Network,
+			Moniker:       n.Moniker,
+			Role:          n.Role,
+			Health:        n.Health,
+			LagBlocks:     n.LagBlocks,
+			LastHeartbeat: n.LastHeartbeat,
+		}
+		if n.Status != nil {
+			p.Height = n.Status.Height
+			p.VersionMonod = n.Status.VersionMonod
+		}
+		list = append(list, p)
+	}
+	writeJSON(w, list)
+}
+
+// authenticate looks up a registered node and verifies a signed request
+// from it with verify. It writes the error response and returns false on
+// failure.
+func (s *Server) authenticate(w http.ResponseWriter, nodeID, network string, verify func(core.VerifyOptions) error) (*NodeRecord, bool) {
+	rec, err := s.store.Get(network, nodeID)
+	if err != nil {
+		s.fail(w, err)
+		return nil, false
+	}
+	if rec == nil {
+		http.Error(w, "node is not registered", http.StatusNotFound)
+		return nil, false
+	}
+
+	pubKey, err := base64.StdEncoding.DecodeString(rec.PublicKey)
+	if err != nil {
+		s.fail(w, fmt.Errorf("stored public key for %s is invalid: %w", nodeID, err))
+		return nil, false
+	}
+	err = verify(core.VerifyOptions{
+		PublicKey: pubKey,
+		MaxSkew:   s.MaxClockSkew,
+		Nonces:    s.Nonces,
+		Now:       s.now(),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	return rec, true
+}
+
+func (s *Server) fail(w http.ResponseWriter, err error) {
+	s.logf("error: %v", err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
+
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.Log != nil {
+		fmt.Fprintf(s.Log, "%s %s\n", s.now().UTC().Format(time.RFC3339), fmt.Sprintf(format, args...))
+	}
+}
+
+// decode reads a JSON request body, writing a 400 response on failure.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<20)).Decode(v); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
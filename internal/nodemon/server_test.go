@@ -0,0 +1,181 @@
+package nodemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+)
+
+func newTestKeys(t *testing.T, nodeID string) *core.MonitorKeys {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &core.MonitorKeys{NodeID: nodeID, PublicKey: pub, PrivateKey: priv}
+}
+
+func heartbeat(t *testing.T, api string, keys *core.MonitorKeys, height int64) (*core.HeartbeatResponse, error) {
+	t.Helper()
+	payload, err := core.SignHeartbeat(keys, "Sprintnet", &core.MonitorStatus{Height: height}, &core.MonitorCapabilities{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return core.SendHeartbeat(context.Background(), api, payload)
+}
+
+// TestServer_Protocol drives the server with the same client functions the
+// monitor commands use.
+func TestServer_Protocol(t *testing.T) {
+	storePath := filepath.Join(t.TempDir(), "nodemon.json")
+	store, err := OpenFileStore(storePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log bytes.Buffer
+	s := NewServer(store)
+	s.Log = &log
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+	ctx := context.Background()
+
+	a := newTestKeys(t, "node-a")
+	b := newTestKeys(t, "node-b")
+
+	// Heartbeats from unregistered nodes are rejected
+	if _, err := heartbeat(t, srv.URL, a, 100); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Fatalf("unregistered heartbeat error = %v, want HTTP 404", err)
+	}
+
+	for _, k := range []*core.MonitorKeys{a, b} {
+		resp, err := core.StartRegistration(ctx, srv.URL, k, "Sprintnet", k.NodeID, "fullnode")
+		if err != nil {
+			t.Fatalf("StartRegistration(%s) error = %v", k.NodeID, err)
+		}
+		if resp.LinkToken == "" || !resp.ExpiresAt.After(time.Now()) {
+			t.Errorf("unexpected registration response %+v", resp)
+		}
+	}
+
+	// Re-registering with another key is refused
+	if _, err := core.StartRegistration(ctx, srv.URL, newTestKeys(t, "node-a"), "Sprintnet", "x", "fullnode"); err == nil ||
+		!strings.Contains(err.Error(), "409") {
+		t.Errorf("re-registration error = %v, want HTTP 409", err)
+	}
+
+	payload, _ := core.SignHeartbeat(a, "Sprintnet", &core.MonitorStatus{Height: 1000}, &core.MonitorCapabilities{})
+	resp, err := core.SendHeartbeat(ctx, srv.URL, payload)
+	if err != nil {
+		t.Fatalf("heartbeat error = %v", err)
+	}
+	if _, err := core.SendHeartbeat(ctx, srv.URL, payload); err == nil || !strings.Contains(err.Error(), "nonce already used") {
+		t.Errorf("replayed heartbeat error = %v, want nonce rejection", err)
+	}
+	if resp.CanonicalHeight != 1000 || resp.LagBlocks != 0 || resp.Health != HealthHealthy {
+		t.Errorf("first heartbeat = %+v", resp)
+	}
+
+	// Lag is measured against the highest recent heartbeat of the network
+	resp, err = heartbeat(t, srv.URL, b, 950)
+	if err != nil {
+		t.Fatalf("heartbeat error = %v", err)
+	}
+	if resp.CanonicalHeight != 1000 || resp.LagBlocks != 50 || resp.Health != HealthLagging {
+		t.Errorf("lagging heartbeat = %+v", resp)
+	}
+
+	// A heartbeat signed by another key fails verification
+	forged := &core.MonitorKeys{NodeID: "node-a", PublicKey: b.PublicKey, PrivateKey: b.PrivateKey}
+	if _, err := heartbeat(t, srv.URL, forged, 2000); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("forged heartbeat error = %v, want HTTP 401", err)
+	}
+
+	if _, err := core.SetVisibility(ctx, srv.URL, a, "Sprintnet", "public"); err != nil {
+		t.Fatalf("SetVisibility error = %v", err)
+	}
+
+	alert, _ := core.SignAlert(a, "Sprintnet", core.MonitorAlert{Kind: "gov_pending_votes", Severity: "warning", Message: "1 vote pending"})
+	if err := core.SendAlert(ctx, srv.URL, alert); err != nil {
+		t.Fatalf("SendAlert error = %v", err)
+	}
+	if !strings.Contains(log.String(), "alert Sprintnet/node-a warning gov_pending_votes: 1 vote pending") {
+		t.Errorf("alert not logged:\n%s", log.String())
+	}
+
+	// The signature covers the alert body
+	tampered, _ := core.SignAlert(a, "Sprintnet", core.MonitorAlert{Kind: "gov_pending_votes", Severity: "info", Message: "ok"})
+	tampered.Alert.Message = "validator jailed"
+	if err := core.SendAlert(ctx, srv.URL, tampered); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("tampered alert error = %v, want HTTP 401", err)
+	}
+
+	// Only the public node is listed
+	httpResp, err := http.Get(srv.URL + "/v1/nodes?network=sprintnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer httpResp.Body.Close()
+	var nodes []PublicNode
+	json.NewDecoder(httpResp.Body).Decode(&nodes)
+	if len(nodes) != 1 || nodes[0].NodeID != "node-a" || nodes[0].Height != 1000 {
+		t.Errorf("nodes = %+v", nodes)
+	}
+
+	// Records survive a restart
+	reopened, err := OpenFileStore(storePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec, _ := reopened.Get("sprintnet", "node-b")
+	if rec == nil || rec.Status == nil || rec.Status.Height != 950 || rec.Health != HealthLagging {
+		t.Errorf("reopened record = %+v", rec)
+	}
+}
+
+func TestServer_ClockSkew(t *testing.T) {
+	store, _ := OpenFileStore("")
+	s := NewServer(store)
+	s.now = func() time.Time { return time.Now().Add(time.Hour) }
+	srv := httptest.NewServer(s.Handler())
+	defer srv.Close()
+
+	keys := newTestKeys(t, "node-a")
+	if _, err := core.StartRegistration(context.Background(), srv.URL, keys, "Sprintnet", "a", "fullnode"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := heartbeat(t, srv.URL, keys, 1); err == nil || !strings.Contains(err.Error(), "clock skew") {
+		t.Errorf("heartbeat error = %v, want clock skew rejection", err)
+	}
+}
+
+func TestServer_StaleHeightsIgnored(t *testing.T) {
+	store, _ := OpenFileStore("")
+	store.Put(&NodeRecord{
+		NodeID:        "old",
+		Network:       "Sprintnet",
+		LastHeartbeat: time.Now().Add(-time.Hour),
+		Status:        &core.HeartbeatStatus{Height: 5000},
+	})
+	srv := httptest.NewServer(NewServer(store).Handler())
+	defer srv.Close()
+
+	keys := newTestKeys(t, "node-a")
+	core.StartRegistration(context.Background(), srv.URL, keys, "Sprintnet", "a", "fullnode")
+	resp, err := heartbeat(t, srv.URL, keys, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.CanonicalHeight != 100 || resp.Health != HealthHealthy {
+		t.Errorf("heartbeat = %+v, want the stale node ignored", resp)
+	}
+}
@@ -0,0 +1,143 @@
+package nodemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+)
+
+// NodeRecord is a registered node and its latest heartbeat.
+type NodeRecord struct {
+	NodeID       string    `json:"node_id"`
+	Network      string    `json:"network"`
+	Moniker      string    `json:"moniker"`
+	Role         string    `json:"role"`
+	PublicKey    string    `json:"public_key"` // base64 ed25519 key heartbeats are verified against
+	Visibility   string    `json:"visibility"` // public or private
+	RegisteredAt time.Time `json:"registered_at"`
+
+	LastHeartbeat time.Time                 `json:"last_heartbeat,omitempty"`
+	Status        *core.HeartbeatStatus     `json:"status,omitempty"`
+	Capabilities  *core.MonitorCapabilities `json:"capabilities,omitempty"`
+	Health        string                    `json:"health,omitempty"`
+	LagBlocks     int64                     `json:"lag_blocks"`
+}
+
+// Store persists node records. Networks are matched case-insensitively.
+type Store interface {
+	// Get returns the node, or nil if it is not registered.
+	Get(network, nodeID string) (*NodeRecord, error)
+	Put(rec *NodeRecord) error
+	// List returns the nodes of a network, or of all networks if network
+	// is empty, ordered by network and node ID.
+	List(network string) ([]*NodeRecord, error)
+}
+
+// FileStore keeps all records in memory and rewrites a JSON file on every
+// change. It suits fleets of up to a few hundred nodes.
+type FileStore struct {
+	path  string
+	mu    sync.Mutex
+	nodes map[string]*NodeRecord
+}
+
+// OpenFileStore loads the store at path, which is created on the first
+// write. An empty path keeps the records in memory only.
+func OpenFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, nodes: map[string]*NodeRecord{}}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store: %w", err)
+	}
+	var nodes []*NodeRecord
+	if err := json.Unmarshal(data, &nodes); err != nil {
+		return nil, fmt.Errorf("failed to parse store %s: %w", path, err)
+	}
+	for _, n := range nodes {
+		s.nodes[storeKey(n.Network, n.NodeID)] = n
+	}
+	return s, nil
+}
+
+func storeKey(network, nodeID string) string {
+	return strings.ToLower(network) + "/" + nodeID
+}
+
+// Get implements Store.
+func (s *FileStore) Get(network, nodeID string) (*NodeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, ok := s.nodes[storeKey(network, nodeID)]
+	if !ok {
+		return nil, nil
+	}
+	rec := *n
+	return &rec, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(rec *NodeRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *rec
+	s.nodes[storeKey(rec.Network, rec.NodeID)] = &stored
+	return s.save()
+}
+
+// List implements Store.
+func (s *FileStore) List(network string) ([]*NodeRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.list(network), nil
+}
+
+func (s *FileStore) list(network string) []*NodeRecord {
+	var nodes []*NodeRecord
+	for _, n := range s.nodes {
+		if network == "" || strings.EqualFold(n.Network, network) {
+			rec := *n
+			nodes = append(nodes, &rec)
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return storeKey(nodes[i].Network, nodes[i].NodeID) < storeKey(nodes[j].Network, nodes[j].NodeID)
+	})
+	return nodes
+}
+
+// save writes the records to a temporary file and renames it over the
+// store so a crash never leaves a truncated file. Callers hold s.mu.
+func (s *FileStore) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s.list(""), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create store directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write store: %w", err)
+	}
+	return nil
+}
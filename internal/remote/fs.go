@@ -0,0 +1,136 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// notExistStatus is the exit status FS commands use for a missing path.
+const notExistStatus = 44
+
+// FS reads and writes files on the remote host with shell commands over
+// the client's connection. It satisfies core.FS, so node-home operations
+// can run against a remote home. Relative paths are relative to the
+// remote user's home directory. Stat and WriteFile use GNU stat (Linux)
+// and fall back to BSD stat (macOS, FreeBSD) when stat -c is missing.
+type FS struct {
+	client *Client
+}
+
+// NewFS returns an FS on c's host.
+func NewFS(c *Client) *FS {
+	return &FS{client: c}
+}
+
+// run runs cmd, mapping notExistStatus to fs.ErrNotExist.
+func (f *FS) run(op, name, cmd string, stdin []byte, stdout *bytes.Buffer) error {
+	var in io.Reader
+	if stdin != nil {
+		in = bytes.NewReader(stdin)
+	}
+	var out io.Writer
+	if stdout != nil {
+		out = stdout
+	}
+	err := f.client.Run(context.Background(), cmd, in, out, nil)
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) && exitErr.Code == notExistStatus {
+		return &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	if err != nil {
+		return &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	return nil
+}
+
+// ifExists prefixes cmd with a check that p exists.
+func ifExists(p, cmd string) string {
+	return fmt.Sprintf("[ -e %s ] || exit %d; %s", ShellQuote(p), notExistStatus, cmd)
+}
+
+func (f *FS) ReadFile(name string) ([]byte, error) {
+	var out bytes.Buffer
+	if err := f.run("open", name, ifExists(name, "cat -- "+ShellQuote(name)), nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// statCmd runs GNU stat with gnuFormat on p, or BSD stat with bsdFormat
+// where stat -c is not supported.
+func statCmd(p, gnuFormat, bsdFormat string) string {
+	q := ShellQuote(p)
+	return fmt.Sprintf("if stat -c %%s / >/dev/null 2>&1; then stat -L -c '%s' -- %s; else stat -L -f '%s' -- %s; fi",
+		gnuFormat, q, bsdFormat, q)
+}
+
+// WriteFile behaves like os.WriteFile, except that the data goes to a
+// sibling temp file that replaces name, so a failed transfer never leaves
+// a truncated file. The directory must exist, and an existing file keeps
+// its mode.
+func (f *FS) WriteFile(name string, data []byte, perm os.FileMode) error {
+	q, tmp := ShellQuote(name), ShellQuote(name+".write")
+	cmd := fmt.Sprintf("mode=%o; if [ -e %s ]; then mode=$(%s) || exit 1; fi; "+
+		"cat > %s && chmod \"$mode\" %s && mv -f %s %s || { rm -f %s; exit 1; }",
+		perm.Perm(), q, statCmd(name, "%a", "%Lp"), tmp, tmp, tmp, q, tmp)
+	return f.run("open", name, ifExists(path.Dir(name), cmd), data, nil)
+}
+
+func (f *FS) Stat(name string) (os.FileInfo, error) {
+	var out bytes.Buffer
+	if err := f.run("stat", name, ifExists(name, statCmd(name, "%s %f %Y", "%z %Xp %m")), nil, &out); err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(out.String())
+	if len(fields) != 3 {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fmt.Errorf("unexpected stat output %q", out.String())}
+	}
+	size, err1 := strconv.ParseInt(fields[0], 10, 64)
+	rawMode, err2 := strconv.ParseUint(fields[1], 16, 32)
+	mtime, err3 := strconv.ParseInt(fields[2], 10, 64)
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+	}
+
+	mode := os.FileMode(rawMode & 0777)
+	if rawMode&0170000 == 0040000 {
+		mode |= fs.ModeDir
+	}
+	return fileInfo{name: path.Base(name), size: size, mode: mode, modTime: time.Unix(mtime, 0)}, nil
+}
+
+func (f *FS) MkdirAll(p string, perm os.FileMode) error {
+	return f.run("mkdir", p, fmt.Sprintf("mkdir -p -m %o -- %s", perm.Perm(), ShellQuote(p)), nil, nil)
+}
+
+func (f *FS) Remove(name string) error {
+	q := ShellQuote(name)
+	return f.run("remove", name, ifExists(name, fmt.Sprintf("if [ -d %s ]; then rmdir -- %s; else rm -f -- %s; fi", q, q, q)), nil, nil)
+}
+
+func (f *FS) RemoveAll(p string) error {
+	return f.run("remove", p, "rm -rf -- "+ShellQuote(p), nil, nil)
+}
+
+type fileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi fileInfo) Name() string       { return fi.name }
+func (fi fileInfo) Size() int64        { return fi.size }
+func (fi fileInfo) Mode() os.FileMode  { return fi.mode }
+func (fi fileInfo) ModTime() time.Time { return fi.modTime }
+func (fi fileInfo) IsDir() bool        { return fi.mode.IsDir() }
+func (fi fileInfo) Sys() interface{}   { return nil }
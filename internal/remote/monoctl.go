@@ -0,0 +1,120 @@
+package remote
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// BinDir is where copied monoctl binaries are kept, relative to the
+// remote user's home directory.
+const BinDir = ".mono-commander/bin"
+
+// Platform returns the remote GOOS/GOARCH, e.g. "linux/amd64".
+func (c *Client) Platform(ctx context.Context) (string, error) {
+	out, err := c.Output(ctx, "uname -sm")
+	if err != nil {
+		return "", err
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return "", fmt.Errorf("unexpected uname output %q", strings.TrimSpace(string(out)))
+	}
+	goos := strings.ToLower(fields[0])
+	goarch := fields[1]
+	switch goarch {
+	case "x86_64", "amd64":
+		goarch = "amd64"
+	case "aarch64", "arm64":
+		goarch = "arm64"
+	}
+	return goos + "/" + goarch, nil
+}
+
+// EnsureBinary copies the local monoctl binary at localPath to the remote
+// host, unless the same build is already there, and returns its remote
+// path. Binaries are named by content hash, so hosts never run a stale
+// copy and repeated runs upload nothing.
+func EnsureBinary(ctx context.Context, c *Client, localPath string) (string, error) {
+	f, err := os.Open(localPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open local binary: %w", err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to hash local binary: %w", err)
+	}
+	remotePath := fmt.Sprintf("%s/monoctl-%s", BinDir, hex.EncodeToString(h.Sum(nil))[:16])
+
+	err = c.Run(ctx, "test -x "+ShellQuote(remotePath), nil, nil, nil)
+	if err == nil {
+		return remotePath, nil
+	}
+	var exitErr *ExitError
+	if !errors.As(err, &exitErr) {
+		return "", err
+	}
+
+	platform, err := c.Platform(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to detect remote platform: %w", err)
+	}
+	if local := runtime.GOOS + "/" + runtime.GOARCH; platform != local {
+		return "", fmt.Errorf("%s is %s but this monoctl is built for %s; install monoctl there and pass its path instead",
+			c.target, platform, local)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	if err := c.Upload(ctx, remotePath, f, 0755); err != nil {
+		return "", err
+	}
+	return remotePath, nil
+}
+
+// RunMonoctl runs binary with args on the remote host. A non-zero exit is
+// returned as *ExitError so callers can pass the status on.
+func RunMonoctl(ctx context.Context, c *Client, binary string, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, ShellQuote(binary))
+	for _, a := range args {
+		quoted = append(quoted, ShellQuote(a))
+	}
+	return c.Run(ctx, strings.Join(quoted, " "), stdin, stdout, stderr)
+}
+
+// StripFlags removes the named flags and their values from args, in both
+// "--name value" and "--name=value" form. Arguments after "--" are kept
+// as they are.
+func StripFlags(args []string, names ...string) []string {
+	strip := map[string]bool{}
+	for _, n := range names {
+		strip["--"+n] = true
+	}
+
+	out := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "--" {
+			return append(out, args[i:]...)
+		}
+		if strip[a] {
+			i++ // skip the value
+			continue
+		}
+		if eq := strings.Index(a, "="); eq > 0 && strip[a[:eq]] {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
@@ -0,0 +1,242 @@
+package remote_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monolythium/mono-commander/internal/core"
+	"github.com/monolythium/mono-commander/internal/remote"
+	"github.com/monolythium/mono-commander/internal/remote/sshtest"
+)
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    remote.Target
+		wantErr bool
+	}{
+		{"monod@node1", remote.Target{User: "monod", Host: "node1", Port: 22}, false},
+		{"monod@node1:2222", remote.Target{User: "monod", Host: "node1", Port: 2222}, false},
+		{"monod@[::1]:2222", remote.Target{User: "monod", Host: "::1", Port: 2222}, false},
+		{"monod@10.0.0.1", remote.Target{User: "monod", Host: "10.0.0.1", Port: 22}, false},
+		{"@node1", remote.Target{}, true},
+		{"monod@", remote.Target{}, true},
+		{"monod@node1:ssh", remote.Target{}, true},
+		{"monod@node1:70000", remote.Target{}, true},
+	}
+	for _, tt := range tests {
+		got, err := remote.ParseTarget(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTarget(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("ParseTarget(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+
+	if got, err := remote.ParseTarget("node1"); err != nil || got.User == "" {
+		t.Errorf("ParseTarget(node1) = %+v, %v; want the local user", got, err)
+	}
+}
+
+func TestStripFlags(t *testing.T) {
+	args := []string{"--ssh", "monod@node1", "config", "doctor", "--ssh-key=/k", "--network", "Sprintnet", "--", "--ssh", "x"}
+	got := remote.StripFlags(args, "ssh", "ssh-key")
+	want := []string{"config", "doctor", "--network", "Sprintnet", "--", "--ssh", "x"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("StripFlags() = %q, want %q", got, want)
+	}
+}
+
+func dial(t *testing.T, s *sshtest.Server) *remote.Client {
+	t.Helper()
+	c, err := remote.Dial(s.Config())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestClient_Run(t *testing.T) {
+	s := sshtest.NewServer(t)
+	c := dial(t, s)
+	ctx := context.Background()
+
+	var stdout bytes.Buffer
+	err := c.Run(ctx, "cat; echo \"$HOME\"", strings.NewReader("piped\n"), &stdout, nil)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if want := "piped\n" + s.Home + "\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+
+	err = c.Run(ctx, "echo oops >&2; exit 3", nil, nil, nil)
+	var exitErr *remote.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 || exitErr.Stderr != "oops\n" {
+		t.Errorf("Run() error = %#v, want exit status 3 with stderr", err)
+	}
+
+	// Arguments survive the remote shell unchanged
+	arg := `it's "quoted" $HOME; rm -rf /`
+	out, err := c.Output(ctx, "printf %s "+remote.ShellQuote(arg))
+	if err != nil || string(out) != arg {
+		t.Errorf("quoted argument = %q, %v; want %q", out, err, arg)
+	}
+
+	platform, err := c.Platform(ctx)
+	if err != nil || platform != runtime.GOOS+"/"+runtime.GOARCH {
+		t.Errorf("Platform() = %q, %v", platform, err)
+	}
+}
+
+func TestDial_Rejects(t *testing.T) {
+	s := sshtest.NewServer(t)
+
+	cfg := s.Config()
+	cfg.KnownHostsFile = filepath.Join(t.TempDir(), "empty")
+	os.WriteFile(cfg.KnownHostsFile, nil, 0644)
+	if _, err := remote.Dial(cfg); err == nil || !strings.Contains(err.Error(), "ssh-keyscan") {
+		t.Errorf("unknown host error = %v", err)
+	}
+
+	other := sshtest.NewServer(t)
+	cfg = s.Config()
+	cfg.IdentityFiles = []string{other.IdentityFile}
+	if _, err := remote.Dial(cfg); err == nil {
+		t.Error("expected an unauthorized key to be rejected")
+	}
+}
+
+func TestEnsureBinary(t *testing.T) {
+	s := sshtest.NewServer(t)
+	c := dial(t, s)
+	ctx := context.Background()
+
+	local := filepath.Join(t.TempDir(), "monoctl")
+	os.WriteFile(local, []byte("#!/bin/sh\necho \"remote monoctl $*\"\nexit 4\n"), 0755)
+
+	path, err := remote.EnsureBinary(ctx, c, local)
+	if err != nil {
+		t.Fatalf("EnsureBinary() error = %v", err)
+	}
+	if !strings.HasPrefix(path, remote.BinDir+"/monoctl-") {
+		t.Errorf("remote path = %s", path)
+	}
+	info, err := os.Stat(filepath.Join(s.Home, path))
+	if err != nil || info.Mode().Perm() != 0755 {
+		t.Fatalf("uploaded binary = %v, %v", info, err)
+	}
+
+	// The same build is not uploaded again
+	os.Chtimes(filepath.Join(s.Home, path), info.ModTime().Add(-time.Second), info.ModTime().Add(-time.Second))
+	if again, err := remote.EnsureBinary(ctx, c, local); err != nil || again != path {
+		t.Fatalf("second EnsureBinary() = %s, %v", again, err)
+	}
+	if info2, _ := os.Stat(filepath.Join(s.Home, path)); !info2.ModTime().Before(info.ModTime()) {
+		t.Error("binary was uploaded again")
+	}
+
+	var stdout bytes.Buffer
+	err = remote.RunMonoctl(ctx, c, path, []string{"config", "doctor", "--home", "my home"}, nil, &stdout, nil)
+	var exitErr *remote.ExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 4 {
+		t.Errorf("RunMonoctl() error = %v, want exit status 4", err)
+	}
+	if stdout.String() != "remote monoctl config doctor --home my home\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestFS(t *testing.T) {
+	s := sshtest.NewServer(t)
+	fsys := remote.NewFS(dial(t, s))
+	var _ core.FS = fsys
+
+	if _, err := fsys.ReadFile("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("ReadFile(missing) error = %v, want ErrNotExist", err)
+	}
+	if err := fsys.WriteFile("no/dir/file", []byte("x"), 0644); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("WriteFile() without parent error = %v, want ErrNotExist", err)
+	}
+	if err := fsys.MkdirAll("node home/config", 0755); err != nil {
+		t.Fatal(err)
+	}
+	name := "node home/config/it's.toml"
+	if err := fsys.WriteFile(name, []byte("a = 1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := fsys.ReadFile(name); err != nil || string(data) != "a = 1\n" {
+		t.Errorf("ReadFile() = %q, %v", data, err)
+	}
+	info, err := fsys.Stat(name)
+	if err != nil || info.Mode().Perm() != 0600 || info.Size() != 6 || info.IsDir() {
+		t.Errorf("Stat() = %+v, %v", info, err)
+	}
+	if err := fsys.WriteFile(name, []byte("a = 22\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := fsys.Stat(name); err != nil || info.Mode().Perm() != 0600 || info.Size() != 7 {
+		t.Errorf("Stat() after overwrite = %+v, %v, want mode kept", info, err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(s.Home, "node home", "config")); len(entries) != 1 {
+		t.Errorf("WriteFile() left %d entries, want only the file", len(entries))
+	}
+	if info, err := fsys.Stat("node home/config"); err != nil || !info.IsDir() {
+		t.Errorf("Stat(dir) = %+v, %v", info, err)
+	}
+	if err := fsys.Remove("node home/config"); err == nil {
+		t.Error("Remove() of a non-empty directory should fail")
+	}
+	if err := fsys.Remove("missing"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Remove(missing) error = %v, want ErrNotExist", err)
+	}
+	if err := fsys.RemoveAll("node home"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(s.Home, "node home")); !os.IsNotExist(err) {
+		t.Errorf("RemoveAll() left %v", err)
+	}
+}
+
+// TestFS_ResetNodeHome resets a node home on the remote host.
+func TestFS_ResetNodeHome(t *testing.T) {
+	s := sshtest.NewServer(t)
+	home := filepath.Join(s.Home, ".monod")
+	os.MkdirAll(filepath.Join(home, "config"), 0755)
+	os.MkdirAll(filepath.Join(home, "data", "state.db"), 0755)
+	os.WriteFile(filepath.Join(home, "config", "config.toml"), []byte("[p2p]\n"), 0644)
+	os.WriteFile(filepath.Join(home, "config", "priv_validator_key.json"), []byte(`{"validator":"key"}`), 0600)
+
+	fsys := remote.NewFS(dial(t, s))
+	if !core.HasStaleDataFS(fsys, ".monod") {
+		t.Fatal("expected stale data before reset")
+	}
+	result, err := core.ResetNodeHome(fsys, ".monod", true)
+	if err != nil {
+		t.Fatalf("ResetNodeHome() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.KeysPreserved, []string{"priv_validator_key.json"}) {
+		t.Errorf("KeysPreserved = %v", result.KeysPreserved)
+	}
+	if _, err := os.Stat(filepath.Join(home, "data", "state.db")); !os.IsNotExist(err) {
+		t.Error("state.db survived the reset")
+	}
+	if _, err := os.Stat(filepath.Join(home, "config", "config.toml")); !os.IsNotExist(err) {
+		t.Error("config.toml survived the reset")
+	}
+	if data, _ := os.ReadFile(filepath.Join(home, "config", "priv_validator_key.json")); string(data) != `{"validator":"key"}` {
+		t.Errorf("validator key = %s", data)
+	}
+}
@@ -0,0 +1,307 @@
+// Package remote runs mono-commander operations on other hosts over SSH.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	gonet "net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// DefaultPort is the SSH port used when the target has none.
+const DefaultPort = 22
+
+// DefaultDialTimeout bounds the TCP connect and SSH handshake.
+const DefaultDialTimeout = 15 * time.Second
+
+// Target is a remote host in user@host[:port] form.
+type Target struct {
+	User string
+	Host string
+	Port int
+}
+
+// ParseTarget parses user@host[:port]. The user defaults to the local
+// user and the port to 22.
+func ParseTarget(s string) (Target, error) {
+	t := Target{Port: DefaultPort}
+	hostPort := s
+	if i := strings.LastIndex(s, "@"); i >= 0 {
+		t.User, hostPort = s[:i], s[i+1:]
+		if t.User == "" {
+			return Target{}, fmt.Errorf("invalid SSH target %q: empty user", s)
+		}
+	}
+
+	if host, port, err := gonet.SplitHostPort(hostPort); err == nil {
+		p, err := strconv.Atoi(port)
+		if err != nil || p < 1 || p > 65535 {
+			return Target{}, fmt.Errorf("invalid SSH target %q: bad port %q", s, port)
+		}
+		t.Host, t.Port = host, p
+	} else {
+		t.Host = strings.Trim(hostPort, "[]")
+	}
+	if t.Host == "" {
+		return Target{}, fmt.Errorf("invalid SSH target %q: empty host", s)
+	}
+
+	if t.User == "" {
+		u, err := user.Current()
+		if err != nil {
+			return Target{}, fmt.Errorf("invalid SSH target %q: no user and the local user is unknown: %w", s, err)
+		}
+		t.User = u.Username
+	}
+	return t, nil
+}
+
+// Addr returns host:port.
+func (t Target) Addr() string {
+	return gonet.JoinHostPort(t.Host, strconv.Itoa(t.Port))
+}
+
+// String returns user@host[:port].
+func (t Target) String() string {
+	if t.Port == DefaultPort {
+		return t.User + "@" + t.Host
+	}
+	return t.User + "@" + t.Addr()
+}
+
+// Config configures an SSH connection.
+type Config struct {
+	Target Target
+	// IdentityFiles are private key files to offer. If empty, the running
+	// ssh-agent and ~/.ssh/id_ed25519, ~/.ssh/id_ecdsa and ~/.ssh/id_rsa
+	// are used.
+	IdentityFiles []string
+	// KnownHostsFile verifies the host key (default: ~/.ssh/known_hosts).
+	// Unknown hosts are rejected; add them with ssh-keyscan first.
+	KnownHostsFile string
+	// DialTimeout defaults to DefaultDialTimeout.
+	DialTimeout time.Duration
+}
+
+// Client is a connection to a remote host.
+type Client struct {
+	target Target
+	conn   *ssh.Client
+}
+
+// Dial connects and authenticates to cfg.Target.
+func Dial(cfg Config) (*Client, error) {
+	homeDir, _ := os.UserHomeDir()
+
+	knownHostsFile := cfg.KnownHostsFile
+	if knownHostsFile == "" {
+		knownHostsFile = filepath.Join(homeDir, ".ssh", "known_hosts")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load known hosts: %w", err)
+	}
+
+	auth, err := authMethods(cfg.IdentityFiles, homeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.DialTimeout
+	if timeout == 0 {
+		timeout = DefaultDialTimeout
+	}
+	conn, err := ssh.Dial("tcp", cfg.Target.Addr(), &ssh.ClientConfig{
+		User:            cfg.Target.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         timeout,
+	})
+	if err != nil {
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) && len(keyErr.Want) == 0 {
+			return nil, fmt.Errorf("host %s is not in %s (add it with: ssh-keyscan -p %d %s >> %s)",
+				cfg.Target.Host, knownHostsFile, cfg.Target.Port, cfg.Target.Host, knownHostsFile)
+		}
+		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.Target, err)
+	}
+	return &Client{target: cfg.Target, conn: conn}, nil
+}
+
+// authMethods returns the explicit identity files, or the ssh-agent plus
+// the default key files.
+func authMethods(identityFiles []string, homeDir string) ([]ssh.AuthMethod, error) {
+	var signers []ssh.Signer
+	for _, path := range identityFiles {
+		signer, err := loadSigner(path)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, signer)
+	}
+	if len(identityFiles) > 0 {
+		return []ssh.AuthMethod{ssh.PublicKeys(signers...)}, nil
+	}
+
+	var methods []ssh.AuthMethod
+	if sock := os.Getenv("SSH_AUTH_SOCK"); sock != "" {
+		if conn, err := gonet.Dial("unix", sock); err == nil {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+	for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+		// Missing or passphrase-protected default keys are skipped; the
+		// agent is expected to hold protected keys
+		if signer, err := loadSigner(filepath.Join(homeDir, ".ssh", name)); err == nil {
+			signers = append(signers, signer)
+		}
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no SSH keys: start ssh-agent or pass an identity file")
+	}
+	return methods, nil
+}
+
+func loadSigner(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH key: %w", err)
+	}
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, fmt.Errorf("SSH key %s is passphrase-protected; add it to ssh-agent instead", path)
+		}
+		return nil, fmt.Errorf("failed to parse SSH key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// Target returns the host the client is connected to.
+func (c *Client) Target() Target {
+	return c.target
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// ExitError is returned when a remote command exits non-zero.
+type ExitError struct {
+	Command string
+	Code    int
+	Stderr  string
+}
+
+func (e *ExitError) Error() string {
+	msg := fmt.Sprintf("remote command %q exited with status %d", e.Command, e.Code)
+	if e.Stderr != "" {
+		msg += ": " + strings.TrimSpace(e.Stderr)
+	}
+	return msg
+}
+
+// Run runs cmd through the remote user's shell, wiring up stdin, stdout
+// and stderr (any may be nil). A non-zero exit is returned as *ExitError.
+// Cancelling ctx closes the session.
+func (c *Client) Run(ctx context.Context, cmd string, stdin io.Reader, stdout, stderr io.Writer) error {
+	session, err := c.conn.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to open SSH session: %w", err)
+	}
+	defer session.Close()
+
+	var errBuf bytes.Buffer
+	session.Stdin = stdin
+	session.Stdout = stdout
+	if stderr != nil {
+		session.Stderr = io.MultiWriter(stderr, &errBuf)
+	} else {
+		session.Stderr = &errBuf
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.Signal(ssh.SIGTERM)
+			session.Close()
+		case <-done:
+		}
+	}()
+
+	err = session.Run(cmd)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		stderrText := ""
+		if stderr == nil {
+			stderrText = errBuf.String()
+		}
+		return &ExitError{Command: cmd, Code: exitErr.ExitStatus(), Stderr: stderrText}
+	}
+	if err != nil {
+		return fmt.Errorf("remote command %q failed: %w", cmd, err)
+	}
+	return nil
+}
+
+// Output runs cmd and returns its stdout.
+func (c *Client) Output(ctx context.Context, cmd string) ([]byte, error) {
+	var out bytes.Buffer
+	err := c.Run(ctx, cmd, nil, &out, nil)
+	return out.Bytes(), err
+}
+
+// Upload writes r to path on the remote host with the given mode. The
+// file is written next to path and renamed, so a partial upload never
+// replaces an existing file. Parent directories are created.
+func (c *Client) Upload(ctx context.Context, path string, r io.Reader, mode os.FileMode) error {
+	tmp := path + ".upload"
+	cmd := fmt.Sprintf("mkdir -p %s && cat > %s && chmod %o %s && mv -f %s %s",
+		ShellQuote(dirOf(path)), ShellQuote(tmp), mode.Perm(), ShellQuote(tmp), ShellQuote(tmp), ShellQuote(path))
+	if err := c.Run(ctx, cmd, r, nil, nil); err != nil {
+		return fmt.Errorf("failed to upload %s: %w", path, err)
+	}
+	return nil
+}
+
+// dirOf is path.Dir for remote (always slash-separated) paths.
+func dirOf(p string) string {
+	i := strings.LastIndex(p, "/")
+	switch {
+	case i < 0:
+		return "."
+	case i == 0:
+		return "/"
+	}
+	return p[:i]
+}
+
+// ShellQuote quotes s for a POSIX shell.
+func ShellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:@,+") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,202 @@
+// Package sshtest provides an in-process SSH server for testing remote
+// operations, in the spirit of net/http/httptest.
+package sshtest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/monolythium/mono-commander/internal/remote"
+)
+
+// Server runs exec requests with sh in Home, as the user running the test.
+// Only the generated client key is accepted.
+type Server struct {
+	// Target is the server address, as user "test"
+	Target remote.Target
+	// Home is the working directory and $HOME of remote commands
+	Home string
+	// KnownHostsFile lists the server's host key
+	KnownHostsFile string
+	// IdentityFile is the client private key
+	IdentityFile string
+
+	listener net.Listener
+	config   *ssh.ServerConfig
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a server that is closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	dir := t.TempDir()
+	s := &Server{Home: filepath.Join(dir, "home")}
+	if err := os.MkdirAll(s.Home, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, hostPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostPriv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientPub, clientPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshClientPub, err := ssh.NewPublicKey(clientPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := ssh.MarshalPrivateKey(clientPriv, "sshtest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.IdentityFile = filepath.Join(dir, "id_ed25519")
+	if err := os.WriteFile(s.IdentityFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	s.config = &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(sshClientPub.Marshal()) {
+				return nil, errors.New("unknown key")
+			}
+			return nil, nil
+		},
+	}
+	s.config.AddHostKey(hostSigner)
+
+	s.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := s.listener.Addr().(*net.TCPAddr)
+	s.Target = remote.Target{User: "test", Host: "127.0.0.1", Port: addr.Port}
+
+	s.KnownHostsFile = filepath.Join(dir, "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(s.Target.Addr())}, hostSigner.PublicKey())
+	if err := os.WriteFile(s.KnownHostsFile, []byte(line+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Config returns a client config for the server.
+func (s *Server) Config() remote.Config {
+	return remote.Config{
+		Target:         s.Target,
+		IdentityFiles:  []string{s.IdentityFile},
+		KnownHostsFile: s.KnownHostsFile,
+	}
+}
+
+// Close stops accepting connections.
+func (s *Server) Close() {
+	s.listener.Close()
+	s.wg.Wait()
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handleConn(conn)
+	}
+}
+
+func (s *Server) handleConn(conn net.Conn) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			newChan.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+		ch, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go s.handleSession(ch, requests)
+	}
+}
+
+func (s *Server) handleSession(ch ssh.Channel, requests <-chan *ssh.Request) {
+	defer ch.Close()
+	for req := range requests {
+		if req.Type != "exec" || len(req.Payload) < 4 {
+			req.Reply(false, nil)
+			continue
+		}
+		n := binary.BigEndian.Uint32(req.Payload)
+		command := string(req.Payload[4 : 4+n])
+		req.Reply(true, nil)
+
+		status := s.exec(ch, command)
+		ch.SendRequest("exit-status", false, binary.BigEndian.AppendUint32(nil, uint32(status)))
+		return
+	}
+}
+
+func (s *Server) exec(ch ssh.Channel, command string) int {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Dir = s.Home
+	cmd.Env = append(os.Environ(), "HOME="+s.Home)
+	cmd.Stdout = ch
+	cmd.Stderr = ch.Stderr()
+
+	// Copy stdin without waiting for it, so commands that exit before
+	// reading all input don't hang
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return 255
+	}
+	if err := cmd.Start(); err != nil {
+		io.WriteString(ch.Stderr(), err.Error()+"\n")
+		return 127
+	}
+	go func() {
+		io.Copy(stdin, ch)
+		stdin.Close()
+	}()
+
+	err = cmd.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	if err != nil {
+		io.WriteString(ch.Stderr(), "sshtest: "+strconv.Quote(err.Error())+"\n")
+		return 255
+	}
+	return 0
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -60,6 +61,26 @@ type NetInfoResponse struct {
 	} `json:"result"`
 }
 
+// txResultResponse represents the /tx RPC response.
+type txResultResponse struct {
+	Result *struct {
+		Hash     string `json:"hash"`
+		Height   string `json:"height"`
+		TxResult struct {
+			Code      uint32    `json:"code"`
+			Codespace string    `json:"codespace"`
+			Log       string    `json:"log"`
+			GasWanted string    `json:"gas_wanted"`
+			GasUsed   string    `json:"gas_used"`
+			Events    []TxEvent `json:"events"`
+		} `json:"tx_result"`
+	} `json:"result"`
+	Error *struct {
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
 // Status fetches the node status from /status endpoint.
 func (c *CometClient) Status() (*StatusResponse, error) {
 	url := c.BaseURL + "/status"
@@ -127,3 +148,46 @@ func (c *CometClient) Health() error {
 
 	return nil
 }
+
+// GetTx fetches an included transaction by hash from the /tx endpoint.
+// ErrTxNotFound is returned while it is not in a block.
+func (c *CometClient) GetTx(hash string) (*TxResponse, error) {
+	url := c.BaseURL + "/tx?hash=0x" + strings.TrimPrefix(hash, "0x")
+	resp, err := c.Client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// Errors come back as JSON-RPC errors, with HTTP 200 or 500
+	var result txResultResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse tx response (status %d): %w", resp.StatusCode, err)
+	}
+	if result.Error != nil {
+		if strings.Contains(result.Error.Data, "not found") {
+			return nil, ErrTxNotFound
+		}
+		return nil, fmt.Errorf("tx query failed: %s %s", result.Error.Message, result.Error.Data)
+	}
+	if result.Result == nil {
+		return nil, fmt.Errorf("unexpected tx response from %s", url)
+	}
+
+	r := result.Result
+	return &TxResponse{
+		Height:    r.Height,
+		TxHash:    strings.ToUpper(r.Hash),
+		Codespace: r.TxResult.Codespace,
+		Code:      r.TxResult.Code,
+		RawLog:    r.TxResult.Log,
+		GasWanted: r.TxResult.GasWanted,
+		GasUsed:   r.TxResult.GasUsed,
+		Events:    r.TxResult.Events,
+	}, nil
+}
@@ -1,10 +1,15 @@
 package rpc
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -137,3 +142,280 @@ func (c *CosmosClient) LatestBlock() (*LatestBlockResponse, error) {
 
 	return &block, nil
 }
+
+// Account holds the signing state of an on-chain account.
+type Account struct {
+	Address       string
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+// baseAccountJSON is the JSON form of cosmos.auth.v1beta1.BaseAccount.
+type baseAccountJSON struct {
+	Address       string `json:"address"`
+	AccountNumber string `json:"account_number"`
+	Sequence      string `json:"sequence"`
+}
+
+// accountResponse represents the /cosmos/auth/v1beta1/accounts/{address} response.
+// EVM chains may wrap the base account (e.g. EthAccount.base_account).
+type accountResponse struct {
+	Account struct {
+		baseAccountJSON
+		BaseAccount *baseAccountJSON `json:"base_account"`
+	} `json:"account"`
+}
+
+// TxResponse represents the tx_response returned by broadcast and tx queries.
+type TxResponse struct {
+	Height    string    `json:"height"`
+	TxHash    string    `json:"txhash"`
+	Codespace string    `json:"codespace"`
+	Code      uint32    `json:"code"`
+	RawLog    string    `json:"raw_log"`
+	GasWanted string    `json:"gas_wanted"`
+	GasUsed   string    `json:"gas_used"`
+	Events    []TxEvent `json:"events"`
+}
+
+// TxEvent is an ABCI event emitted by a transaction.
+type TxEvent struct {
+	Type       string             `json:"type"`
+	Attributes []TxEventAttribute `json:"attributes"`
+}
+
+// TxEventAttribute is a key/value attribute of a TxEvent.
+type TxEventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ErrTxNotFound is returned by tx lookups for a hash that is not (yet)
+// included in a block.
+var ErrTxNotFound = errors.New("tx not found")
+
+// getTxResponse represents the /cosmos/tx/v1beta1/txs/{hash} response.
+type getTxResponse struct {
+	TxResponse TxResponse `json:"tx_response"`
+}
+
+// broadcastResponse represents the /cosmos/tx/v1beta1/txs POST response.
+type broadcastResponse struct {
+	TxResponse TxResponse `json:"tx_response"`
+}
+
+// GasInfo is the gas_info of a simulated transaction.
+type GasInfo struct {
+	GasWanted uint64
+	GasUsed   uint64
+}
+
+// simulateResponse represents the /cosmos/tx/v1beta1/simulate response.
+type simulateResponse struct {
+	GasInfo struct {
+		GasWanted string `json:"gas_wanted"`
+		GasUsed   string `json:"gas_used"`
+	} `json:"gas_info"`
+}
+
+// apiError represents the error body returned by the gRPC gateway.
+type apiError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Account fetches the account number and sequence for an address.
+func (c *CosmosClient) Account(address string) (*Account, error) {
+	url := c.BaseURL + "/cosmos/auth/v1beta1/accounts/" + address
+	resp, err := c.Client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("account %s not found on chain (it must receive funds before it can sign)", address)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, url, apiErrorMessage(body))
+	}
+
+	var acc accountResponse
+	if err := json.Unmarshal(body, &acc); err != nil {
+		return nil, fmt.Errorf("failed to parse account response: %w", err)
+	}
+
+	base := acc.Account.baseAccountJSON
+	if acc.Account.BaseAccount != nil {
+		base = *acc.Account.BaseAccount
+	}
+
+	result := &Account{Address: base.Address}
+	if base.AccountNumber != "" {
+		if result.AccountNumber, err = strconv.ParseUint(base.AccountNumber, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid account_number %q", base.AccountNumber)
+		}
+	}
+	if base.Sequence != "" {
+		if result.Sequence, err = strconv.ParseUint(base.Sequence, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid sequence %q", base.Sequence)
+		}
+	}
+
+	return result, nil
+}
+
+// DelegatorValidators lists the validators an address has delegated to.
+func (c *CosmosClient) DelegatorValidators(address string) ([]string, error) {
+	url := c.BaseURL + "/cosmos/distribution/v1beta1/delegators/" + address + "/validators"
+	resp, err := c.Client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, url, apiErrorMessage(body))
+	}
+
+	var result struct {
+		Validators []string `json:"validators"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse delegator validators response: %w", err)
+	}
+
+	return result.Validators, nil
+}
+
+// BroadcastTx broadcasts protobuf-encoded TxRaw bytes in sync mode.
+// A non-zero code in the returned TxResponse means the tx was rejected by CheckTx.
+func (c *CosmosClient) BroadcastTx(txBytes []byte) (*TxResponse, error) {
+	url := c.BaseURL + "/cosmos/tx/v1beta1/txs"
+	reqBody, err := json.Marshal(map[string]string{
+		"tx_bytes": base64.StdEncoding.EncodeToString(txBytes),
+		"mode":     "BROADCAST_MODE_SYNC",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode broadcast request: %w", err)
+	}
+
+	resp, err := c.Client.Post(url, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("broadcast rejected with status %d: %s", resp.StatusCode, apiErrorMessage(body))
+	}
+
+	var result broadcastResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse broadcast response: %w", err)
+	}
+
+	return &result.TxResponse, nil
+}
+
+// GetTx fetches an included transaction by hash. ErrTxNotFound is returned
+// while it is not in a block.
+func (c *CosmosClient) GetTx(hash string) (*TxResponse, error) {
+	url := c.BaseURL + "/cosmos/tx/v1beta1/txs/" + hash
+	resp, err := c.Client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrTxNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, url, apiErrorMessage(body))
+	}
+
+	var result getTxResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse tx response: %w", err)
+	}
+
+	return &result.TxResponse, nil
+}
+
+// SimulateTx simulates protobuf-encoded TxRaw bytes. Signatures are not
+// verified, but signer infos must carry the account's current sequence.
+func (c *CosmosClient) SimulateTx(txBytes []byte) (*GasInfo, error) {
+	return c.simulate(map[string]interface{}{"tx_bytes": base64.StdEncoding.EncodeToString(txBytes)})
+}
+
+// SimulateTxJSON simulates a transaction given in its JSON form. The node
+// decodes the messages itself, so this works for message types the caller
+// cannot encode.
+func (c *CosmosClient) SimulateTxJSON(tx json.RawMessage) (*GasInfo, error) {
+	return c.simulate(map[string]interface{}{"tx": tx})
+}
+
+func (c *CosmosClient) simulate(req map[string]interface{}) (*GasInfo, error) {
+	url := c.BaseURL + "/cosmos/tx/v1beta1/simulate"
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode simulate request: %w", err)
+	}
+
+	resp, err := c.Client.Post(url, "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("simulation failed: %s", apiErrorMessage(body))
+	}
+
+	var result simulateResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse simulate response: %w", err)
+	}
+
+	info := &GasInfo{}
+	if info.GasUsed, err = strconv.ParseUint(result.GasInfo.GasUsed, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid gas_used %q", result.GasInfo.GasUsed)
+	}
+	info.GasWanted, _ = strconv.ParseUint(result.GasInfo.GasWanted, 10, 64)
+	return info, nil
+}
+
+// apiErrorMessage extracts the message from a gRPC gateway error body.
+func apiErrorMessage(body []byte) string {
+	var e apiError
+	if err := json.Unmarshal(body, &e); err == nil && e.Message != "" {
+		return e.Message
+	}
+	return strings.TrimSpace(string(body))
+}
@@ -0,0 +1,223 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Coin is an amount of a denom as returned by the REST API.
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// TallyResult holds the vote counts of a proposal, in voting power.
+type TallyResult struct {
+	YesCount        string `json:"yes_count"`
+	AbstainCount    string `json:"abstain_count"`
+	NoCount         string `json:"no_count"`
+	NoWithVetoCount string `json:"no_with_veto_count"`
+}
+
+// Proposal is a gov v1 proposal.
+type Proposal struct {
+	ID       string `json:"id"`
+	Messages []struct {
+		Type string `json:"@type"`
+	} `json:"messages"`
+	Status           string      `json:"status"`
+	FinalTallyResult TallyResult `json:"final_tally_result"`
+	SubmitTime       time.Time   `json:"submit_time"`
+	DepositEndTime   time.Time   `json:"deposit_end_time"`
+	TotalDeposit     []Coin      `json:"total_deposit"`
+	VotingStartTime  time.Time   `json:"voting_start_time"`
+	VotingEndTime    time.Time   `json:"voting_end_time"`
+	Metadata         string      `json:"metadata"`
+	Title            string      `json:"title"`
+	Summary          string      `json:"summary"`
+	Proposer         string      `json:"proposer"`
+	Expedited        bool        `json:"expedited"`
+	FailedReason     string      `json:"failed_reason"`
+}
+
+// WeightedVoteOption is one option of a (possibly split) vote.
+type WeightedVoteOption struct {
+	Option string `json:"option"`
+	Weight string `json:"weight"`
+}
+
+// Vote is a voter's vote on a proposal.
+type Vote struct {
+	ProposalID string               `json:"proposal_id"`
+	Voter      string               `json:"voter"`
+	Options    []WeightedVoteOption `json:"options"`
+}
+
+// TallyParams are the gov parameters that decide a proposal's outcome.
+type TallyParams struct {
+	Quorum             string `json:"quorum"`
+	Threshold          string `json:"threshold"`
+	VetoThreshold      string `json:"veto_threshold"`
+	ExpeditedThreshold string `json:"expedited_threshold"`
+}
+
+// StakingPool is the bonded and unbonded token supply.
+type StakingPool struct {
+	NotBondedTokens string `json:"not_bonded_tokens"`
+	BondedTokens    string `json:"bonded_tokens"`
+}
+
+// Proposals lists proposals, newest first. status is a gov v1
+// ProposalStatus name (e.g. PROPOSAL_STATUS_VOTING_PERIOD) or "" for all.
+func (c *CosmosClient) Proposals(status string, limit int) ([]Proposal, error) {
+	q := url.Values{}
+	if status != "" {
+		q.Set("proposal_status", status)
+	}
+	q.Set("pagination.reverse", "true")
+	if limit > 0 {
+		q.Set("pagination.limit", strconv.Itoa(limit))
+	}
+
+	var result struct {
+		Proposals []Proposal `json:"proposals"`
+	}
+	if err := c.getJSON("/cosmos/gov/v1/proposals?"+q.Encode(), &result); err != nil {
+		return nil, err
+	}
+	return result.Proposals, nil
+}
+
+// Proposal fetches a single proposal.
+func (c *CosmosClient) Proposal(id uint64) (*Proposal, error) {
+	var result struct {
+		Proposal Proposal `json:"proposal"`
+	}
+	if err := c.getJSON(fmt.Sprintf("/cosmos/gov/v1/proposals/%d", id), &result); err != nil {
+		return nil, err
+	}
+	return &result.Proposal, nil
+}
+
+// ProposalTally fetches the current tally of a proposal. For proposals past
+// their voting period this is the final tally.
+func (c *CosmosClient) ProposalTally(id uint64) (*TallyResult, error) {
+	var result struct {
+		Tally TallyResult `json:"tally"`
+	}
+	if err := c.getJSON(fmt.Sprintf("/cosmos/gov/v1/proposals/%d/tally", id), &result); err != nil {
+		return nil, err
+	}
+	return &result.Tally, nil
+}
+
+// ProposalVote fetches voter's vote on a proposal. It returns nil without an
+// error if the voter has not voted. Votes are pruned once voting ends.
+func (c *CosmosClient) ProposalVote(id uint64, voter string) (*Vote, error) {
+	var result struct {
+		Vote Vote `json:"vote"`
+	}
+	err := c.getJSON(fmt.Sprintf("/cosmos/gov/v1/proposals/%d/votes/%s", id, voter), &result)
+	if err != nil {
+		// x/gov reports a missing vote as InvalidArgument, not NotFound
+		var se *statusError
+		if isNotFound(err) || errors.As(err, &se) && se.Code == grpcInvalidArgument && strings.Contains(se.Message, "not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &result.Vote, nil
+}
+
+// TallyParams fetches the gov tallying parameters.
+func (c *CosmosClient) TallyParams() (*TallyParams, error) {
+	// SDK v0.47+ returns all gov params under "params"; older versions
+	// only fill "tally_params"
+	var result struct {
+		Params      *TallyParams `json:"params"`
+		TallyParams *TallyParams `json:"tally_params"`
+	}
+	if err := c.getJSON("/cosmos/gov/v1/params/tallying", &result); err != nil {
+		return nil, err
+	}
+	if result.Params != nil && result.Params.Quorum != "" {
+		return result.Params, nil
+	}
+	if result.TallyParams != nil {
+		return result.TallyParams, nil
+	}
+	return nil, fmt.Errorf("tallying params missing from response")
+}
+
+// StakingPool fetches the staking pool.
+func (c *CosmosClient) StakingPool() (*StakingPool, error) {
+	var result struct {
+		Pool StakingPool `json:"pool"`
+	}
+	if err := c.getJSON("/cosmos/staking/v1beta1/pool", &result); err != nil {
+		return nil, err
+	}
+	return &result.Pool, nil
+}
+
+// getJSON fetches path and decodes the JSON response into out. Error
+// responses carry the gRPC gateway message.
+func (c *CosmosClient) getJSON(path string, out interface{}) error {
+	url := c.BaseURL + path
+	resp, err := c.Client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		se := &statusError{URL: url, StatusCode: resp.StatusCode, Message: apiErrorMessage(body)}
+		var e apiError
+		if json.Unmarshal(body, &e) == nil {
+			se.Code = e.Code
+		}
+		return se
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response from %s: %w", url, err)
+	}
+	return nil
+}
+
+// gRPC status codes the gateway reports in error bodies.
+const (
+	grpcInvalidArgument = 3
+	grpcNotFound        = 5
+)
+
+// statusError is a non-200 response from getJSON.
+type statusError struct {
+	URL        string
+	StatusCode int    // HTTP status
+	Code       int    // gRPC status code from the body, 0 if absent
+	Message    string // error message from the body
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code %d from %s: %s", e.StatusCode, e.URL, e.Message)
+}
+
+// isNotFound reports whether err is a getJSON response for an object that
+// does not exist (HTTP 404 or gRPC NotFound).
+func isNotFound(err error) bool {
+	var se *statusError
+	return errors.As(err, &se) && (se.StatusCode == http.StatusNotFound || se.Code == grpcNotFound)
+}
@@ -0,0 +1,85 @@
+package rpc
+
+import (
+	"fmt"
+	"time"
+)
+
+// Validator is a staking validator.
+type Validator struct {
+	OperatorAddress string `json:"operator_address"`
+	ConsensusPubkey struct {
+		Type string `json:"@type"`
+		Key  string `json:"key"`
+	} `json:"consensus_pubkey"`
+	Jailed          bool   `json:"jailed"`
+	Status          string `json:"status"` // BOND_STATUS_BONDED, ...
+	Tokens          string `json:"tokens"`
+	DelegatorShares string `json:"delegator_shares"`
+	Description     struct {
+		Moniker string `json:"moniker"`
+	} `json:"description"`
+	MinSelfDelegation string `json:"min_self_delegation"`
+}
+
+// Delegation is a delegator's stake with a validator.
+type Delegation struct {
+	Shares  string `json:"shares"`
+	Balance Coin   `json:"balance"`
+}
+
+// SigningInfo is a validator's liveness record in the slashing module.
+type SigningInfo struct {
+	Address             string    `json:"address"`
+	StartHeight         string    `json:"start_height"`
+	JailedUntil         time.Time `json:"jailed_until"`
+	Tombstoned          bool      `json:"tombstoned"`
+	MissedBlocksCounter string    `json:"missed_blocks_counter"`
+}
+
+// Validator fetches a validator by operator address.
+func (c *CosmosClient) Validator(valoper string) (*Validator, error) {
+	var result struct {
+		Validator Validator `json:"validator"`
+	}
+	if err := c.getJSON("/cosmos/staking/v1beta1/validators/"+valoper, &result); err != nil {
+		return nil, err
+	}
+	return &result.Validator, nil
+}
+
+// Delegation fetches delegator's delegation to a validator. It returns nil
+// without an error if there is none.
+func (c *CosmosClient) Delegation(valoper, delegator string) (*Delegation, error) {
+	var result struct {
+		DelegationResponse struct {
+			Delegation struct {
+				Shares string `json:"shares"`
+			} `json:"delegation"`
+			Balance Coin `json:"balance"`
+		} `json:"delegation_response"`
+	}
+	err := c.getJSON(fmt.Sprintf("/cosmos/staking/v1beta1/validators/%s/delegations/%s", valoper, delegator), &result)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &Delegation{
+		Shares:  result.DelegationResponse.Delegation.Shares,
+		Balance: result.DelegationResponse.Balance,
+	}, nil
+}
+
+// SigningInfo fetches the slashing signing info of a consensus address
+// (monovalcons1...).
+func (c *CosmosClient) SigningInfo(consAddr string) (*SigningInfo, error) {
+	var result struct {
+		Info SigningInfo `json:"val_signing_info"`
+	}
+	if err := c.getJSON("/cosmos/slashing/v1beta1/signing_infos/"+consAddr, &result); err != nil {
+		return nil, err
+	}
+	return &result.Info, nil
+}
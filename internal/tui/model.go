@@ -107,7 +107,10 @@ const (
 type Config struct {
 	SelectedNetwork string         `json:"selected_network"`
 	DeploymentMode  DeploymentMode `json:"deployment_mode"`
-	LastUpdated     string         `json:"last_updated"`
+	// ValidatorAddress is the validator to watch on the Health tab, as an
+	// operator (monovaloper1...) or account (mono1...) address
+	ValidatorAddress string `json:"validator_address,omitempty"`
+	LastUpdated      string `json:"last_updated"`
 }
 
 // LoadConfig loads the user config from disk
@@ -305,6 +308,10 @@ type ValidatorHealthInfo struct {
 	MissedBlocks  int64
 	JailedUntil   time.Time
 	NotConfigured bool
+	// PendingVotes are proposals in their voting period the operator has
+	// not voted on; GovError is set if they could not be checked
+	PendingVotes []core.GovProposal
+	GovError     string
 }
 
 // LogsData holds log viewer state
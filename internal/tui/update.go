@@ -19,6 +19,7 @@ import (
 	"github.com/monolythium/mono-commander/internal/logs"
 	"github.com/monolythium/mono-commander/internal/mesh"
 	"github.com/monolythium/mono-commander/internal/net"
+	"github.com/monolythium/mono-commander/internal/rpc"
 	"github.com/monolythium/mono-commander/internal/update"
 	"github.com/monolythium/mono-commander/internal/walletgen"
 )
@@ -528,10 +529,8 @@ func (m Model) refreshHealth() tea.Cmd {
 
 		data.NodeHealth = nodeHealth
 
-		// Validator health (placeholder - would need keyring access)
-		data.ValidatorHealth = &ValidatorHealthInfo{
-			NotConfigured: true,
-		}
+		// Validator health: governance votes of the configured validator
+		data.ValidatorHealth = checkValidatorHealth(m.config, endpoints.CosmosREST)
 
 		// Check for multi-node setup
 		homeDir, _ := os.UserHomeDir()
@@ -550,6 +549,38 @@ func (m Model) refreshHealth() tea.Cmd {
 	}
 }
 
+// checkValidatorHealth checks the bonding and liveness of the validator set
+// in the config, and the proposals its operator has not voted on.
+func checkValidatorHealth(cfg *Config, cosmosREST string) *ValidatorHealthInfo {
+	if cfg == nil || cfg.ValidatorAddress == "" {
+		return &ValidatorHealthInfo{NotConfigured: true}
+	}
+
+	info := &ValidatorHealthInfo{IsValidator: true}
+	voter, err := core.GovVoterAddress(cfg.ValidatorAddress)
+	if err != nil {
+		info.GovError = err.Error()
+		return info
+	}
+	info.ValoperAddr, _ = core.AccountToValoperAddress(voter)
+
+	client := rpc.NewCosmosClient(cosmosREST)
+	if h, err := core.GetValidatorHealth(client, info.ValoperAddr); err == nil {
+		info.Status = h.Status
+		info.Jailed = h.Jailed
+		info.JailedUntil = h.JailedUntil
+		info.MissedBlocks = h.MissedBlocks
+	}
+
+	pending, err := core.PendingVotes(client, voter)
+	if err != nil {
+		info.GovError = err.Error()
+		return info
+	}
+	info.PendingVotes = pending
+	return info
+}
+
 // Logs commands
 func (m Model) startLogsStream() tea.Cmd {
 	return func() tea.Msg {
@@ -894,7 +925,7 @@ func (m Model) setupInstallMonodForm() Model {
 func (m Model) setupJoinForm() Model {
 	m.formFields = []FormField{
 		{Label: "network", Placeholder: string(m.selectedNetwork), Required: true, Input: newInput("Network")},
-		{Label: "sync", Placeholder: "bootstrap", Required: false, Input: newInput("Sync strategy (bootstrap/default)")},
+		{Label: "sync", Placeholder: "bootstrap", Required: false, Input: newInput("Sync strategy (bootstrap/default/statesync)")},
 		{Label: "home", Placeholder: "~/.monod", Required: false, Input: newInput("Node home directory")},
 		{Label: "genesis-sha256", Placeholder: "", Required: false, Input: newInput("Genesis SHA256 (optional)")},
 	}
@@ -918,10 +949,13 @@ func (m Model) setupJoinForm() Model {
 			if err != nil {
 				return dashboardRefreshMsg{err: err}
 			}
-			// Determine sync strategy
-			strategy := core.SyncStrategyDefault
-			if syncStrategy == "bootstrap" || syncStrategy == "" {
-				strategy = core.SyncStrategyBootstrap
+			// Determine sync strategy (empty defaults to bootstrap)
+			strategy := core.SyncStrategyBootstrap
+			if syncStrategy != "" {
+				strategy, err = core.ParseSyncStrategy(syncStrategy)
+				if err != nil {
+					return dashboardRefreshMsg{err: err}
+				}
 			}
 			opts := core.JoinOptions{
 				Network:       network,
@@ -3,8 +3,10 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/monolythium/mono-commander/internal/core"
 )
 
 // View renders the UI
@@ -473,7 +475,7 @@ func (m Model) renderValidatorHealthCard(width int) string {
 	h := m.healthData.ValidatorHealth
 
 	if h.NotConfigured {
-		body := TextMuted.Render("Validator not configured (no operator key detected)")
+		body := TextMuted.Render("Validator not configured (set validator_address in ~/.mono-commander/config.json)")
 		// Use gradient border for primary health cards
 		return GradientCard("[3] Validator Health", body, width)
 	}
@@ -482,6 +484,20 @@ func (m Model) renderValidatorHealthCard(width int) string {
 		{Label: "Valoper", Status: BadgeInfo, Value: truncateNote(h.ValoperAddr, 20)},
 	}
 
+	if h.Status != "" {
+		rows = append(rows, validatorStatusRows(h)...)
+	}
+	rows = append(rows, governanceRows(h)...)
+
+	body := StatusTable(rows, 0)
+	// Use gradient border for primary health cards
+	return GradientCard("[3] Validator Health", body, width)
+}
+
+// validatorStatusRows renders the bonding, jail and signing status.
+func validatorStatusRows(h *ValidatorHealthInfo) []StatusRow {
+	var rows []StatusRow
+
 	// Status badge
 	statusBadge := BadgeNA
 	switch h.Status {
@@ -505,9 +521,33 @@ func (m Model) renderValidatorHealthCard(width int) string {
 		rows = append(rows, StatusRow{Label: "Missed Blocks", Status: BadgeWarn, Value: fmt.Sprintf("%d", h.MissedBlocks)})
 	}
 
-	body := StatusTable(rows, 0)
-	// Use gradient border for primary health cards
-	return GradientCard("[3] Validator Health", body, width)
+	return rows
+}
+
+// governanceRows renders the proposals the operator has not voted on.
+func governanceRows(h *ValidatorHealthInfo) []StatusRow {
+	if h.GovError != "" {
+		return []StatusRow{{Label: "Gov Votes", Status: BadgeNA, Value: "UNKNOWN", Note: truncateNote(h.GovError, 40)}}
+	}
+	if len(h.PendingVotes) == 0 {
+		return []StatusRow{{Label: "Gov Votes", Status: BadgeOK, Value: "UP TO DATE"}}
+	}
+
+	rows := []StatusRow{{Label: "Gov Votes", Status: BadgeWarn, Value: fmt.Sprintf("%d PENDING", len(h.PendingVotes))}}
+	for _, p := range h.PendingVotes {
+		left := time.Until(p.VotingEndTime)
+		badge := BadgeWarn
+		if left < core.PendingVoteUrgent {
+			badge = BadgeFail
+		}
+		rows = append(rows, StatusRow{
+			Label:  fmt.Sprintf("#%d", p.ID),
+			Status: badge,
+			Value:  core.FormatTimeLeft(left) + " left",
+			Note:   truncateNote(p.Title, 30),
+		})
+	}
+	return rows
 }
 
 // Logs rendering with viewport
@@ -1206,7 +1246,9 @@ func (m Model) renderWalletResult() string {
 			"EVM Address:    " + result.EVMAddress + "\n" +
 			"Bech32 Address: " + result.Bech32Address + "\n\n" +
 			TextMuted.Render("Keystore saved to:") + "\n" +
-			TextMuted.Render(result.KeystorePath) + "\n",
+			TextMuted.Render(result.KeystorePath) + "\n\n" +
+			TextMuted.Render("To use it with --from in tx commands:") + "\n" +
+			TextMuted.Render("monoctl wallet import-to-keyring --file "+result.KeystorePath+" --name <key>") + "\n",
 	)
 
 	b.WriteString("  ")
@@ -0,0 +1,157 @@
+package walletgen
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EthCoinType is the SLIP-44 coin type of eth_secp256k1 accounts. monod's
+// keyring (--coin-type 60) and MetaMask both derive on it.
+const EthCoinType = 60
+
+// DefaultHDPath is the BIP44 path of the first account, matching MetaMask
+// and `monod keys add --recover`.
+const DefaultHDPath = "m/44'/60'/0'/0/0"
+
+// hardenedOffset marks a hardened BIP32 child index.
+const hardenedOffset = 0x80000000
+
+// NewMnemonic generates a BIP39 mnemonic of 12 or 24 words using
+// crypto/rand.
+func NewMnemonic(words int) (string, error) {
+	var bits int
+	switch words {
+	case 12:
+		bits = 128
+	case 24:
+		bits = 256
+	default:
+		return "", fmt.Errorf("mnemonic must have 12 or 24 words, got %d", words)
+	}
+	entropy, err := bip39.NewEntropy(bits)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate entropy: %w", err)
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NormalizeMnemonic lowercases a mnemonic and collapses its whitespace.
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
+
+// ValidateMnemonic checks the word count, the words and the checksum.
+func ValidateMnemonic(mnemonic string) error {
+	mnemonic = NormalizeMnemonic(mnemonic)
+	if n := len(strings.Fields(mnemonic)); n != 12 && n != 24 {
+		return fmt.Errorf("mnemonic must have 12 or 24 words, got %d", n)
+	}
+	if _, err := bip39.MnemonicToByteArray(mnemonic); err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	return nil
+}
+
+// HDPathForIndex returns the BIP44 path of account index on EthCoinType.
+// Like MetaMask, accounts differ in the last (address index) component.
+func HDPathForIndex(index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/0'/0/%d", EthCoinType, index)
+}
+
+// FromMnemonic derives the keypair at path (e.g. DefaultHDPath) from a
+// BIP39 mnemonic and optional passphrase.
+func FromMnemonic(mnemonic, passphrase, path string) (*Keypair, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	indexes, err := ParseHDPath(path)
+	if err != nil {
+		return nil, err
+	}
+	seed := bip39.NewSeed(NormalizeMnemonic(mnemonic), passphrase)
+	key, err := deriveKey(seed, indexes)
+	if err != nil {
+		return nil, err
+	}
+	return FromPrivateKeyBytes(key)
+}
+
+// ParseHDPath parses a BIP32 path like m/44'/60'/0'/0/0 into child
+// indexes, with hardened indexes offset by 2^31.
+func ParseHDPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) < 2 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid HD path %q: must start with m/", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		hardened := strings.HasSuffix(p, "'") || strings.HasSuffix(p, "h")
+		if hardened {
+			p = p[:len(p)-1]
+		}
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil || n >= hardenedOffset {
+			return nil, fmt.Errorf("invalid HD path %q: bad component %q", path, p)
+		}
+		if hardened {
+			n += hardenedOffset
+		}
+		indexes = append(indexes, uint32(n))
+	}
+	return indexes, nil
+}
+
+// deriveKey derives the BIP32 private key at indexes from seed.
+func deriveKey(seed []byte, indexes []uint32) ([]byte, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	n := crypto.S256().Params().N
+	if k := new(big.Int).SetBytes(key); k.Sign() == 0 || k.Cmp(n) >= 0 {
+		return nil, errors.New("invalid master key")
+	}
+
+	for _, index := range indexes {
+		var data []byte
+		if index >= hardenedOffset {
+			data = append([]byte{0}, key...)
+		} else {
+			priv, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			data = crypto.CompressPubkey(&priv.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		// The child key is IL + parent key (mod n); BIP32 skips to the
+		// next index for the ~2^-127 invalid cases, which we report instead
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(n) >= 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		child := il.Add(il, new(big.Int).SetBytes(key))
+		child.Mod(child, n)
+		if child.Sign() == 0 {
+			return nil, fmt.Errorf("invalid child key at index %d", index)
+		}
+		key = child.FillBytes(make([]byte, 32))
+		chainCode = sum[32:]
+	}
+	return key, nil
+}
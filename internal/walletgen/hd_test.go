@@ -0,0 +1,142 @@
+package walletgen
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// testMnemonic is the all-"abandon" BIP39 test vector. It is public and
+// must never hold funds.
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// TestFromMnemonicMetaMaskCompat checks the addresses MetaMask (and
+// `monod keys add --recover --coin-type 60`) derive from the test vector.
+func TestFromMnemonicMetaMaskCompat(t *testing.T) {
+	tests := []struct {
+		index   uint32
+		evmAddr string
+	}{
+		{0, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{1, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"},
+		{2, "0xb6716976A3ebe8D39aCEB04372f22Ff8e6802D7A"},
+	}
+	for _, tt := range tests {
+		kp, err := FromMnemonic(testMnemonic, "", HDPathForIndex(tt.index))
+		if err != nil {
+			t.Fatalf("FromMnemonic(index %d) error = %v", tt.index, err)
+		}
+		if kp.EVMAddress() != tt.evmAddr {
+			t.Errorf("index %d: EVM address = %s, want %s", tt.index, kp.EVMAddress(), tt.evmAddr)
+		}
+		bech32Addr, _ := kp.Bech32Address()
+		if evm, _ := Bech32ToEVMAddress(bech32Addr); !strings.EqualFold(evm, tt.evmAddr) {
+			t.Errorf("index %d: bech32 address %s does not map to %s", tt.index, bech32Addr, tt.evmAddr)
+		}
+	}
+
+	if HDPathForIndex(0) != DefaultHDPath {
+		t.Errorf("HDPathForIndex(0) = %s, want %s", HDPathForIndex(0), DefaultHDPath)
+	}
+}
+
+// TestDeriveKeyBIP32Vector checks derivation against BIP32 test vector 1.
+func TestDeriveKeyBIP32Vector(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	tests := []struct {
+		path string
+		key  string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0'", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, tt := range tests {
+		var indexes []uint32
+		if tt.path != "m" {
+			var err error
+			if indexes, err = ParseHDPath(tt.path); err != nil {
+				t.Fatal(err)
+			}
+		}
+		key, err := deriveKey(seed, indexes)
+		if err != nil {
+			t.Fatalf("deriveKey(%s) error = %v", tt.path, err)
+		}
+		if hex.EncodeToString(key) != tt.key {
+			t.Errorf("deriveKey(%s) = %x, want %s", tt.path, key, tt.key)
+		}
+	}
+}
+
+func TestFromMnemonicPassphrase(t *testing.T) {
+	plain, err := FromMnemonic(testMnemonic, "", DefaultHDPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	withPass, err := FromMnemonic(testMnemonic, "TREZOR", DefaultHDPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain.EVMAddress() == withPass.EVMAddress() {
+		t.Error("passphrase did not change the derived address")
+	}
+
+	// Case and whitespace do not matter
+	messy := "  ABANDON abandon\tabandon abandon abandon abandon abandon abandon abandon abandon abandon\nabout "
+	if kp, err := FromMnemonic(messy, "", DefaultHDPath); err != nil || kp.EVMAddress() != plain.EVMAddress() {
+		t.Errorf("FromMnemonic(messy) = %v, %v", kp, err)
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	for _, words := range []int{12, 24} {
+		m, err := NewMnemonic(words)
+		if err != nil {
+			t.Fatalf("NewMnemonic(%d) error = %v", words, err)
+		}
+		if n := len(strings.Fields(m)); n != words {
+			t.Errorf("NewMnemonic(%d) has %d words", words, n)
+		}
+		if err := ValidateMnemonic(m); err != nil {
+			t.Errorf("ValidateMnemonic(NewMnemonic(%d)) error = %v", words, err)
+		}
+	}
+	if _, err := NewMnemonic(15); err == nil {
+		t.Error("expected an error for 15 words")
+	}
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	invalid := []string{
+		"",
+		"abandon abandon abandon",
+		// Bad checksum
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+		// Unknown word
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon monolythium",
+	}
+	for _, m := range invalid {
+		if err := ValidateMnemonic(m); err == nil {
+			t.Errorf("ValidateMnemonic(%q) expected an error", m)
+		}
+	}
+}
+
+func TestParseHDPath(t *testing.T) {
+	got, err := ParseHDPath("m/44'/60'/0'/0/7")
+	want := []uint32{44 + hardenedOffset, 60 + hardenedOffset, hardenedOffset, 0, 7}
+	if err != nil || len(got) != len(want) {
+		t.Fatalf("ParseHDPath() = %v, %v", got, err)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("component %d = %d, want %d", i, got[i], want[i])
+		}
+	}
+	for _, bad := range []string{"", "44'/60'", "m/x", "m/2147483648", "m//0"} {
+		if _, err := ParseHDPath(bad); err == nil {
+			t.Errorf("ParseHDPath(%q) expected an error", bad)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package walletgen
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/pbkdf2"
 	"crypto/rand"
+	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
@@ -49,6 +51,11 @@ type ScryptParamsV3 struct {
 	P     int    `json:"p"`
 	DKLen int    `json:"dklen"`
 	Salt  string `json:"salt"`
+
+	// PBKDF2 parameters of keystores from other tools. They are only read:
+	// monoctl always writes scrypt keystores.
+	C   int    `json:"c,omitempty"`
+	PRF string `json:"prf,omitempty"`
 }
 
 // Default scrypt parameters (matches go-ethereum defaults)
@@ -153,22 +160,13 @@ func createKeystoreWithParams(kp *Keypair, password string, n, r, p int) (*Keyst
 }
 
 // DecryptKeystore decrypts a keystore and returns the private key bytes.
-// This is used for compatibility testing - the password is required.
+// Keystores encrypted with scrypt or PBKDF2 (hmac-sha256) are supported.
 // WARNING: Returns raw private key bytes - handle with extreme care.
 func DecryptKeystore(ks *KeystoreV3, password string) ([]byte, error) {
-	if ks.Crypto.KDF != "scrypt" {
-		return nil, errors.New("unsupported KDF: only scrypt is supported")
-	}
 	if ks.Crypto.Cipher != "aes-128-ctr" {
 		return nil, errors.New("unsupported cipher: only aes-128-ctr is supported")
 	}
 
-	// Decode hex values
-	salt, err := hex.DecodeString(ks.Crypto.KDFParams.Salt)
-	if err != nil {
-		return nil, fmt.Errorf("invalid salt: %w", err)
-	}
-
 	ciphertext, err := hex.DecodeString(ks.Crypto.CipherText)
 	if err != nil {
 		return nil, fmt.Errorf("invalid ciphertext: %w", err)
@@ -184,15 +182,9 @@ func DecryptKeystore(ks *KeystoreV3, password string) ([]byte, error) {
 		return nil, fmt.Errorf("invalid MAC: %w", err)
 	}
 
-	// Derive key using scrypt
-	n := ks.Crypto.KDFParams.N
-	r := ks.Crypto.KDFParams.R
-	p := ks.Crypto.KDFParams.P
-	dkLen := ks.Crypto.KDFParams.DKLen
-
-	derivedKey, err := scrypt.Key([]byte(password), salt, n, r, p, dkLen)
+	derivedKey, err := deriveKeystoreKey(&ks.Crypto, password)
 	if err != nil {
-		return nil, fmt.Errorf("failed to derive key: %w", err)
+		return nil, err
 	}
 
 	// Verify MAC
@@ -217,6 +209,127 @@ func DecryptKeystore(ks *KeystoreV3, password string) ([]byte, error) {
 	return privBytes, nil
 }
 
+// deriveKeystoreKey derives the encryption and MAC key of a keystore.
+func deriveKeystoreKey(c *CryptoV3, password string) ([]byte, error) {
+	params := c.KDFParams
+	if params.DKLen < 32 {
+		return nil, fmt.Errorf("invalid dklen %d: must be at least 32", params.DKLen)
+	}
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("invalid salt: %w", err)
+	}
+
+	var derivedKey []byte
+	switch c.KDF {
+	case "scrypt":
+		derivedKey, err = scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+	case "pbkdf2":
+		if params.PRF != "hmac-sha256" {
+			return nil, fmt.Errorf("unsupported pbkdf2 prf: %q", params.PRF)
+		}
+		if params.C <= 0 {
+			return nil, fmt.Errorf("invalid pbkdf2 iteration count %d", params.C)
+		}
+		derivedKey, err = pbkdf2.Key(sha256.New, password, salt, params.C, params.DKLen)
+	default:
+		return nil, fmt.Errorf("unsupported KDF: %q (scrypt and pbkdf2 are supported)", c.KDF)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+	return derivedKey, nil
+}
+
+// IsWeakKDF reports whether a keystore is encrypted with weaker parameters
+// than CreateKeystore uses: PBKDF2, or scrypt below ScryptN (for example
+// CreateKeystoreLight).
+func IsWeakKDF(ks *KeystoreV3) bool {
+	return ks.Crypto.KDF != "scrypt" || ks.Crypto.KDFParams.N < ScryptN
+}
+
+// ReencryptKeystore decrypts ks with oldPassword and encrypts its key with
+// newPassword and the default scrypt parameters. The keystore ID is kept.
+func ReencryptKeystore(ks *KeystoreV3, oldPassword, newPassword string) (*KeystoreV3, error) {
+	privBytes, err := DecryptKeystore(ks, oldPassword)
+	if err != nil {
+		return nil, err
+	}
+	kp, err := FromPrivateKeyBytes(privBytes)
+	clear(privBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(strings.TrimPrefix(kp.EVMAddress(), "0x"), strings.TrimPrefix(ks.Address, "0x")) {
+		return nil, fmt.Errorf("keystore key derives %s, but the file says %s", kp.EVMAddress(), GetKeystoreAddress(ks))
+	}
+
+	out, err := CreateKeystore(kp, newPassword)
+	if err != nil {
+		return nil, err
+	}
+	if ks.ID != "" {
+		out.ID = ks.ID
+	}
+	return out, nil
+}
+
+// ReplaceKeystore atomically replaces the keystore at path with ks. The
+// old file is first copied to a timestamped .bak file next to it, whose
+// path is returned.
+func ReplaceKeystore(ks *KeystoreV3, path string) (string, error) {
+	old, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read keystore: %w", err)
+	}
+	data, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal keystore: %w", err)
+	}
+
+	backupPath := path + "." + time.Now().UTC().Format("20060102T150405.000000000Z") + ".bak"
+	if err := writeFileSync(backupPath, old, os.O_EXCL); err != nil {
+		return "", fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return backupPath, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err == nil {
+		_, err = tmp.Write(data)
+		if err == nil {
+			err = tmp.Sync()
+		}
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return backupPath, fmt.Errorf("failed to write keystore: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return backupPath, fmt.Errorf("failed to replace keystore: %w", err)
+	}
+	return backupPath, nil
+}
+
+// writeFileSync writes data to a new owner-only file and syncs it.
+func writeFileSync(path string, data []byte, flag int) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC|flag, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
 // SaveKeystore saves a keystore to a file
 func SaveKeystore(ks *KeystoreV3, path string) error {
 	// Ensure directory exists
@@ -1,6 +1,7 @@
 package walletgen
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -327,3 +328,153 @@ func TestKeystoreNoSecrets(t *testing.T) {
 		t.Error("SECURITY: password found in keystore JSON!")
 	}
 }
+
+// Web3 Secret Storage test vectors: password "testpassword"
+const (
+	testVectorPrivKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	pbkdf2TestVector  = `{
+  "version": 3,
+  "id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+  "address": "008aeeda4d805471df9b2a5b0f38a0c3bcba786b",
+  "crypto": {
+    "cipher": "aes-128-ctr",
+    "cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+    "ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+    "kdf": "pbkdf2",
+    "kdfparams": {"c": 262144, "dklen": 32, "prf": "hmac-sha256", "salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"},
+    "mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+  }
+}`
+	scryptTestVector = `{
+  "version": 3,
+  "id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+  "address": "008aeeda4d805471df9b2a5b0f38a0c3bcba786b",
+  "crypto": {
+    "cipher": "aes-128-ctr",
+    "cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+    "ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+    "kdf": "scrypt",
+    "kdfparams": {"dklen": 32, "n": 262144, "r": 1, "p": 8, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+    "mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+  }
+}`
+)
+
+// TestDecryptKeystoreTestVectors checks decryption of keystores written
+// by other tools, including PBKDF2 ones.
+func TestDecryptKeystoreTestVectors(t *testing.T) {
+	for name, vector := range map[string]string{"pbkdf2": pbkdf2TestVector, "scrypt": scryptTestVector} {
+		var ks KeystoreV3
+		if err := json.Unmarshal([]byte(vector), &ks); err != nil {
+			t.Fatal(err)
+		}
+		priv, err := DecryptKeystore(&ks, "testpassword")
+		if err != nil {
+			t.Fatalf("%s: DecryptKeystore() error = %v", name, err)
+		}
+		if got := hex.EncodeToString(priv); got != testVectorPrivKey {
+			t.Errorf("%s: private key = %s, want %s", name, got, testVectorPrivKey)
+		}
+		if _, err := DecryptKeystore(&ks, "wrong"); err == nil {
+			t.Errorf("%s: expected an error for a wrong password", name)
+		}
+	}
+
+	var ks KeystoreV3
+	json.Unmarshal([]byte(pbkdf2TestVector), &ks)
+	ks.Crypto.KDFParams.PRF = "hmac-sha512"
+	if _, err := DecryptKeystore(&ks, "testpassword"); err == nil || !strings.Contains(err.Error(), "prf") {
+		t.Errorf("unsupported prf error = %v", err)
+	}
+	ks.Crypto.KDF = "argon2"
+	if _, err := DecryptKeystore(&ks, "testpassword"); err == nil || !strings.Contains(err.Error(), "unsupported KDF") {
+		t.Errorf("unsupported KDF error = %v", err)
+	}
+}
+
+func TestReencryptKeystore(t *testing.T) {
+	var pbkdf2KS KeystoreV3
+	json.Unmarshal([]byte(pbkdf2TestVector), &pbkdf2KS)
+	kp, _ := GenerateKeypair()
+	light, _ := CreateKeystoreLight(kp, "old-password")
+
+	for name, tt := range map[string]struct {
+		ks       *KeystoreV3
+		password string
+	}{
+		"pbkdf2": {&pbkdf2KS, "testpassword"},
+		"light":  {light, "old-password"},
+	} {
+		if !IsWeakKDF(tt.ks) {
+			t.Errorf("%s: IsWeakKDF() = false", name)
+		}
+		if _, err := ReencryptKeystore(tt.ks, "wrong", "new-password"); err == nil {
+			t.Errorf("%s: expected an error for a wrong password", name)
+		}
+
+		out, err := ReencryptKeystore(tt.ks, tt.password, "new-password")
+		if err != nil {
+			t.Fatalf("%s: ReencryptKeystore() error = %v", name, err)
+		}
+		if IsWeakKDF(out) || out.Crypto.KDFParams.N != ScryptN {
+			t.Errorf("%s: re-encrypted with %s n=%d", name, out.Crypto.KDF, out.Crypto.KDFParams.N)
+		}
+		if out.ID != tt.ks.ID || out.Address != tt.ks.Address {
+			t.Errorf("%s: id/address = %s/%s, want %s/%s", name, out.ID, out.Address, tt.ks.ID, tt.ks.Address)
+		}
+		want, _ := DecryptKeystore(tt.ks, tt.password)
+		got, err := DecryptKeystore(out, "new-password")
+		if err != nil || hex.EncodeToString(got) != hex.EncodeToString(want) {
+			t.Errorf("%s: decrypt with new password = %x, %v", name, got, err)
+		}
+	}
+
+	// A keystore whose address does not match its key is refused
+	pbkdf2KS.Address = strings.Repeat("0", 40)
+	if _, err := ReencryptKeystore(&pbkdf2KS, "testpassword", "new-password"); err == nil {
+		t.Error("expected an error for a mismatched address")
+	}
+}
+
+func TestReplaceKeystore(t *testing.T) {
+	dir := t.TempDir()
+	kp, _ := GenerateKeypair()
+	oldKS, _ := CreateKeystoreLight(kp, "old-password")
+	path := filepath.Join(dir, GenerateKeystoreFilename("ops", kp.EVMAddress()))
+	if err := SaveKeystore(oldKS, path); err != nil {
+		t.Fatal(err)
+	}
+	oldData, _ := os.ReadFile(path)
+
+	newKS, _ := CreateKeystoreLight(kp, "new-password")
+	backup, err := ReplaceKeystore(newKS, path)
+	if err != nil {
+		t.Fatalf("ReplaceKeystore() error = %v", err)
+	}
+
+	if data, _ := os.ReadFile(backup); string(data) != string(oldData) {
+		t.Error("backup does not hold the old keystore")
+	}
+	loaded, err := LoadKeystore(path)
+	if err != nil || loaded.Crypto.MAC != newKS.Crypto.MAC {
+		t.Fatalf("LoadKeystore() = %+v, %v", loaded, err)
+	}
+	for _, p := range []string{path, backup} {
+		if info, _ := os.Stat(p); info.Mode().Perm() != 0600 {
+			t.Errorf("%s mode = %v, want 0600", p, info.Mode().Perm())
+		}
+	}
+
+	// Only the keystore and its backup are left; backups are not listed
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("directory has %d entries, want 2", len(entries))
+	}
+	if infos, _ := ListKeystores(dir); len(infos) != 1 {
+		t.Errorf("ListKeystores() = %d keystores, want 1", len(infos))
+	}
+
+	if _, err := ReplaceKeystore(newKS, filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing keystore")
+	}
+}
@@ -0,0 +1,81 @@
+package walletgen
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestEntry describes one generated wallet. It never holds key
+// material, so manifests can be shared (e.g. to fund faucet accounts).
+type ManifestEntry struct {
+	Name          string `json:"name"`
+	EVMAddress    string `json:"evm_address"`
+	Bech32Address string `json:"bech32_address"`
+	HDPath        string `json:"hd_path,omitempty"`
+	Keystore      string `json:"keystore"`
+}
+
+// manifestHeader is the CSV header row.
+var manifestHeader = []string{"name", "evm_address", "bech32_address", "hd_path", "keystore"}
+
+// ManifestFormat returns "csv" or "json" for a manifest path, by extension.
+func ManifestFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv", nil
+	case ".json":
+		return "json", nil
+	}
+	return "", fmt.Errorf("manifest %s must end in .csv or .json", path)
+}
+
+// EncodeManifest encodes entries as "csv" or "json".
+func EncodeManifest(entries []ManifestEntry, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		if entries == nil {
+			entries = []ManifestEntry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "csv":
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		w.Write(manifestHeader)
+		for _, e := range entries {
+			w.Write([]string{e.Name, e.EVMAddress, e.Bech32Address, e.HDPath, e.Keystore})
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	}
+	return nil, fmt.Errorf("unknown manifest format %q (use csv or json)", format)
+}
+
+// WriteManifest writes entries to path, in the format its extension names.
+func WriteManifest(path string, entries []ManifestEntry) error {
+	format, err := ManifestFormat(path)
+	if err != nil {
+		return err
+	}
+	data, err := EncodeManifest(entries, format)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
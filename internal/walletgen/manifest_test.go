@@ -0,0 +1,55 @@
+package walletgen
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteManifest(t *testing.T) {
+	entries := []ManifestEntry{
+		{Name: "faucet-1", EVMAddress: "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", Bech32Address: "mono1npvwllfr9dqr8erajqqr6s0vxnk2ak55f0uq4u", Keystore: "/w/a.json"},
+		{Name: "faucet,2", EVMAddress: "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", Bech32Address: "mono1d7kv6xxfzg6rh7r05uzfxexafepy4wwqvcrcxc", HDPath: "m/44'/60'/0'/0/1", Keystore: "/w/b.json"},
+	}
+	dir := t.TempDir()
+
+	csvPath := filepath.Join(dir, "out", "m.CSV")
+	if err := WriteManifest(csvPath, entries); err != nil {
+		t.Fatalf("WriteManifest(csv) error = %v", err)
+	}
+	f, _ := os.Open(csvPath)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || strings.Join(rows[0], ",") != "name,evm_address,bech32_address,hd_path,keystore" {
+		t.Fatalf("csv rows = %v", rows)
+	}
+	if rows[2][0] != "faucet,2" || rows[2][3] != "m/44'/60'/0'/0/1" {
+		t.Errorf("csv row = %v", rows[2])
+	}
+
+	jsonPath := filepath.Join(dir, "m.json")
+	if err := WriteManifest(jsonPath, entries); err != nil {
+		t.Fatalf("WriteManifest(json) error = %v", err)
+	}
+	data, _ := os.ReadFile(jsonPath)
+	var got []ManifestEntry
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1] != entries[1] {
+		t.Errorf("json entries = %+v", got)
+	}
+	if strings.Contains(string(data), "private") {
+		t.Error("manifest mentions a private key")
+	}
+
+	if err := WriteManifest(filepath.Join(dir, "m.yaml"), entries); err == nil {
+		t.Error("expected an error for a .yaml manifest")
+	}
+}
@@ -0,0 +1,166 @@
+package walletgen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// VanityOptions configures a vanity address search.
+type VanityOptions struct {
+	Prefix string // Wanted start of the address after "mono1" (or "0x" with EVM)
+	Suffix string // Wanted end of the address
+	EVM    bool   // Match the EVM address (case-insensitive hex) instead of bech32
+	// Workers is the number of search goroutines (default: runtime.NumCPU())
+	Workers int
+	// Progress, if set, is called about every ProgressInterval (default 1s)
+	// with the number of keys tried so far.
+	Progress         func(attempts uint64)
+	ProgressInterval time.Duration
+}
+
+// Validate checks that the pattern can occur in an address.
+func (o VanityOptions) Validate() error {
+	alphabet, maxLen := charset, 38 // data and checksum characters of a 20-byte address
+	if o.EVM {
+		alphabet, maxLen = "0123456789abcdef", 40
+	}
+	prefix, suffix := o.pattern()
+	pattern := prefix + suffix
+	if pattern == "" {
+		return errors.New("vanity search needs a prefix or a suffix")
+	}
+	if len(pattern) > maxLen {
+		return fmt.Errorf("vanity pattern is longer than the address (%d characters)", maxLen)
+	}
+	for _, r := range pattern {
+		if !strings.ContainsRune(alphabet, r) {
+			if o.EVM {
+				return fmt.Errorf("invalid character %q: EVM addresses are hex", r)
+			}
+			return fmt.Errorf("invalid character %q: bech32 addresses use only %s", r, charset)
+		}
+	}
+	return nil
+}
+
+// Difficulty returns the expected number of keys to try.
+func (o VanityOptions) Difficulty() float64 {
+	base := 32.0
+	if o.EVM {
+		base = 16
+	}
+	prefix, suffix := o.pattern()
+	return math.Pow(base, float64(len(prefix)+len(suffix)))
+}
+
+// pattern returns the lowercased prefix and suffix. A leading "mono1" or
+// "0x" is dropped, as every address has it.
+func (o VanityOptions) pattern() (prefix, suffix string) {
+	prefix = strings.ToLower(o.Prefix)
+	if o.EVM {
+		prefix = strings.TrimPrefix(prefix, "0x")
+	} else {
+		prefix = strings.TrimPrefix(prefix, Bech32PrefixAccAddr+"1")
+	}
+	return prefix, strings.ToLower(o.Suffix)
+}
+
+// match reports whether the address of kp matches the lowercased pattern.
+func (o VanityOptions) match(kp *Keypair, prefix, suffix string) bool {
+	var s string
+	if o.EVM {
+		s = strings.ToLower(PrivateKeyToEVMAddress(kp.PrivateKey())[2:])
+	} else {
+		addr, err := PrivateKeyToBech32Address(kp.PrivateKey(), Bech32PrefixAccAddr)
+		if err != nil {
+			return false
+		}
+		s = addr[len(Bech32PrefixAccAddr)+1:]
+	}
+	return strings.HasPrefix(s, prefix) && strings.HasSuffix(s, suffix)
+}
+
+// FindVanityKeypair generates keys on all workers until one has an address
+// matching opts, or ctx is done. It returns the keypair and the number of
+// keys tried.
+func FindVanityKeypair(ctx context.Context, opts VanityOptions) (*Keypair, uint64, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, 0, err
+	}
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	interval := opts.ProgressInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	prefix, suffix := opts.pattern()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var attempts atomic.Uint64
+	found := make(chan *Keypair, 1)
+	errc := make(chan error, 1)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				kp, err := GenerateKeypair()
+				if err != nil {
+					select {
+					case errc <- err:
+					default:
+					}
+					cancel()
+					return
+				}
+				attempts.Add(1)
+				if opts.match(kp, prefix, suffix) {
+					select {
+					case found <- kp:
+					default:
+					}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if opts.Progress != nil {
+				opts.Progress(attempts.Load())
+			}
+		case <-done:
+			select {
+			case kp := <-found:
+				return kp, attempts.Load(), nil
+			case err := <-errc:
+				return nil, attempts.Load(), err
+			default:
+				return nil, attempts.Load(), ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package walletgen
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFindVanityKeypair(t *testing.T) {
+	tests := []VanityOptions{
+		{Prefix: "q"},
+		{Prefix: "mono1Q", Suffix: "p"},
+		{Prefix: "0xA", EVM: true},
+		{Suffix: "f0", EVM: true, Workers: 2},
+	}
+	for _, opts := range tests {
+		kp, attempts, err := FindVanityKeypair(context.Background(), opts)
+		if err != nil {
+			t.Fatalf("FindVanityKeypair(%+v) error = %v", opts, err)
+		}
+		if attempts == 0 {
+			t.Errorf("FindVanityKeypair(%+v) reported no attempts", opts)
+		}
+		addr, _ := kp.Bech32Address()
+		if opts.EVM {
+			addr = strings.ToLower(kp.EVMAddress())
+		}
+		prefix, suffix := opts.pattern()
+		if opts.EVM {
+			prefix = "0x" + prefix
+		} else {
+			prefix = "mono1" + prefix
+		}
+		if !strings.HasPrefix(addr, prefix) || !strings.HasSuffix(addr, suffix) {
+			t.Errorf("FindVanityKeypair(%+v) = %s", opts, addr)
+		}
+	}
+}
+
+func TestFindVanityKeypairCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var reports int
+	opts := VanityOptions{
+		Prefix:           strings.Repeat("q", 20),
+		Progress:         func(uint64) { reports++ },
+		ProgressInterval: 20 * time.Millisecond,
+	}
+	kp, attempts, err := FindVanityKeypair(ctx, opts)
+	if !errors.Is(err, context.DeadlineExceeded) || kp != nil {
+		t.Fatalf("FindVanityKeypair() = %v, %v, want deadline exceeded", kp, err)
+	}
+	if attempts == 0 || reports == 0 {
+		t.Errorf("attempts = %d, progress reports = %d", attempts, reports)
+	}
+}
+
+func TestVanityOptionsValidate(t *testing.T) {
+	invalid := []VanityOptions{
+		{},
+		{Prefix: "mono1"},
+		{Prefix: "abc"}, // 'b' is not in the bech32 charset
+		{Suffix: "1"},
+		{Prefix: "xyz", EVM: true},
+		{Prefix: strings.Repeat("q", 39)},
+		{Prefix: strings.Repeat("a", 41), EVM: true},
+	}
+	for _, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected an error", opts)
+		}
+	}
+	if err := (VanityOptions{Prefix: "MONO1ACE"}).Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if d := (VanityOptions{Prefix: "mono1ac", Suffix: "q"}).Difficulty(); d != 32*32*32 {
+		t.Errorf("Difficulty() = %v", d)
+	}
+	if d := (VanityOptions{Prefix: "0xcafe", EVM: true}).Difficulty(); d != 16*16*16*16 {
+		t.Errorf("EVM Difficulty() = %v", d)
+	}
+}